@@ -40,6 +40,7 @@ func (a *UIApp) Stop() {
 	case <-a.stopCh:
 	default:
 		close(a.stopCh)
+		a.ui.Close()
 	}
 }
 
@@ -63,8 +64,15 @@ func (a *UIApp) HandleKey(ev *tcell.EventKey) { a.ui.HandleKey(ev) }
 
 func (a *UIApp) HandleMouse(ev *tcell.EventMouse) { a.ui.HandleMouse(ev) }
 
+// HandlePaste implements core.PasteHandler; the focused widget gets the text.
+func (a *UIApp) HandlePaste(data []byte) { a.ui.HandlePaste(data) }
+
 func (a *UIApp) SetRefreshNotifier(ch chan<- bool) { a.refresh = ch; a.ui.SetRefreshNotifier(ch) }
 
+// SetClipboardService implements core.ClipboardAware; widgets get the
+// service through the UIManager.
+func (a *UIApp) SetClipboardService(cs core.ClipboardService) { a.ui.SetClipboardService(cs) }
+
 // RefreshChan returns the refresh notification channel, or nil if not set.
 func (a *UIApp) RefreshChan() chan<- bool { return a.refresh }
 
@@ -66,6 +66,9 @@ func New() core.App {
 	// === Gradients Tab (dynamic color pipeline demo) ===
 	tabPanel.AddTab("Gradients", createGradientsTab())
 
+	// === Themes Tab (runtime theme switching) ===
+	tabPanel.AddTab("Themes", createThemesTab(statusBar))
+
 	ui.AddWidget(tabPanel)
 	ui.Focus(tabPanel)
 
@@ -99,6 +102,29 @@ func New() core.App {
 	return app
 }
 
+// createThemesTab creates the Themes tab with a theme switcher.
+// Demonstrates: ThemeSwitcher, runtime theme switching
+func createThemesTab(statusBar *widgets.StatusBar) *widgets.Pane {
+	pane := widgets.NewPane()
+
+	title := widgets.NewLabel("Pick a theme - Enter applies it to the whole UI")
+	title.SetPosition(2, 1)
+	pane.AddChild(title)
+
+	switcher := widgets.NewThemeSwitcher()
+	switcher.SetPosition(2, 3)
+	switcher.Resize(40, 8)
+	switcher.OnChange = func(name string) {
+		statusBar.ShowSuccess("Theme: " + name)
+	}
+	switcher.OnError = func(err error) {
+		statusBar.ShowError(err.Error())
+	}
+	pane.AddChild(switcher)
+
+	return pane
+}
+
 // createInputsTab creates the Inputs tab using the Form widget.
 // Demonstrates: Form, Input, ComboBox, TextArea, ColorPicker, Checkbox
 func createInputsTab() *widgets.Form {
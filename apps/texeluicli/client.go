@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
@@ -18,13 +19,22 @@ func SocketPath(override string) (string, error) {
 	if env := os.Getenv("TEXELUI_SOCKET"); env != "" {
 		return env, nil
 	}
+	return filepath.Join(socketDir(), fmt.Sprintf("daemon-%d.sock", os.Getuid())), nil
+}
+
+// socketDir is where servers put their sockets by default.
+func socketDir() string {
 	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
 	if runtimeDir == "" {
 		runtimeDir = os.TempDir()
 	}
-	dir := filepath.Join(runtimeDir, "texelui")
-	uid := os.Getuid()
-	return filepath.Join(dir, fmt.Sprintf("daemon-%d.sock", uid)), nil
+	return filepath.Join(runtimeDir, "texelui")
+}
+
+// ServerSockets returns the Unix sockets in the default socket directory,
+// where auto-started servers listen.
+func ServerSockets() ([]string, error) {
+	return filepath.Glob(filepath.Join(socketDir(), "*.sock"))
 }
 
 func EnsureServer(socketPath string) error {
@@ -64,22 +74,69 @@ func EnsureServer(socketPath string) error {
 	return errors.New("failed to start texelui server")
 }
 
-func SendRequest(req Request, socketPath string) (Response, error) {
+// dialServer connects to the server at socketPath, a Unix socket (started
+// if needed) or a tcp:// or ws:// endpoint.
+func dialServer(socketPath string) (net.Conn, error) {
 	if socketPath == "" {
 		var err error
 		socketPath, err = SocketPath("")
 		if err != nil {
-			return Response{}, err
+			return nil, err
 		}
 	}
-	if err := EnsureServer(socketPath); err != nil {
+	scheme, rest, err := parseEndpoint(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if scheme != "unix" {
+		return dialEndpoint(scheme, rest)
+	}
+	if err := EnsureServer(rest); err != nil {
+		return nil, err
+	}
+	return net.Dial("unix", rest)
+}
+
+// withToken fills in the request token from TEXELUI_TOKEN, which network
+// endpoints require.
+func withToken(req Request) Request {
+	if req.Token == "" {
+		req.Token = os.Getenv("TEXELUI_TOKEN")
+	}
+	return req
+}
+
+func SendRequest(req Request, socketPath string) (Response, error) {
+	conn, err := dialServer(socketPath)
+	if err != nil {
 		return Response{}, err
 	}
-	conn, err := net.Dial("unix", socketPath)
+	return exchange(conn, req)
+}
+
+// QueryServer sends req to the server at socketPath without starting one,
+// failing if none is running there.
+func QueryServer(req Request, socketPath string) (Response, error) {
+	scheme, rest, err := parseEndpoint(socketPath)
+	if err != nil {
+		return Response{}, err
+	}
+	var conn net.Conn
+	if scheme == "unix" {
+		conn, err = net.Dial("unix", rest)
+	} else {
+		conn, err = dialEndpoint(scheme, rest)
+	}
 	if err != nil {
 		return Response{}, err
 	}
+	return exchange(conn, req)
+}
+
+// exchange sends req on conn and reads the response, closing conn.
+func exchange(conn net.Conn, req Request) (Response, error) {
 	defer conn.Close()
+	req = withToken(req)
 	enc := json.NewEncoder(conn)
 	if err := enc.Encode(req); err != nil {
 		return Response{}, err
@@ -91,3 +148,37 @@ func SendRequest(req Request, socketPath string) (Response, error) {
 	}
 	return resp, nil
 }
+
+// ErrTimedOut is returned by WatchEvents when the watch timeout expired.
+var ErrTimedOut = errors.New("timed out")
+
+// WatchEvents sends a watch request and calls fn with each streamed event
+// until the server ends the stream: nil when the session closed, ErrTimedOut
+// when no event arrived within the request's timeout.
+func WatchEvents(req Request, socketPath string, fn func(Response)) error {
+	conn, err := dialServer(socketPath)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := json.NewEncoder(conn).Encode(withToken(req)); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(conn)
+	for {
+		var resp Response
+		if err := dec.Decode(&resp); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if !resp.OK {
+			return errors.New(resp.Error)
+		}
+		if resp.TimedOut {
+			return ErrTimedOut
+		}
+		fn(resp)
+	}
+}
@@ -0,0 +1,165 @@
+package texeluicli
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// eventHistory is how many recent events a session keeps for its consumers
+// to catch up on and for replay.
+const eventHistory = 256
+
+var errWaitTimeout = errors.New("wait timed out")
+
+// eventLog records a session's events with sequence numbers. Consumers read
+// it through their own cursor, so each consumer sees every event: unnamed
+// waits share the default cursor, named clients have one each, and so does
+// every watch.
+type eventLog struct {
+	mu      sync.Mutex
+	events  []Event            // Recent events, oldest first
+	next    uint64             // Seq of the next event
+	cursors map[string]*uint64 // Seq of the next event each client reads
+	changed chan struct{}      // Closed and replaced on every event
+	closed  bool
+
+	// snapshot returns the widget values each event captures; nil until
+	// the session is built
+	snapshot func() map[string]string
+}
+
+func newEventLog() *eventLog {
+	first := uint64(1)
+	return &eventLog{
+		next:    1,
+		cursors: map[string]*uint64{"": &first},
+		changed: make(chan struct{}),
+	}
+}
+
+// emit appends ev with a snapshot of the widget values. When the log is
+// full the oldest low-priority event is dropped first, so a burst of
+// changes cannot push out a click a slow consumer has yet to read.
+func (l *eventLog) emit(ev Event) {
+	if l.snapshot != nil {
+		ev.values = l.snapshot()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	ev.Seq = l.next
+	l.next++
+	if len(l.events) >= eventHistory {
+		drop := 0
+		for i, old := range l.events {
+			if !isHighPriorityEvent(old.Type) {
+				drop = i
+				break
+			}
+		}
+		l.events = append(l.events[:drop], l.events[drop+1:]...)
+	}
+	l.events = append(l.events, ev)
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// close ends the log: waits return the events still unread, then fail.
+func (l *eventLog) close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.closed {
+		l.closed = true
+		close(l.changed)
+	}
+}
+
+// cursor returns the cursor of the named client ("" is the default one),
+// starting replay events back from the latest when first used.
+func (l *eventLog) cursor(client string, replay int) *uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if c, ok := l.cursors[client]; ok {
+		return c
+	}
+	c := l.replayFromLocked(replay)
+	l.cursors[client] = c
+	return c
+}
+
+// forget drops the cursor of a named client that went away, so the log
+// doesn't keep one for every client name it has seen. The default cursor
+// is kept.
+func (l *eventLog) forget(client string) {
+	if client == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.cursors, client)
+}
+
+// newCursor returns a private cursor starting replay events back.
+func (l *eventLog) newCursor(replay int) *uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.replayFromLocked(replay)
+}
+
+func (l *eventLog) replayFromLocked(replay int) *uint64 {
+	seq := l.next
+	if replay > 0 && len(l.events) > 0 {
+		seq = l.events[max(len(l.events)-replay, 0)].Seq
+	}
+	return &seq
+}
+
+// wait returns the first event matching filters from *cursor on, moving the
+// cursor past it and the non-matching events before it. A positive timeout
+// gives up with errWaitTimeout, and closing done cancels the wait.
+func (l *eventLog) wait(cursor *uint64, filters []string, timeout time.Duration, done <-chan struct{}) (Event, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	for {
+		l.mu.Lock()
+		for _, ev := range l.events {
+			if ev.Seq < *cursor {
+				continue
+			}
+			*cursor = ev.Seq + 1
+			if matchesEvent(filters, ev) {
+				l.mu.Unlock()
+				return ev, nil
+			}
+		}
+		closed, changed := l.closed, l.changed
+		l.mu.Unlock()
+		if closed {
+			return Event{}, errors.New("session closed")
+		}
+
+		select {
+		case <-changed:
+		case <-done:
+			return Event{}, errors.New("wait canceled")
+		case <-expired:
+			return Event{}, errWaitTimeout
+		}
+	}
+}
+
+func isHighPriorityEvent(eventType string) bool {
+	switch eventType {
+	case "click", "submit", "select", "menu", "picked", "close", "exit":
+		return true
+	default:
+		return false
+	}
+}
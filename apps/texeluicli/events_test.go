@@ -0,0 +1,99 @@
+package texeluicli
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventLogCursors(t *testing.T) {
+	// Each step reads one event for client, or times out when want is ""
+	type step struct {
+		client  string
+		replay  int
+		filters []string
+		want    string
+	}
+	cases := []struct {
+		name  string
+		steps []step
+	}{
+		{"default queue is shared", []step{
+			{"", 0, nil, "click:a"},
+			{"", 0, nil, "change:b"},
+			{"", 0, nil, "click:c"},
+			{"", 0, nil, ""},
+		}},
+		{"named clients read every event", []step{
+			{"x", 0, nil, ""},
+			{"", 0, nil, "click:a"},
+			{"y", 3, nil, "click:a"},
+			{"y", 0, nil, "change:b"},
+		}},
+		{"replay starts back from the latest", []step{
+			{"x", 1, nil, "click:c"},
+			{"y", 2, nil, "change:b"},
+			{"z", 10, nil, "click:a"},
+		}},
+		{"replay only applies on first use", []step{
+			{"x", 1, nil, "click:c"},
+			{"x", 3, nil, ""},
+		}},
+		{"filters skip past other events", []step{
+			{"", 0, []string{"click:c"}, "click:c"},
+			{"", 0, nil, ""},
+		}},
+		{"type and id wildcards", []step{
+			{"x", 3, []string{"change"}, "change:b"},
+			{"y", 3, []string{"*:c"}, "click:c"},
+			{"z", 3, []string{"click:*"}, "click:a"},
+		}},
+	}
+	for _, c := range cases {
+		l := newEventLog()
+		l.emit(Event{Type: "click", ID: "a"})
+		l.emit(Event{Type: "change", ID: "b"})
+		l.emit(Event{Type: "click", ID: "c"})
+		for i, s := range c.steps {
+			ev, err := l.wait(l.cursor(s.client, s.replay), s.filters, time.Millisecond, nil)
+			got := ev.Type + ":" + ev.ID
+			if errors.Is(err, errWaitTimeout) {
+				got = ""
+			} else if err != nil {
+				t.Fatalf("%s: step %d: %v", c.name, i, err)
+			}
+			if got != s.want {
+				t.Errorf("%s: step %d (client %q): got %q, want %q", c.name, i, s.client, got, s.want)
+			}
+		}
+	}
+}
+
+func TestEventLogForgetAndClose(t *testing.T) {
+	l := newEventLog()
+	l.emit(Event{Type: "click", ID: "a"})
+
+	x := l.cursor("x", 1)
+	l.forget("x")
+	l.forget("")
+	if _, ok := l.cursors["x"]; ok {
+		t.Error("forget should drop a named client's cursor")
+	}
+	if _, ok := l.cursors[""]; !ok {
+		t.Error("forget should keep the default cursor")
+	}
+	if l.cursor("x", 0) == x {
+		t.Error("a forgotten client should get a new cursor")
+	}
+
+	// Events emitted before close are still read, then waits fail
+	l.close()
+	l.emit(Event{Type: "click", ID: "late"})
+	cur := l.newCursor(1)
+	if ev, err := l.wait(cur, nil, 0, nil); err != nil || ev.ID != "a" {
+		t.Errorf("after close: got %v, %v; want click:a", ev, err)
+	}
+	if _, err := l.wait(cur, nil, 0, nil); err == nil {
+		t.Error("wait on a closed, drained log should fail")
+	}
+}
@@ -0,0 +1,391 @@
+package texeluicli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/framegrace/texelui/widgets"
+)
+
+// runFlushInterval is how often streamed command output is appended to its
+// widget.
+const runFlushInterval = 50 * time.Millisecond
+
+// job is a command started by run. A foreground run waits for it; a
+// background one returns its id for jobs, kill and wait --job.
+type job struct {
+	id      string
+	argv    []string
+	started time.Time
+	cmd     *exec.Cmd
+	done    chan struct{} // Closed once the command has exited
+
+	mu     sync.Mutex
+	status string // "running", "exited", "killed" or "failed"
+	exit   int    // Exit code; 128+signal when killed by a signal
+	err    error  // Why waiting for the command failed
+}
+
+// info describes the job for the jobs command.
+func (j *job) info() JobInfo {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	info := JobInfo{ID: j.id, Command: strings.Join(j.argv, " "), Status: j.status, Started: j.started}
+	if j.status == "exited" || j.status == "killed" {
+		code := j.exit
+		info.ExitCode = &code
+	}
+	return info
+}
+
+// statusText is the status shown in a run --status widget.
+func (j *job) statusText() string {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	switch j.status {
+	case "exited", "killed":
+		return fmt.Sprintf("%s %d", j.status, j.exit)
+	case "failed":
+		return "failed: " + j.err.Error()
+	}
+	return j.status
+}
+
+// response is the result of a finished job.
+func (j *job) response() Response {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.err != nil {
+		return Response{OK: false, Error: j.err.Error(), Job: j.id}
+	}
+	code := j.exit
+	return Response{OK: true, ExitCode: &code, Job: j.id}
+}
+
+// signal sends sig to the job's process group, so the children of a shell
+// command stop too.
+func (j *job) signal(sig syscall.Signal) error {
+	select {
+	case <-j.done:
+		return fmt.Errorf("job %s is not running", j.id)
+	default:
+	}
+	return signalGroup(j.cmd, sig)
+}
+
+// finish records how the command ended.
+func (j *job) finish(waitErr error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.status = "exited"
+	var exitErr *exec.ExitError
+	switch {
+	case waitErr == nil:
+		j.exit = j.cmd.ProcessState.ExitCode()
+	case errors.As(waitErr, &exitErr):
+		j.exit = exitErr.ExitCode()
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			j.status = "killed"
+			j.exit = 128 + int(ws.Signal())
+		}
+	default:
+		j.status = "failed"
+		j.err = waitErr
+	}
+}
+
+// startJob starts the command of a run request, streaming its output into
+// the requested widgets. env is added to the server's environment before
+// the request's own variables. The job ends with an exit:<job id> event.
+func (s *Server) startJob(session *Session, run *RunRequest, env []string) (*job, error) {
+	argv := run.Argv
+	if len(argv) == 0 && run.Cmd != "" {
+		argv = []string{run.Cmd}
+	}
+	if len(argv) == 0 {
+		return nil, errors.New("command required")
+	}
+	if run.Status != "" {
+		if _, ok := session.Binding(run.Status); !ok {
+			return nil, fmt.Errorf("unknown widget %q", run.Status)
+		}
+	}
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	if run.Cwd != "" {
+		cmd.Dir = run.Cwd
+	}
+	if run.EnvFromWidgets {
+		for id, val := range session.inputValues() {
+			env = append(env, "TEXELUI_VALUE_"+envName(id)+"="+val)
+		}
+	}
+	for _, kv := range run.Env {
+		if name, _, ok := strings.Cut(kv, "="); !ok || name == "" {
+			return nil, fmt.Errorf("invalid environment variable %q (want KEY=VALUE)", kv)
+		}
+		env = append(env, kv)
+	}
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	switch {
+	case run.Stdin != "" && run.Input != "":
+		return nil, errors.New("stdin widget and input data are exclusive")
+	case (run.Stdin != "" || run.Input != "") && run.PTY:
+		return nil, errors.New("stdin cannot be fed to a pty command")
+	case run.Stdin != "":
+		b, ok := session.Binding(run.Stdin)
+		if !ok || b.get == nil {
+			return nil, fmt.Errorf("unknown widget %q", run.Stdin)
+		}
+		cmd.Stdin = strings.NewReader(b.get())
+	case run.Input != "":
+		cmd.Stdin = strings.NewReader(run.Input)
+	}
+
+	// With a PTY, stdout and stderr are the same terminal and stream into
+	// one widget.
+	ptyTarget := run.Stdout
+	if ptyTarget == "" {
+		ptyTarget = run.Stderr
+	}
+	cols, rows := 80, 24
+	if run.PTY {
+		if ptyTarget == "" {
+			return nil, errors.New("pty requires a stdout or stderr widget")
+		}
+		b, ok := session.Binding(ptyTarget)
+		if !ok {
+			return nil, fmt.Errorf("unknown widget %q", ptyTarget)
+		}
+		// The log keeps its last column for the scrollbar
+		if w, h := b.widget.Size(); w > 1 && h > 0 {
+			cols, rows = w-1, h
+		}
+	}
+
+	var stdout io.ReadCloser
+	var stderr io.ReadCloser
+	var err error
+	stdoutTarget := run.Stdout
+	if !run.PTY {
+		setProcessGroup(cmd)
+		if run.Stdout != "" {
+			stdout, err = cmd.StdoutPipe()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			cmd.Stdout = io.Discard
+		}
+		if run.Stderr != "" {
+			stderr, err = cmd.StderrPipe()
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			cmd.Stderr = io.Discard
+		}
+	}
+
+	if run.Clear != "" {
+		_ = s.runner.Post(func() error {
+			if b, ok := session.Binding(run.Clear); ok && b.set != nil {
+				_ = b.set("")
+				invalidateWidget(session.UI, b.widget)
+			}
+			return nil
+		})
+	}
+
+	if run.PTY {
+		master, err := startPTY(cmd, cols, rows)
+		if err != nil {
+			return nil, err
+		}
+		stdout, stdoutTarget = master, ptyTarget
+	} else if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	j := &job{argv: argv, started: time.Now(), cmd: cmd, done: make(chan struct{}), status: "running"}
+	session.addJob(j)
+	s.showJobStatus(session, run.Status, j)
+
+	var wg sync.WaitGroup
+	if stdout != nil {
+		wg.Add(1)
+		go s.stream(session, stdout, stdoutTarget, &wg)
+	}
+	if stderr != nil {
+		wg.Add(1)
+		go s.stream(session, stderr, run.Stderr, &wg)
+	}
+	go func() {
+		// All output must be read before Wait closes the pipes.
+		wg.Wait()
+		j.finish(cmd.Wait())
+		if stdout != nil && run.PTY {
+			stdout.Close()
+		}
+		s.showJobStatus(session, run.Status, j)
+		session.events.emit(Event{Type: "exit", ID: j.id})
+		close(j.done)
+	}()
+	return j, nil
+}
+
+// showJobStatus sets the run --status widget, if any, to the job's status.
+func (s *Server) showJobStatus(session *Session, target string, j *job) {
+	if target == "" {
+		return
+	}
+	text := j.statusText()
+	_ = s.runner.Post(func() error {
+		b, ok := session.Binding(target)
+		if ok && b.set != nil {
+			_ = b.set(text)
+			invalidateWidget(session.UI, b.widget)
+		}
+		return nil
+	})
+}
+
+// stream appends the lines read from r to the target widget, emitting an
+// output event for every batch.
+func (s *Server) stream(session *Session, r io.Reader, target string, wg *sync.WaitGroup) {
+	defer wg.Done()
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		buf := make([]byte, 0, 64*1024)
+		scanner.Buffer(buf, 1024*1024)
+		for scanner.Scan() {
+			// Terminals end lines with \r\n
+			lines <- strings.TrimSuffix(scanner.Text(), "\r") + "\n"
+		}
+		// Keep draining so the command never blocks on a full pipe.
+		_, _ = io.Copy(io.Discard, r)
+	}()
+
+	// Lines are batched and flushed at most every runFlushInterval, so a
+	// chatty command costs one repaint per interval, not per line.
+	var pending strings.Builder
+	flush := func() {
+		if pending.Len() == 0 {
+			return
+		}
+		text := pending.String()
+		pending.Reset()
+		_ = s.runner.Post(func() error {
+			b, ok := session.Binding(target)
+			if ok && b.append != nil {
+				b.append(text)
+				invalidateWidget(session.UI, b.widget)
+			}
+			return nil
+		})
+		session.events.emit(Event{Type: "output", ID: target})
+	}
+	ticker := time.NewTicker(runFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				flush()
+				return
+			}
+			pending.WriteString(line)
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// runEventActions runs the commands the spec binds to widget events, as
+// background jobs, reading events from cursor until the session closes.
+// Each command gets the event as TEXELUI_EVENT, the session and socket (so
+// it can call texelui itself), and the widget values at the time of the
+// event as TEXELUI_VALUE_<ID>.
+func (s *Server) runEventActions(session *Session, cursor *uint64) {
+	for {
+		ev, err := session.events.wait(cursor, nil, 0, nil)
+		if err != nil {
+			return
+		}
+		action, ok := session.eventAction(ev)
+		if !ok {
+			continue
+		}
+		env := []string{
+			"TEXELUI_EVENT=" + ev.Type + ":" + ev.ID,
+			"TEXELUI_SESSION=" + session.ID,
+			"TEXELUI_SOCKET=" + s.socketPath,
+		}
+		for id, val := range ev.values {
+			env = append(env, "TEXELUI_VALUE_"+envName(id)+"="+val)
+		}
+		run := &RunRequest{
+			Argv:   []string{"sh", "-c", action.Run},
+			Stdout: action.Stdout,
+			Stderr: action.Stderr,
+			Clear:  action.Clear,
+			Status: action.Status,
+			PTY:    action.PTY,
+		}
+		if _, err := s.startJob(session, run, env); err != nil {
+			text := fmt.Sprintf("%s:%s: %v", ev.Type, ev.ID, err)
+			_ = s.runner.Post(func() error {
+				session.Notify(text, widgets.MessageError, 0)
+				return nil
+			})
+		}
+	}
+}
+
+// envName turns a widget id into the upper-case letters, digits and
+// underscores of an environment variable name.
+func envName(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// parseSignal parses a signal name (TERM or SIGTERM) or number; "" is TERM.
+func parseSignal(name string) (syscall.Signal, error) {
+	switch strings.TrimPrefix(strings.ToUpper(name), "SIG") {
+	case "", "TERM":
+		return syscall.SIGTERM, nil
+	case "KILL":
+		return syscall.SIGKILL, nil
+	case "INT":
+		return syscall.SIGINT, nil
+	case "HUP":
+		return syscall.SIGHUP, nil
+	case "QUIT":
+		return syscall.SIGQUIT, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil && n > 0 {
+		return syscall.Signal(n), nil
+	}
+	return 0, fmt.Errorf("unknown signal %q (want TERM, KILL, INT, HUP, QUIT or a number)", name)
+}
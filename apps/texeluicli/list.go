@@ -0,0 +1,85 @@
+package texeluicli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/framegrace/texelui/primitives"
+)
+
+// newListWidget creates the ScrollableList behind a "list" widget.
+func newListWidget(texts []string, multi bool) *primitives.ScrollableList {
+	list := primitives.NewScrollableList(0, 0, 1, 1)
+	list.MultiSelect = multi
+	list.ShowScrollIndicators = true
+	items := make([]primitives.ListItem, len(texts))
+	for i, text := range texts {
+		items[i] = primitives.ListItem{Text: text}
+	}
+	list.SetItems(items)
+	return list
+}
+
+// selectText moves the cursor to the first item with the given text (and,
+// with multi-select, checks only that item). It reports whether one was
+// found.
+func selectText(list *primitives.ScrollableList, text string) bool {
+	idx := slices.IndexFunc(list.Items, func(item primitives.ListItem) bool { return item.Text == text })
+	if idx < 0 {
+		return false
+	}
+	list.SetSelected(idx)
+	if list.MultiSelect {
+		list.SetSelectedIndices([]int{idx})
+	}
+	return true
+}
+
+// listItems returns all items, or only the selected ones: the cursor item,
+// or the checked items when multi-select is on.
+func listItems(list *primitives.ScrollableList, selected bool) []ListItem {
+	var out []ListItem
+	if selected {
+		for _, idx := range list.SelectedIndices() {
+			out = append(out, ListItem{Index: idx, Text: list.Items[idx].Text})
+		}
+		return out
+	}
+	for i, item := range list.Items {
+		out = append(out, ListItem{Index: i, Text: item.Text})
+	}
+	return out
+}
+
+// removeListItems removes the items at indices, keeping the cursor and the
+// checked items on the same items when they survive.
+func removeListItems(list *primitives.ScrollableList, indices []int) error {
+	drop := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(list.Items) {
+			return fmt.Errorf("index %d out of range", idx)
+		}
+		drop[idx] = true
+	}
+	cursor := list.SelectedIdx
+	var items []primitives.ListItem
+	var checked []int
+	for i, item := range list.Items {
+		if drop[i] {
+			if i < list.SelectedIdx {
+				cursor--
+			}
+			continue
+		}
+		if list.MultiSelect && list.IsSelected(i) {
+			checked = append(checked, len(items))
+		}
+		items = append(items, item)
+	}
+	list.SelectedIdx = max(cursor, 0)
+	list.SetItems(items)
+	if len(checked) > 0 {
+		list.SetSelectedIndices(checked)
+	}
+	return nil
+}
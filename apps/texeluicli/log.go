@@ -0,0 +1,370 @@
+package texeluicli
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/scroll"
+	"github.com/framegrace/texelui/theme"
+)
+
+// logMaxEscape bounds an escape sequence left incomplete at the end of an
+// append; longer ones are garbage and dropped.
+const logMaxEscape = 256
+
+// logWidget is the "log" widget: read-only output that renders SGR escape
+// sequences (colors and attributes) and follows the tail as text arrives.
+// Other escape sequences are dropped, \r returns to the start of the line
+// so progress lines overwrite themselves, and ESC[K erases the line.
+type logWidget struct {
+	core.BaseWidget
+	pane    *scroll.ScrollPane
+	content *logContent
+	inv     func(core.Rect)
+}
+
+// logContent holds the styled lines. Cells keep tcell.ColorDefault until an
+// SGR sequence sets a color, and take the theme colors when drawn.
+type logContent struct {
+	core.BaseWidget
+	lines [][]core.Cell
+	col   int         // Cursor column in the last line
+	style tcell.Style // Current SGR style
+	esc   string      // Incomplete escape sequence from the last append
+	fg    tcell.Color
+	bg    tcell.Color
+	width int // Wrap width
+}
+
+func newLogWidget() *logWidget {
+	l := &logWidget{content: &logContent{lines: [][]core.Cell{nil}, width: 1}}
+	l.pane = scroll.NewScrollPane()
+	l.pane.SetChild(l.content)
+	l.pane.SetFollowTail(true)
+	l.applyTheme()
+	l.SetFocusable(true)
+	return l
+}
+
+func (l *logWidget) applyTheme() {
+	tm := theme.Get()
+	l.content.fg = tm.GetSemanticColor("text.primary")
+	l.content.bg = tm.GetSemanticColor("bg.surface")
+}
+
+// OnThemeChanged implements core.ThemeAware.
+func (l *logWidget) OnThemeChanged() { l.applyTheme() }
+
+// Text returns the text without its escape sequences.
+func (l *logWidget) Text() string {
+	lines := make([]string, len(l.content.lines))
+	for i, line := range l.content.lines {
+		var sb strings.Builder
+		for _, cell := range line {
+			sb.WriteRune(cell.Ch)
+		}
+		lines[i] = sb.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetText replaces the content, resetting the SGR style.
+func (l *logWidget) SetText(text string) {
+	c := l.content
+	c.lines = [][]core.Cell{nil}
+	c.col = 0
+	c.style = tcell.StyleDefault
+	c.esc = ""
+	l.AppendText(text)
+}
+
+// AppendText adds text at the end, interpreting its control characters and
+// escape sequences.
+func (l *logWidget) AppendText(text string) {
+	l.content.write(text)
+	l.updateContentSize()
+	l.invalidate()
+}
+
+func (l *logWidget) updateContentSize() {
+	h := l.content.rows()
+	l.content.Resize(l.content.width, h)
+	l.pane.SetContentHeight(h)
+}
+
+func (l *logWidget) Draw(p *core.Painter) {
+	l.pane.Draw(p)
+}
+
+func (l *logWidget) Resize(w, h int) {
+	l.BaseWidget.Resize(w, h)
+	l.pane.Resize(w, h)
+	// Reserve a column for the scrollbar, like TextArea
+	l.content.width = max(w-1, 1)
+	l.updateContentSize()
+}
+
+func (l *logWidget) SetPosition(x, y int) {
+	l.BaseWidget.SetPosition(x, y)
+	l.pane.SetPosition(x, y)
+}
+
+func (l *logWidget) Focus() {
+	l.BaseWidget.Focus()
+	l.pane.Focus()
+}
+
+func (l *logWidget) Blur() {
+	l.BaseWidget.Blur()
+	l.pane.Blur()
+}
+
+func (l *logWidget) HandleKey(ev *tcell.EventKey) bool {
+	return l.pane.HandleKey(ev)
+}
+
+func (l *logWidget) HandleMouse(ev *tcell.EventMouse) bool {
+	return l.pane.HandleMouse(ev)
+}
+
+func (l *logWidget) SetInvalidator(fn func(core.Rect)) {
+	l.inv = fn
+	l.pane.SetInvalidator(fn)
+}
+
+func (l *logWidget) invalidate() {
+	if l.inv != nil {
+		l.inv(l.Rect)
+	}
+}
+
+// rows returns the number of screen rows the wrapped lines take.
+func (c *logContent) rows() int {
+	n := 0
+	for _, line := range c.lines {
+		n += max((len(line)+c.width-1)/c.width, 1)
+	}
+	return n
+}
+
+func (c *logContent) Draw(p *core.Painter) {
+	base := tcell.StyleDefault.Foreground(c.fg).Background(c.bg)
+	p.Fill(c.Rect, ' ', base)
+	y := c.Rect.Y
+	for _, line := range c.lines {
+		for start := 0; start < len(line) || start == 0; start += c.width {
+			for x, cell := range line[start:min(start+c.width, len(line))] {
+				style := cell.Style
+				fg, bg, _ := style.Decompose()
+				if fg == tcell.ColorDefault {
+					style = style.Foreground(c.fg)
+				}
+				if bg == tcell.ColorDefault {
+					style = style.Background(c.bg)
+				}
+				p.SetCell(c.Rect.X+x, y, cell.Ch, style)
+			}
+			y++
+		}
+	}
+}
+
+// write appends text at the cursor.
+func (c *logContent) write(text string) {
+	text = c.esc + text
+	c.esc = ""
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case r == '\x1b':
+			n, ok := escapeLen(text[i:])
+			if !ok {
+				if len(text)-i <= logMaxEscape {
+					c.esc = text[i:]
+				}
+				return
+			}
+			c.escape(text[i : i+n])
+			size = n
+		case r == '\n':
+			c.lines = append(c.lines, nil)
+			c.col = 0
+		case r == '\r':
+			c.col = 0
+		case r == '\b':
+			c.col = max(c.col-1, 0)
+		case r == '\t':
+			for c.put(' '); c.col%8 != 0; {
+				c.put(' ')
+			}
+		case r >= ' ' && r != 0x7f:
+			c.put(r)
+		}
+		i += size
+	}
+}
+
+// put writes r at the cursor, overwriting what a \r left behind.
+func (c *logContent) put(r rune) {
+	last := len(c.lines) - 1
+	cell := core.Cell{Ch: r, Style: c.style}
+	if c.col < len(c.lines[last]) {
+		c.lines[last][c.col] = cell
+	} else {
+		for len(c.lines[last]) < c.col {
+			c.lines[last] = append(c.lines[last], core.Cell{Ch: ' ', Style: c.style})
+		}
+		c.lines[last] = append(c.lines[last], cell)
+	}
+	c.col++
+}
+
+// escapeLen returns the length of the escape sequence seq starts with, or
+// false if seq ends before the sequence does.
+func escapeLen(seq string) (int, bool) {
+	if len(seq) < 2 {
+		return 0, false
+	}
+	switch seq[1] {
+	case '[': // CSI: parameters, intermediates, final byte
+		for j := 2; j < len(seq); j++ {
+			if b := seq[j]; b >= 0x40 && b <= 0x7e {
+				return j + 1, true
+			} else if b < 0x20 {
+				return j, true // Malformed: drop what came before
+			}
+		}
+		return 0, false
+	case ']': // OSC: ends with BEL or ST
+		for j := 2; j < len(seq); j++ {
+			if seq[j] == '\a' {
+				return j + 1, true
+			}
+			if seq[j] == '\x1b' && j+1 < len(seq) && seq[j+1] == '\\' {
+				return j + 2, true
+			}
+		}
+		return 0, false
+	}
+	j := 1
+	for j < len(seq) && seq[j] >= 0x20 && seq[j] <= 0x2f {
+		j++
+	}
+	if j == len(seq) {
+		return 0, false
+	}
+	return j + 1, true
+}
+
+// escape applies the SGR and erase-in-line sequences and ignores the rest.
+func (c *logContent) escape(seq string) {
+	if len(seq) < 3 || seq[1] != '[' {
+		return
+	}
+	params := seq[2 : len(seq)-1]
+	switch seq[len(seq)-1] {
+	case 'm':
+		c.sgr(params)
+	case 'K':
+		last := len(c.lines) - 1
+		switch params {
+		case "", "0":
+			c.lines[last] = c.lines[last][:min(c.col, len(c.lines[last]))]
+		case "2":
+			c.lines[last] = nil
+		}
+	}
+}
+
+// sgr applies Select Graphic Rendition parameters to the current style.
+func (c *logContent) sgr(params string) {
+	codes := strings.Split(params, ";")
+	num := func(i int) int {
+		if i >= len(codes) {
+			return -1
+		}
+		n, err := strconv.Atoi(codes[i])
+		if err != nil {
+			if codes[i] == "" {
+				return 0
+			}
+			return -1
+		}
+		return n
+	}
+	st := c.style
+	for i := 0; i < len(codes); i++ {
+		switch n := num(i); {
+		case n == 0:
+			st = tcell.StyleDefault
+		case n == 1:
+			st = st.Bold(true)
+		case n == 2:
+			st = st.Dim(true)
+		case n == 3:
+			st = st.Italic(true)
+		case n == 4:
+			st = st.Underline(true)
+		case n == 5:
+			st = st.Blink(true)
+		case n == 7:
+			st = st.Reverse(true)
+		case n == 9:
+			st = st.StrikeThrough(true)
+		case n == 22:
+			st = st.Bold(false).Dim(false)
+		case n == 23:
+			st = st.Italic(false)
+		case n == 24:
+			st = st.Underline(false)
+		case n == 25:
+			st = st.Blink(false)
+		case n == 27:
+			st = st.Reverse(false)
+		case n == 29:
+			st = st.StrikeThrough(false)
+		case n >= 30 && n <= 37:
+			st = st.Foreground(tcell.PaletteColor(n - 30))
+		case n == 39:
+			st = st.Foreground(tcell.ColorDefault)
+		case n >= 40 && n <= 47:
+			st = st.Background(tcell.PaletteColor(n - 40))
+		case n == 49:
+			st = st.Background(tcell.ColorDefault)
+		case n >= 90 && n <= 97:
+			st = st.Foreground(tcell.PaletteColor(n - 90 + 8))
+		case n >= 100 && n <= 107:
+			st = st.Background(tcell.PaletteColor(n - 100 + 8))
+		case n == 38 || n == 48:
+			var color tcell.Color
+			switch num(i + 1) {
+			case 5:
+				if idx := num(i + 2); idx >= 0 && idx <= 255 {
+					color = tcell.PaletteColor(idx)
+				}
+				i += 2
+			case 2:
+				r, g, b := num(i+2), num(i+3), num(i+4)
+				if r >= 0 && g >= 0 && b >= 0 {
+					color = tcell.NewRGBColor(int32(r), int32(g), int32(b))
+				}
+				i += 4
+			default:
+				i = len(codes)
+				continue
+			}
+			if color == tcell.ColorDefault {
+				continue
+			}
+			if n == 38 {
+				st = st.Foreground(color)
+			} else {
+				st = st.Background(color)
+			}
+		}
+	}
+	c.style = st
+}
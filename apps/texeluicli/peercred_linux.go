@@ -0,0 +1,35 @@
+package texeluicli
+
+import (
+	"errors"
+	"net"
+	"os"
+	"syscall"
+)
+
+// checkPeer allows a Unix socket connection only from a process of the
+// user running the server, whatever the socket's file permissions.
+func checkPeer(conn net.Conn) error {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil
+	}
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var cred *syscall.Ucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	}); err != nil {
+		return err
+	}
+	if credErr != nil {
+		return credErr
+	}
+	if int(cred.Uid) != os.Getuid() {
+		return errors.New("permission denied")
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package texeluicli
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup does nothing: Windows has no process groups to signal.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// signalGroup signals the process itself; only KILL is supported.
+func signalGroup(cmd *exec.Cmd, sig syscall.Signal) error {
+	return cmd.Process.Signal(sig)
+}
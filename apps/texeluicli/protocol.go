@@ -1,9 +1,16 @@
 package texeluicli
 
+import (
+	"time"
+
+	"github.com/framegrace/texelui/core"
+)
+
 type Request struct {
 	Cmd     string     `json:"cmd"`
 	Session string     `json:"session,omitempty"`
 	Spec    *Spec      `json:"spec,omitempty"`
+	Patch   *SpecPatch `json:"patch,omitempty"`
 	Events  []string   `json:"events,omitempty"`
 	IDs     []string   `json:"ids,omitempty"`
 	Values  []string   `json:"values,omitempty"`
@@ -11,7 +18,32 @@ type Request struct {
 	Text    string     `json:"text,omitempty"`
 	Value   string     `json:"value,omitempty"`
 	Checked *bool      `json:"checked,omitempty"`
-	Run     *RunRequest `json:"run,omitempty"`
+	Action  string     `json:"action,omitempty"`
+	Columns []string   `json:"columns,omitempty"`
+	Rows    [][]string `json:"rows,omitempty"`
+	Items   []string   `json:"items,omitempty"`
+	Indices []int      `json:"indices,omitempty"`
+	// Set maps widget ids to values for a set of several widgets at once
+	Set map[string]string `json:"set,omitempty"`
+	// Selected limits a table get to the selected rows
+	Selected bool `json:"selected,omitempty"`
+	// Level and Duration (a Go duration such as "5s") style a notify message
+	Level    string `json:"level,omitempty"`
+	Duration string `json:"duration,omitempty"`
+	// Timeout (a Go duration) limits how long wait and watch wait for an event
+	Timeout string `json:"timeout,omitempty"`
+	// Client names the event queue of wait and watch; Replay starts a new
+	// queue that many events back
+	Client string      `json:"client,omitempty"`
+	Replay int         `json:"replay,omitempty"`
+	Run    *RunRequest `json:"run,omitempty"`
+	// Job selects the job of kill and wait; Signal is the one kill sends
+	Job    string `json:"job,omitempty"`
+	Signal string `json:"signal,omitempty"`
+	// Plain asks screenshot for text without ANSI escapes
+	Plain bool `json:"plain,omitempty"`
+	// Token authenticates requests on tcp:// and ws:// endpoints
+	Token string `json:"token,omitempty"`
 }
 
 type RunRequest struct {
@@ -21,6 +53,19 @@ type RunRequest struct {
 	Stderr string   `json:"stderr,omitempty"`
 	Clear  string   `json:"clear,omitempty"`
 	Cwd    string   `json:"cwd,omitempty"`
+	PTY    bool     `json:"pty,omitempty"`
+	// Background returns the job id at once instead of waiting for the
+	// command; Status is a widget showing the job's status
+	Background bool   `json:"background,omitempty"`
+	Status     string `json:"status,omitempty"`
+	// Stdin is a widget whose value the command reads on stdin; Input is
+	// stdin data sent by the client instead
+	Stdin string `json:"stdin,omitempty"`
+	Input string `json:"input,omitempty"`
+	// Env adds KEY=VALUE variables to the command's environment, after the
+	// widget values EnvFromWidgets exports as TEXELUI_VALUE_<ID>
+	Env            []string `json:"env,omitempty"`
+	EnvFromWidgets bool     `json:"env_from_widgets,omitempty"`
 }
 
 type Response struct {
@@ -30,4 +75,44 @@ type Response struct {
 	Event    string            `json:"event,omitempty"`
 	Values   map[string]string `json:"values,omitempty"`
 	ExitCode *int              `json:"exit_code,omitempty"`
+	Rows     [][]string        `json:"rows,omitempty"`
+	Items    []ListItem        `json:"items,omitempty"`
+	// TimedOut is set when a wait or watch timeout expired
+	TimedOut bool `json:"timed_out,omitempty"`
+	// Seq is the event's position in the session's event log
+	Seq      uint64        `json:"seq,omitempty"`
+	Sessions []SessionInfo `json:"sessions,omitempty"`
+	Job      string        `json:"job,omitempty"`
+	Jobs     []JobInfo     `json:"jobs,omitempty"`
+	// Tree is the widget tree returned by debug dump
+	Tree []core.WidgetNode `json:"tree,omitempty"`
+	// Screen is the frame returned by screenshot
+	Screen string `json:"screen,omitempty"`
+}
+
+// SessionInfo describes a running session.
+type SessionInfo struct {
+	ID      string    `json:"id"`
+	Title   string    `json:"title"`
+	Created time.Time `json:"created"`
+	// Widgets counts the widgets by type, e.g. "2 input, 1 button"
+	Widgets string `json:"widgets"`
+	// Socket is the server's socket, filled in by the client
+	Socket string `json:"socket,omitempty"`
+}
+
+// JobInfo describes a command started by run.
+type JobInfo struct {
+	ID      string `json:"id"`
+	Command string `json:"command"`
+	// Status is running, exited, killed (by a signal) or failed
+	Status   string    `json:"status"`
+	ExitCode *int      `json:"exit_code,omitempty"`
+	Started  time.Time `json:"started"`
+}
+
+// ListItem is an item of a list widget with its position.
+type ListItem struct {
+	Index int    `json:"index"`
+	Text  string `json:"text"`
 }
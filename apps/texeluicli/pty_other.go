@@ -0,0 +1,14 @@
+//go:build !linux
+
+package texeluicli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+// startPTY is only implemented on Linux.
+func startPTY(cmd *exec.Cmd, cols, rows int) (*os.File, error) {
+	return nil, errors.New("--pty is not supported on this platform")
+}
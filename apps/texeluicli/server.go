@@ -1,32 +1,62 @@
 package texeluicli
 
 import (
-	"bufio"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"os"
 	"os/signal"
-	"os/exec"
 	"path/filepath"
+	"slices"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/core"
 )
 
 type Server struct {
 	socketPath string
-	ln         net.Listener
+	listeners  []net.Listener
+	token      string
+	remoteExec bool
 	mu         sync.Mutex
 	session    *Session
+	opening    bool // An open is building its session, guarded by mu
 	runner     *uiRunner
 	stopOnce   sync.Once
 }
 
+// ServerConfig configures RunServerConfig.
+type ServerConfig struct {
+	// SocketPath is the Unix socket, SocketPath("") when empty.
+	SocketPath string
+	// Listen lists extra tcp:// and ws:// endpoints to serve.
+	Listen []string
+	// Token must be sent with every request on the Listen endpoints.
+	Token string
+	// RemoteExec lets requests on the Listen endpoints run commands on
+	// this host. Without it they are refused, as the token would
+	// otherwise grant a shell to anyone who learns it.
+	RemoteExec bool
+}
+
 func RunServer(socketPath string) error {
+	return RunServerConfig(ServerConfig{SocketPath: socketPath})
+}
+
+// RunServerConfig runs the server on its Unix socket and the configured
+// network endpoints until the session closes or the process is signaled.
+func RunServerConfig(cfg ServerConfig) error {
+	if err := core.ConfigureLogFromEnv(); err != nil {
+		return err
+	}
+	socketPath := cfg.SocketPath
 	if socketPath == "" {
 		var err error
 		socketPath, err = SocketPath("")
@@ -34,6 +64,13 @@ func RunServer(socketPath string) error {
 			return err
 		}
 	}
+	scheme, socketPath, err := parseEndpoint(socketPath)
+	if err != nil {
+		return err
+	}
+	if scheme != "unix" {
+		return fmt.Errorf("--socket must be a Unix socket path, use --listen for %s", cfg.SocketPath)
+	}
 	if err := os.MkdirAll(filepath.Dir(socketPath), 0700); err != nil {
 		return err
 	}
@@ -51,7 +88,13 @@ func RunServer(socketPath string) error {
 		return err
 	}
 
-	server := &Server{socketPath: socketPath, runner: newUIRunner(), ln: ln}
+	server := &Server{socketPath: socketPath, runner: newUIRunner(), listeners: []net.Listener{ln}, token: cfg.Token, remoteExec: cfg.RemoteExec}
+	for _, addr := range cfg.Listen {
+		if err := server.listen(addr); err != nil {
+			server.closeListeners()
+			return err
+		}
+	}
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	go func() {
@@ -59,15 +102,12 @@ func RunServer(socketPath string) error {
 		server.shutdown()
 	}()
 
-	for {
-		conn, err := ln.Accept()
-		if err != nil {
-			if errors.Is(err, net.ErrClosed) {
-				return nil
-			}
-			continue
-		}
-		go server.handle(conn)
+	return server.serve(ln, false)
+}
+
+func (s *Server) closeListeners() {
+	for _, ln := range s.listeners {
+		_ = ln.Close()
 	}
 }
 
@@ -75,9 +115,7 @@ func (s *Server) shutdown() {
 	s.stopOnce.Do(func() {
 		s.runner.Stop()
 		s.runner.Wait()
-		if s.ln != nil {
-			_ = s.ln.Close()
-		}
+		s.closeListeners()
 		s.mu.Lock()
 		session := s.session
 		s.session = nil
@@ -88,18 +126,72 @@ func (s *Server) shutdown() {
 	})
 }
 
-func (s *Server) handle(conn net.Conn) {
+// handle serves one request. Unix socket peers must be the server's user;
+// when the server has a token every request must carry it, and requests on
+// remote (network) connections must name their session and may only run
+// commands if the server allows remote execution.
+func (s *Server) handle(conn net.Conn, remote bool) {
 	defer conn.Close()
+	if !remote {
+		if err := checkPeer(conn); err != nil {
+			_ = json.NewEncoder(conn).Encode(Response{OK: false, Error: err.Error()})
+			return
+		}
+	}
 	dec := json.NewDecoder(conn)
 	var req Request
 	if err := dec.Decode(&req); err != nil {
 		_ = json.NewEncoder(conn).Encode(Response{OK: false, Error: err.Error()})
 		return
 	}
+	if s.token != "" && !s.authorized(req.Token) {
+		_ = json.NewEncoder(conn).Encode(Response{OK: false, Error: "invalid token"})
+		return
+	}
+	if remote && req.Cmd != "open" && req.Session == "" {
+		_ = json.NewEncoder(conn).Encode(Response{OK: false, Error: "session id required"})
+		return
+	}
+	if remote && !s.remoteExec {
+		if err := checkRemoteExec(req); err != nil {
+			_ = json.NewEncoder(conn).Encode(Response{OK: false, Error: err.Error()})
+			return
+		}
+	}
+	if req.Cmd == "watch" {
+		s.watch(conn, req)
+		return
+	}
 	resp := s.dispatch(req)
 	_ = json.NewEncoder(conn).Encode(resp)
 }
 
+// checkRemoteExec refuses requests that would run commands on this host,
+// for remote connections without --allow-remote-exec: run, and specs or
+// patches binding on: actions to widget events.
+func checkRemoteExec(req Request) error {
+	if req.Cmd == "run" {
+		return errors.New("run is not allowed on network endpoints (start the server with --allow-remote-exec)")
+	}
+	if req.Spec != nil && req.Spec.hasActions() {
+		return errors.New("on: actions are not allowed on network endpoints (start the server with --allow-remote-exec)")
+	}
+	if req.Patch != nil {
+		for _, raw := range req.Patch.Widgets {
+			var ws struct {
+				On map[string]EventAction `json:"on"`
+			}
+			if err := json.Unmarshal(raw, &ws); err != nil {
+				return err
+			}
+			if actionsRun(ws.On) {
+				return errors.New("on: actions are not allowed on network endpoints (start the server with --allow-remote-exec)")
+			}
+		}
+	}
+	return nil
+}
+
 func (s *Server) dispatch(req Request) Response {
 	switch req.Cmd {
 	case "open":
@@ -110,10 +202,40 @@ func (s *Server) dispatch(req Request) Response {
 		return s.get(req)
 	case "set":
 		return s.set(req)
+	case "enable", "disable":
+		return s.setDisabled(req, req.Cmd == "disable")
+	case "focus":
+		return s.focus(req)
+	case "dump":
+		return s.dump(req)
+	case "screenshot":
+		return s.screenshot(req)
+	case "update":
+		return s.update(req)
 	case "append":
 		return s.append(req)
+	case "table":
+		return s.table(req)
+	case "progress":
+		return s.progress(req)
+	case "list":
+		return s.list(req)
+	case "notify":
+		return s.notify(req)
+	case "title":
+		return s.title(req)
+	case "status":
+		return s.status(req)
+	case "ls":
+		return s.ls(req)
+	case "attach":
+		return s.attach(req)
 	case "run":
 		return s.run(req)
+	case "jobs":
+		return s.jobs(req)
+	case "kill":
+		return s.kill(req)
 	case "close":
 		return s.close(req)
 	default:
@@ -125,46 +247,125 @@ func (s *Server) open(req Request) Response {
 	if req.Spec == nil {
 		return Response{OK: false, Error: "spec is required"}
 	}
+	// Reserve the slot so concurrent opens can't both start a session
 	s.mu.Lock()
-	if s.session != nil {
+	if s.session != nil || s.opening {
 		s.mu.Unlock()
 		return Response{OK: false, Error: "session already active"}
 	}
+	s.opening = true
 	s.mu.Unlock()
 
 	session, err := BuildSession(*req.Spec)
 	if err != nil {
+		s.endOpen(nil)
 		return Response{OK: false, Error: err.Error()}
 	}
+	// Event commands must see the events from the first frame on
+	actions := session.events.newCursor(0)
 	if err := s.runner.Start(session, func() {
 		s.clearSession(session.ID)
 		s.shutdown()
 	}); err != nil {
+		s.endOpen(nil)
 		return Response{OK: false, Error: err.Error()}
 	}
+	s.endOpen(session)
+	go s.runEventActions(session, actions)
+	return Response{OK: true, Session: session.ID}
+}
+
+// endOpen releases the reservation taken by open and installs session,
+// which is nil when the open failed.
+func (s *Server) endOpen(session *Session) {
 	s.mu.Lock()
-	s.session = session
+	s.opening = false
+	if session != nil {
+		s.session = session
+	}
 	s.mu.Unlock()
-	return Response{OK: true, Session: session.ID}
 }
 
 func (s *Server) wait(req Request) Response {
+	if req.Job != "" {
+		return s.waitJob(req)
+	}
 	session, err := s.getSession(req.Session)
 	if err != nil {
 		return Response{OK: false, Error: err.Error()}
 	}
-	ev, err := session.Wait(req.Events)
+	timeout, err := parseTimeout(req.Timeout)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	ev, err := session.Wait(req.Client, req.Replay, req.Events, timeout)
+	if errors.Is(err, errWaitTimeout) {
+		return Response{OK: true, TimedOut: true}
+	}
 	if err != nil {
 		return Response{OK: false, Error: err.Error()}
 	}
 	values := map[string]string{}
 	if len(req.Values) > 0 {
-		values, err = session.Values(req.Values)
+		values, err = session.EventValues(ev, req.Values)
 		if err != nil {
 			return Response{OK: false, Error: err.Error()}
 		}
 	}
-	return Response{OK: true, Event: fmt.Sprintf("%s:%s", ev.Type, ev.ID), Values: values}
+	return Response{OK: true, Event: fmt.Sprintf("%s:%s", ev.Type, ev.ID), Values: values, Seq: ev.Seq}
+}
+
+// watch streams a response line for every matching event until the
+// session closes, the client disconnects, or no event arrives within the
+// timeout, which ends the stream with a TimedOut response. Each watch reads
+// its own queue, or the named client's, so it takes no events from waits;
+// a named client's queue is dropped when it disconnects.
+func (s *Server) watch(conn net.Conn, req Request) {
+	enc := json.NewEncoder(conn)
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		_ = enc.Encode(Response{OK: false, Error: err.Error()})
+		return
+	}
+	timeout, err := parseTimeout(req.Timeout)
+	if err != nil {
+		_ = enc.Encode(Response{OK: false, Error: err.Error()})
+		return
+	}
+	cursor := session.events.newCursor(req.Replay)
+	if req.Client != "" {
+		cursor = session.events.cursor(req.Client, req.Replay)
+	}
+	// The client sends nothing after the request: EOF means it went away
+	gone := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, conn)
+		close(gone)
+	}()
+	for {
+		ev, err := session.events.wait(cursor, req.Events, timeout, gone)
+		if errors.Is(err, errWaitTimeout) {
+			_ = enc.Encode(Response{OK: true, TimedOut: true})
+			return
+		}
+		if err != nil {
+			// The client went away or the session closed
+			session.events.forget(req.Client)
+			return
+		}
+		resp := Response{OK: true, Event: fmt.Sprintf("%s:%s", ev.Type, ev.ID), Seq: ev.Seq}
+		if len(req.Values) > 0 {
+			resp.Values, err = session.EventValues(ev, req.Values)
+			if err != nil {
+				_ = enc.Encode(Response{OK: false, Error: err.Error()})
+				return
+			}
+		}
+		if err := enc.Encode(resp); err != nil {
+			session.events.forget(req.Client)
+			return
+		}
+	}
 }
 
 func (s *Server) get(req Request) Response {
@@ -184,6 +385,9 @@ func (s *Server) set(req Request) Response {
 	if err != nil {
 		return Response{OK: false, Error: err.Error()}
 	}
+	if len(req.Set) > 0 {
+		return s.setMany(session, req.Set)
+	}
 	b, ok := session.Binding(req.ID)
 	if !ok {
 		return Response{OK: false, Error: fmt.Sprintf("unknown widget %q", req.ID)}
@@ -217,6 +421,221 @@ func (s *Server) set(req Request) Response {
 	return Response{OK: true}
 }
 
+// setMany sets several widgets in one UI update. Every id is checked before
+// a widget changes, and if a widget rejects its value the ones already set
+// get their old values back, so the batch applies entirely or not at all.
+func (s *Server) setMany(session *Session, values map[string]string) Response {
+	ids := slices.Sorted(maps.Keys(values))
+	bindings := make([]*binding, len(ids))
+	for i, id := range ids {
+		b, ok := session.Binding(id)
+		if !ok {
+			return Response{OK: false, Error: fmt.Sprintf("unknown widget %q", id)}
+		}
+		if b.set == nil {
+			return Response{OK: false, Error: fmt.Sprintf("widget %q is not writable", id)}
+		}
+		bindings[i] = b
+	}
+	apply := func() error {
+		old := make([]string, len(ids))
+		for i, b := range bindings {
+			if b.get != nil {
+				old[i] = b.get()
+			}
+			if err := b.set(values[ids[i]]); err != nil {
+				for j := i - 1; j >= 0; j-- {
+					_ = bindings[j].set(old[j])
+				}
+				return fmt.Errorf("widget %q: %w", ids[i], err)
+			}
+		}
+		for _, b := range bindings {
+			invalidateWidget(session.UI, b.widget)
+		}
+		return nil
+	}
+	done := make(chan error, 1)
+	action := func() error {
+		err := apply()
+		done <- err
+		return err
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	// Report rejected values back to the caller, like update
+	var err error
+	select {
+	case err = <-done:
+	case <-session.closedCh:
+		err = errors.New("session closed")
+	}
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
+
+// setDisabled disables or enables the widgets in req.IDs. When the focused
+// widget gets disabled, the focus moves to an enabled one.
+func (s *Server) setDisabled(req Request, disabled bool) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	if len(req.IDs) == 0 {
+		return Response{OK: false, Error: "ids are required"}
+	}
+	targets := make([]core.Disableable, len(req.IDs))
+	bindings := make([]*binding, len(req.IDs))
+	for i, id := range req.IDs {
+		b, ok := session.Binding(id)
+		if !ok {
+			return Response{OK: false, Error: fmt.Sprintf("unknown widget %q", id)}
+		}
+		d, ok := b.widget.(core.Disableable)
+		if !ok {
+			return Response{OK: false, Error: fmt.Sprintf("widget %q cannot be disabled", id)}
+		}
+		targets[i], bindings[i] = d, b
+	}
+	action := func() error {
+		root := focusTarget(session.Root)
+		refocus := false
+		for _, b := range bindings {
+			refocus = refocus || disabled && core.IsDescendantFocused(b.widget)
+		}
+		if refocus {
+			// The containers remember the focused position to restore
+			root.Blur()
+		}
+		for i, d := range targets {
+			d.SetDisabled(disabled)
+			invalidateWidget(session.UI, bindings[i].widget)
+		}
+		if refocus {
+			session.UI.Focus(root)
+			root.Focus()
+		}
+		return nil
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
+
+func (s *Server) focus(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	if req.ID == "" {
+		return Response{OK: false, Error: "id is required"}
+	}
+	done := make(chan error, 1)
+	action := func() error {
+		err := session.Focus(req.ID)
+		done <- err
+		return err
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	select {
+	case err = <-done:
+	case <-session.closedCh:
+		err = errors.New("session closed")
+	}
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
+
+// dump returns the widget tree of the session's UI.
+func (s *Server) dump(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	done := make(chan []core.WidgetNode, 1)
+	action := func() error {
+		done <- session.UI.DumpTree()
+		return nil
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	select {
+	case tree := <-done:
+		return Response{OK: true, Tree: tree}
+	case <-session.closedCh:
+		return Response{OK: false, Error: "session closed"}
+	}
+}
+
+func (s *Server) screenshot(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	done := make(chan string, 1)
+	action := func() error {
+		if req.Plain {
+			done <- session.UI.ScreenshotText()
+		} else {
+			done <- session.UI.Screenshot()
+		}
+		return nil
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	select {
+	case screen := <-done:
+		return Response{OK: true, Screen: screen}
+	case <-session.closedCh:
+		return Response{OK: false, Error: "session closed"}
+	}
+}
+
+func (s *Server) update(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	if req.Patch == nil && req.Spec == nil {
+		return Response{OK: false, Error: "patch is required"}
+	}
+	done := make(chan error, 1)
+	action := func() error {
+		var err error
+		if req.Spec != nil {
+			// A whole spec replaces the current one, as open --watch sends
+			err = session.Reload(*req.Spec)
+		} else {
+			err = session.Update(*req.Patch)
+		}
+		done <- err
+		return err
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	// Report patch errors (unknown ids or types) back to the caller
+	select {
+	case err = <-done:
+	case <-session.closedCh:
+		err = errors.New("session closed")
+	}
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
+
 func (s *Server) append(req Request) Response {
 	session, err := s.getSession(req.Session)
 	if err != nil {
@@ -240,103 +659,288 @@ func (s *Server) append(req Request) Response {
 	return Response{OK: true}
 }
 
-func (s *Server) run(req Request) Response {
+func (s *Server) table(req Request) Response {
 	session, err := s.getSession(req.Session)
 	if err != nil {
 		return Response{OK: false, Error: err.Error()}
 	}
-	if req.Run == nil {
-		return Response{OK: false, Error: "run request missing"}
+	b, ok := session.Binding(req.ID)
+	if !ok {
+		return Response{OK: false, Error: fmt.Sprintf("unknown widget %q", req.ID)}
 	}
-	argv := req.Run.Argv
-	if len(argv) == 0 && req.Run.Cmd != "" {
-		argv = []string{req.Run.Cmd}
+	if b.setRows == nil {
+		return Response{OK: false, Error: fmt.Sprintf("widget %q is not a table", req.ID)}
 	}
-	if len(argv) == 0 {
-		return Response{OK: false, Error: "command required"}
+	switch req.Action {
+	case "set-rows":
+		action := func() error {
+			b.setRows(req.Columns, req.Rows)
+			invalidateWidget(session.UI, b.widget)
+			return nil
+		}
+		if err := s.runner.Post(action); err != nil {
+			return Response{OK: false, Error: err.Error()}
+		}
+		return Response{OK: true}
+	case "get":
+		return Response{OK: true, Rows: b.rows(req.Selected)}
+	default:
+		return Response{OK: false, Error: fmt.Sprintf("unknown table action %q", req.Action)}
 	}
+}
 
-	cmd := exec.Command(argv[0], argv[1:]...)
-	if req.Run.Cwd != "" {
-		cmd.Dir = req.Run.Cwd
+func (s *Server) list(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	b, ok := session.Binding(req.ID)
+	if !ok {
+		return Response{OK: false, Error: fmt.Sprintf("unknown widget %q", req.ID)}
+	}
+	if b.items == nil {
+		return Response{OK: false, Error: fmt.Sprintf("widget %q is not a list", req.ID)}
+	}
+	var action func() error
+	switch req.Action {
+	case "append":
+		action = func() error {
+			b.addItems(req.Items)
+			return nil
+		}
+	case "remove":
+		action = func() error { return b.delItems(req.Indices) }
+	case "get":
+		return Response{OK: true, Items: b.items(req.Selected)}
+	default:
+		return Response{OK: false, Error: fmt.Sprintf("unknown list action %q", req.Action)}
+	}
+	done := make(chan error, 1)
+	post := func() error {
+		err := action()
+		invalidateWidget(session.UI, b.widget)
+		done <- err
+		return err
+	}
+	if err := s.runner.Post(post); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	select {
+	case err = <-done:
+	case <-session.closedCh:
+		err = errors.New("session closed")
+	}
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
 	}
+	return Response{OK: true}
+}
 
-	var stdout io.ReadCloser
-	var stderr io.ReadCloser
-	if req.Run.Stdout != "" {
-		stdout, err = cmd.StdoutPipe()
-		if err != nil {
+func (s *Server) progress(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	b, ok := session.Binding(req.ID)
+	if !ok {
+		return Response{OK: false, Error: fmt.Sprintf("unknown widget %q", req.ID)}
+	}
+	if b.setLabel == nil {
+		return Response{OK: false, Error: fmt.Sprintf("widget %q is not a progress bar", req.ID)}
+	}
+	if req.Value != "" {
+		if _, err := parsePercent(req.Value); err != nil {
 			return Response{OK: false, Error: err.Error()}
 		}
-	} else {
-		cmd.Stdout = io.Discard
 	}
-	if req.Run.Stderr != "" {
-		stderr, err = cmd.StderrPipe()
-		if err != nil {
-			return Response{OK: false, Error: err.Error()}
+	action := func() error {
+		if req.Value != "" {
+			_ = b.set(req.Value)
+		}
+		if req.Text != "" {
+			b.setLabel(req.Text)
+		}
+		invalidateWidget(session.UI, b.widget)
+		return nil
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
+
+func (s *Server) notify(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	if req.Text == "" {
+		return Response{OK: false, Error: "text is required"}
+	}
+	level, err := parseMessageLevel(req.Level)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	var duration time.Duration
+	if req.Duration != "" {
+		if duration, err = time.ParseDuration(req.Duration); err != nil || duration <= 0 {
+			return Response{OK: false, Error: fmt.Sprintf("invalid duration %q", req.Duration)}
 		}
-	} else {
-		cmd.Stderr = io.Discard
 	}
+	action := func() error {
+		session.Notify(req.Text, level, duration)
+		return nil
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
 
-	if req.Run.Clear != "" {
-		_ = s.runner.Post(func() error {
-			if b, ok := session.Binding(req.Run.Clear); ok && b.set != nil {
-				_ = b.set("")
-				invalidateWidget(session.UI, b.widget)
-			}
-			return nil
-		})
+// title changes the session's title; the UI loop passes it on to the
+// terminal when it next draws.
+func (s *Server) title(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	action := func() error {
+		session.SetTitle(req.Text)
+		return nil
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
 	}
+	return Response{OK: true}
+}
 
-	if err := cmd.Start(); err != nil {
+// status sets or, with no text, clears the persistent status text.
+func (s *Server) status(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
 		return Response{OK: false, Error: err.Error()}
 	}
+	level, err := parseMessageLevel(req.Level)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	action := func() error {
+		session.SetStatus(req.Text, level)
+		return nil
+	}
+	if err := s.runner.Post(action); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true}
+}
 
-	var wg sync.WaitGroup
-	stream := func(r io.Reader, target string) {
-		defer wg.Done()
-		scanner := bufio.NewScanner(r)
-		buf := make([]byte, 0, 64*1024)
-		scanner.Buffer(buf, 1024*1024)
-		for scanner.Scan() {
-			line := scanner.Text() + "\n"
-			_ = s.runner.Post(func() error {
-				b, ok := session.Binding(target)
-				if ok && b.append != nil {
-					b.append(line)
-					invalidateWidget(session.UI, b.widget)
-				}
-				return nil
-			})
+// ls describes the server's session, if any. A named session must match,
+// as remote clients name theirs and must not learn other ids.
+func (s *Server) ls(req Request) Response {
+	s.mu.Lock()
+	session := s.session
+	s.mu.Unlock()
+	if req.Session != "" {
+		var err error
+		if session, err = s.getSession(req.Session); err != nil {
+			return Response{OK: false, Error: err.Error()}
 		}
 	}
+	if session == nil {
+		return Response{OK: true}
+	}
+	return Response{OK: true, Sessions: []SessionInfo{session.Info()}}
+}
 
-	if stdout != nil {
-		wg.Add(1)
-		go stream(stdout, req.Run.Stdout)
+// attach describes the session and repaints its terminal, which output of
+// a crashed script may have garbled.
+func (s *Server) attach(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	if err := s.runner.Post(s.runner.sync); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true, Sessions: []SessionInfo{session.Info()}}
+}
+
+func (s *Server) run(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	if req.Run == nil {
+		return Response{OK: false, Error: "run request missing"}
+	}
+	j, err := s.startJob(session, req.Run, nil)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
 	}
-	if stderr != nil {
-		wg.Add(1)
-		go stream(stderr, req.Run.Stderr)
+	if req.Run.Background {
+		return Response{OK: true, Job: j.id}
 	}
+	<-j.done
+	return j.response()
+}
 
-	waitErr := cmd.Wait()
-	wg.Wait()
+func (s *Server) jobs(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	infos := []JobInfo{}
+	for _, j := range session.Jobs() {
+		infos = append(infos, j.info())
+	}
+	return Response{OK: true, Jobs: infos}
+}
 
-	exitCode := 0
-	if waitErr != nil {
-		if exitErr, ok := waitErr.(*exec.ExitError); ok {
-			exitCode = exitErr.ExitCode()
-		} else {
-			return Response{OK: false, Error: waitErr.Error()}
-		}
-	} else if cmd.ProcessState != nil {
-		exitCode = cmd.ProcessState.ExitCode()
+func (s *Server) kill(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	j, ok := session.Job(req.Job)
+	if !ok {
+		return Response{OK: false, Error: fmt.Sprintf("job %q not found", req.Job)}
 	}
+	sig, err := parseSignal(req.Signal)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	if err := j.signal(sig); err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	return Response{OK: true, Job: j.id}
+}
 
-	return Response{OK: true, ExitCode: &exitCode}
+// waitJob waits for a job to finish and reports its exit code.
+func (s *Server) waitJob(req Request) Response {
+	session, err := s.getSession(req.Session)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	j, ok := session.Job(req.Job)
+	if !ok {
+		return Response{OK: false, Error: fmt.Sprintf("job %q not found", req.Job)}
+	}
+	timeout, err := parseTimeout(req.Timeout)
+	if err != nil {
+		return Response{OK: false, Error: err.Error()}
+	}
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	select {
+	case <-j.done:
+		return j.response()
+	case <-expired:
+		return Response{OK: true, TimedOut: true, Job: j.id}
+	}
 }
 
 func (s *Server) close(req Request) Response {
@@ -350,13 +954,25 @@ func (s *Server) close(req Request) Response {
 	return Response{OK: true}
 }
 
+// parseTimeout parses a request timeout; "" means none.
+func parseTimeout(val string) (time.Duration, error) {
+	if val == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(val)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid timeout %q", val)
+	}
+	return timeout, nil
+}
+
 func (s *Server) getSession(id string) (*Session, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.session == nil {
 		return nil, errors.New("no active session")
 	}
-	if id == "" || id == s.session.ID {
+	if id == "" || subtle.ConstantTimeCompare([]byte(id), []byte(s.session.ID)) == 1 {
 		return s.session, nil
 	}
 	return nil, fmt.Errorf("session %q not found", id)
@@ -379,6 +995,7 @@ type uiRunner struct {
 	stopCh    chan struct{}
 	doneCh    chan struct{}
 	onClosed  func()
+	title     string // Title last set on the screen
 }
 
 func newUIRunner() *uiRunner {
@@ -556,6 +1173,17 @@ func (r *uiRunner) drainActions() {
 	}
 }
 
+// sync repaints the whole terminal. Must run on the UI goroutine.
+func (r *uiRunner) sync() error {
+	r.mu.Lock()
+	screen := r.screen
+	r.mu.Unlock()
+	if screen != nil {
+		screen.Sync()
+	}
+	return nil
+}
+
 func (r *uiRunner) draw() {
 	r.mu.Lock()
 	screen := r.screen
@@ -564,6 +1192,10 @@ func (r *uiRunner) draw() {
 	if screen == nil || session == nil {
 		return
 	}
+	if title := session.CurrentTitle(); title != r.title {
+		r.title = title
+		screen.SetTitle(title)
+	}
 	screen.Clear()
 	buffer := session.UI.Render()
 	if buffer != nil {
@@ -0,0 +1,29 @@
+package texeluicli
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCheckRemoteExec(t *testing.T) {
+	withAction := &Spec{Widgets: []WidgetSpec{{ID: "b", Type: "button", On: map[string]EventAction{"click": {Run: "make"}}}}}
+	plain := &Spec{Widgets: []WidgetSpec{{ID: "b", Type: "button"}}}
+	cases := []struct {
+		name    string
+		req     Request
+		allowed bool
+	}{
+		{"run", Request{Cmd: "run", Run: &RunRequest{Argv: []string{"true"}}}, false},
+		{"open with action", Request{Cmd: "open", Spec: withAction}, false},
+		{"open plain", Request{Cmd: "open", Spec: plain}, true},
+		{"update with action", Request{Cmd: "update", Patch: &SpecPatch{Widgets: []json.RawMessage{json.RawMessage(`{"id":"b","on":{"click":"rm -rf ~"}}`)}}}, false},
+		{"update plain", Request{Cmd: "update", Patch: &SpecPatch{Widgets: []json.RawMessage{json.RawMessage(`{"id":"b","label":"Go"}`)}}}, true},
+		{"get", Request{Cmd: "get"}, true},
+	}
+	for _, c := range cases {
+		err := checkRemoteExec(c.req)
+		if (err == nil) != c.allowed {
+			t.Errorf("%s: allowed %v, got error %v", c.name, c.allowed, err)
+		}
+	}
+}
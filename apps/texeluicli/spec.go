@@ -3,8 +3,13 @@ package texeluicli
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"slices"
+
+	"gopkg.in/yaml.v3"
 )
 
 type Spec struct {
@@ -33,20 +38,317 @@ type WidgetSpec struct {
 	Placeholder string      `json:"placeholder,omitempty"`
 	Flex        bool        `json:"flex,omitempty"`
 	Editable    bool        `json:"editable,omitempty"`
+	Hidden      bool        `json:"hidden,omitempty"`
+	Disabled    bool        `json:"disabled,omitempty"`
+	Columns     []string    `json:"columns,omitempty"`
+	Rows        [][]string  `json:"rows,omitempty"`
+	Multi       bool        `json:"multi,omitempty"`
+	Items       []string    `json:"items,omitempty"`
+	// On maps event types (click, change, submit, select) to commands the
+	// server runs when this widget emits them
+	On map[string]EventAction `json:"on,omitempty"`
+	// Menu holds the menus of a menu widget
+	Menu []MenuItemSpec `json:"menu,omitempty"`
+	// StartDir, Pattern and DirsOnly configure the browser of a filepicker
+	StartDir string `json:"start_dir,omitempty"`
+	Pattern  string `json:"pattern,omitempty"`
+	DirsOnly bool   `json:"dirs_only,omitempty"`
+}
+
+// MenuItemSpec is an entry of a menu widget: a menu of the bar, a submenu
+// holding items, or a command whose id names its menu:<id> event.
+type MenuItemSpec struct {
+	ID       string         `json:"id,omitempty"`
+	Label    string         `json:"label"`
+	Items    []MenuItemSpec `json:"items,omitempty"`
+	Disabled bool           `json:"disabled,omitempty"`
+}
+
+// EventAction is a command bound to a widget event. In a spec it is either
+// the command line, run with sh -c, or an object that also names the
+// widgets taking its output, like texelui run.
+type EventAction struct {
+	Run    string `json:"run"`
+	Stdout string `json:"stdout,omitempty"`
+	Stderr string `json:"stderr,omitempty"`
+	Clear  string `json:"clear,omitempty"`
+	Status string `json:"status,omitempty"`
+	PTY    bool   `json:"pty,omitempty"`
+}
+
+func (a *EventAction) UnmarshalJSON(data []byte) error {
+	var cmd string
+	if err := json.Unmarshal(data, &cmd); err == nil {
+		*a = EventAction{Run: cmd}
+		return nil
+	}
+	type plain EventAction
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// SpecPatch describes changes to a live session. Each widget entry is a
+// partial widget spec: an entry whose id exists is merged into that widget
+// field by field, any other entry adds a widget, placed after or before an
+// existing one ("after"/"before") or at the end. Remove lists the ids of
+// widgets to drop.
+type SpecPatch struct {
+	Title   *string           `json:"title,omitempty"`
+	Widgets []json.RawMessage `json:"widgets,omitempty"`
+	Remove  []string          `json:"remove,omitempty"`
+}
+
+// hasActions reports whether any widget binds a command to an event.
+func (s Spec) hasActions() bool {
+	for _, ws := range s.Widgets {
+		if actionsRun(ws.On) {
+			return true
+		}
+	}
+	return false
+}
+
+// actionsRun reports whether on binds any command.
+func actionsRun(on map[string]EventAction) bool {
+	for _, action := range on {
+		if action.Run != "" {
+			return true
+		}
+	}
+	return false
 }
 
+// DecodeSpec reads a JSON or YAML spec, telling them apart by the first
+// character: JSON specs start with '{'.
 func DecodeSpec(r io.Reader) (Spec, error) {
+	return DecodeSpecFormat(r, "auto")
+}
+
+// DecodeSpecFormat reads a spec in the given format: "json", "yaml", or
+// "auto" (or "") to detect it.
+func DecodeSpecFormat(r io.Reader, format string) (Spec, error) {
+	return DecodeSpecWith(r, DecodeOptions{Format: format})
+}
+
+// DecodeSpecWith reads a spec as DecodeSpecFormat, then substitutes ${name}
+// references with opts.Vars or the environment and splices in the widgets
+// of include items.
+func DecodeSpecWith(r io.Reader, opts DecodeOptions) (Spec, error) {
+	var spec Spec
+	if err := decodeDocument(r, opts, &spec); err != nil {
+		return Spec{}, err
+	}
+	return spec, nil
+}
+
+// DecodeSpecPatch reads a JSON or YAML patch, detected like DecodeSpec.
+func DecodeSpecPatch(r io.Reader) (SpecPatch, error) {
+	return DecodeSpecPatchFormat(r, "auto")
+}
+
+// DecodeSpecPatchFormat reads a patch in the given format, as
+// DecodeSpecFormat.
+func DecodeSpecPatchFormat(r io.Reader, format string) (SpecPatch, error) {
+	return DecodeSpecPatchWith(r, DecodeOptions{Format: format})
+}
+
+// DecodeSpecPatchWith reads a patch with variables and includes, as
+// DecodeSpecWith.
+func DecodeSpecPatchWith(r io.Reader, opts DecodeOptions) (SpecPatch, error) {
+	var patch SpecPatch
+	if err := decodeDocument(r, opts, &patch); err != nil {
+		return SpecPatch{}, err
+	}
+	return patch, nil
+}
+
+// decodeDocument decodes a JSON or YAML document into v through the JSON
+// field names, keeping numbers as json.Number. YAML is converted to JSON
+// first so both formats accept exactly the same fields; so is JSON using
+// variables or includes, once it is known to be valid.
+func decodeDocument(r io.Reader, opts DecodeOptions, v any) error {
 	data, err := io.ReadAll(r)
 	if err != nil {
-		return Spec{}, err
+		return err
+	}
+	format, err := detectFormat(data, opts.Format)
+	if err != nil {
+		return err
+	}
+	templated := bytes.Contains(data, []byte("${")) || bytes.Contains(data, []byte(`"include"`))
+	if format == "yaml" || templated && json.Valid(data) {
+		if data, err = expandDocument(data, format, opts); err != nil {
+			return err
+		}
 	}
 	dec := json.NewDecoder(bytes.NewReader(data))
 	dec.UseNumber()
-	var spec Spec
-	if err := dec.Decode(&spec); err != nil {
-		return Spec{}, err
+	return dec.Decode(v)
+}
+
+// detectFormat resolves format for data to "json" or "yaml". Auto-detection
+// takes input starting with '{' as JSON and anything else as YAML.
+func detectFormat(data []byte, format string) (string, error) {
+	switch format {
+	case "", "auto":
+		if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+			return "json", nil
+		}
+		return "yaml", nil
+	case "yaml", "yml":
+		return "yaml", nil
+	case "json":
+		return "json", nil
 	}
-	return spec, nil
+	return "", fmt.Errorf("unknown format %q (want json, yaml or auto)", format)
+}
+
+// expandDocument converts a JSON or YAML document to JSON, applying the
+// variables and includes of opts. Scalars inside YAML sequences stay
+// strings, as written: every scalar list in a spec (options, items, rows,
+// remove) is a list of strings, so "- [main.go, 12]" must not turn 12 into a
+// number.
+func expandDocument(data []byte, format string, opts DecodeOptions) ([]byte, error) {
+	root, err := parseDocument(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return []byte("{}"), nil // Empty document
+	}
+	t := &specTemplate{vars: opts.Vars, dir: opts.Dir, includes: true}
+	if err := t.expand(root); err != nil {
+		return nil, err
+	}
+	v, err := yamlValue(root, false)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func yamlValue(n *yaml.Node, inSeq bool) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		return yamlValue(n.Content[0], false)
+	case yaml.AliasNode:
+		return yamlValue(n.Alias, inSeq)
+	case yaml.MappingNode:
+		m := make(map[string]any, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("yaml: line %d: mapping keys must be scalars", key.Line)
+			}
+			if key.Tag == "!!merge" {
+				return nil, fmt.Errorf("yaml: line %d: merge keys are not supported", key.Line)
+			}
+			val, err := yamlValue(n.Content[i+1], false)
+			if err != nil {
+				return nil, err
+			}
+			m[key.Value] = val
+		}
+		return m, nil
+	case yaml.SequenceNode:
+		s := make([]any, len(n.Content))
+		for i, item := range n.Content {
+			val, err := yamlValue(item, true)
+			if err != nil {
+				return nil, err
+			}
+			s[i] = val
+		}
+		return s, nil
+	case yaml.ScalarNode:
+		if inSeq && n.Tag != "!!null" {
+			return n.Value, nil
+		}
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("yaml: line %d: unsupported node", n.Line)
+}
+
+// Apply returns the spec with patch applied, and the ids of the widgets the
+// patch added or changed.
+func (s Spec) Apply(patch SpecPatch) (Spec, map[string]bool, error) {
+	out := s
+	out.Widgets = slices.Clone(s.Widgets)
+	if patch.Title != nil {
+		out.Title = *patch.Title
+	}
+	for _, id := range patch.Remove {
+		i := out.widgetIndex(id)
+		if i < 0 {
+			return Spec{}, nil, fmt.Errorf("unknown widget %q", id)
+		}
+		out.Widgets = slices.Delete(out.Widgets, i, i+1)
+	}
+
+	changed := map[string]bool{}
+	for _, raw := range patch.Widgets {
+		var place struct {
+			ID     string `json:"id"`
+			After  string `json:"after"`
+			Before string `json:"before"`
+		}
+		if err := json.Unmarshal(raw, &place); err != nil {
+			return Spec{}, nil, err
+		}
+		if place.ID == "" {
+			return Spec{}, nil, errors.New("widget id is required")
+		}
+		if i := out.widgetIndex(place.ID); i >= 0 {
+			ws := out.Widgets[i]
+			// Decoding reuses slices' backing arrays: keep the original intact
+			ws.Options = slices.Clone(ws.Options)
+			if err := decodeWidget(raw, &ws); err != nil {
+				return Spec{}, nil, err
+			}
+			if !reflect.DeepEqual(ws, out.Widgets[i]) {
+				changed[ws.ID] = true
+			}
+			out.Widgets[i] = ws
+			continue
+		}
+
+		var ws WidgetSpec
+		if err := decodeWidget(raw, &ws); err != nil {
+			return Spec{}, nil, err
+		}
+		at := len(out.Widgets)
+		ref, after := place.Before, false
+		if place.After != "" {
+			ref, after = place.After, true
+		}
+		if ref != "" {
+			at = out.widgetIndex(ref)
+			if at < 0 {
+				return Spec{}, nil, fmt.Errorf("unknown widget %q", ref)
+			}
+			if after {
+				at++
+			}
+		}
+		out.Widgets = slices.Insert(out.Widgets, at, ws)
+		changed[ws.ID] = true
+	}
+	return out, changed, nil
+}
+
+// decodeWidget merges a partial widget spec into ws, keeping numbers as
+// json.Number like DecodeSpec.
+func decodeWidget(raw json.RawMessage, ws *WidgetSpec) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	return dec.Decode(ws)
+}
+
+func (s Spec) widgetIndex(id string) int {
+	return slices.IndexFunc(s.Widgets, func(ws WidgetSpec) bool { return ws.ID == id })
 }
 
 func (s Spec) LayoutType() string {
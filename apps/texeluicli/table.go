@@ -0,0 +1,196 @@
+package texeluicli
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/primitives"
+	"github.com/framegrace/texelui/theme"
+)
+
+// tableCheckboxW is the width of the "[ ] " box multi-select rows start with.
+const tableCheckboxW = 4
+
+// tableWidget shows rows of cells under a header line, using a columned
+// ScrollableList for the rows. Column widths fit the widest cell, except
+// the last column which takes the remaining width.
+type tableWidget struct {
+	core.BaseWidget
+	columns []string
+	rows    [][]string
+	widths  []int
+	list    *primitives.ScrollableList
+	inv     func(core.Rect)
+}
+
+func newTableWidget(columns []string, multi bool) *tableWidget {
+	t := &tableWidget{columns: columns}
+	t.list = primitives.NewScrollableList(0, 0, 1, 1)
+	t.list.MultiSelect = multi
+	t.list.ShowScrollIndicators = true
+	t.SetFocusable(true)
+	return t
+}
+
+// SetRows replaces the rows, and the columns when columns is non-nil. Rows
+// shorter than the header are padded with empty cells.
+func (t *tableWidget) SetRows(columns []string, rows [][]string) {
+	if columns != nil {
+		t.columns = columns
+	}
+	n := len(t.columns)
+	for _, row := range rows {
+		n = max(n, len(row))
+	}
+	t.widths = make([]int, n)
+	for i, name := range t.columns {
+		t.widths[i] = len([]rune(name))
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			t.widths[i] = max(t.widths[i], len([]rune(cell)))
+		}
+	}
+	if n > 0 {
+		t.widths[n-1] = 0 // Last column fills the row
+	}
+
+	items := make([]primitives.ListItem, len(rows))
+	for r, row := range rows {
+		cells := make([]primitives.ListColumn, n)
+		padded := make([]string, n)
+		copy(padded, row)
+		for i := range cells {
+			cells[i] = primitives.ListColumn{Text: padded[i], Width: t.widths[i]}
+		}
+		items[r] = primitives.ListItem{Text: strings.Join(padded, " "), Columns: cells}
+	}
+	t.rows = rows
+	t.list.SetItems(items)
+	t.invalidate()
+}
+
+// Rows returns all rows, or only the selected ones: the cursor row, or the
+// checked rows when multi-select is on.
+func (t *tableWidget) Rows(selected bool) [][]string {
+	if !selected {
+		return t.rows
+	}
+	var rows [][]string
+	for _, idx := range t.list.SelectedIndices() {
+		rows = append(rows, t.rows[idx])
+	}
+	return rows
+}
+
+// selection returns the selected row indices, comma-separated.
+func (t *tableWidget) selection() string {
+	var parts []string
+	for _, idx := range t.list.SelectedIndices() {
+		parts = append(parts, strconv.Itoa(idx))
+	}
+	return strings.Join(parts, ",")
+}
+
+// setSelection selects rows by comma-separated index.
+func (t *tableWidget) setSelection(val string) error {
+	var indices []int
+	for _, part := range splitList(val) {
+		idx, err := strconv.Atoi(part)
+		if err != nil {
+			return err
+		}
+		indices = append(indices, idx)
+	}
+	if t.list.MultiSelect {
+		t.list.SetSelectedIndices(indices)
+	} else if len(indices) > 0 {
+		t.list.SetSelected(indices[0])
+	}
+	t.invalidate()
+	return nil
+}
+
+func (t *tableWidget) Draw(p *core.Painter) {
+	tm := theme.Get()
+	style := tcell.StyleDefault.Foreground(tm.GetSemanticColor("text.primary")).
+		Background(tm.GetSemanticColor("bg.surface")).Bold(true)
+	p.Fill(core.Rect{X: t.Rect.X, Y: t.Rect.Y, W: t.Rect.W, H: 1}, ' ', style)
+	x := t.Rect.X
+	if t.list.MultiSelect {
+		x += tableCheckboxW
+	}
+	right := t.Rect.X + t.Rect.W
+	for i, name := range t.columns {
+		w := right - x
+		if i < len(t.widths) && t.widths[i] > 0 {
+			w = min(w, t.widths[i])
+		}
+		if w <= 0 {
+			break
+		}
+		if runes := []rune(name); len(runes) > w {
+			name = string(runes[:w])
+		}
+		p.DrawText(x, t.Rect.Y, name, style)
+		x += w + 1 // Same gap as between the list's columns
+	}
+	t.list.Draw(p)
+}
+
+func (t *tableWidget) Resize(w, h int) {
+	t.BaseWidget.Resize(w, h)
+	t.layout()
+}
+
+func (t *tableWidget) SetPosition(x, y int) {
+	t.BaseWidget.SetPosition(x, y)
+	t.layout()
+}
+
+func (t *tableWidget) layout() {
+	t.list.SetPosition(t.Rect.X, t.Rect.Y+1)
+	t.list.Resize(t.Rect.W, max(t.Rect.H-1, 0))
+}
+
+func (t *tableWidget) Focus() {
+	t.BaseWidget.Focus()
+	t.list.Focus()
+}
+
+func (t *tableWidget) Blur() {
+	t.BaseWidget.Blur()
+	t.list.Blur()
+}
+
+func (t *tableWidget) HandleKey(ev *tcell.EventKey) bool {
+	return t.list.HandleKey(ev)
+}
+
+func (t *tableWidget) HandleMouse(ev *tcell.EventMouse) bool {
+	return t.list.HandleMouse(ev)
+}
+
+func (t *tableWidget) SetInvalidator(fn func(core.Rect)) {
+	t.inv = fn
+	t.list.SetInvalidator(fn)
+}
+
+func (t *tableWidget) invalidate() {
+	if t.inv != nil {
+		t.inv(t.Rect)
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(val string) []string {
+	var out []string
+	for _, part := range strings.Split(val, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
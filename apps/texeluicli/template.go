@@ -0,0 +1,207 @@
+package texeluicli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// maxIncludeDepth bounds nested includes, which also stops include cycles.
+const maxIncludeDepth = 16
+
+// DecodeOptions controls how specs and patches are read.
+type DecodeOptions struct {
+	// Format is "json", "yaml", or "auto" (or "") to detect it
+	Format string
+	// Vars are the values of ${name} references, looked up before the
+	// environment
+	Vars map[string]string
+	// Dir resolves relative include paths; "" is the working directory
+	Dir string
+}
+
+// varPattern matches ${name}, ${name:-default} and the $${ escape.
+var varPattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// specInclude is a list item replaced by the items of another spec file:
+// its list of widgets, or the widgets of the spec it holds.
+type specInclude struct {
+	Include string                 `json:"include"`
+	Vars    map[string]interface{} `json:"vars,omitempty"`
+}
+
+// specTemplate expands a parsed spec: ${name} references in string values,
+// and, when includes is set, include items in lists. The validator leaves
+// includes in place, so every position it reports is in the file checked.
+type specTemplate struct {
+	vars     map[string]string
+	dir      string
+	file     string // The included file being expanded, "" at the top
+	depth    int
+	includes bool
+}
+
+func (t *specTemplate) errorf(n *yaml.Node, format string, args ...any) error {
+	err := SpecError{Line: n.Line, Column: n.Column, Message: fmt.Sprintf(format, args...)}
+	if t.file != "" {
+		return fmt.Errorf("%s:%w", t.file, err)
+	}
+	return err
+}
+
+func (t *specTemplate) expand(n *yaml.Node) error {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		return t.expand(n.Content[0])
+	case yaml.ScalarNode:
+		return t.substitute(n)
+	case yaml.MappingNode:
+		for i := 1; i < len(n.Content); i += 2 {
+			if err := t.expand(n.Content[i]); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		var items []*yaml.Node
+		for _, item := range n.Content {
+			if t.includes && mappingValue(item, "include") != nil {
+				included, err := t.include(item)
+				if err != nil {
+					return err
+				}
+				items = append(items, included...)
+				continue
+			}
+			if err := t.expand(item); err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		n.Content = items
+	}
+	return nil
+}
+
+// substitute replaces the variable references in a string scalar. A plain
+// YAML scalar that was a single reference takes the type of its value, so
+// "height: ${rows}" gives a number.
+func (t *specTemplate) substitute(n *yaml.Node) error {
+	if n.Tag != "!!str" || !strings.Contains(n.Value, "${") {
+		return nil
+	}
+	var err error
+	whole := varPattern.FindStringIndex(n.Value)
+	value := varPattern.ReplaceAllStringFunc(n.Value, func(ref string) string {
+		if ref == "$${" {
+			return "${"
+		}
+		m := varPattern.FindStringSubmatch(ref)
+		if val, ok := t.lookup(m[1]); ok {
+			return val
+		}
+		if m[2] != "" {
+			return strings.TrimPrefix(m[2], ":-")
+		}
+		if err == nil {
+			err = t.errorf(n, "undefined variable %q", m[1])
+		}
+		return ""
+	})
+	if err != nil {
+		return err
+	}
+	if n.Style == 0 && whole != nil && whole[0] == 0 && whole[1] == len(n.Value) && n.Value[:3] != "$${" {
+		// Resolve the value like YAML resolves a plain scalar
+		var resolved yaml.Node
+		if yaml.Unmarshal([]byte(value), &resolved) == nil && len(resolved.Content) == 1 && resolved.Content[0].Kind == yaml.ScalarNode {
+			n.Tag = resolved.Content[0].Tag
+		}
+	}
+	n.Value = value
+	return nil
+}
+
+func (t *specTemplate) lookup(name string) (string, bool) {
+	if val, ok := t.vars[name]; ok {
+		return val, true
+	}
+	return os.LookupEnv(name)
+}
+
+// include reads the file an include item names and returns its expanded
+// list items.
+func (t *specTemplate) include(item *yaml.Node) ([]*yaml.Node, error) {
+	pathNode := mappingValue(item, "include")
+	if err := t.substitute(pathNode); err != nil {
+		return nil, err
+	}
+	if !isString(pathNode) || pathNode.Value == "" {
+		return nil, t.errorf(pathNode, "include must be a file path")
+	}
+	vars := make(map[string]string, len(t.vars))
+	for k, v := range t.vars {
+		vars[k] = v
+	}
+	if varsNode := mappingValue(item, "vars"); varsNode != nil {
+		if varsNode.Kind != yaml.MappingNode {
+			return nil, t.errorf(varsNode, "vars must be an object")
+		}
+		for i := 0; i+1 < len(varsNode.Content); i += 2 {
+			val := varsNode.Content[i+1]
+			if err := t.substitute(val); err != nil {
+				return nil, err
+			}
+			if val.Kind != yaml.ScalarNode {
+				return nil, t.errorf(val, "vars values must be scalars")
+			}
+			vars[varsNode.Content[i].Value] = val.Value
+		}
+	}
+	if t.depth >= maxIncludeDepth {
+		return nil, t.errorf(pathNode, "includes nested deeper than %d (is there a cycle?)", maxIncludeDepth)
+	}
+
+	path := pathNode.Value
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(t.dir, path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, t.errorf(pathNode, "%v", err)
+	}
+	root, err := parseDocument(data, "auto")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if root == nil {
+		return nil, nil
+	}
+	sub := &specTemplate{vars: vars, dir: filepath.Dir(path), file: path, depth: t.depth + 1, includes: true}
+	if err := sub.expand(root); err != nil {
+		return nil, err
+	}
+	if root.Kind == yaml.MappingNode {
+		root = mappingValue(root, "widgets")
+	}
+	if root == nil || root.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("%s: an included file must hold a list of widgets or a spec with widgets", path)
+	}
+	return root.Content, nil
+}
+
+// parseDocument parses a JSON or YAML document into a node tree, nil when
+// it is empty.
+func parseDocument(data []byte, format string) (*yaml.Node, error) {
+	format, err := detectFormat(data, format)
+	if err != nil {
+		return nil, err
+	}
+	if format == "json" {
+		return parseJSONNode(data)
+	}
+	return parseYAMLNode(data)
+}
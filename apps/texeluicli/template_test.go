@@ -0,0 +1,110 @@
+package texeluicli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDecodeSpecVariables(t *testing.T) {
+	t.Setenv("TEXELUI_TEST_USER", "env-user")
+	vars := map[string]string{"title": "Deploy", "rows": "4", "TEXELUI_TEST_USER": "var-user"}
+	cases := []struct {
+		name  string
+		value string // YAML for the label of widget "w"
+		want  string
+		err   string
+	}{
+		{"var", "${title}", "Deploy", ""},
+		{"embedded", "'Run ${title} now'", "Run Deploy now", ""},
+		{"vars before env", "${TEXELUI_TEST_USER}", "var-user", ""},
+		{"default", "${missing:-fallback}", "fallback", ""},
+		{"empty default", "'[${missing:-}]'", "[]", ""},
+		{"escape", "'$${title}'", "${title}", ""},
+		{"escape then var", "'$${a} ${title}'", "${a} Deploy", ""},
+		{"undefined", "${missing}", "", `undefined variable "missing"`},
+	}
+	for _, c := range cases {
+		src := "widgets:\n  - id: w\n    type: label\n    label: " + c.value + "\n"
+		spec, err := DecodeSpecWith(strings.NewReader(src), DecodeOptions{Vars: vars})
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if got := spec.Widgets[0].Label; got != c.want {
+			t.Errorf("%s: label = %q, want %q", c.name, got, c.want)
+		}
+	}
+
+	// A lone reference in a plain scalar takes the type of its value
+	spec, err := DecodeSpecWith(strings.NewReader("widgets:\n  - id: w\n    type: log\n    height: ${rows}\n"),
+		DecodeOptions{Vars: vars})
+	if err != nil || spec.Widgets[0].Height != 4 {
+		t.Errorf("height: got %v, %v; want 4", spec.Widgets, err)
+	}
+
+	// The environment is used when no var is set
+	t.Setenv("TEXELUI_TEST_ONLY_ENV", "from-env")
+	spec, err = DecodeSpecWith(strings.NewReader("widgets:\n  - id: w\n    type: label\n    label: ${TEXELUI_TEST_ONLY_ENV}\n"),
+		DecodeOptions{})
+	if err != nil || spec.Widgets[0].Label != "from-env" {
+		t.Errorf("env: got %v, %v; want from-env", spec.Widgets, err)
+	}
+}
+
+func TestDecodeSpecIncludes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("fields.yaml", "- id: ${prefix}name\n  type: input\n- include: more.yaml\n")
+	write("more.yaml", "widgets:\n  - id: ${prefix}ok\n    type: button\n")
+	write("loop.yaml", "- include: loop.yaml\n")
+	write("scalar.yaml", "just text\n")
+
+	cases := []struct {
+		name    string
+		include string
+		want    []string // Widget ids after expansion
+		err     string
+	}{
+		{"list and nested spec", "- include: fields.yaml\n    vars: {prefix: a_}", []string{"top", "a_name", "a_ok"}, ""},
+		{"var in path", "- include: ${file}", []string{"top", "name", "ok"}, ""},
+		{"cycle", "- include: loop.yaml", nil, "nested deeper than"},
+		{"missing file", "- include: nope.yaml", nil, "nope.yaml"},
+		{"not a list", "- include: scalar.yaml", nil, "must hold a list of widgets"},
+	}
+	for _, c := range cases {
+		src := "widgets:\n  - id: top\n    type: label\n  " + c.include + "\n"
+		spec, err := DecodeSpecWith(strings.NewReader(src), DecodeOptions{
+			Dir:  dir,
+			Vars: map[string]string{"prefix": "", "file": "fields.yaml"},
+		})
+		if c.err != "" {
+			if err == nil || !strings.Contains(err.Error(), c.err) {
+				t.Errorf("%s: error %v, want %q", c.name, err, c.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		var ids []string
+		for _, w := range spec.Widgets {
+			ids = append(ids, w.ID)
+		}
+		if strings.Join(ids, ",") != strings.Join(c.want, ",") {
+			t.Errorf("%s: ids = %v, want %v", c.name, ids, c.want)
+		}
+	}
+}
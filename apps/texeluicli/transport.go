@@ -0,0 +1,125 @@
+package texeluicli
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Server and client addresses are Unix socket paths (optionally written
+// unix:///path), tcp://host:port, or ws://host:port/path for a WebSocket
+// endpoint. Every transport carries the same JSON requests and responses,
+// one request per connection.
+
+// parseEndpoint splits addr into its scheme ("unix", "tcp" or "ws") and the
+// socket path, host:port, or WebSocket URL.
+func parseEndpoint(addr string) (string, string, error) {
+	scheme, rest, ok := strings.Cut(addr, "://")
+	if !ok {
+		return "unix", addr, nil
+	}
+	switch scheme {
+	case "unix":
+		return "unix", rest, nil
+	case "tcp":
+		if _, _, err := net.SplitHostPort(rest); err != nil {
+			return "", "", fmt.Errorf("invalid address %q: %w", addr, err)
+		}
+		return "tcp", rest, nil
+	case "ws":
+		u, err := url.Parse(addr)
+		if err != nil || u.Host == "" {
+			return "", "", fmt.Errorf("invalid address %q", addr)
+		}
+		if u.Path == "" {
+			u.Path = "/"
+		}
+		return "ws", u.String(), nil
+	}
+	return "", "", fmt.Errorf("unsupported address %q (want a socket path, tcp://host:port or ws://host:port/path)", addr)
+}
+
+// listen adds a TCP or WebSocket endpoint to the server. Network endpoints
+// require the server token, as anyone who can connect can run commands.
+func (s *Server) listen(addr string) error {
+	scheme, rest, err := parseEndpoint(addr)
+	if err != nil {
+		return err
+	}
+	if s.token == "" {
+		return fmt.Errorf("listening on %s requires a token", addr)
+	}
+	switch scheme {
+	case "tcp":
+		ln, err := net.Listen("tcp", rest)
+		if err != nil {
+			return err
+		}
+		s.listeners = append(s.listeners, ln)
+		go func() { _ = s.serve(ln, true) }()
+	case "ws":
+		u, _ := url.Parse(rest)
+		ln, err := net.Listen("tcp", u.Host)
+		if err != nil {
+			return err
+		}
+		s.listeners = append(s.listeners, ln)
+		mux := http.NewServeMux()
+		mux.Handle(u.Path, websocket.Server{Handler: func(ws *websocket.Conn) {
+			ws.PayloadType = websocket.TextFrame
+			s.handle(ws, true)
+		}})
+		go func() { _ = http.Serve(ln, mux) }()
+	default:
+		return fmt.Errorf("cannot listen on %s: the server has a single Unix socket (--socket)", addr)
+	}
+	return nil
+}
+
+// serve accepts connections on ln until it is closed. Requests on remote
+// connections must carry the server token. Accept errors (e.g. running
+// out of file descriptors) back off like net/http: 5ms, doubling up to 1s.
+func (s *Server) serve(ln net.Listener, remote bool) error {
+	var delay time.Duration
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			if delay == 0 {
+				delay = 5 * time.Millisecond
+			} else {
+				delay = min(2*delay, time.Second)
+			}
+			time.Sleep(delay)
+			continue
+		}
+		delay = 0
+		go s.handle(conn, remote)
+	}
+}
+
+// authorized reports whether token matches the server token.
+func (s *Server) authorized(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// dialEndpoint connects to a TCP or WebSocket endpoint.
+func dialEndpoint(scheme, rest string) (net.Conn, error) {
+	switch scheme {
+	case "tcp":
+		return net.Dial("tcp", rest)
+	case "ws":
+		u, _ := url.Parse(rest)
+		return websocket.Dial(rest, "", "http://"+u.Host+"/")
+	}
+	return nil, fmt.Errorf("unsupported transport %q", scheme)
+}
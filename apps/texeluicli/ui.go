@@ -3,17 +3,27 @@ package texeluicli
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/primitives"
 	"github.com/framegrace/texelui/widgets"
 )
 
 type Event struct {
 	Type string
 	ID   string
+	Seq  uint64 // Position in the session's event log, from 1
+
+	values map[string]string // Input widget values when the event happened
 }
 
 type binding struct {
@@ -24,6 +34,12 @@ type binding struct {
 	set        func(string) error
 	setChecked func(bool) error
 	append     func(string)
+	setRows    func(columns []string, rows [][]string)
+	rows       func(selected bool) [][]string
+	setLabel   func(string)
+	addItems   func([]string)
+	delItems   func([]int) error
+	items      func(selected bool) []ListItem
 }
 
 type Session struct {
@@ -31,46 +47,62 @@ type Session struct {
 	Title    string
 	UI       *core.UIManager
 	Root     core.Widget
+	spec     Spec
+	Created  time.Time
+	mu       sync.Mutex // guards bindings, spec and Title, replaced by Update, status and jobs
 	bindings map[string]*binding
-	events   chan Event
+	status   *widgets.StatusBar // Added by the first Notify
+	jobs     []*job             // Commands started by run, oldest first
+	events   *eventLog
 	closed   bool
 	closedCh chan struct{}
 }
 
 func BuildSession(spec Spec) (*Session, error) {
 	ui := core.NewUIManager()
-	events := make(chan Event, 64)
-	root, bindings, err := buildRoot(spec, events)
+	events := newEventLog()
+	root, bindings, err := buildRoot(spec, events, nil)
 	if err != nil {
 		return nil, err
 	}
 	if root != nil {
 		ui.SetRootWidget(root)
-		focusTarget := root
-		if padded, ok := root.(*paddedContainer); ok {
-			if padded.child != nil {
-				focusTarget = padded.child
-			}
-		}
-		ui.Focus(focusTarget)
+		ui.Focus(focusTarget(root))
 	}
-	return &Session{
+	s := &Session{
 		ID:       newSessionID(),
 		Title:    spec.Title,
+		Created:  time.Now(),
 		UI:       ui,
 		Root:     root,
+		spec:     spec,
 		bindings: bindings,
 		events:   events,
 		closedCh: make(chan struct{}),
-	}, nil
+	}
+	events.snapshot = s.inputValues
+	return s, nil
+}
+
+// focusTarget returns the widget to focus for root: the child of a padded
+// container rather than the container itself.
+func focusTarget(root core.Widget) core.Widget {
+	if padded, ok := root.(*paddedContainer); ok && padded.child != nil {
+		return padded.child
+	}
+	return root
 }
 
 func (s *Session) Binding(id string) (*binding, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	b, ok := s.bindings[id]
 	return b, ok
 }
 
 func (s *Session) Values(ids []string) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	out := make(map[string]string, len(ids))
 	for _, id := range ids {
 		b, ok := s.bindings[id]
@@ -82,14 +114,243 @@ func (s *Session) Values(ids []string) (map[string]string, error) {
 	return out, nil
 }
 
-func (s *Session) Emit(ev Event) {
-	if s.closed {
-		return
+// EventValues returns widget values as Values does, but as they were when
+// ev happened, so they match the event even if the user has moved on.
+// Logs, which events do not capture, are read now.
+func (s *Session) EventValues(ev Event, ids []string) (map[string]string, error) {
+	var live []string
+	out := make(map[string]string, len(ids))
+	for _, id := range ids {
+		if val, ok := ev.values[id]; ok {
+			out[id] = val
+		} else {
+			live = append(live, id)
+		}
 	}
-	select {
-	case s.events <- ev:
-	default:
+	if len(live) > 0 {
+		now, err := s.Values(live)
+		if err != nil {
+			return nil, err
+		}
+		for id, val := range now {
+			out[id] = val
+		}
+	}
+	return out, nil
+}
+
+// Update applies patch to the live session. Widgets the patch adds or
+// changes are rebuilt; the others keep their widget and state, and the
+// layout is rebuilt around them. Must run on the UI goroutine.
+func (s *Session) Update(patch SpecPatch) error {
+	spec, changed, err := s.liveSpec().Apply(patch)
+	if err != nil {
+		return err
+	}
+	return s.rebuild(spec, func(id string) bool { return !changed[id] })
+}
+
+// Reload replaces the session's spec, as when its file was edited. Widgets
+// keep their values by id as long as their type stays the same, and those
+// whose spec is otherwise unchanged keep their widget and state as with
+// Update. Must run on the UI goroutine.
+func (s *Session) Reload(spec Spec) error {
+	live := s.liveSpec()
+	spec.Widgets = slices.Clone(spec.Widgets)
+	unchanged := map[string]bool{}
+	for i, ws := range spec.Widgets {
+		j := live.widgetIndex(ws.ID)
+		if j < 0 || !strings.EqualFold(live.Widgets[j].Type, ws.Type) {
+			continue
+		}
+		old := live.Widgets[j]
+		if old.Value != nil {
+			spec.Widgets[i].Value = old.Value
+		}
+		// Rows and items loaded at run time, unless the spec has its own
+		if ws.Columns == nil && ws.Rows == nil {
+			spec.Widgets[i].Columns, spec.Widgets[i].Rows = old.Columns, old.Rows
+		}
+		if ws.Items == nil {
+			spec.Widgets[i].Items = old.Items
+		}
+		if reflect.DeepEqual(spec.Widgets[i], old) {
+			unchanged[ws.ID] = true
+		}
 	}
+	return s.rebuild(spec, func(id string) bool { return unchanged[id] })
+}
+
+// rebuild makes spec the session's spec, keeping the widgets of the ids
+// keepID accepts and building the others anew.
+func (s *Session) rebuild(spec Spec, keepID func(id string) bool) error {
+	s.mu.Lock()
+	keep := make(map[string]*binding, len(s.bindings))
+	focusedID := ""
+	for id, b := range s.bindings {
+		if !keepID(id) {
+			continue
+		}
+		keep[id] = b
+		if core.IsDescendantFocused(b.widget) {
+			focusedID = id
+		}
+	}
+	s.mu.Unlock()
+
+	root, bindings, err := buildRoot(spec, s.events, keep)
+	if err != nil {
+		return err
+	}
+	s.UI.SetRootWidget(root)
+	s.UI.Focus(focusTarget(root))
+	// Keep the focus on the widget the user was in, if it is still shown
+	if i := spec.widgetIndex(focusedID); i >= 0 && !spec.Widgets[i].Hidden && !spec.Widgets[i].Disabled {
+		for _, b := range bindings {
+			if core.IsDescendantFocused(b.widget) {
+				b.widget.Blur()
+			}
+		}
+		bindings[focusedID].widget.Focus()
+	}
+
+	s.mu.Lock()
+	s.bindings = bindings
+	s.spec = spec
+	s.Title = spec.Title
+	s.mu.Unlock()
+	s.Root = root
+	return nil
+}
+
+// Focus moves the focus to the widget id. The containers on the way to it
+// are focused as a click would focus them, so Tab carries on from there,
+// and scroll panes bring the widget into view when they next draw. Must
+// run on the UI goroutine.
+func (s *Session) Focus(id string) error {
+	b, ok := s.Binding(id)
+	if !ok {
+		return fmt.Errorf("unknown widget %q", id)
+	}
+	if core.IsDisabled(b.widget) {
+		return fmt.Errorf("widget %q is disabled", id)
+	}
+	if !b.widget.Focusable() {
+		return fmt.Errorf("widget %q cannot take the focus", id)
+	}
+	root := focusTarget(s.Root)
+	path := widgetPath(root, b.widget)
+	if path == nil {
+		return fmt.Errorf("widget %q is hidden", id)
+	}
+	root.Blur()
+	for i, w := range path {
+		w.Focus()
+		if i == len(path)-1 {
+			break
+		}
+		// Containers focus a child of their choosing: move off it
+		if cc, ok := w.(core.ChildContainer); ok {
+			cc.VisitChildren(func(child core.Widget) {
+				if child != path[i+1] && core.IsDescendantFocused(child) {
+					child.Blur()
+				}
+			})
+		}
+	}
+	return nil
+}
+
+// widgetPath returns the widgets from root down to w, or nil if w is not in
+// root's tree.
+func widgetPath(root, w core.Widget) []core.Widget {
+	if root == w {
+		return []core.Widget{w}
+	}
+	cc, ok := root.(core.ChildContainer)
+	if !ok {
+		return nil
+	}
+	var path []core.Widget
+	cc.VisitChildren(func(child core.Widget) {
+		if path != nil {
+			return
+		}
+		if p := widgetPath(child, w); p != nil {
+			path = append([]core.Widget{root}, p...)
+		}
+	})
+	return path
+}
+
+// Info summarizes the session for texelui ls.
+func (s *Session) Info() SessionInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := map[string]int{}
+	var kinds []string
+	for _, ws := range s.spec.Widgets {
+		kind := strings.ToLower(ws.Type)
+		if counts[kind] == 0 {
+			kinds = append(kinds, kind)
+		}
+		counts[kind]++
+	}
+	parts := make([]string, len(kinds))
+	for i, kind := range kinds {
+		parts[i] = fmt.Sprintf("%d %s", counts[kind], kind)
+	}
+	return SessionInfo{
+		ID:      s.ID,
+		Title:   s.Title,
+		Created: s.Created,
+		Widgets: strings.Join(parts, ", "),
+	}
+}
+
+// liveSpec returns the session's spec with the widgets' current values, so
+// that rebuilding a widget keeps what the user entered.
+func (s *Session) liveSpec() Spec {
+	spec := s.spec
+	spec.Widgets = slices.Clone(s.spec.Widgets)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, ws := range spec.Widgets {
+		b, ok := s.bindings[ws.ID]
+		if ok {
+			spec.Widgets[i].Disabled = core.IsDisabled(b.widget)
+		}
+		if !ok || b.set == nil {
+			continue
+		}
+		if t, ok := b.widget.(*tableWidget); ok {
+			// Keep rows loaded with set-rows
+			spec.Widgets[i].Columns = t.columns
+			spec.Widgets[i].Rows = t.rows
+		}
+		if b.items != nil {
+			spec.Widgets[i].Items = nil
+			for _, item := range b.items(false) {
+				spec.Widgets[i].Items = append(spec.Widgets[i].Items, item.Text)
+			}
+		}
+		if b.setLabel != nil {
+			spec.Widgets[i].Text = b.widget.(*widgets.ProgressBar).Label
+		}
+		if b.kind == "label" {
+			// Only keep text changed with set, so a patched label still shows
+			if text := b.get(); text != ws.Text && (ws.Text != "" || text != ws.Label) {
+				spec.Widgets[i].Text = text
+			}
+		} else {
+			spec.Widgets[i].Value = b.get()
+		}
+	}
+	return spec
+}
+
+func (s *Session) Emit(ev Event) {
+	s.events.emit(ev)
 }
 
 func (s *Session) Close() {
@@ -97,30 +358,162 @@ func (s *Session) Close() {
 		return
 	}
 	s.Emit(Event{Type: "close", ID: "session"})
+	s.events.close()
 	s.closed = true
 	close(s.closedCh)
+	s.mu.Lock()
+	if s.status != nil {
+		s.status.Stop()
+	}
+	jobs := s.jobs
+	s.mu.Unlock()
+	s.UI.Close()
+	// Commands do not outlive their session
+	for _, j := range jobs {
+		_ = j.signal(syscall.SIGTERM)
+	}
 }
 
-func (s *Session) Wait(filters []string) (Event, error) {
-	for {
-		select {
-		case ev := <-s.events:
-			if matchesEvent(filters, ev) {
-				return ev, nil
+// eventAction returns the command the spec binds to ev, if any.
+func (s *Session) eventAction(ev Event) (EventAction, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ws := range s.spec.Widgets {
+		if ws.ID != ev.ID {
+			continue
+		}
+		for typ, action := range ws.On {
+			if strings.EqualFold(typ, ev.Type) && action.Run != "" {
+				return action, true
 			}
-		case <-s.closedCh:
-			return Event{}, errors.New("session closed")
 		}
 	}
+	return EventAction{}, false
 }
 
-func buildRoot(spec Spec, events chan Event) (core.Widget, map[string]*binding, error) {
+// inputValues returns the values of all widgets but logs, which hold
+// output, not input, and can grow large. They are captured with every
+// event and exported to commands as TEXELUI_VALUE_<ID>.
+func (s *Session) inputValues() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]string, len(s.bindings))
+	for id, b := range s.bindings {
+		if b.get != nil && b.kind != "log" {
+			out[id] = b.get()
+		}
+	}
+	return out
+}
+
+// addJob gives j the next job id and adds it to the session.
+func (s *Session) addJob(j *job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	j.id = fmt.Sprintf("job-%d", len(s.jobs)+1)
+	s.jobs = append(s.jobs, j)
+}
+
+// Job returns the job with the given id.
+func (s *Session) Job(id string) (*job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, j := range s.jobs {
+		if j.id == id {
+			return j, true
+		}
+	}
+	return nil, false
+}
+
+// Jobs returns the session's jobs, oldest first.
+func (s *Session) Jobs() []*job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*job(nil), s.jobs...)
+}
+
+// Notify shows a timed message in the session's status bar, adding the bar
+// on first use. A zero duration uses the bar's default. Must run on the UI
+// goroutine.
+func (s *Session) Notify(text string, level widgets.MessageLevel, duration time.Duration) {
+	sb := s.statusBar()
+	if duration <= 0 {
+		duration = sb.DefaultMessageDuration
+	}
+	sb.ShowFromWithDuration("", text, level, duration)
+}
+
+// SetStatus shows text at the left of the session's status bar until it is
+// replaced, colored like messages of level; an empty text removes it. Must
+// run on the UI goroutine.
+func (s *Session) SetStatus(text string, level widgets.MessageLevel) {
+	sb := s.statusBar()
+	if text == "" {
+		sb.RemoveSegment(statusSegmentID)
+		return
+	}
+	sb.AddSegment(widgets.StatusSegment{
+		ID:    statusSegmentID,
+		Align: widgets.AlignLeft,
+		Text:  func() string { return text },
+		Style: color.DynamicStyle{FG: color.Solid(widgets.MessageColor(level))},
+	})
+}
+
+// statusSegmentID identifies the status bar segment of SetStatus.
+const statusSegmentID = "texelui.status"
+
+// statusBar returns the session's status bar, adding it on first use. Must
+// run on the UI goroutine.
+func (s *Session) statusBar() *widgets.StatusBar {
+	s.mu.Lock()
+	sb := s.status
+	if sb == nil {
+		sb = widgets.NewStatusBar()
+		s.status = sb
+	}
+	s.mu.Unlock()
+	if s.UI.StatusBar() == nil {
+		s.UI.SetStatusBar(sb)
+	}
+	return sb
+}
+
+// SetTitle changes the session's title, which the terminal window shows.
+func (s *Session) SetTitle(title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Title = title
+	s.spec.Title = title
+}
+
+// CurrentTitle returns the session's title.
+func (s *Session) CurrentTitle() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.Title
+}
+
+// errWaitTimeout is returned by Wait when no matching event arrived in time.
+// Wait returns the next event matching filters for client. Unnamed clients
+// ("") share one queue, as concurrent waits in a script expect; a named
+// client has its own, which starts replay events back when first used. A
+// positive timeout gives up after that long with errWaitTimeout. Events
+// emitted before the session closed (close:session) are still delivered.
+func (s *Session) Wait(client string, replay int, filters []string, timeout time.Duration) (Event, error) {
+	return s.events.wait(s.events.cursor(client, replay), filters, timeout, nil)
+}
+
+// buildRoot builds the layout for spec. Widgets with a binding in keep are
+// reused instead of being built anew.
+func buildRoot(spec Spec, events *eventLog, keep map[string]*binding) (core.Widget, map[string]*binding, error) {
 	layoutType := strings.ToLower(spec.LayoutType())
 	switch layoutType {
 	case "form":
-		return buildForm(spec, events)
+		return buildForm(spec, events, keep)
 	case "vbox":
-		root, bindings, err := buildVBox(spec, events)
+		root, bindings, err := buildVBox(spec, events, keep)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -133,7 +526,7 @@ func buildRoot(spec Spec, events chan Event) (core.Widget, map[string]*binding,
 	}
 }
 
-func buildForm(spec Spec, events chan Event) (core.Widget, map[string]*binding, error) {
+func buildForm(spec Spec, events *eventLog, keep map[string]*binding) (core.Widget, map[string]*binding, error) {
 	cfg := widgets.DefaultFormConfig()
 	if spec.Layout.Padding > 0 {
 		cfg.PaddingX = spec.Layout.Padding
@@ -149,16 +542,19 @@ func buildForm(spec Spec, events chan Event) (core.Widget, map[string]*binding,
 	bindings := make(map[string]*binding, len(spec.Widgets))
 
 	for _, ws := range spec.Widgets {
-		w, b, err := newWidget(ws, events)
+		w, b, err := widgetFor(ws, events, keep)
 		if err != nil {
 			return nil, nil, err
 		}
 		if err := registerBinding(bindings, ws.ID, b); err != nil {
 			return nil, nil, err
 		}
+		if ws.Hidden {
+			continue
+		}
 
 		switch ws.Type {
-		case "textarea", "log":
+		case "textarea", "log", "table", "list":
 			if ws.Label != "" {
 				form.AddRow(widgets.FormRow{Label: widgets.NewLabel(ws.Label), Height: 1})
 			}
@@ -167,7 +563,7 @@ func buildForm(spec Spec, events chan Event) (core.Widget, map[string]*binding,
 				height = 4
 			}
 			form.AddFullWidthField(w, height)
-		case "checkbox", "button", "label":
+		case "checkbox", "button", "label", "menu":
 			height := ws.Height
 			if height <= 0 {
 				height = 1
@@ -193,7 +589,7 @@ func buildForm(spec Spec, events chan Event) (core.Widget, map[string]*binding,
 	return form, bindings, nil
 }
 
-func buildVBox(spec Spec, events chan Event) (core.Widget, map[string]*binding, error) {
+func buildVBox(spec Spec, events *eventLog, keep map[string]*binding) (core.Widget, map[string]*binding, error) {
 	vbox := widgets.NewVBox()
 	if spec.Layout.Gap > 0 {
 		vbox.Spacing = spec.Layout.Gap
@@ -202,7 +598,7 @@ func buildVBox(spec Spec, events chan Event) (core.Widget, map[string]*binding,
 	labelWidth := spec.Layout.LabelWidth
 	if labelWidth <= 0 {
 		for _, ws := range spec.Widgets {
-			if usesInlineLabel(ws.Type) || ws.Label == "" {
+			if usesInlineLabel(ws.Type) || ws.Label == "" || ws.Hidden {
 				continue
 			}
 			if len(ws.Label) > labelWidth {
@@ -215,16 +611,19 @@ func buildVBox(spec Spec, events chan Event) (core.Widget, map[string]*binding,
 	}
 
 	for _, ws := range spec.Widgets {
-		w, b, err := newWidget(ws, events)
+		w, b, err := widgetFor(ws, events, keep)
 		if err != nil {
 			return nil, nil, err
 		}
 		if err := registerBinding(bindings, ws.ID, b); err != nil {
 			return nil, nil, err
 		}
+		if ws.Hidden {
+			continue
+		}
 
 		var child core.Widget = w
-		if ws.Label != "" && !usesInlineLabel(ws.Type) && ws.Type != "label" {
+		if ws.Label != "" && !usesInlineLabel(ws.Type) && ws.Type != "label" && ws.Type != "menu" {
 			row := widgets.NewHBox()
 			row.Spacing = 1
 			label := widgets.NewLabel(ws.Label)
@@ -244,7 +643,24 @@ func buildVBox(spec Spec, events chan Event) (core.Widget, map[string]*binding,
 	return vbox, bindings, nil
 }
 
-func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error) {
+// widgetFor returns the kept widget for ws.ID, or builds a new one.
+func widgetFor(ws WidgetSpec, events *eventLog, keep map[string]*binding) (core.Widget, *binding, error) {
+	if b, ok := keep[ws.ID]; ok {
+		return b.widget, b, nil
+	}
+	w, b, err := newWidget(ws, events)
+	if idw, ok := w.(interface{ SetID(string) }); ok && err == nil {
+		idw.SetID(ws.ID)
+	}
+	if err == nil && ws.Disabled {
+		if d, ok := w.(core.Disableable); ok {
+			d.SetDisabled(true)
+		}
+	}
+	return w, b, err
+}
+
+func newWidget(ws WidgetSpec, events *eventLog) (core.Widget, *binding, error) {
 	if ws.ID == "" {
 		return nil, nil, errors.New("widget id is required")
 	}
@@ -263,7 +679,10 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 			input.Resize(ws.Width, 1)
 		}
 		input.OnChange = func(text string) {
-			emitEvent(events, Event{Type: "change", ID: ws.ID})
+			events.emit(Event{Type: "change", ID: ws.ID})
+		}
+		input.OnSubmit = func(string) {
+			events.emit(Event{Type: "submit", ID: ws.ID})
 		}
 		b := &binding{
 			id:     ws.ID,
@@ -282,7 +701,10 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 	case "combobox":
 		combo := widgets.NewComboBox(ws.Options, ws.Editable)
 		value := ws.ValueString()
-		if value == "" && len(ws.Options) > 0 {
+		// A fixed list can't hold a value it doesn't offer (e.g. after an
+		// update replaced the options)
+		invalid := !ws.Editable && !slices.Contains(ws.Options, value)
+		if (value == "" || invalid) && len(ws.Options) > 0 {
 			value = ws.Options[0]
 		}
 		if value != "" {
@@ -292,7 +714,7 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 			combo.Resize(ws.Width, 1)
 		}
 		combo.OnChange = func(text string) {
-			emitEvent(events, Event{Type: "change", ID: ws.ID})
+			events.emit(Event{Type: "change", ID: ws.ID})
 		}
 		b := &binding{
 			id:     ws.ID,
@@ -306,12 +728,36 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 		}
 		return combo, b, nil
 
+	case "filepicker":
+		picker := widgets.NewFilePicker(ws.StartDir)
+		picker.Pattern = ws.Pattern
+		picker.DirsOnly = ws.DirsOnly
+		picker.Placeholder = ws.Placeholder
+		picker.SetPath(ws.ValueString())
+		if ws.Width > 0 {
+			picker.Resize(ws.Width, 1)
+		}
+		picker.OnPick = func(string) {
+			events.emit(Event{Type: "picked", ID: ws.ID})
+		}
+		b := &binding{
+			id:     ws.ID,
+			kind:   "filepicker",
+			widget: picker,
+			get:    picker.Path,
+			set: func(val string) error {
+				picker.SetPath(val)
+				return nil
+			},
+		}
+		return picker, b, nil
+
 	case "checkbox":
 		label := ws.Label
 		checkbox := widgets.NewCheckbox(label)
 		checkbox.Checked = ws.ValueBool()
 		checkbox.OnChange = func(checked bool) {
-			emitEvent(events, Event{Type: "change", ID: ws.ID})
+			events.emit(Event{Type: "change", ID: ws.ID})
 		}
 		b := &binding{
 			id:     ws.ID,
@@ -339,7 +785,7 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 			button.Resize(ws.Width, 1)
 		}
 		button.OnClick = func() {
-			emitEvent(events, Event{Type: "click", ID: ws.ID})
+			events.emit(Event{Type: "click", ID: ws.ID})
 		}
 		b := &binding{
 			id:     ws.ID,
@@ -373,7 +819,37 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 		}
 		return label, b, nil
 
-	case "textarea", "log":
+	case "log":
+		log := newLogWidget()
+		width := ws.Width
+		if width <= 0 {
+			width = 20
+		}
+		height := ws.Height
+		if height <= 0 {
+			height = 4
+		}
+		log.Resize(width, height)
+		if ws.ReadOnly {
+			log.SetFocusable(false)
+		}
+		if value := ws.ValueString(); value != "" {
+			log.SetText(value)
+		}
+		b := &binding{
+			id:     ws.ID,
+			kind:   "log",
+			widget: log,
+			get:    log.Text,
+			set: func(val string) error {
+				log.SetText(val)
+				return nil
+			},
+			append: log.AppendText,
+		}
+		return log, b, nil
+
+	case "textarea":
 		ta := widgets.NewTextArea()
 		width := ws.Width
 		height := ws.Height
@@ -392,9 +868,9 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 		if value := ws.ValueString(); value != "" {
 			ta.SetText(value)
 		}
-		if !ws.ReadOnly && strings.ToLower(ws.Type) != "log" {
+		if !ws.ReadOnly {
 			ta.OnChange = func(text string) {
-				emitEvent(events, Event{Type: "change", ID: ws.ID})
+				events.emit(Event{Type: "change", ID: ws.ID})
 			}
 		}
 		b := &binding{
@@ -406,16 +882,194 @@ func newWidget(ws WidgetSpec, events chan Event) (core.Widget, *binding, error)
 				ta.SetText(val)
 				return nil
 			},
-			append: func(val string) {
-				ta.SetText(ta.Text() + val)
-			},
+			append: ta.AppendText,
 		}
 		return ta, b, nil
+
+	case "table":
+		table := newTableWidget(ws.Columns, ws.Multi)
+		table.SetRows(nil, ws.Rows)
+		if value := ws.ValueString(); value != "" {
+			if err := table.setSelection(value); err != nil {
+				return nil, nil, fmt.Errorf("widget %q: invalid selection %q", ws.ID, value)
+			}
+		}
+		height := ws.Height
+		if height <= 0 {
+			height = 6
+		}
+		width := ws.Width
+		if width <= 0 {
+			width = 20
+		}
+		table.Resize(width, height)
+		table.list.OnChange = func(int) {
+			events.emit(Event{Type: "change", ID: ws.ID})
+		}
+		table.list.OnSelectionChange = func([]int) {
+			events.emit(Event{Type: "change", ID: ws.ID})
+		}
+		table.list.OnActivate = func(int) {
+			events.emit(Event{Type: "submit", ID: ws.ID})
+		}
+		b := &binding{
+			id:      ws.ID,
+			kind:    "table",
+			widget:  table,
+			get:     table.selection,
+			set:     table.setSelection,
+			setRows: table.SetRows,
+			rows:    table.Rows,
+		}
+		return table, b, nil
+
+	case "list":
+		list := newListWidget(ws.Items, ws.Multi)
+		if value := ws.ValueString(); value != "" {
+			selectText(list, value)
+		}
+		height := ws.Height
+		if height <= 0 {
+			height = 6
+		}
+		width := ws.Width
+		if width <= 0 {
+			width = 20
+		}
+		list.Resize(width, height)
+		list.OnChange = func(int) {
+			events.emit(Event{Type: "change", ID: ws.ID})
+		}
+		list.OnSelectionChange = func([]int) {
+			events.emit(Event{Type: "change", ID: ws.ID})
+		}
+		list.OnActivate = func(int) {
+			events.emit(Event{Type: "select", ID: ws.ID})
+		}
+		b := &binding{
+			id:     ws.ID,
+			kind:   "list",
+			widget: list,
+			get: func() string {
+				var texts []string
+				for _, item := range listItems(list, true) {
+					texts = append(texts, item.Text)
+				}
+				return strings.Join(texts, "\n")
+			},
+			set: func(val string) error {
+				if !selectText(list, val) {
+					return fmt.Errorf("no item %q", val)
+				}
+				return nil
+			},
+			addItems: func(texts []string) {
+				for _, text := range texts {
+					list.AppendItems(primitives.ListItem{Text: text})
+				}
+			},
+			delItems: func(indices []int) error {
+				return removeListItems(list, indices)
+			},
+			items: func(selected bool) []ListItem { return listItems(list, selected) },
+		}
+		return list, b, nil
+
+	case "progress":
+		bar := widgets.NewProgressBar(ws.Text)
+		if value := ws.ValueString(); value != "" {
+			percent, err := parsePercent(value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("widget %q: %w", ws.ID, err)
+			}
+			bar.SetFraction(percent / 100)
+		}
+		if ws.Width > 0 {
+			bar.Resize(ws.Width, 1)
+		}
+		b := &binding{
+			id:     ws.ID,
+			kind:   "progress",
+			widget: bar,
+			get:    func() string { return strconv.FormatFloat(bar.Fraction*100, 'f', -1, 64) },
+			set: func(val string) error {
+				percent, err := parsePercent(val)
+				if err != nil {
+					return err
+				}
+				bar.SetFraction(percent / 100)
+				return nil
+			},
+			setLabel: bar.SetLabel,
+		}
+		return bar, b, nil
+
+	case "menu":
+		menus, err := newMenus(ws.Menu, events)
+		if err != nil {
+			return nil, nil, fmt.Errorf("widget %q: %w", ws.ID, err)
+		}
+		bar := widgets.NewMenuBar(menus)
+		b := &binding{
+			id:     ws.ID,
+			kind:   "menu",
+			widget: bar,
+			get:    func() string { return "" },
+		}
+		return bar, b, nil
 	default:
 		return nil, nil, fmt.Errorf("unknown widget type %q", ws.Type)
 	}
 }
 
+// newMenus builds the menus of a menu widget, whose commands emit
+// menu:<id> events.
+func newMenus(specs []MenuItemSpec, events *eventLog) ([]widgets.Menu, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("menu has no menus")
+	}
+	ids := map[string]bool{}
+	var menus []widgets.Menu
+	for _, spec := range specs {
+		if len(spec.Items) == 0 {
+			return nil, fmt.Errorf("menu %q has no items", spec.Label)
+		}
+		items, err := newMenuItems(spec.Items, events, ids)
+		if err != nil {
+			return nil, err
+		}
+		menus = append(menus, widgets.Menu{Label: spec.Label, Items: items})
+	}
+	return menus, nil
+}
+
+func newMenuItems(specs []MenuItemSpec, events *eventLog, ids map[string]bool) ([]widgets.MenuItem, error) {
+	items := make([]widgets.MenuItem, 0, len(specs))
+	for _, spec := range specs {
+		item := widgets.MenuItem{Label: spec.Label, Disabled: spec.Disabled}
+		switch {
+		case len(spec.Items) > 0:
+			sub, err := newMenuItems(spec.Items, events, ids)
+			if err != nil {
+				return nil, err
+			}
+			item.Items = sub
+		case spec.ID == "":
+			return nil, fmt.Errorf("menu item %q needs an id", spec.Label)
+		case ids[spec.ID]:
+			return nil, fmt.Errorf("duplicate menu id %q", spec.ID)
+		default:
+			ids[spec.ID] = true
+			id := spec.ID
+			item.Action = func() {
+				events.emit(Event{Type: "menu", ID: id})
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
 func registerBinding(bindings map[string]*binding, id string, b *binding) error {
 	if id == "" {
 		return errors.New("widget id is required")
@@ -436,6 +1090,12 @@ func usesInlineLabel(kind string) bool {
 	}
 }
 
+// MatchEvent reports whether event, given as type:id, matches filter.
+func MatchEvent(filter, event string) bool {
+	typ, id, _ := strings.Cut(event, ":")
+	return matchesEvent([]string{filter}, Event{Type: typ, ID: id})
+}
+
 func matchesEvent(filters []string, ev Event) bool {
 	if len(filters) == 0 {
 		return true
@@ -461,37 +1121,34 @@ func matchesEvent(filters []string, ev Event) bool {
 	return false
 }
 
-func parseBool(val string) bool {
-	switch strings.ToLower(strings.TrimSpace(val)) {
-	case "1", "true", "yes", "on":
-		return true
-	default:
-		return false
+// parsePercent parses a percentage such as "42", "42.5" or "42%".
+func parsePercent(val string) (float64, error) {
+	val = strings.TrimSuffix(strings.TrimSpace(val), "%")
+	percent, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid percentage %q", val)
 	}
+	return percent, nil
 }
 
-func emitEvent(events chan Event, ev Event) {
-	select {
-	case events <- ev:
-		return
-	default:
-	}
-	if !isHighPriorityEvent(ev.Type) {
-		return
-	}
-	select {
-	case <-events:
-	default:
-	}
-	select {
-	case events <- ev:
-	default:
+// parseMessageLevel parses a notification level name; "" is info.
+func parseMessageLevel(val string) (widgets.MessageLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "", "info":
+		return widgets.MessageInfo, nil
+	case "success":
+		return widgets.MessageSuccess, nil
+	case "warning":
+		return widgets.MessageWarning, nil
+	case "error":
+		return widgets.MessageError, nil
 	}
+	return 0, fmt.Errorf("unknown level %q (want info, success, warning or error)", val)
 }
 
-func isHighPriorityEvent(eventType string) bool {
-	switch eventType {
-	case "click", "submit", "close":
+func parseBool(val string) bool {
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "1", "true", "yes", "on":
 		return true
 	default:
 		return false
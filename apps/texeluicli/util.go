@@ -1,10 +1,15 @@
 package texeluicli
 
 import (
-	"fmt"
-	"time"
+	"crypto/rand"
+	"encoding/hex"
 )
 
+// newSessionID returns a random session id. It doubles as the session key:
+// clients on network endpoints must name their session, so only those
+// given the id can drive it.
 func newSessionID() string {
-	return fmt.Sprintf("sess-%d", time.Now().UnixNano())
+	var b [16]byte
+	_, _ = rand.Read(b[:]) // Never fails, see crypto/rand.Read
+	return "sess-" + hex.EncodeToString(b[:])
 }
@@ -0,0 +1,504 @@
+package texeluicli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// widgetTypes and layoutTypes are the values newWidget and buildRoot accept;
+// actionEvents are the widget events "on" can bind.
+var (
+	widgetTypes  = []string{"input", "number", "combobox", "checkbox", "button", "label", "textarea", "log", "table", "list", "progress", "menu", "filepicker"}
+	layoutTypes  = []string{"form", "vbox"}
+	actionEvents = []string{"click", "change", "submit", "select", "picked"}
+)
+
+// SpecError is a problem found by ValidateSpec. Line and Column locate it in
+// the spec source (1-based, 0 when unknown) and Field names the offending
+// field, such as "widgets[2].type".
+type SpecError struct {
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e SpecError) Error() string {
+	msg := e.Message
+	if e.Field != "" {
+		msg = e.Field + ": " + msg
+	}
+	if e.Line > 0 {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, msg)
+	}
+	return msg
+}
+
+// ValidateSpec checks a JSON or YAML spec ("json", "yaml", or "auto"/"" to
+// detect it) without opening it, and returns every problem found in source
+// order: syntax errors, unknown fields, values of the wrong type, missing or
+// duplicate ids, and unknown widget or layout types.
+func ValidateSpec(data []byte, format string) []SpecError {
+	return ValidateSpecWith(data, DecodeOptions{Format: format})
+}
+
+// ValidateSpecWith checks a spec as ValidateSpec, substituting variables
+// like DecodeSpecWith. Include items are checked in place; the files they
+// name are only read to check the spec decodes.
+func ValidateSpecWith(data []byte, opts DecodeOptions) []SpecError {
+	format, err := detectFormat(data, opts.Format)
+	if err != nil {
+		return []SpecError{{Message: err.Error()}}
+	}
+	var root *yaml.Node
+	if format == "json" {
+		root, err = parseJSONNode(data)
+	} else {
+		root, err = parseYAMLNode(data)
+	}
+	if err != nil {
+		return []SpecError{syntaxError(data, err)}
+	}
+	if root == nil {
+		return []SpecError{{Line: 1, Column: 1, Message: "spec is empty"}}
+	}
+	t := &specTemplate{vars: opts.Vars, dir: opts.Dir}
+	if err := t.expand(root); err != nil {
+		var se SpecError
+		errors.As(err, &se)
+		return []SpecError{se}
+	}
+
+	v := &specValidator{}
+	v.object(root, reflect.TypeOf(Spec{}), "")
+	v.semantics(root)
+	if len(v.errs) == 0 {
+		// The checks above mirror DecodeSpec; catch anything they miss
+		opts.Format = format
+		if _, err := DecodeSpecWith(bytes.NewReader(data), opts); err != nil {
+			return []SpecError{{Message: err.Error()}}
+		}
+	}
+	sort.SliceStable(v.errs, func(i, j int) bool {
+		a, b := v.errs[i], v.errs[j]
+		return a.Line < b.Line || a.Line == b.Line && a.Column < b.Column
+	})
+	return v.errs
+}
+
+type specValidator struct {
+	errs []SpecError
+}
+
+func (v *specValidator) errorf(n *yaml.Node, field, format string, args ...any) {
+	v.errs = append(v.errs, SpecError{Line: n.Line, Column: n.Column, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// object checks that n is a mapping whose keys are fields of struct type t,
+// matched by JSON name like encoding/json does, with values of the right
+// type.
+func (v *specValidator) object(n *yaml.Node, t reflect.Type, path string) {
+	if n.Kind != yaml.MappingNode {
+		v.errorf(n, path, "must be an object")
+		return
+	}
+	seen := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := n.Content[i], n.Content[i+1]
+		field := joinField(path, key.Value)
+		if prev, ok := seen[strings.ToLower(key.Value)]; ok {
+			v.errorf(key, field, "duplicate field (first set on line %d)", prev.Line)
+			continue
+		}
+		seen[strings.ToLower(key.Value)] = key
+		sf, ok := structField(t, key.Value)
+		if !ok {
+			v.errorf(key, field, "unknown field")
+			continue
+		}
+		v.value(val, sf.Type, field)
+	}
+}
+
+// value checks that n can be decoded into a value of type t.
+func (v *specValidator) value(n *yaml.Node, t reflect.Type, path string) {
+	if n.Kind == yaml.ScalarNode && n.Tag == "!!null" {
+		return // Leaves the field unset
+	}
+	if t == reflect.TypeOf(EventAction{}) && n.Kind != yaml.MappingNode {
+		if !isString(n) {
+			v.errorf(n, path, "must be a command or an object")
+		}
+		return
+	}
+	switch t.Kind() {
+	case reflect.String:
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+			v.errorf(n, path, "must be a string")
+		}
+	case reflect.Int:
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+			v.errorf(n, path, "must be an integer")
+		} else if i, err := strconv.Atoi(n.Value); err != nil || i < 0 {
+			v.errorf(n, path, "must be a non-negative integer")
+		}
+	case reflect.Bool:
+		if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" {
+			v.errorf(n, path, "must be true or false")
+		}
+	case reflect.Interface:
+		if n.Kind != yaml.ScalarNode {
+			v.errorf(n, path, "must be a string, number or boolean")
+		}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return // json.RawMessage
+		}
+		if n.Kind != yaml.SequenceNode {
+			v.errorf(n, path, "must be a list")
+			return
+		}
+		for i, item := range n.Content {
+			if t.Elem() == reflect.TypeOf(WidgetSpec{}) && mappingValue(item, "include") != nil {
+				v.object(item, reflect.TypeOf(specInclude{}), fmt.Sprintf("%s[%d]", path, i))
+				continue
+			}
+			v.value(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i))
+		}
+	case reflect.Map:
+		if n.Kind != yaml.MappingNode {
+			v.errorf(n, path, "must be an object")
+			return
+		}
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v.value(n.Content[i+1], t.Elem(), joinField(path, n.Content[i].Value))
+		}
+	case reflect.Struct:
+		v.object(n, t, path)
+	}
+}
+
+// semantics checks what the types alone do not: widget ids, widget and
+// layout types, and values that must parse for their widget type.
+func (v *specValidator) semantics(root *yaml.Node) {
+	if layout := mappingValue(root, "layout"); layout != nil {
+		if typ := mappingValue(layout, "type"); isString(typ) && !slices.Contains(layoutTypes, strings.ToLower(typ.Value)) {
+			v.errorf(typ, "layout.type", "unknown layout type %q (want %s)", typ.Value, strings.Join(layoutTypes, ", "))
+		}
+	}
+	widgetsNode := mappingValue(root, "widgets")
+	if widgetsNode == nil || widgetsNode.Kind != yaml.SequenceNode {
+		return
+	}
+	ids := map[string]*yaml.Node{}
+	for i, w := range widgetsNode.Content {
+		if w.Kind != yaml.MappingNode || mappingValue(w, "include") != nil {
+			continue
+		}
+		path := fmt.Sprintf("widgets[%d]", i)
+		id := mappingValue(w, "id")
+		switch {
+		case id == nil || isString(id) && id.Value == "":
+			v.errorf(w, path+".id", "required")
+		case !isString(id):
+		case ids[id.Value] != nil:
+			v.errorf(id, path+".id", "duplicate id %q (first used on line %d)", id.Value, ids[id.Value].Line)
+		default:
+			ids[id.Value] = id
+		}
+
+		typ := mappingValue(w, "type")
+		if typ == nil || isString(typ) && typ.Value == "" {
+			v.errorf(w, path+".type", "required")
+			continue
+		}
+		if !isString(typ) {
+			continue
+		}
+		kind := strings.ToLower(typ.Value)
+		if !slices.Contains(widgetTypes, kind) {
+			v.errorf(typ, path+".type", "unknown widget type %q (want %s)", typ.Value, strings.Join(widgetTypes, ", "))
+			continue
+		}
+		v.widget(w, kind, path)
+		v.actions(w, path)
+	}
+}
+
+// actions checks the event bindings of a widget.
+func (v *specValidator) actions(w *yaml.Node, path string) {
+	on := mappingValue(w, "on")
+	if on == nil || on.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(on.Content); i += 2 {
+		key, val := on.Content[i], on.Content[i+1]
+		field := joinField(path+".on", key.Value)
+		if !slices.Contains(actionEvents, strings.ToLower(key.Value)) {
+			v.errorf(key, field, "unknown event %q (want %s)", key.Value, strings.Join(actionEvents, ", "))
+			continue
+		}
+		cmd := val
+		if val.Kind == yaml.MappingNode {
+			cmd = mappingValue(val, "run")
+		}
+		if cmd == nil || isString(cmd) && strings.TrimSpace(cmd.Value) == "" {
+			v.errorf(val, field, "command required")
+		}
+	}
+}
+
+// widget checks the fields a widget of the given type requires or parses.
+func (v *specValidator) widget(w *yaml.Node, kind, path string) {
+	value := mappingValue(w, "value")
+	if value != nil && (value.Kind != yaml.ScalarNode || value.Tag == "!!null") {
+		value = nil
+	}
+	switch kind {
+	case "number":
+		if value != nil {
+			if _, err := strconv.ParseFloat(value.Value, 64); err != nil {
+				v.errorf(value, path+".value", "must be a number")
+			}
+		}
+	case "combobox":
+		options := mappingValue(w, "options")
+		editable := mappingValue(w, "editable")
+		if (options == nil || len(options.Content) == 0) && (editable == nil || editable.Value != "true") {
+			v.errorf(w, path+".options", "required unless the combobox is editable")
+		}
+	case "progress":
+		if value != nil {
+			if _, err := parsePercent(value.Value); err != nil {
+				v.errorf(value, path+".value", "must be a percentage")
+			}
+		}
+	case "table":
+		if value != nil {
+			for _, part := range splitList(value.Value) {
+				if _, err := strconv.Atoi(part); err != nil {
+					v.errorf(value, path+".value", "must be comma-separated row indices")
+					break
+				}
+			}
+		}
+	case "filepicker":
+		if pattern := mappingValue(w, "pattern"); isString(pattern) {
+			if _, err := filepath.Match(pattern.Value, ""); err != nil {
+				v.errorf(pattern, path+".pattern", "invalid pattern: %v", err)
+			}
+		}
+	case "menu":
+		menu := mappingValue(w, "menu")
+		if menu == nil || menu.Kind != yaml.SequenceNode || len(menu.Content) == 0 {
+			v.errorf(w, path+".menu", "required")
+			return
+		}
+		v.menuItems(menu, path+".menu", true, map[string]*yaml.Node{})
+	}
+}
+
+// menuItems checks the entries of a menu widget: menus and submenus need
+// items, commands need an id unique within the widget.
+func (v *specValidator) menuItems(items *yaml.Node, path string, top bool, ids map[string]*yaml.Node) {
+	for i, item := range items.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		field := fmt.Sprintf("%s[%d]", path, i)
+		if sub := mappingValue(item, "items"); sub != nil && sub.Kind == yaml.SequenceNode && len(sub.Content) > 0 {
+			v.menuItems(sub, field+".items", false, ids)
+			continue
+		}
+		id := mappingValue(item, "id")
+		switch {
+		case top:
+			v.errorf(item, field+".items", "required")
+		case id == nil || isString(id) && id.Value == "":
+			v.errorf(item, field+".id", "required")
+		case !isString(id):
+		case ids[id.Value] != nil:
+			v.errorf(id, field+".id", "duplicate menu id %q (first used on line %d)", id.Value, ids[id.Value].Line)
+		default:
+			ids[id.Value] = id
+		}
+	}
+}
+
+// structField finds the field of t with the given JSON name.
+func structField(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if tag == "" {
+			tag = sf.Name
+		}
+		if strings.EqualFold(tag, name) {
+			return sf, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// mappingValue returns the value for key in mapping n, or nil.
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if strings.EqualFold(n.Content[i].Value, key) {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func isString(n *yaml.Node) bool {
+	return n != nil && n.Kind == yaml.ScalarNode && n.Tag == "!!str"
+}
+
+func joinField(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// parseYAMLNode parses a YAML document, tagging scalars inside sequences as
+// strings the way expandDocument reads them. It returns nil for an empty
+// document.
+func parseYAMLNode(data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == 0 {
+		return nil, nil
+	}
+	var walk func(n *yaml.Node, inSeq bool) *yaml.Node
+	walk = func(n *yaml.Node, inSeq bool) *yaml.Node {
+		if n.Kind == yaml.AliasNode {
+			alias := *n.Alias
+			alias.Line, alias.Column = n.Line, n.Column
+			n = &alias
+		}
+		switch n.Kind {
+		case yaml.ScalarNode:
+			if inSeq && n.Tag != "!!null" {
+				n.Tag = "!!str"
+			}
+		case yaml.MappingNode, yaml.SequenceNode:
+			content := make([]*yaml.Node, len(n.Content))
+			for i, c := range n.Content {
+				content[i] = walk(c, n.Kind == yaml.SequenceNode)
+			}
+			n.Content = content
+		}
+		return n
+	}
+	return walk(doc.Content[0], false), nil
+}
+
+// jsonParser reads JSON into a yaml.Node tree, so JSON and YAML specs are
+// validated alike with the positions of their values.
+type jsonParser struct {
+	data []byte
+	dec  *json.Decoder
+}
+
+func parseJSONNode(data []byte) (*yaml.Node, error) {
+	p := &jsonParser{data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+	p.dec.UseNumber()
+	return p.value()
+}
+
+func (p *jsonParser) value() (*yaml.Node, error) {
+	// The next token starts after the separators following the last one
+	start := int(p.dec.InputOffset())
+	for start < len(p.data) && strings.IndexByte(" \t\r\n,:", p.data[start]) >= 0 {
+		start++
+	}
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	n := &yaml.Node{Kind: yaml.ScalarNode}
+	n.Line, n.Column = position(p.data, start)
+	switch tok := tok.(type) {
+	case json.Delim:
+		n.Kind = yaml.SequenceNode
+		if tok == '{' {
+			n.Kind = yaml.MappingNode
+		}
+		for p.dec.More() {
+			if n.Kind == yaml.MappingNode {
+				key, err := p.value()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, key)
+			}
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, item)
+		}
+		if _, err := p.dec.Token(); err != nil { // Closing delimiter
+			return nil, err
+		}
+	case string:
+		n.Tag, n.Value, n.Style = "!!str", tok, yaml.DoubleQuotedStyle
+	case json.Number:
+		n.Tag, n.Value = "!!int", tok.String()
+		if strings.ContainsAny(n.Value, ".eE") {
+			n.Tag = "!!float"
+		}
+	case bool:
+		n.Tag, n.Value = "!!bool", strconv.FormatBool(tok)
+	case nil:
+		n.Tag, n.Value = "!!null", "null"
+	}
+	return n, nil
+}
+
+// position returns the 1-based line and column of byte offset off in data.
+func position(data []byte, off int) (int, int) {
+	off = min(off, len(data))
+	line := 1 + bytes.Count(data[:off], []byte("\n"))
+	lineStart := bytes.LastIndexByte(data[:off], '\n') + 1
+	return line, 1 + utf8.RuneCount(data[lineStart:off])
+}
+
+var yamlLinePattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// syntaxError locates a JSON or YAML parse error.
+func syntaxError(data []byte, err error) SpecError {
+	var se *json.SyntaxError
+	switch {
+	case errors.As(err, &se):
+		line, col := position(data, int(se.Offset))
+		return SpecError{Line: line, Column: col, Message: se.Error()}
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		line, col := position(data, len(data))
+		return SpecError{Line: line, Column: col, Message: "unexpected end of input"}
+	}
+	if m := yamlLinePattern.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return SpecError{Line: line, Column: 1, Message: m[2]}
+	}
+	return SpecError{Message: err.Error()}
+}
@@ -0,0 +1,42 @@
+package texeluicli
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateSpec(t *testing.T) {
+	cases := []struct {
+		name string
+		spec string
+		want []string // Substrings of each error, in order; none when valid
+	}{
+		{"valid yaml", "widgets:\n  - id: name\n    type: input\n", nil},
+		{"valid json", `{"widgets": [{"id": "ok", "type": "button", "on": {"click": "true"}}]}`, nil},
+		{"empty", "", []string{"spec is empty"}},
+		{"syntax", "{\"widgets\": [", []string{""}},
+		{"unknown field", "widgets:\n  - id: a\n    type: input\n    colour: red\n", []string{"4:5: widgets[0].colour"}},
+		{"wrong type", "widgets:\n  - id: a\n    type: input\n    height: tall\n", []string{"widgets[0].height: must be an integer"}},
+		{"missing id", "widgets:\n  - type: input\n", []string{"widgets[0].id: required"}},
+		{"duplicate id", "widgets:\n  - id: a\n    type: input\n  - id: a\n    type: label\n", []string{`duplicate id "a" (first used on line 2)`}},
+		{"unknown widget", "widgets:\n  - id: a\n    type: slider\n", []string{`unknown widget type "slider"`}},
+		{"unknown layout", "layout:\n  type: grid\nwidgets: []\n", []string{`layout.type: unknown layout type "grid"`}},
+		{"unknown event", "widgets:\n  - id: a\n    type: button\n    on:\n      hover: echo\n", []string{`widgets[0].on.hover: unknown event "hover"`}},
+		{"empty command", "widgets:\n  - id: a\n    type: button\n    on:\n      click: \" \"\n", []string{"widgets[0].on.click: command required"}},
+		{"combobox options", "widgets:\n  - id: a\n    type: combobox\n", []string{"widgets[0].options: required unless the combobox is editable"}},
+		{"bad number", "widgets:\n  - id: a\n    type: number\n    value: lots\n", []string{"widgets[0].value: must be a number"}},
+		{"source order", "widgets:\n  - id: a\n    type: slider\n  - type: input\n", []string{"3:11:", "4:5:"}},
+	}
+	for _, c := range cases {
+		errs := ValidateSpec([]byte(c.spec), "auto")
+		if len(errs) != len(c.want) {
+			t.Errorf("%s: got %d errors %v, want %d", c.name, len(errs), errs, len(c.want))
+			continue
+		}
+		for i, err := range errs {
+			if !strings.Contains(err.Error(), c.want[i]) {
+				t.Errorf("%s: error %d = %q, want it to contain %q", c.name, i, err.Error(), c.want[i])
+			}
+		}
+	}
+}
@@ -1,21 +1,39 @@
 package main
 
 import (
+	"bufio"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/framegrace/texelui/apps/texeluicli"
+	"github.com/framegrace/texelui/core"
 )
 
+// exitTimeout is the exit code of wait and watch when --timeout expires, as
+// in timeout(1).
+const exitTimeout = 124
+
 func main() {
 	global := flag.NewFlagSet("texelui", flag.ExitOnError)
 	serverMode := global.Bool("server", false, "run server daemon")
-	socketPath := global.String("socket", "", "override socket path")
+	socketPath := global.String("socket", "", "override socket path, or tcp://host:port or ws://host:port/path to reach a remote server")
+	var listen listFlag
+	global.Var(&listen, "listen", "also serve tcp://host:port or ws://host:port/path (repeatable; needs TEXELUI_TOKEN)")
+	remoteExec := global.Bool("allow-remote-exec", false, "let --listen clients run commands on this host (run and spec on: actions)")
 	_ = global.Parse(os.Args[1:])
 
 	if *serverMode {
@@ -23,7 +41,8 @@ func main() {
 		if err != nil {
 			exitError(err)
 		}
-		if err := texeluicli.RunServer(path); err != nil {
+		cfg := texeluicli.ServerConfig{SocketPath: path, Listen: listen, Token: os.Getenv("TEXELUI_TOKEN"), RemoteExec: *remoteExec}
+		if err := texeluicli.RunServerConfig(cfg); err != nil {
 			exitError(err)
 		}
 		return
@@ -42,16 +61,52 @@ func main() {
 		openCmd(cmdArgs, *socketPath)
 	case "wait":
 		waitCmd(cmdArgs, *socketPath)
+	case "watch":
+		watchCmd(cmdArgs, *socketPath)
 	case "get":
 		getCmd(cmdArgs, *socketPath)
 	case "set":
 		setCmd(cmdArgs, *socketPath)
+	case "enable", "disable":
+		enableCmd(cmd, cmdArgs, *socketPath)
+	case "focus":
+		focusCmd(cmdArgs, *socketPath)
+	case "title":
+		titleCmd(cmdArgs, *socketPath)
+	case "status":
+		statusCmd(cmdArgs, *socketPath)
+	case "update":
+		updateCmd(cmdArgs, *socketPath)
 	case "append":
 		appendCmd(cmdArgs, *socketPath)
+	case "table":
+		tableCmd(cmdArgs, *socketPath)
+	case "progress":
+		progressCmd(cmdArgs, *socketPath)
+	case "list":
+		listCmd(cmdArgs, *socketPath)
+	case "validate":
+		validateCmd(cmdArgs)
+	case "notify":
+		notifyCmd(cmdArgs, *socketPath)
+	case "dialog":
+		dialogCmd(cmdArgs, *socketPath)
 	case "run":
 		runCmd(cmdArgs, *socketPath)
+	case "jobs":
+		jobsCmd(cmdArgs, *socketPath)
+	case "kill":
+		killCmd(cmdArgs, *socketPath)
+	case "ls":
+		lsCmd(cmdArgs, *socketPath)
+	case "attach":
+		attachCmd(cmdArgs, *socketPath)
 	case "close":
 		closeCmd(cmdArgs, *socketPath)
+	case "debug":
+		debugCmd(cmdArgs, *socketPath)
+	case "screenshot":
+		screenshotCmd(cmdArgs, *socketPath)
 	default:
 		usage()
 	}
@@ -60,21 +115,24 @@ func main() {
 func openCmd(args []string, socketPath string) {
 	fs := flag.NewFlagSet("open", flag.ExitOnError)
 	specPath := fs.String("spec", "-", "spec file path or - for stdin")
+	format := fs.String("format", "auto", "spec format: auto, json or yaml")
+	vars := varFlag{}
+	fs.Var(vars, "var", "NAME=VALUE for ${NAME} in the spec (repeatable)")
+	watch := fs.Bool("watch", false, "keep running and reload the spec file into the dialog when it changes")
 	_ = fs.Parse(args)
 
-	var reader io.Reader
+	opts := texeluicli.DecodeOptions{Format: *format, Vars: vars}
+	var spec texeluicli.Spec
+	var err error
 	if *specPath == "-" {
-		reader = os.Stdin
-	} else {
-		f, err := os.Open(*specPath)
-		if err != nil {
-			exitError(err)
+		if *watch {
+			exitError(fmt.Errorf("--watch needs a spec file"))
 		}
-		defer f.Close()
-		reader = f
+		spec, err = texeluicli.DecodeSpecWith(os.Stdin, opts)
+	} else {
+		opts.Dir = filepath.Dir(*specPath)
+		spec, err = decodeSpecFile(*specPath, opts)
 	}
-
-	spec, err := texeluicli.DecodeSpec(reader)
 	if err != nil {
 		exitError(err)
 	}
@@ -87,21 +145,100 @@ func openCmd(args []string, socketPath string) {
 		exitError(errors.New(resp.Error))
 	}
 	fmt.Println(resp.Session)
+	if *watch {
+		watchSpec(*specPath, opts, spec, resp.Session, socketPath)
+	}
+}
+
+func decodeSpecFile(path string, opts texeluicli.DecodeOptions) (texeluicli.Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return texeluicli.Spec{}, err
+	}
+	defer f.Close()
+	return texeluicli.DecodeSpecWith(f, opts)
+}
+
+// specPollInterval is how often open --watch reads the spec file.
+const specPollInterval = 500 * time.Millisecond
+
+// watchSpec reloads the spec file into the session whenever what it decodes
+// to changes, which also catches edits to included files, until the session
+// closes. The server draws on this terminal, so problems with the file show
+// in the dialog's status bar, and the dialog keeps running as it was.
+func watchSpec(path string, opts texeluicli.DecodeOptions, last texeluicli.Spec, session, socketPath string) {
+	closed := make(chan struct{})
+	go func() {
+		req := texeluicli.Request{Cmd: "watch", Session: session, Events: []string{"close:session"}}
+		_ = texeluicli.WatchEvents(req, socketPath, func(texeluicli.Response) {})
+		close(closed)
+	}()
+	notify := func(text, level string) {
+		req := texeluicli.Request{Cmd: "notify", Session: session, Text: text, Level: level, Duration: "10s"}
+		_, _ = texeluicli.SendRequest(req, socketPath)
+	}
+
+	ticker := time.NewTicker(specPollInterval)
+	defer ticker.Stop()
+	failed := ""
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ticker.C:
+		}
+		spec, err := decodeSpecFile(path, opts)
+		if err == nil && reflect.DeepEqual(spec, last) {
+			failed = ""
+			continue
+		}
+		if err == nil {
+			var resp texeluicli.Response
+			resp, err = texeluicli.SendRequest(texeluicli.Request{Cmd: "update", Session: session, Spec: &spec}, socketPath)
+			if err == nil && !resp.OK {
+				err = errors.New(resp.Error)
+			}
+		}
+		if err != nil {
+			// Report each problem once, not on every poll
+			if err.Error() != failed {
+				failed = err.Error()
+				notify(fmt.Sprintf("%s: %v", filepath.Base(path), err), "error")
+			}
+			continue
+		}
+		last, failed = spec, ""
+		notify("Reloaded "+filepath.Base(path), "success")
+	}
 }
 
 func waitCmd(args []string, socketPath string) {
 	fs := flag.NewFlagSet("wait", flag.ExitOnError)
-	events := fs.String("events", "", "comma-separated event filters (e.g., click:run)")
+	events := fs.String("events", "", "comma-separated event filters, each optionally =exit code (e.g., click:ok=0,click:cancel=1)")
 	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
 	value := fs.String("value", "", "single widget id to return value for")
 	values := fs.String("values", "", "comma-separated widget ids to return values for")
 	format := fs.String("format", "event", "output: event|json|sh")
+	timeout := fs.String("timeout", "", "give up after this long (e.g. 30s) and exit 124")
+	var fallback stringFlag
+	fs.Var(&fallback, "default", "text printed when the timeout expires")
+	client := fs.String("client", "", "read events from this named queue instead of the shared one")
+	replay := fs.Int("replay", 0, "start a new --client queue this many events back")
+	job := fs.String("job", "", "wait for this job to finish and exit with its exit code")
 	_ = fs.Parse(args)
 
+	filters, codes, err := parseEventCodes(splitCSV(*events))
+	if err != nil {
+		exitError(err)
+	}
 	req := texeluicli.Request{
 		Cmd:     "wait",
 		Session: resolveSession(*session),
-		Events:  splitCSV(*events),
+		Events:  filters,
+		Timeout: *timeout,
+		Client:  *client,
+		Replay:  *replay,
+		Job:     *job,
 	}
 	if *value != "" {
 		req.Values = []string{*value}
@@ -116,21 +253,110 @@ func waitCmd(args []string, socketPath string) {
 	if !resp.OK {
 		exitError(errors.New(resp.Error))
 	}
-
-	if *value != "" {
-		fmt.Println(resp.Values[*value])
+	if resp.TimedOut {
+		if fallback.set {
+			fmt.Println(fallback.value)
+		}
+		os.Exit(exitTimeout)
+	}
+	if *job != "" {
+		if resp.ExitCode != nil && *resp.ExitCode != 0 {
+			os.Exit(*resp.ExitCode)
+		}
 		return
 	}
-	if *values != "" {
+
+	switch {
+	case strings.EqualFold(*format, "json"):
+		writeEvent(resp)
+	case *value != "":
+		fmt.Println(resp.Values[*value])
+	case *values != "":
 		switch strings.ToLower(*format) {
 		case "sh":
 			fmt.Print(formatShell(resp.Values))
 		default:
 			writeJSON(resp.Values)
 		}
-		return
+	case len(codes) == 0:
+		// With exit codes the exit status tells the event
+		fmt.Println(resp.Event)
+	}
+	for _, c := range codes {
+		if texeluicli.MatchEvent(c.filter, resp.Event) {
+			os.Exit(c.code)
+		}
+	}
+}
+
+// eventCode is the exit code wait returns for events matching filter.
+type eventCode struct {
+	filter string
+	code   int
+}
+
+// parseEventCodes splits wait filters such as click:cancel=1 into the
+// filter and its exit code.
+func parseEventCodes(specs []string) ([]string, []eventCode, error) {
+	filters := make([]string, 0, len(specs))
+	var codes []eventCode
+	for _, spec := range specs {
+		filter, code, ok := strings.Cut(spec, "=")
+		filters = append(filters, filter)
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(code)
+		if err != nil || n < 0 || n > 255 {
+			return nil, nil, fmt.Errorf("invalid exit code %q for %s (want 0-255)", code, filter)
+		}
+		codes = append(codes, eventCode{filter: filter, code: n})
+	}
+	return filters, codes, nil
+}
+
+func watchCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	events := fs.String("events", "", "comma-separated event filters (e.g., change:*)")
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	values := fs.String("values", "", "comma-separated widget ids to return values for")
+	timeout := fs.String("timeout", "", "stop when no event arrives for this long (e.g. 5m) and exit 124")
+	client := fs.String("client", "", "resume this named queue instead of starting a new one")
+	replay := fs.Int("replay", 0, "start this many events back")
+	format := fs.String("format", "event", "output: event|json")
+	_ = fs.Parse(args)
+
+	req := texeluicli.Request{
+		Cmd:     "watch",
+		Session: resolveSession(*session),
+		Events:  splitCSV(*events),
+		Values:  splitCSV(*values),
+		Timeout: *timeout,
+		Client:  *client,
+		Replay:  *replay,
+	}
+	err := texeluicli.WatchEvents(req, socketPath, func(resp texeluicli.Response) {
+		if strings.EqualFold(*format, "json") {
+			writeEvent(resp)
+			return
+		}
+		line := struct {
+			Event  string            `json:"event"`
+			Seq    uint64            `json:"seq"`
+			Values map[string]string `json:"values,omitempty"`
+		}{resp.Event, resp.Seq, resp.Values}
+		data, err := json.Marshal(line)
+		if err != nil {
+			exitError(err)
+		}
+		fmt.Println(string(data))
+	})
+	if errors.Is(err, texeluicli.ErrTimedOut) {
+		os.Exit(exitTimeout)
+	}
+	if err != nil {
+		exitError(err)
 	}
-	fmt.Println(resp.Event)
 }
 
 func getCmd(args []string, socketPath string) {
@@ -173,13 +399,27 @@ func setCmd(args []string, socketPath string) {
 	fs.Var(&text, "text", "text value")
 	fs.Var(&value, "value", "value")
 	fs.Var(&checked, "checked", "checkbox value (true/false)")
+	fields := varFlag{}
+	fs.Var(fields, "field", "ID=VALUE to set several widgets at once (repeatable)")
+	fromJSON := fs.Bool("json", false, "read a JSON object of widget ids and values from stdin")
 	_ = fs.Parse(args)
 
-	if *id == "" {
-		exitError(fmt.Errorf("id required"))
-	}
 	req := texeluicli.Request{Cmd: "set", Session: resolveSession(*session), ID: *id}
-	if checked.set {
+	if *fromJSON {
+		values, err := readValuesJSON(os.Stdin)
+		if err != nil {
+			exitError(err)
+		}
+		maps.Copy(fields, values)
+	}
+	if len(fields) > 0 {
+		if *id != "" {
+			exitError(fmt.Errorf("--id cannot be combined with --field or --json"))
+		}
+		req.Set = fields
+	} else if *id == "" {
+		exitError(fmt.Errorf("id required"))
+	} else if checked.set {
 		v := strings.ToLower(checked.value)
 		parsed := v == "true" || v == "1" || v == "yes" || v == "on"
 		req.Checked = &parsed
@@ -199,6 +439,169 @@ func setCmd(args []string, socketPath string) {
 	}
 }
 
+// readValuesJSON reads a JSON object mapping widget ids to values. Numbers
+// and booleans are taken as their text, so {"count": 3, "force": true} works.
+func readValuesJSON(r io.Reader) (map[string]string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("reading values: %w", err)
+	}
+	values := make(map[string]string, len(raw))
+	for id, msg := range raw {
+		var s string
+		if err := json.Unmarshal(msg, &s); err == nil {
+			values[id] = s
+			continue
+		}
+		var scalar interface{}
+		if err := json.Unmarshal(msg, &scalar); err != nil {
+			return nil, err
+		}
+		switch scalar.(type) {
+		case float64, bool:
+			values[id] = string(msg)
+		default:
+			return nil, fmt.Errorf("value of %q must be a string, number or boolean", id)
+		}
+	}
+	return values, nil
+}
+
+// enableCmd runs enable or disable, named by cmd.
+func enableCmd(cmd string, args []string, socketPath string) {
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	ids := fs.String("id", "", "comma-separated widget ids")
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	_ = fs.Parse(args)
+
+	if *ids == "" {
+		exitError(fmt.Errorf("id required"))
+	}
+	req := texeluicli.Request{Cmd: cmd, Session: resolveSession(*session), IDs: splitCSV(*ids)}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+}
+
+func focusCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("focus", flag.ExitOnError)
+	id := fs.String("id", "", "widget id")
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	_ = fs.Parse(args)
+
+	if *id == "" {
+		exitError(fmt.Errorf("id required"))
+	}
+	req := texeluicli.Request{Cmd: "focus", Session: resolveSession(*session), ID: *id}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+}
+
+// debugCmd runs the debugging actions: dump prints the session's widget
+// tree.
+func debugCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("debug", flag.ExitOnError)
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	format := fs.String("format", "text", "dump output: text|json")
+	_ = fs.Parse(args)
+	// Flags may also follow the action: debug dump --format json
+	action := fs.Arg(0)
+	_ = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+
+	if action != "dump" {
+		exitError(fmt.Errorf("unknown debug action %q (want dump)", action))
+	}
+	req := texeluicli.Request{Cmd: "dump", Session: resolveSession(*session)}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+	switch *format {
+	case "json":
+		if resp.Tree == nil {
+			resp.Tree = []core.WidgetNode{}
+		}
+		data, err := json.Marshal(resp.Tree)
+		if err != nil {
+			exitError(err)
+		}
+		fmt.Println(string(data))
+	case "text":
+		fmt.Print(core.FormatTree(resp.Tree))
+	default:
+		exitError(fmt.Errorf("unknown format %q (want text or json)", *format))
+	}
+}
+
+func screenshotCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("screenshot", flag.ExitOnError)
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	format := fs.String("format", "ansi", "output: ansi|text")
+	_ = fs.Parse(args)
+
+	if *format != "ansi" && *format != "text" {
+		exitError(fmt.Errorf("unknown format %q (want ansi or text)", *format))
+	}
+	req := texeluicli.Request{Cmd: "screenshot", Session: resolveSession(*session), Plain: *format == "text"}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+	fmt.Print(resp.Screen)
+}
+
+func updateCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	patchPath := fs.String("patch", "-", "patch file path or - for stdin")
+	format := fs.String("format", "auto", "patch format: auto, json or yaml")
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	vars := varFlag{}
+	fs.Var(vars, "var", "NAME=VALUE for ${NAME} in the patch (repeatable)")
+	_ = fs.Parse(args)
+
+	var reader io.Reader
+	dir := ""
+	if *patchPath == "-" {
+		reader = os.Stdin
+	} else {
+		dir = filepath.Dir(*patchPath)
+		f, err := os.Open(*patchPath)
+		if err != nil {
+			exitError(err)
+		}
+		defer f.Close()
+		reader = f
+	}
+
+	patch, err := texeluicli.DecodeSpecPatchWith(reader, texeluicli.DecodeOptions{Format: *format, Vars: vars, Dir: dir})
+	if err != nil {
+		exitError(err)
+	}
+	req := texeluicli.Request{Cmd: "update", Session: resolveSession(*session), Patch: &patch}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+}
+
 func appendCmd(args []string, socketPath string) {
 	fs := flag.NewFlagSet("append", flag.ExitOnError)
 	id := fs.String("id", "", "widget id")
@@ -224,6 +627,475 @@ func appendCmd(args []string, socketPath string) {
 	}
 }
 
+func tableCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("table", flag.ExitOnError)
+	id := fs.String("id", "", "table widget id")
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	format := fs.String("format", "json", "rows format: json|csv")
+	header := fs.Bool("header", false, "csv: the first line holds the column names")
+	selected := fs.Bool("selected", false, "get: only the selected rows")
+	_ = fs.Parse(args)
+	// Flags may also follow the action: table --id t get --selected
+	action := fs.Arg(0)
+	_ = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+
+	if *id == "" {
+		exitError(fmt.Errorf("id required"))
+	}
+	req := texeluicli.Request{
+		Cmd:      "table",
+		Session:  resolveSession(*session),
+		ID:       *id,
+		Action:   action,
+		Selected: *selected,
+	}
+	csvFormat := strings.ToLower(*format) == "csv"
+	switch action {
+	case "set-rows":
+		var err error
+		if csvFormat {
+			req.Columns, req.Rows, err = readCSVRows(os.Stdin, *header)
+		} else {
+			req.Columns, req.Rows, err = readJSONRows(os.Stdin)
+		}
+		if err != nil {
+			exitError(err)
+		}
+	case "get":
+	default:
+		exitError(fmt.Errorf("action required: set-rows or get"))
+	}
+
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+	if action != "get" {
+		return
+	}
+	if csvFormat {
+		w := csv.NewWriter(os.Stdout)
+		if err := w.WriteAll(resp.Rows); err != nil {
+			exitError(err)
+		}
+		return
+	}
+	rows := resp.Rows
+	if rows == nil {
+		rows = [][]string{}
+	}
+	data, err := json.Marshal(rows)
+	if err != nil {
+		exitError(err)
+	}
+	fmt.Println(string(data))
+}
+
+// readJSONRows reads either an array of rows or an object with "columns"
+// and "rows".
+func readJSONRows(r io.Reader) ([]string, [][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows [][]string
+	if err := json.Unmarshal(data, &rows); err == nil {
+		return nil, rows, nil
+	}
+	var table struct {
+		Columns []string   `json:"columns"`
+		Rows    [][]string `json:"rows"`
+	}
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, nil, err
+	}
+	return table.Columns, table.Rows, nil
+}
+
+func readCSVRows(r io.Reader, header bool) ([]string, [][]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if rows == nil {
+		rows = [][]string{}
+	}
+	if header && len(rows) > 0 {
+		return rows[0], rows[1:], nil
+	}
+	return nil, rows, nil
+}
+
+func listCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	id := fs.String("id", "", "list widget id")
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	var text stringFlag
+	fs.Var(&text, "text", "append: item text (default: one item per stdin line)")
+	index := fs.String("index", "", "remove: comma-separated item indices")
+	selected := fs.Bool("selected", false, "get: only the selected items")
+	format := fs.String("format", "json", "get output: json|text")
+	_ = fs.Parse(args)
+	// Flags may also follow the action: list --id l get --selected
+	action := fs.Arg(0)
+	_ = fs.Parse(fs.Args()[min(1, fs.NArg()):])
+
+	if *id == "" {
+		exitError(fmt.Errorf("id required"))
+	}
+	req := texeluicli.Request{
+		Cmd:      "list",
+		Session:  resolveSession(*session),
+		ID:       *id,
+		Action:   action,
+		Selected: *selected,
+	}
+	switch action {
+	case "append":
+		if text.set {
+			req.Items = []string{text.value}
+			break
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			req.Items = append(req.Items, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			exitError(err)
+		}
+	case "remove":
+		for _, part := range splitCSV(*index) {
+			idx, err := strconv.Atoi(part)
+			if err != nil {
+				exitError(fmt.Errorf("invalid index %q", part))
+			}
+			req.Indices = append(req.Indices, idx)
+		}
+		if len(req.Indices) == 0 {
+			exitError(fmt.Errorf("index required"))
+		}
+	case "get":
+	default:
+		exitError(fmt.Errorf("action required: append, remove or get"))
+	}
+
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+	if action != "get" {
+		return
+	}
+	if strings.ToLower(*format) == "text" {
+		for _, item := range resp.Items {
+			fmt.Println(item.Text)
+		}
+		return
+	}
+	items := resp.Items
+	if items == nil {
+		items = []texeluicli.ListItem{}
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		exitError(err)
+	}
+	fmt.Println(string(data))
+}
+
+func progressCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("progress", flag.ExitOnError)
+	id := fs.String("id", "", "progress widget id")
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	value := fs.String("value", "", "percentage (0-100)")
+	label := fs.String("label", "", "text shown before the bar")
+	stdin := fs.Bool("stdin", false, "read percentages from stdin, one per line")
+	_ = fs.Parse(args)
+
+	if *id == "" {
+		exitError(fmt.Errorf("id required"))
+	}
+	send := func(value, label string) {
+		req := texeluicli.Request{
+			Cmd:     "progress",
+			Session: resolveSession(*session),
+			ID:      *id,
+			Value:   value,
+			Text:    label,
+		}
+		resp, err := texeluicli.SendRequest(req, socketPath)
+		if err != nil {
+			exitError(err)
+		}
+		if !resp.OK {
+			exitError(errors.New(resp.Error))
+		}
+	}
+	if !*stdin {
+		if *value == "" && *label == "" {
+			exitError(fmt.Errorf("value or label required"))
+		}
+		send(*value, *label)
+		return
+	}
+
+	if *value != "" || *label != "" {
+		send(*value, *label)
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if percent, ok := linePercent(scanner.Text()); ok {
+			send(percent, "")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		exitError(err)
+	}
+}
+
+var percentPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*%`)
+
+// linePercent returns the percentage on a line of progress output: a bare
+// number ("42") or the first "N%" in the line ("copied 3 files 42% 1.2MB/s").
+func linePercent(line string) (string, bool) {
+	line = strings.TrimSpace(line)
+	if _, err := strconv.ParseFloat(line, 64); err == nil {
+		return line, true
+	}
+	if m := percentPattern.FindStringSubmatch(line); m != nil {
+		return m[1], true
+	}
+	return "", false
+}
+
+func notifyCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("notify", flag.ExitOnError)
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	level := fs.String("level", "info", "message level: info, success, warning or error")
+	duration := fs.String("duration", "", "how long the message shows (e.g. 5s; default 3s)")
+	_ = fs.Parse(args)
+
+	text := strings.Join(fs.Args(), " ")
+	if text == "" {
+		exitError(fmt.Errorf("message required"))
+	}
+	req := texeluicli.Request{
+		Cmd:      "notify",
+		Session:  resolveSession(*session),
+		Text:     text,
+		Level:    *level,
+		Duration: *duration,
+	}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+}
+
+func titleCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("title", flag.ExitOnError)
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	var text stringFlag
+	fs.Var(&text, "text", "new title")
+	_ = fs.Parse(args)
+
+	if !text.set {
+		exitError(fmt.Errorf("text required"))
+	}
+	req := texeluicli.Request{Cmd: "title", Session: resolveSession(*session), Text: text.value}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+}
+
+func statusCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	var text stringFlag
+	fs.Var(&text, "text", "status text (empty clears it)")
+	level := fs.String("level", "info", "text color: info, success, warning or error")
+	remove := fs.Bool("clear", false, "remove the status text")
+	_ = fs.Parse(args)
+
+	if !text.set && !*remove {
+		exitError(fmt.Errorf("text required"))
+	}
+	req := texeluicli.Request{Cmd: "status", Session: resolveSession(*session), Level: *level}
+	if !*remove {
+		req.Text = text.value
+	}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+}
+
+// Dialog exit codes, as in dialog(1) and whiptail(1).
+const (
+	dialogExitOK     = 0
+	dialogExitCancel = 1
+	dialogExitEsc    = 255
+)
+
+// dialogCmd shows a prebuilt yes/no, input or choice dialog in a temporary
+// session, prints the entered text or chosen option and exits with
+// dialogExitOK, dialogExitCancel or dialogExitEsc.
+func dialogCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("dialog", flag.ExitOnError)
+	kind := fs.String("type", "yesno", "dialog type: yesno, input or choice")
+	title := fs.String("title", "", "window title")
+	message := fs.String("message", "", "message shown above the field and buttons")
+	options := fs.String("options", "", "comma-separated choices (choice)")
+	value := fs.String("default", "", "initial text (input) or choice (choice)")
+	_ = fs.Parse(args)
+
+	var widgets []texeluicli.WidgetSpec
+	for i, line := range strings.Split(*message, "\n") {
+		if *message == "" {
+			break
+		}
+		widgets = append(widgets, texeluicli.WidgetSpec{ID: fmt.Sprintf("message%d", i), Type: "label", Text: line})
+	}
+	field, okID, cancelID := "", "ok", "cancel"
+	okText, cancelText := "OK", "Cancel"
+	switch *kind {
+	case "yesno":
+		okID, cancelID = "yes", "no"
+		okText, cancelText = "Yes", "No"
+	case "input":
+		field = "input"
+		widgets = append(widgets, texeluicli.WidgetSpec{ID: field, Type: "input", Value: *value})
+	case "choice":
+		items := splitCSV(*options)
+		if len(items) == 0 {
+			exitError(fmt.Errorf("options required"))
+		}
+		field = "choice"
+		widgets = append(widgets, texeluicli.WidgetSpec{ID: field, Type: "list", Items: items, Value: *value, Height: min(len(items), 10)})
+	default:
+		exitError(fmt.Errorf("unknown dialog type %q", *kind))
+	}
+	widgets = append(widgets,
+		texeluicli.WidgetSpec{ID: okID, Type: "button", Text: okText},
+		texeluicli.WidgetSpec{ID: cancelID, Type: "button", Text: cancelText},
+	)
+	spec := texeluicli.Spec{Title: *title, Widgets: widgets}
+
+	resp, err := texeluicli.SendRequest(texeluicli.Request{Cmd: "open", Spec: &spec}, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+	session := resp.Session
+
+	req := texeluicli.Request{
+		Cmd:     "wait",
+		Session: session,
+		Events:  []string{"click:" + okID, "click:" + cancelID, "submit:input", "select:choice", "close:session"},
+	}
+	if field != "" {
+		req.Values = []string{field}
+	}
+	resp, err = texeluicli.SendRequest(req, socketPath)
+	if err != nil || !resp.OK || resp.Event == "close:session" {
+		// Closed with Esc or Ctrl+C, which also stopped the server
+		os.Exit(dialogExitEsc)
+	}
+	// Sending close to a stopped server would start a new one, so only
+	// close the session while it is still open
+	_, _ = texeluicli.SendRequest(texeluicli.Request{Cmd: "close", Session: session}, socketPath)
+	switch resp.Event {
+	case "click:" + okID, "submit:input", "select:choice":
+		if field != "" {
+			fmt.Println(resp.Values[field])
+		}
+		os.Exit(dialogExitOK)
+	case "click:" + cancelID:
+		os.Exit(dialogExitCancel)
+	default:
+		os.Exit(dialogExitEsc)
+	}
+}
+
+// validateCmd checks spec files offline, printing one "file:line:col: field:
+// message" line per problem, and exits non-zero if any file has problems.
+func validateCmd(args []string) {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	format := fs.String("format", "auto", "spec format: auto, json or yaml")
+	asJSON := fs.Bool("json", false, "print problems as a JSON array")
+	vars := varFlag{}
+	fs.Var(vars, "var", "NAME=VALUE for ${NAME} in the spec (repeatable)")
+	_ = fs.Parse(args)
+
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+	type fileError struct {
+		File string `json:"file"`
+		texeluicli.SpecError
+	}
+	problems := []fileError{}
+	for _, path := range paths {
+		var data []byte
+		var err error
+		name := path
+		opts := texeluicli.DecodeOptions{Format: *format, Vars: vars}
+		if path == "-" {
+			name = "<stdin>"
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			opts.Dir = filepath.Dir(path)
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			exitError(err)
+		}
+		for _, specErr := range texeluicli.ValidateSpecWith(data, opts) {
+			problems = append(problems, fileError{File: name, SpecError: specErr})
+		}
+	}
+
+	if *asJSON {
+		data, err := json.Marshal(problems)
+		if err != nil {
+			exitError(err)
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, p := range problems {
+			if p.Line > 0 {
+				fmt.Fprintf(os.Stderr, "%s:%s\n", p.File, p.SpecError.Error())
+			} else {
+				fmt.Fprintf(os.Stderr, "%s: %s\n", p.File, p.SpecError.Error())
+			}
+		}
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
+
 func runCmd(args []string, socketPath string) {
 	fs := flag.NewFlagSet("run", flag.ExitOnError)
 	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
@@ -231,20 +1103,42 @@ func runCmd(args []string, socketPath string) {
 	stderr := fs.String("stderr", "", "widget id for stderr")
 	clear := fs.String("clear", "", "widget id to clear before run")
 	cwd := fs.String("cwd", "", "working directory")
+	pty := fs.Bool("pty", false, "run on a pseudo-terminal, streaming its output to the --stdout widget")
+	background := fs.Bool("background", false, "print the job id and return without waiting for the command")
+	status := fs.String("status", "", "widget id showing the job status")
+	stdin := fs.String("stdin", "", "widget id whose value is the command's stdin, or - for this process's stdin")
+	var env listFlag
+	fs.Var(&env, "env", "KEY=VALUE added to the command's environment (repeatable)")
+	envFromWidgets := fs.Bool("env-from-widgets", false, "export the widget values as TEXELUI_VALUE_<ID>")
 	_ = fs.Parse(args)
 	argv := fs.Args()
 	if len(argv) == 0 {
 		exitError(fmt.Errorf("command required"))
 	}
+	var input string
+	if *stdin == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			exitError(err)
+		}
+		input, *stdin = string(data), ""
+	}
 	req := texeluicli.Request{
 		Cmd:     "run",
 		Session: resolveSession(*session),
 		Run: &texeluicli.RunRequest{
-			Argv:   argv,
-			Stdout: *stdout,
-			Stderr: *stderr,
-			Clear:  *clear,
-			Cwd:    *cwd,
+			Argv:           argv,
+			Stdout:         *stdout,
+			Stderr:         *stderr,
+			Clear:          *clear,
+			Cwd:            *cwd,
+			PTY:            *pty,
+			Background:     *background,
+			Status:         *status,
+			Stdin:          *stdin,
+			Input:          input,
+			Env:            env,
+			EnvFromWidgets: *envFromWidgets,
 		},
 	}
 	resp, err := texeluicli.SendRequest(req, socketPath)
@@ -254,16 +1148,175 @@ func runCmd(args []string, socketPath string) {
 	if !resp.OK {
 		exitError(errors.New(resp.Error))
 	}
+	if *background {
+		fmt.Println(resp.Job)
+		return
+	}
 	if resp.ExitCode != nil && *resp.ExitCode != 0 {
 		os.Exit(*resp.ExitCode)
 	}
 }
 
+func jobsCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("jobs", flag.ExitOnError)
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	format := fs.String("format", "table", "output: table|json")
+	_ = fs.Parse(args)
+
+	resp, err := texeluicli.SendRequest(texeluicli.Request{Cmd: "jobs", Session: resolveSession(*session)}, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+	if *format == "json" {
+		jobs := resp.Jobs
+		if jobs == nil {
+			jobs = []texeluicli.JobInfo{}
+		}
+		data, err := json.Marshal(jobs)
+		if err != nil {
+			exitError(err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tEXIT\tAGE\tCOMMAND")
+	for _, j := range resp.Jobs {
+		exit := ""
+		if j.ExitCode != nil {
+			exit = strconv.Itoa(*j.ExitCode)
+		}
+		age := time.Since(j.Started).Round(time.Second)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", j.ID, j.Status, exit, age, j.Command)
+	}
+	_ = tw.Flush()
+}
+
+func killCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("kill", flag.ExitOnError)
+	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	job := fs.String("job", "", "job id (required)")
+	signal := fs.String("signal", "TERM", "signal to send: TERM, KILL, INT, HUP, QUIT or a number")
+	_ = fs.Parse(args)
+	if *job == "" {
+		exitError(errors.New("--job is required"))
+	}
+	req := texeluicli.Request{Cmd: "kill", Session: resolveSession(*session), Job: *job, Signal: *signal}
+	resp, err := texeluicli.SendRequest(req, socketPath)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+}
+
+// findSessions asks every running server for its session: those in the
+// default socket directory, and the one selected by --socket or
+// TEXELUI_SOCKET. Servers are not started.
+func findSessions(socketPath string) []texeluicli.SessionInfo {
+	sockets, err := texeluicli.ServerSockets()
+	if err != nil {
+		exitError(err)
+	}
+	if path, err := texeluicli.SocketPath(socketPath); err == nil && !slices.Contains(sockets, path) {
+		sockets = append(sockets, path)
+	}
+	var sessions []texeluicli.SessionInfo
+	for _, socket := range sockets {
+		resp, err := texeluicli.QueryServer(texeluicli.Request{Cmd: "ls"}, socket)
+		if err != nil || !resp.OK {
+			continue // Not running, or not ours
+		}
+		for _, info := range resp.Sessions {
+			info.Socket = socket
+			sessions = append(sessions, info)
+		}
+	}
+	return sessions
+}
+
+func lsCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("ls", flag.ExitOnError)
+	format := fs.String("format", "table", "output: table|json")
+	_ = fs.Parse(args)
+
+	sessions := findSessions(socketPath)
+	if *format == "json" {
+		if sessions == nil {
+			sessions = []texeluicli.SessionInfo{}
+		}
+		data, err := json.Marshal(sessions)
+		if err != nil {
+			exitError(err)
+		}
+		fmt.Println(string(data))
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tAGE\tTITLE\tWIDGETS\tSOCKET")
+	for _, s := range sessions {
+		age := time.Since(s.Created).Round(time.Second)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", s.ID, age, s.Title, s.Widgets, s.Socket)
+	}
+	_ = tw.Flush()
+}
+
+// attachCmd picks a running session, repaints its terminal and prints the
+// environment that points the other commands at it.
+func attachCmd(args []string, socketPath string) {
+	fs := flag.NewFlagSet("attach", flag.ExitOnError)
+	session := fs.String("session", "", "session id (required when several sessions run)")
+	_ = fs.Parse(args)
+
+	var found []texeluicli.SessionInfo
+	for _, info := range findSessions(socketPath) {
+		if *session == "" || info.ID == *session {
+			found = append(found, info)
+		}
+	}
+	switch {
+	case len(found) == 0 && *session != "":
+		exitError(fmt.Errorf("session %q not found", *session))
+	case len(found) == 0:
+		exitError(errors.New("no running sessions"))
+	case len(found) > 1:
+		exitError(fmt.Errorf("%d sessions are running, choose one with --session (see texelui ls)", len(found)))
+	}
+	info := found[0]
+	resp, err := texeluicli.QueryServer(texeluicli.Request{Cmd: "attach", Session: info.ID}, info.Socket)
+	if err != nil {
+		exitError(err)
+	}
+	if !resp.OK {
+		exitError(errors.New(resp.Error))
+	}
+	fmt.Printf("export TEXELUI_SOCKET=%s\n", shellEscape(info.Socket))
+	fmt.Printf("export TEXELUI_SESSION=%s\n", shellEscape(info.ID))
+}
+
 func closeCmd(args []string, socketPath string) {
 	fs := flag.NewFlagSet("close", flag.ExitOnError)
 	session := fs.String("session", "", "session id (defaults to TEXELUI_SESSION)")
+	all := fs.Bool("all", false, "close every running session")
 	_ = fs.Parse(args)
 
+	if *all {
+		for _, info := range findSessions(socketPath) {
+			resp, err := texeluicli.QueryServer(texeluicli.Request{Cmd: "close", Session: info.ID}, info.Socket)
+			if err != nil {
+				exitError(err)
+			}
+			if !resp.OK {
+				exitError(errors.New(resp.Error))
+			}
+		}
+		return
+	}
+
 	req := texeluicli.Request{Cmd: "close", Session: resolveSession(*session)}
 	resp, err := texeluicli.SendRequest(req, socketPath)
 	if err != nil {
@@ -332,6 +1385,34 @@ func (s *stringFlag) Set(val string) error {
 	return nil
 }
 
+// listFlag collects the values of a repeatable flag.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(val string) error {
+	*l = append(*l, val)
+	return nil
+}
+
+// varFlag collects the NAME=VALUE definitions of a repeatable --var flag.
+type varFlag map[string]string
+
+func (v varFlag) String() string {
+	return ""
+}
+
+func (v varFlag) Set(val string) error {
+	name, value, ok := strings.Cut(val, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("want NAME=VALUE, got %q", val)
+	}
+	v[name] = value
+	return nil
+}
+
 func writeJSON(values map[string]string) {
 	data, err := json.Marshal(values)
 	if err != nil {
@@ -340,9 +1421,29 @@ func writeJSON(values map[string]string) {
 	fmt.Println(string(data))
 }
 
+// writeEvent prints the event in resp as one JSON object, with the values
+// the server captured when it happened.
+func writeEvent(resp texeluicli.Response) {
+	typ, id, _ := strings.Cut(resp.Event, ":")
+	line := struct {
+		Event  string            `json:"event"`
+		ID     string            `json:"id"`
+		Seq    uint64            `json:"seq"`
+		Values map[string]string `json:"values"`
+	}{typ, id, resp.Seq, resp.Values}
+	if line.Values == nil {
+		line.Values = map[string]string{}
+	}
+	data, err := json.Marshal(line)
+	if err != nil {
+		exitError(err)
+	}
+	fmt.Println(string(data))
+}
+
 func usage() {
-	fmt.Fprintln(os.Stderr, "usage: texelui [--server] [--socket path] <command> [args]")
-	fmt.Fprintln(os.Stderr, "commands: open, wait, get, set, append, run, close")
+	fmt.Fprintln(os.Stderr, "usage: texelui [--server [--listen addr]... [--allow-remote-exec]] [--socket path|addr] <command> [args]")
+	fmt.Fprintln(os.Stderr, "commands: open, wait, watch, get, set, enable, disable, focus, title, status, update, append, table, list, progress, notify, dialog, validate, run, jobs, kill, ls, attach, close, debug, screenshot")
 }
 
 func exitError(err error) {
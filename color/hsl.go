@@ -0,0 +1,68 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/color/hsl.go
+// Summary: HSL color space conversion utilities.
+
+package color
+
+import "math"
+
+// HSLToRGB converts HSL to RGB.
+// H: Hue (0 - 360 degrees)
+// S: Saturation (0.0 - 1.0)
+// L: Lightness (0.0 - 1.0)
+func HSLToRGB(H, S, L float64) RGB {
+	H = math.Mod(H, 360)
+	if H < 0 {
+		H += 360
+	}
+	c := (1 - math.Abs(2*L-1)) * S
+	x := c * (1 - math.Abs(math.Mod(H/60, 2)-1))
+	m := L - c/2
+
+	var r, g, b float64
+	switch {
+	case H < 60:
+		r, g, b = c, x, 0
+	case H < 120:
+		r, g, b = x, c, 0
+	case H < 180:
+		r, g, b = 0, c, x
+	case H < 240:
+		r, g, b = 0, x, c
+	case H < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	to8 := func(v float64) int32 { return clamp(int32(math.Round((v+m)*255)), 0, 255) }
+	return RGB{R: to8(r), G: to8(g), B: to8(b)}
+}
+
+// RGBToHSL converts RGB to HSL. Grays have hue and saturation 0.
+func RGBToHSL(r, g, b int32) (H, S, L float64) {
+	rf, gf, bf := float64(r)/255, float64(g)/255, float64(b)/255
+	maxC := math.Max(rf, math.Max(gf, bf))
+	minC := math.Min(rf, math.Min(gf, bf))
+	L = (maxC + minC) / 2
+	d := maxC - minC
+	if d == 0 {
+		return 0, 0, L
+	}
+
+	S = d / (1 - math.Abs(2*L-1))
+	switch maxC {
+	case rf:
+		H = math.Mod((gf-bf)/d, 6)
+	case gf:
+		H = (bf-rf)/d + 2
+	default:
+		H = (rf-gf)/d + 4
+	}
+	H *= 60
+	if H < 0 {
+		H += 360
+	}
+	return H, S, L
+}
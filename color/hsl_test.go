@@ -0,0 +1,31 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package color
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHSLRoundTrip(t *testing.T) {
+	cases := []struct {
+		rgb     RGB
+		h, s, l float64
+	}{
+		{RGB{255, 0, 0}, 0, 1, 0.5},
+		{RGB{0, 255, 0}, 120, 1, 0.5},
+		{RGB{0, 0, 255}, 240, 1, 0.5},
+		{RGB{128, 128, 128}, 0, 0, 128.0 / 255},
+		{RGB{203, 166, 247}, 267.4, 0.835, 0.810},
+	}
+	for _, tc := range cases {
+		h, s, l := RGBToHSL(tc.rgb.R, tc.rgb.G, tc.rgb.B)
+		if math.Abs(h-tc.h) > 0.1 || math.Abs(s-tc.s) > 0.01 || math.Abs(l-tc.l) > 0.01 {
+			t.Errorf("RGBToHSL(%v) = %.1f, %.3f, %.3f; want %.1f, %.3f, %.3f", tc.rgb, h, s, l, tc.h, tc.s, tc.l)
+		}
+		if got := HSLToRGB(h, s, l); got != tc.rgb {
+			t.Errorf("HSLToRGB(%.1f, %.3f, %.3f) = %v, want %v", h, s, l, got, tc.rgb)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: core/accel.go
+// Summary: Global shortcuts and Alt+letter mnemonics for the UIManager.
+// Shortcuts fire regardless of focus and may be key sequences ("Ctrl+X
+// Ctrl+S", "g g"); mnemonics activate the widget that shows the letter
+// underlined. Both are checked before normal key routing.
+
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// KeyStroke is one key with its modifiers, such as Ctrl+S, F5 or Alt+f.
+// Runes are kept lowercase when Ctrl or Alt is held, and Shift is implied
+// by the rune itself, so a KeyStroke compares equal to the one built from
+// any event producing it.
+type KeyStroke struct {
+	Key  tcell.Key // tcell.KeyRune for characters, including Ctrl+letter
+	Rune rune      // For tcell.KeyRune
+	Mod  tcell.ModMask
+}
+
+// KeyStrokeOf returns the normalized KeyStroke of ev. Ctrl+letter control
+// codes become the letter with ModCtrl, except Tab, Enter and Backspace,
+// which share their codes.
+func KeyStrokeOf(ev *tcell.EventKey) KeyStroke {
+	ks := KeyStroke{Key: ev.Key(), Mod: ev.Modifiers() &^ tcell.ModMeta}
+	if ev.Modifiers()&tcell.ModMeta != 0 {
+		ks.Mod |= tcell.ModAlt // Terminals report Alt as Meta or Alt
+	}
+	switch {
+	case ks.Key == tcell.KeyRune:
+		ks.Rune = ev.Rune()
+	case isCtrlLetter(ks.Key):
+		ks.Rune = rune('a' + ks.Key - tcell.KeyCtrlA)
+		ks.Key = tcell.KeyRune
+		ks.Mod |= tcell.ModCtrl
+	}
+	return ks.normalize()
+}
+
+// normalize lowercases runes under Ctrl or Alt and drops Shift from runes.
+func (ks KeyStroke) normalize() KeyStroke {
+	if ks.Key != tcell.KeyRune {
+		ks.Rune = 0
+		return ks
+	}
+	if ks.Mod&(tcell.ModCtrl|tcell.ModAlt) != 0 {
+		ks.Rune = unicode.ToLower(ks.Rune)
+	}
+	ks.Mod &^= tcell.ModShift
+	return ks
+}
+
+// ParseKeyStroke parses a key such as "Ctrl+S", "Alt+f", "F5",
+// "Shift+Tab", "g" or the hint style "C-s". Modifiers are Ctrl (C), Alt
+// (A, Meta, M) and Shift (S); key names are tcell's ("Enter", "Esc",
+// "PgDn", ...).
+func ParseKeyStroke(s string) (KeyStroke, error) {
+	var ks KeyStroke
+	rest := s
+	for {
+		i := strings.IndexAny(rest, "+-")
+		if i <= 0 || i == len(rest)-1 {
+			break
+		}
+		switch strings.ToLower(rest[:i]) {
+		case "ctrl", "c":
+			ks.Mod |= tcell.ModCtrl
+		case "alt", "a", "meta", "m":
+			ks.Mod |= tcell.ModAlt
+		case "shift", "s":
+			ks.Mod |= tcell.ModShift
+		default:
+			return KeyStroke{}, fmt.Errorf("key %q: unknown modifier %q", s, rest[:i])
+		}
+		rest = rest[i+1:]
+	}
+	if r := []rune(rest); len(r) == 1 {
+		ks.Key, ks.Rune = tcell.KeyRune, r[0]
+		if ks.Mod&tcell.ModShift != 0 && ks.Mod&(tcell.ModCtrl|tcell.ModAlt) == 0 {
+			ks.Rune = unicode.ToUpper(ks.Rune)
+		}
+		return ks.normalize(), nil
+	}
+	for k, name := range tcell.KeyNames {
+		if strings.EqualFold(name, rest) && !isCtrlLetter(k) {
+			ks.Key = k
+			return ks.normalize(), nil
+		}
+	}
+	return KeyStroke{}, fmt.Errorf("key %q: unknown key %q", s, rest)
+}
+
+// ParseKeySequence parses space-separated keys, such as "Ctrl+X Ctrl+S"
+// or "g g", each read by ParseKeyStroke.
+func ParseKeySequence(s string) ([]KeyStroke, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("key sequence %q: no keys", s)
+	}
+	keys := make([]KeyStroke, len(fields))
+	for i, f := range fields {
+		ks, err := ParseKeyStroke(f)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = ks
+	}
+	return keys, nil
+}
+
+// FormatKeySequence formats keys the way ParseKeySequence reads them.
+func FormatKeySequence(keys []KeyStroke) string {
+	parts := make([]string, len(keys))
+	for i, ks := range keys {
+		parts[i] = ks.String()
+	}
+	return strings.Join(parts, " ")
+}
+
+// isCtrlLetter reports whether k is Ctrl+A..Ctrl+Z, other than the keys
+// that share those codes (Tab, Enter, Backspace).
+func isCtrlLetter(k tcell.Key) bool {
+	return k >= tcell.KeyCtrlA && k <= tcell.KeyCtrlZ &&
+		k != tcell.KeyTab && k != tcell.KeyEnter && k != tcell.KeyBackspace
+}
+
+// String formats the key in the style ParseKeyStroke reads, e.g. "Ctrl+S".
+func (ks KeyStroke) String() string {
+	var b strings.Builder
+	if ks.Mod&tcell.ModCtrl != 0 {
+		b.WriteString("Ctrl+")
+	}
+	if ks.Mod&tcell.ModAlt != 0 {
+		b.WriteString("Alt+")
+	}
+	if ks.Mod&tcell.ModShift != 0 {
+		b.WriteString("Shift+")
+	}
+	switch {
+	case ks.Key != tcell.KeyRune:
+		b.WriteString(tcell.KeyNames[ks.Key])
+	case ks.Mod&tcell.ModCtrl != 0:
+		b.WriteRune(unicode.ToUpper(ks.Rune))
+	default:
+		b.WriteRune(ks.Rune)
+	}
+	return b.String()
+}
+
+// MnemonicTarget is implemented by widgets with an Alt+letter mnemonic,
+// drawn underlined in their label, such as buttons. On an Alt+letter key
+// the UIManager activates the first enabled widget in the tree whose
+// mnemonic matches, before routing the key to the focused widget. Only
+// visited children count, so widgets on hidden tabs don't answer. While
+// the focused widget is modal, it sees the key first and only widgets
+// inside it answer.
+type MnemonicTarget interface {
+	// MnemonicRune returns the mnemonic letter, or 0 for none.
+	MnemonicRune() rune
+	// ActivateMnemonic acts as if the widget was clicked. It runs after
+	// HandleKey has released the UI lock, so callbacks may call the
+	// UIManager.
+	ActivateMnemonic() bool
+}
+
+// PendingKeysIndicator is implemented by status bars that show the keys of
+// an unfinished key sequence, such as "Ctrl+X" while waiting for the rest
+// of "Ctrl+X Ctrl+S". keys is "" once the sequence completes or is broken;
+// otherwise it is dropped at until unless updated.
+type PendingKeysIndicator interface {
+	SetPendingKeys(keys string, until time.Time)
+}
+
+// DefaultKeySequenceTimeout is how long a key sequence waits for its next
+// key when UIManager.KeySequenceTimeout is zero.
+const DefaultKeySequenceTimeout = 1500 * time.Millisecond
+
+// shortcut is a registered key sequence and its action.
+type shortcut struct {
+	keys []KeyStroke
+	fn   func()
+}
+
+// AddShortcut registers fn to run when key is pressed, whatever has focus.
+// key is parsed by ParseKeySequence: a single key such as "Ctrl+S" or a
+// sequence such as "Ctrl+X Ctrl+S" or "g g". Registering a key again
+// replaces it. Shortcuts are checked before mnemonics and normal key
+// routing, except that an open modal (such as a dropdown) sees keys first,
+// and that a sequence starting with a plain character only starts when no
+// widget took that key, so typing into inputs still works. While a
+// sequence is pending, the status bar shows its keys; a key that continues
+// no sequence, or a pause longer than KeySequenceTimeout, cancels it.
+// fn runs after HandleKey has released the UI lock, so it may call
+// UIManager methods such as Focus.
+func (u *UIManager) AddShortcut(key string, fn func()) error {
+	keys, err := ParseKeySequence(key)
+	if err != nil {
+		return err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.shortcuts == nil {
+		u.shortcuts = make(map[string]shortcut)
+	}
+	u.shortcuts[FormatKeySequence(keys)] = shortcut{keys: keys, fn: fn}
+	return nil
+}
+
+// RemoveShortcut unregisters the shortcut for key.
+func (u *UIManager) RemoveShortcut(key string) {
+	keys, err := ParseKeySequence(key)
+	if err != nil {
+		return
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	delete(u.shortcuts, FormatKeySequence(keys))
+}
+
+// acceleratorLocked finds the shortcut or mnemonic bound to ev, or advances
+// a pending key sequence. Mnemonics are searched under scope, or in every
+// layer when scope is nil. Returns true if ev was used, with the action to
+// run once u.mu is released (nil when ev only advanced a sequence), since
+// actions commonly call back into the UIManager.
+func (u *UIManager) acceleratorLocked(ev *tcell.EventKey, scope Widget) (bool, func()) {
+	ks := KeyStrokeOf(ev)
+	if len(u.pendingKeys) > 0 && Now().After(u.pendingUntil) {
+		u.setPendingKeysLocked(nil)
+	}
+	if len(u.pendingKeys) > 0 || !isPlainKey(ks) {
+		if used, action := u.sequenceKeyLocked(ks); used {
+			return true, action
+		}
+	}
+	if ks.Key != tcell.KeyRune || ks.Mod != tcell.ModAlt || !unicode.IsLetter(ks.Rune) {
+		return false, nil
+	}
+	var target MnemonicTarget
+	if scope != nil {
+		target = findMnemonic(scope, ks.Rune)
+	} else {
+		sorted := u.sortedWidgetsLocked()
+		for i := len(sorted) - 1; i >= 0 && target == nil; i-- {
+			target = findMnemonic(sorted[i], ks.Rune)
+		}
+	}
+	if target == nil {
+		return false, nil
+	}
+	return true, func() { target.ActivateMnemonic() }
+}
+
+// plainSequenceLocked offers a character no widget took to the shortcuts,
+// so it can start a sequence such as "g g". Returns true if it did, with
+// the shortcut to run once u.mu is released, if one completed.
+func (u *UIManager) plainSequenceLocked(ev *tcell.EventKey) (bool, func()) {
+	ks := KeyStrokeOf(ev)
+	if !isPlainKey(ks) {
+		return false, nil
+	}
+	return u.sequenceKeyLocked(ks)
+}
+
+// sequenceKeyLocked adds ks to the pending key sequence. A completed
+// shortcut is returned for the caller to run; a key continuing no shortcut
+// cancels the pending sequence and is dropped. Returns true if ks was used.
+func (u *UIManager) sequenceKeyLocked(ks KeyStroke) (bool, func()) {
+	seq := append(slices.Clone(u.pendingKeys), ks)
+	if sc, ok := u.shortcuts[FormatKeySequence(seq)]; ok {
+		u.setPendingKeysLocked(nil)
+		return true, sc.fn
+	}
+	for _, sc := range u.shortcuts {
+		if len(sc.keys) > len(seq) && slices.Equal(sc.keys[:len(seq)], seq) {
+			u.setPendingKeysLocked(seq)
+			return true, nil
+		}
+	}
+	if len(u.pendingKeys) > 0 {
+		u.setPendingKeysLocked(nil)
+		return true, nil
+	}
+	return false, nil
+}
+
+// setPendingKeysLocked records the pending key sequence and shows it on
+// the status bar.
+func (u *UIManager) setPendingKeysLocked(keys []KeyStroke) {
+	timeout := u.KeySequenceTimeout
+	if timeout <= 0 {
+		timeout = DefaultKeySequenceTimeout
+	}
+	u.pendingKeys = keys
+	u.pendingUntil = Now().Add(timeout)
+	if ind, ok := u.statusBar.(PendingKeysIndicator); ok {
+		ind.SetPendingKeys(FormatKeySequence(keys), u.pendingUntil)
+	}
+}
+
+// isPlainKey reports whether ks is a character typed without Ctrl or Alt.
+func isPlainKey(ks KeyStroke) bool {
+	return ks.Key == tcell.KeyRune && ks.Mod&(tcell.ModCtrl|tcell.ModAlt) == 0
+}
+
+// findMnemonic returns the first enabled widget under w with mnemonic r.
+func findMnemonic(w Widget, r rune) MnemonicTarget {
+	if IsDisabled(w) {
+		return nil
+	}
+	if m, ok := w.(MnemonicTarget); ok && unicode.ToLower(m.MnemonicRune()) == r {
+		return m
+	}
+	var found MnemonicTarget
+	if cc, ok := w.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) {
+			if found == nil {
+				found = findMnemonic(child, r)
+			}
+		})
+	}
+	return found
+}
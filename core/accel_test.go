@@ -0,0 +1,194 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/texeluitest"
+	"github.com/framegrace/texelui/widgets"
+)
+
+func TestParseKeyStrokeMatchesEvents(t *testing.T) {
+	cases := []struct {
+		key string
+		ev  *tcell.EventKey
+	}{
+		{"Ctrl+S", tcell.NewEventKey(tcell.KeyCtrlS, 0, tcell.ModCtrl)},
+		{"C-s", tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModCtrl)},
+		{"Alt+f", tcell.NewEventKey(tcell.KeyRune, 'F', tcell.ModAlt|tcell.ModShift)},
+		{"F5", tcell.NewEventKey(tcell.KeyF5, 0, tcell.ModNone)},
+		{"G", tcell.NewEventKey(tcell.KeyRune, 'G', tcell.ModShift)},
+		{"Ctrl+Tab", tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModCtrl)},
+	}
+	for _, c := range cases {
+		ks, err := core.ParseKeyStroke(c.key)
+		if err != nil {
+			t.Fatalf("%s: %v", c.key, err)
+		}
+		if got := core.KeyStrokeOf(c.ev); got != ks {
+			t.Errorf("%s: event gives %v, want %v", c.key, got, ks)
+		}
+	}
+	if _, err := core.ParseKeyStroke("Hyper+x"); err == nil {
+		t.Error("expected an unknown modifier to fail")
+	}
+	if ks, _ := core.ParseKeyStroke("ctrl+s"); ks.String() != "Ctrl+S" {
+		t.Errorf("String() = %q", ks.String())
+	}
+}
+
+func TestUIManagerShortcutsAndMnemonics(t *testing.T) {
+	form := widgets.NewFormWithConfig(widgets.FormConfig{LabelWidth: 6})
+	name := widgets.NewInput()
+	save := widgets.NewButton("Save")
+	save.Mnemonic = 's'
+	saved := 0
+	save.OnClick = func() { saved++ }
+	form.AddField("Name", name)
+	form.AddField("", save)
+	h := texeluitest.New(t, form, 30, 4)
+
+	fired := 0
+	if err := h.UI.AddShortcut("Ctrl+S", func() { fired++ }); err != nil {
+		t.Fatal(err)
+	}
+	h.PressMod(tcell.KeyCtrlS, tcell.ModCtrl)
+	if fired != 1 || h.Focused() != name {
+		t.Errorf("expected the shortcut to fire with the input focused, fired %d", fired)
+	}
+
+	h.PressRune('s', tcell.ModAlt)
+	if saved != 1 {
+		t.Errorf("expected Alt+s to click Save, clicked %d", saved)
+	}
+
+	h.UI.RemoveShortcut("Ctrl+S")
+	h.PressMod(tcell.KeyCtrlS, tcell.ModCtrl)
+	if fired != 1 {
+		t.Error("expected the removed shortcut not to fire")
+	}
+}
+
+func TestUIManagerShortcutsCanCallTheUI(t *testing.T) {
+	form := widgets.NewFormWithConfig(widgets.FormConfig{LabelWidth: 6})
+	name := widgets.NewInput()
+	save := widgets.NewButton("Save")
+	save.Mnemonic = 's'
+	form.AddField("Name", name)
+	form.AddField("", save)
+	h := texeluitest.New(t, form, 30, 4)
+
+	// Actions run after HandleKey releases the UI lock, so calling back
+	// into the UIManager must not deadlock
+	h.UI.AddShortcut("Ctrl+F", func() { h.UI.Focus(save) })
+	h.UI.AddShortcut("Ctrl+B", func() { h.UI.SetStatusBarEnabled(!h.UI.StatusBarEnabled()) })
+	save.OnClick = func() { h.UI.Focus(name) }
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.UI.HandleKey(tcell.NewEventKey(tcell.KeyCtrlF, 0, tcell.ModCtrl))
+		h.UI.HandleKey(tcell.NewEventKey(tcell.KeyCtrlB, 0, tcell.ModCtrl))
+		h.UI.HandleKey(tcell.NewEventKey(tcell.KeyRune, 's', tcell.ModAlt))
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("a shortcut calling the UIManager deadlocked")
+	}
+	if h.Focused() != name {
+		t.Errorf("expected Alt+s to run Save's OnClick and focus the input, focused %T", h.Focused())
+	}
+}
+
+func TestUIManagerKeySequences(t *testing.T) {
+	h := texeluitest.New(t, widgets.NewButton("OK"), 30, 3)
+	sb := widgets.NewStatusBar()
+	h.UI.SetStatusBar(sb)
+
+	saved, top := 0, 0
+	if err := h.UI.AddShortcut("Ctrl+X Ctrl+S", func() { saved++ }); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.UI.AddShortcut("g g", func() { top++ }); err != nil {
+		t.Fatal(err)
+	}
+
+	h.PressMod(tcell.KeyCtrlX, tcell.ModCtrl)
+	if sb.PendingKeys() != "Ctrl+X" || !strings.Contains(h.ScreenText(), "Ctrl+X -") {
+		t.Errorf("expected the pending Ctrl+X on the status bar, got %q", sb.PendingKeys())
+	}
+	h.PressMod(tcell.KeyCtrlS, tcell.ModCtrl)
+	if saved != 1 || sb.PendingKeys() != "" {
+		t.Errorf("expected Ctrl+X Ctrl+S to fire once and clear, fired %d", saved)
+	}
+
+	h.PressRune('g', 0)
+	h.PressRune('g', 0)
+	if top != 1 {
+		t.Errorf("expected g g to fire once, fired %d", top)
+	}
+
+	// A pause longer than the timeout drops the pending key
+	h.PressRune('g', 0)
+	h.Advance(2 * time.Second)
+	if sb.PendingKeys() != "" || strings.Contains(h.ScreenText(), "g -") {
+		t.Error("expected the pending g to time out")
+	}
+	h.PressRune('g', 0)
+	if top != 1 || sb.PendingKeys() != "g" {
+		t.Errorf("expected a new sequence after the timeout, fired %d", top)
+	}
+
+	// A key continuing no sequence cancels it and is swallowed
+	h.PressRune('q', 0)
+	if sb.PendingKeys() != "" {
+		t.Error("expected q to cancel the pending sequence")
+	}
+}
+
+func TestUIManagerKeySequenceLeavesTypingAlone(t *testing.T) {
+	input := widgets.NewInput()
+	h := texeluitest.New(t, input, 30, 3)
+	top := 0
+	if err := h.UI.AddShortcut("g g", func() { top++ }); err != nil {
+		t.Fatal(err)
+	}
+	h.Type("gg")
+	if top != 0 || input.Text != "gg" {
+		t.Errorf("expected gg typed into the input, got %q and %d shortcuts", input.Text, top)
+	}
+}
+
+func TestUIManagerMnemonicsStayInsideModal(t *testing.T) {
+	form := widgets.NewFormWithConfig(widgets.FormConfig{LabelWidth: 6})
+	combo := widgets.NewComboBox([]string{"one", "two"}, false)
+	save := widgets.NewButton("Save")
+	save.Mnemonic = 's'
+	saved := 0
+	save.OnClick = func() { saved++ }
+	form.AddField("Pick", combo)
+	form.AddField("", save)
+	h := texeluitest.New(t, form, 30, 8)
+	reloaded := 0
+	if err := h.UI.AddShortcut("F5", func() { reloaded++ }); err != nil {
+		t.Fatal(err)
+	}
+
+	h.Press(tcell.KeyEnter)
+	if !combo.IsModal() {
+		t.Fatal("expected Enter to open the dropdown")
+	}
+	h.PressRune('s', tcell.ModAlt)
+	if saved != 0 || !combo.IsModal() {
+		t.Errorf("expected Alt+s not to reach Save behind the dropdown, clicked %d", saved)
+	}
+	h.Press(tcell.KeyF5)
+	if reloaded != 1 {
+		t.Errorf("expected the shortcut to get the key the dropdown declines, fired %d", reloaded)
+	}
+}
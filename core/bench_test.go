@@ -0,0 +1,62 @@
+package core_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/texeluitest"
+	"github.com/framegrace/texelui/widgets"
+)
+
+// benchUI returns a 200x50 UI showing 50 rows of 8 labels and buttons.
+func benchUI() *core.UIManager {
+	root := widgets.NewVBox()
+	for row := range 50 {
+		hb := widgets.NewHBox()
+		for col := range 8 {
+			if col%2 == 0 {
+				hb.AddFlexChild(widgets.NewLabel(fmt.Sprintf("Label %d.%d", row, col)))
+			} else {
+				hb.AddFlexChild(widgets.NewButton("OK"))
+			}
+		}
+		root.AddChildWithSize(hb, 1)
+	}
+	ui := core.NewUIManager()
+	ui.Headless = true
+	ui.Resize(200, 50)
+	ui.SetRootWidget(root)
+	ui.Render()
+	return ui
+}
+
+func BenchmarkRenderManyWidgets(b *testing.B) {
+	ui := benchUI()
+	b.ReportAllocs()
+	for b.Loop() {
+		ui.InvalidateAll()
+		ui.Render()
+	}
+}
+
+// BenchmarkRenderDirtyRects renders frames of 100 scattered, partly
+// overlapping invalidations, which are merged before drawing.
+func BenchmarkRenderDirtyRects(b *testing.B) {
+	ui := benchUI()
+	b.ReportAllocs()
+	for b.Loop() {
+		for i := range 100 {
+			ui.Invalidate(core.Rect{X: i * 7 % 190, Y: i * 3 % 48, W: 10, H: 2})
+		}
+		ui.Render()
+	}
+}
+
+func TestPerfBudgets(t *testing.T) {
+	texeluitest.CheckBudgets(t, []texeluitest.Budget{
+		{Name: "RenderManyWidgets", Bench: BenchmarkRenderManyWidgets, MaxAllocs: 400, MaxTime: 2 * time.Millisecond},
+		{Name: "RenderDirtyRects", Bench: BenchmarkRenderDirtyRects, MaxAllocs: 15000, MaxTime: 8 * time.Millisecond},
+	})
+}
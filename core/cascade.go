@@ -0,0 +1,132 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/cascade.go
+// Summary: Style cascade from containers to their children.
+
+package core
+
+import (
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/theme"
+)
+
+// cascadeGen counts the changes that require rerunning the style cascade.
+var cascadeGen atomic.Uint64
+
+// InvalidateCascade makes UIManagers rerun the style cascade before their
+// next frame. SetBaseStyle calls it, and containers call it when their
+// children change, so new children take on their container's colors.
+func InvalidateCascade() { cascadeGen.Add(1) }
+
+// StyleInheritor is implemented by widgets that take part in the style
+// cascade. Every widget embedding BaseWidget does.
+type StyleInheritor interface {
+	// SetInheritedStyle is called by the cascade with the cascaded style
+	// of the widget's container.
+	SetInheritedStyle(style tcell.Style)
+	// CascadedStyle returns the style passed on to the widget's children.
+	CascadedStyle() tcell.Style
+}
+
+// SetBaseStyle sets colors for this widget and, through the cascade, for
+// its children that don't set their own. A foreground or background left
+// as tcell.ColorDefault is inherited from the container instead.
+func (b *BaseWidget) SetBaseStyle(style tcell.Style) {
+	b.baseStyle = style
+	InvalidateCascade()
+}
+
+// BaseStyle returns the style set with SetBaseStyle.
+func (b *BaseWidget) BaseStyle() tcell.Style { return b.baseStyle }
+
+// SetInheritedStyle implements StyleInheritor.
+func (b *BaseWidget) SetInheritedStyle(style tcell.Style) { b.inheritedStyle = style }
+
+// CascadedStyle implements StyleInheritor: the base style, with the colors
+// it doesn't set taken from the inherited style. Colors that are still
+// tcell.ColorDefault aren't set anywhere up the tree.
+func (b *BaseWidget) CascadedStyle() tcell.Style {
+	fg, bg, _ := b.baseStyle.Decompose()
+	ifg, ibg, _ := b.inheritedStyle.Decompose()
+	if fg == tcell.ColorDefault {
+		fg = ifg
+	}
+	if bg == tcell.ColorDefault {
+		bg = ibg
+	}
+	return tcell.StyleDefault.Foreground(fg).Background(bg)
+}
+
+// Theme colors surfaces get by default. A widget color set to one of them
+// (or left unset) takes the cascaded color; any other color was set for the
+// widget and wins over its container's.
+var (
+	surfaceFGRoles = []string{"text.primary", "text.secondary"}
+	surfaceBGRoles = []string{"bg.surface"}
+)
+
+// isThemeDefault reports whether c is unset or one of the theme's roles.
+func isThemeDefault(c tcell.Color, roles []string) bool {
+	if c == tcell.ColorDefault {
+		return true
+	}
+	tm := theme.Get()
+	for _, role := range roles {
+		if tm.GetSemanticColor(role) == c {
+			return true
+		}
+	}
+	return false
+}
+
+// isDynamicThemeDefault is isThemeDefault for a dynamic color; animated
+// and computed colors are always the widget's own.
+func isDynamicThemeDefault(dc color.DynamicColor, roles []string) bool {
+	return dc.IsZero() || (dc.IsStatic() && isThemeDefault(dc.Resolve(color.ColorContext{}), roles))
+}
+
+// cascadeColors replaces the theme default colors of style with the
+// cascaded ones that are set, keeping its attributes.
+func (b *BaseWidget) cascadeColors(style tcell.Style) tcell.Style {
+	fg, bg, _ := b.CascadedStyle().Decompose()
+	sfg, sbg, _ := style.Decompose()
+	if fg != tcell.ColorDefault && isThemeDefault(sfg, surfaceFGRoles) {
+		style = style.Foreground(fg)
+	}
+	if bg != tcell.ColorDefault && isThemeDefault(sbg, surfaceBGRoles) {
+		style = style.Background(bg)
+	}
+	return style
+}
+
+// EffectiveDynamic is EffectiveStyle's cascade for dynamic styles, without
+// the focused style: the cascaded colors that are set replace those of ds
+// that are theme defaults, so a style the app set on the widget wins.
+// Surface widgets call it in Draw so they take on their container's colors.
+func (b *BaseWidget) EffectiveDynamic(ds color.DynamicStyle) color.DynamicStyle {
+	fg, bg, _ := b.CascadedStyle().Decompose()
+	if fg != tcell.ColorDefault && isDynamicThemeDefault(ds.FG, surfaceFGRoles) {
+		ds.FG = color.Solid(fg)
+	}
+	if bg != tcell.ColorDefault && isDynamicThemeDefault(ds.BG, surfaceBGRoles) {
+		ds.BG = color.Solid(bg)
+	}
+	return ds
+}
+
+// CascadeStyles pushes cascaded styles down the widget tree rooted at w,
+// which inherits inherited. UIManager runs it before a frame when
+// InvalidateCascade was called since the last run.
+func CascadeStyles(w Widget, inherited tcell.Style) {
+	if si, ok := w.(StyleInheritor); ok {
+		si.SetInheritedStyle(inherited)
+		inherited = si.CascadedStyle()
+	}
+	if cc, ok := w.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) { CascadeStyles(child, inherited) })
+	}
+}
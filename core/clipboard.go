@@ -2,10 +2,21 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 //
 // File: core/clipboard.go
-// Summary: Clipboard service interface for TexelUI apps.
+// Summary: Clipboard service interface and OSC 52 system clipboard for TexelUI apps.
 
 package core
 
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
 // ClipboardService provides clipboard access for apps.
 // In standalone mode, this is provided by the runtime.
 // In embedded mode (texelation), the desktop provides this service.
@@ -35,3 +46,97 @@ type ClipboardService interface {
 type ClipboardAware interface {
 	SetClipboardService(clipboard ClipboardService)
 }
+
+// Clipboard is a ClipboardService backed by the system clipboard. Text
+// copies go out as an OSC 52 escape sequence, which most terminals (and
+// tmux with set-clipboard on) turn into a system clipboard write; with
+// UseTools they are also piped to the first of wl-copy, xclip, xsel or
+// pbcopy found on PATH, for terminals without OSC 52. OSC 52 can't be read
+// back reliably, so GetClipboard returns the last copy.
+type Clipboard struct {
+	// UseTools also copies text through an external clipboard tool.
+	UseTools bool
+
+	mu    sync.Mutex
+	osc52 func(data []byte)
+	mime  string
+	data  []byte
+}
+
+// NewClipboard returns a Clipboard writing OSC 52 sequences to out,
+// typically the terminal. A nil out sends none.
+func NewClipboard(out io.Writer) *Clipboard {
+	c := &Clipboard{}
+	if out != nil {
+		c.osc52 = func(data []byte) { _, _ = io.WriteString(out, OSC52(data)) }
+	}
+	return c
+}
+
+// NewScreenClipboard returns a Clipboard copying through the screen's own
+// OSC 52 support, for use while tcell owns the terminal.
+func NewScreenClipboard(screen tcell.Screen) *Clipboard {
+	return &Clipboard{osc52: screen.SetClipboard}
+}
+
+// OSC52 returns the escape sequence setting the system clipboard to data.
+func OSC52(data []byte) string {
+	return "\x1b]52;c;" + base64.StdEncoding.EncodeToString(data) + "\x07"
+}
+
+// SetClipboard implements ClipboardService. Only text/plain data reaches
+// the system clipboard.
+func (c *Clipboard) SetClipboard(mime string, data []byte) {
+	c.mu.Lock()
+	c.mime = mime
+	c.data = append([]byte(nil), data...)
+	osc52, tools := c.osc52, c.UseTools
+	c.mu.Unlock()
+
+	if mime != "text/plain" || len(data) == 0 {
+		return
+	}
+	if osc52 != nil {
+		osc52(data)
+	}
+	if tools {
+		go copyWithTool(data)
+	}
+}
+
+// GetClipboard implements ClipboardService.
+func (c *Clipboard) GetClipboard() (mime string, data []byte, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.data == nil {
+		return "", nil, false
+	}
+	return c.mime, append([]byte(nil), c.data...), true
+}
+
+// clipboardTools are the external copy commands, in order of preference.
+var clipboardTools = [][]string{
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"pbcopy"},
+}
+
+// copyWithTool pipes data to the first clipboard tool found on PATH.
+func copyWithTool(data []byte) {
+	for _, tool := range clipboardTools {
+		if tool[0] == "wl-copy" && os.Getenv("WAYLAND_DISPLAY") == "" {
+			continue
+		}
+		path, err := exec.LookPath(tool[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, tool[1:]...)
+		cmd.Stdin = bytes.NewReader(data)
+		if err := cmd.Run(); err != nil {
+			Logf(LogWarn, "clipboard", "%s: %v", tool[0], err)
+		}
+		return
+	}
+}
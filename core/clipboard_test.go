@@ -0,0 +1,27 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/framegrace/texelui/core"
+)
+
+func TestClipboardWritesOSC52(t *testing.T) {
+	var out strings.Builder
+	clip := core.NewClipboard(&out)
+	clip.SetClipboard("text/plain", []byte("hi"))
+	if got := out.String(); got != "\x1b]52;c;aGk=\x07" {
+		t.Errorf("OSC 52 = %q", got)
+	}
+	if mime, data, ok := clip.GetClipboard(); !ok || mime != "text/plain" || string(data) != "hi" {
+		t.Errorf("GetClipboard = %q, %q, %v", mime, data, ok)
+	}
+
+	// Only text reaches the terminal
+	out.Reset()
+	clip.SetClipboard("image/png", []byte{1, 2})
+	if out.Len() != 0 {
+		t.Errorf("non-text data sent: %q", out.String())
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/clock.go
+// Summary: Replaceable clock for timed UI behaviour.
+
+package core
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// clock is the time source of timed UI behaviour; nil means time.Now.
+var clock atomic.Pointer[func() time.Time]
+
+// SetClock replaces the clock that animations, status messages and other
+// timed UI behaviour read, so tests can control time. nil restores the
+// system clock.
+func SetClock(now func() time.Time) {
+	if now == nil {
+		clock.Store(nil)
+		return
+	}
+	clock.Store(&now)
+}
+
+// Now returns the current time of the UI clock.
+func Now() time.Time {
+	if now := clock.Load(); now != nil {
+		return (*now)()
+	}
+	return time.Now()
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/dump.go
+// Summary: Text and JSON dumps of the widget tree.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WidgetNode describes a widget of the tree, as DumpTree returns it. It
+// marshals to JSON as is.
+type WidgetNode struct {
+	Type      string       `json:"type"`
+	ID        string       `json:"id,omitempty"`
+	X         int          `json:"x"`
+	Y         int          `json:"y"`
+	W         int          `json:"w"`
+	H         int          `json:"h"`
+	Z         int          `json:"z"`
+	Focusable bool         `json:"focusable"`
+	Focused   bool         `json:"focused"`
+	Disabled  bool         `json:"disabled,omitempty"`
+	StatusBar bool         `json:"status_bar,omitempty"`
+	Children  []WidgetNode `json:"children,omitempty"`
+}
+
+// DumpTree describes the widget trees of the UI, root widgets in drawing
+// order, followed by the status bar if it is enabled.
+func (u *UIManager) DumpTree() []WidgetNode {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.dumpTreeLocked()
+}
+
+func (u *UIManager) dumpTreeLocked() []WidgetNode {
+	var nodes []WidgetNode
+	for _, w := range u.sortedWidgetsLocked() {
+		nodes = append(nodes, DumpWidget(w))
+	}
+	if u.statusBar != nil && u.statusBarEnabled {
+		sb := DumpWidget(u.statusBar)
+		sb.StatusBar = true
+		nodes = append(nodes, sb)
+	}
+	return nodes
+}
+
+// DumpWidget describes w and its descendants.
+func DumpWidget(w Widget) WidgetNode {
+	x, y := w.Position()
+	ww, wh := w.Size()
+	n := WidgetNode{
+		Type:      fmt.Sprintf("%T", w),
+		ID:        WidgetID(w),
+		X:         x,
+		Y:         y,
+		W:         ww,
+		H:         wh,
+		Z:         getZIndex(w),
+		Focusable: w.Focusable(),
+		Disabled:  IsDisabled(w),
+	}
+	if fs, ok := w.(FocusState); ok {
+		n.Focused = fs.IsFocused()
+	}
+	for _, child := range childrenOf(w) {
+		n.Children = append(n.Children, DumpWidget(child))
+	}
+	return n
+}
+
+// FormatTree renders nodes as an indented outline, one widget per line:
+//
+//	*widgets.Form 0,0 60x12 focused
+//	  *widgets.Input #name 8,0 52x1 focusable focused
+func FormatTree(nodes []WidgetNode) string {
+	var sb strings.Builder
+	for _, n := range nodes {
+		formatNode(&sb, n, 0)
+	}
+	return sb.String()
+}
+
+func formatNode(sb *strings.Builder, n WidgetNode, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	sb.WriteString(n.Summary())
+	sb.WriteByte('\n')
+	for _, c := range n.Children {
+		formatNode(sb, c, depth+1)
+	}
+}
+
+// Summary is the line FormatTree writes for the node, without indentation.
+func (n WidgetNode) Summary() string {
+	parts := []string{n.Type}
+	if n.ID != "" {
+		parts = append(parts, "#"+n.ID)
+	}
+	parts = append(parts, fmt.Sprintf("%d,%d %dx%d", n.X, n.Y, n.W, n.H))
+	if n.Z != 0 {
+		parts = append(parts, fmt.Sprintf("z=%d", n.Z))
+	}
+	for _, flag := range []struct {
+		set  bool
+		name string
+	}{
+		{n.StatusBar, "statusbar"},
+		{n.Focusable, "focusable"},
+		{n.Disabled, "disabled"},
+		{n.Focused, "focused"},
+	} {
+		if flag.set {
+			parts = append(parts, flag.name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,42 @@
+package core_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/widgets"
+)
+
+func TestUIManagerDumpTree(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 6)
+	form := widgets.NewFormWithConfig(widgets.FormConfig{LabelWidth: 6})
+	name := widgets.NewInput()
+	name.SetID("name")
+	save := widgets.NewButton("Save")
+	save.SetDisabled(true)
+	form.AddField("Name", name)
+	form.AddField("", save)
+	ui.SetRootWidget(form)
+	ui.Focus(form)
+
+	want := `*widgets.Form 0,0 40x6 focusable focused
+  *widgets.Label 0,0 6x1
+  *widgets.Input #name 8,0 32x1 focusable focused
+  *widgets.Label 0,1 6x1
+  *widgets.Button 8,1 32x1 disabled
+`
+	if got := core.FormatTree(ui.DumpTree()); got != want {
+		t.Errorf("unexpected tree:\n%s\nwant:\n%s", got, want)
+	}
+
+	data, err := json.Marshal(ui.DumpTree())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `{"type":"*widgets.Input","id":"name","x":8,"y":0,"w":32,"h":1,"z":0,"focusable":true,"focused":true}`) {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
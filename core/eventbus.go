@@ -0,0 +1,106 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/eventbus.go
+// Summary: Publish/subscribe event bus for loosely coupled widgets.
+
+package core
+
+import "sync"
+
+// EventBus delivers payloads emitted on a topic to every subscriber of
+// that topic, so widgets and app logic can react to each other (a list's
+// "selection changed" updating a detail pane) without wiring callbacks
+// between them. Handlers run synchronously on the emitting goroutine, in
+// subscription order, and may emit, subscribe and unsubscribe themselves.
+// The zero value is ready to use and safe for concurrent use.
+type EventBus struct {
+	mu     sync.Mutex
+	nextID uint64
+	subs   map[string][]subscription
+}
+
+type subscription struct {
+	id uint64
+	fn func(payload any)
+}
+
+// Subscribe registers fn to receive the payloads emitted on topic. The
+// returned function unsubscribes it; calling it again does nothing.
+func (b *EventBus) Subscribe(topic string, fn func(payload any)) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[string][]subscription)
+	}
+	b.nextID++
+	id := b.nextID
+	b.subs[topic] = append(b.subs[topic], subscription{id: id, fn: fn})
+	return func() { b.unsubscribe(topic, id) }
+}
+
+// unsubscribe removes the subscription id. The slice is rebuilt rather
+// than edited so that an Emit iterating the old one is unaffected.
+func (b *EventBus) unsubscribe(topic string, id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := b.subs[topic]
+	kept := make([]subscription, 0, len(subs))
+	for _, s := range subs {
+		if s.id != id {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) == 0 {
+		delete(b.subs, topic)
+		return
+	}
+	b.subs[topic] = kept
+}
+
+// Emit calls the subscribers of topic with payload and returns how many
+// there were. Subscribers added by a handler only see later emits.
+func (b *EventBus) Emit(topic string, payload any) int {
+	b.mu.Lock()
+	subs := b.subs[topic]
+	b.mu.Unlock()
+	for _, s := range subs {
+		s.fn(payload)
+	}
+	return len(subs)
+}
+
+// SubscribeTo registers a typed handler for topic on b. Payloads that are
+// not a T are ignored, so a topic carries one payload type by convention:
+//
+//	core.SubscribeTo(ui.EventBus(), "files.selected", func(path string) {
+//		preview.Load(path)
+//	})
+func SubscribeTo[T any](b *EventBus, topic string, fn func(T)) (unsubscribe func()) {
+	return b.Subscribe(topic, func(payload any) {
+		if v, ok := payload.(T); ok {
+			fn(v)
+		}
+	})
+}
+
+// EventBus returns the UI's event bus, e.g. for SubscribeTo.
+func (u *UIManager) EventBus() *EventBus {
+	return &u.bus
+}
+
+// Subscribe registers fn to receive the payloads emitted on topic with
+// Emit. The returned function unsubscribes it. See EventBus.
+func (u *UIManager) Subscribe(topic string, fn func(payload any)) (unsubscribe func()) {
+	return u.bus.Subscribe(topic, fn)
+}
+
+// Emit delivers payload to the subscribers of topic and requests a redraw
+// if there were any, since they usually update widgets. It doesn't take
+// the UI lock, so widget callbacks can emit while handling events; handlers
+// emitted to from other goroutines must synchronize their own state.
+func (u *UIManager) Emit(topic string, payload any) {
+	if u.bus.Emit(topic, payload) > 0 {
+		u.RequestRefresh()
+	}
+}
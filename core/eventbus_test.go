@@ -0,0 +1,51 @@
+package core
+
+import "testing"
+
+func TestEventBusEmitSubscribe(t *testing.T) {
+	var bus EventBus
+	var got []string
+	unsub := bus.Subscribe("list.selected", func(payload any) {
+		got = append(got, "a:"+payload.(string))
+	})
+	bus.Subscribe("list.selected", func(payload any) {
+		got = append(got, "b:"+payload.(string))
+	})
+	bus.Subscribe("other", func(any) { t.Fatal("unexpected topic delivered") })
+
+	if n := bus.Emit("list.selected", "x"); n != 2 {
+		t.Fatalf("expected 2 subscribers, got %d", n)
+	}
+	unsub()
+	unsub()
+	bus.Emit("list.selected", "y")
+	want := []string{"a:x", "b:x", "b:y"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestEventBusTypedAndReentrant(t *testing.T) {
+	ui := NewUIManager()
+	var sum int
+	SubscribeTo(ui.EventBus(), "count", func(n int) { sum += n })
+
+	// A handler may unsubscribe itself and emit while being called
+	var unsub func()
+	unsub = ui.Subscribe("once", func(any) {
+		unsub()
+		ui.Emit("count", 5)
+	})
+	ui.Emit("count", 1)
+	ui.Emit("count", "not an int")
+	ui.Emit("once", nil)
+	ui.Emit("once", nil)
+	if sum != 6 {
+		t.Fatalf("expected sum 6, got %d", sum)
+	}
+}
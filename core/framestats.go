@@ -0,0 +1,90 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/framestats.go
+// Summary: Corner overlay with frame rate, redrawn cells and frame time.
+
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// frameStats measures the frames the UIManager renders.
+type frameStats struct {
+	frames []time.Time   // Ends of the frames of the last second
+	cells  int           // Cells redrawn by the last frame
+	took   time.Duration // Duration of the last frame
+}
+
+func (s *frameStats) record(start, end time.Time, cells int) {
+	s.cells = cells
+	s.took = end.Sub(start)
+	cutoff := end.Add(-time.Second)
+	i := 0
+	for i < len(s.frames) && !s.frames[i].After(cutoff) {
+		i++
+	}
+	s.frames = append(s.frames[i:], end)
+}
+
+// FrameStats reports the frame rate over the last second, the cells the
+// last frame redrew and how long it took. ok is false while the overlay is
+// hidden, as frames are only measured while it is shown.
+func (u *UIManager) FrameStats() (fps, cells int, took time.Duration, ok bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.frameStats == nil {
+		return 0, 0, 0, false
+	}
+	return len(u.frameStats.frames), u.frameStats.cells, u.frameStats.took, true
+}
+
+// ShowFrameStats shows or hides the frame stats overlay in the top right
+// corner: frames per second, cells redrawn by the last frame and its
+// duration. Use it to see how much a change makes the UI redraw. F in the
+// widget inspector toggles it.
+func (u *UIManager) ShowFrameStats(show bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.showFrameStatsLocked(show)
+}
+
+// FrameStatsShown reports whether the frame stats overlay is shown.
+func (u *UIManager) FrameStatsShown() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.frameStats != nil
+}
+
+func (u *UIManager) showFrameStatsLocked(show bool) {
+	if show == (u.frameStats != nil) {
+		return
+	}
+	if show {
+		u.frameStats = &frameStats{}
+	} else {
+		u.frameStats = nil
+	}
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+}
+
+// drawFrameStatsLocked draws the overlay into the frame after rendering,
+// whatever was redrawn, so it stays on top. Must be called with u.mu held.
+func (u *UIManager) drawFrameStatsLocked() {
+	s := u.frameStats
+	text := fmt.Sprintf(" %3d fps %6d cells %6.1fms ", len(s.frames), s.cells, float64(s.took.Microseconds())/1000)
+	w := len(text)
+	if u.W < w || u.H < 1 {
+		return
+	}
+	colors := GetWidgetColors()
+	style := tcell.StyleDefault.Foreground(colors.TextInverse).Background(colors.Accent)
+	p := NewPainter(u.buf, Rect{X: 0, Y: 0, W: u.W, H: u.H})
+	p.DrawText(u.W-w, 0, text, style)
+}
@@ -0,0 +1,37 @@
+package core_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/texeluitest"
+	"github.com/framegrace/texelui/widgets"
+)
+
+func TestUIManagerFrameStats(t *testing.T) {
+	h := texeluitest.New(t, widgets.NewLabel("Hello"), 40, 3)
+	if _, _, _, ok := h.UI.FrameStats(); ok {
+		t.Fatal("expected no frame stats while hidden")
+	}
+
+	h.UI.ShowFrameStats(true)
+	h.Draw()
+	h.AssertContains("  1 fps    120 cells    0.0ms")
+
+	// Only the invalidated cells are redrawn
+	h.UI.Invalidate(core.Rect{X: 0, Y: 1, W: 3, H: 1})
+	h.Draw()
+	if fps, cells, _, ok := h.UI.FrameStats(); !ok || fps != 2 || cells != 3 {
+		t.Errorf("expected 2 fps and 3 cells, got %d fps, %d cells", fps, cells)
+	}
+	h.AssertContains("  2 fps      3 cells")
+
+	// Frames older than a second no longer count
+	h.Advance(2 * time.Second)
+	h.AssertContains("  1 fps    120 cells")
+
+	h.UI.ShowFrameStats(false)
+	h.Draw()
+	h.AssertNotContains("fps")
+}
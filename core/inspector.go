@@ -0,0 +1,379 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/inspector.go
+// Summary: Debug overlay highlighting and describing a widget of the tree.
+
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// inspector is the state of the widget inspector overlay: the path from a
+// root widget (or the status bar) to the inspected widget. While it is
+// open, keys walk the tree and the mouse selects the widget under it
+// instead of reaching the widgets.
+type inspector struct {
+	path []Widget
+	// Where the mouse selected the widget, for the routing line; -1 when
+	// the selection came from the keyboard
+	mouseX, mouseY int
+}
+
+// InspectorOpen reports whether the widget inspector is shown.
+func (u *UIManager) InspectorOpen() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.inspector != nil
+}
+
+// ToggleInspector shows or hides the widget inspector, which highlights a
+// widget and describes its type, rect, z-index, focus state and style.
+// It opens on the focused widget. InspectorKey toggles it from the
+// keyboard.
+func (u *UIManager) ToggleInspector() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.toggleInspectorLocked()
+}
+
+func (u *UIManager) toggleInspectorLocked() {
+	if u.inspector != nil {
+		u.inspector = nil
+	} else {
+		if u.logViewer != nil {
+			u.logViewer.cancel()
+			u.logViewer = nil
+		}
+		u.inspector = &inspector{path: u.focusPathLocked(), mouseX: -1, mouseY: -1}
+	}
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+}
+
+// focusPathLocked returns the path to the deepest focused widget, or to the
+// first root widget when nothing is focused.
+func (u *UIManager) focusPathLocked() []Widget {
+	for _, root := range u.widgets {
+		if path := pathTo(root, u.findFocusedInTreeLocked(root)); path != nil {
+			return path
+		}
+	}
+	if len(u.widgets) > 0 {
+		return []Widget{u.widgets[0]}
+	}
+	return nil
+}
+
+// pathTo returns the widgets from root down to target, or nil if target is
+// not in root's tree.
+func pathTo(root, target Widget) []Widget {
+	if root == nil || target == nil {
+		return nil
+	}
+	if root == target {
+		return []Widget{root}
+	}
+	var path []Widget
+	if cc, ok := root.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) {
+			if path == nil {
+				if sub := pathTo(child, target); sub != nil {
+					path = append([]Widget{root}, sub...)
+				}
+			}
+		})
+	}
+	return path
+}
+
+// childrenOf returns the direct children of w.
+func childrenOf(w Widget) []Widget {
+	var children []Widget
+	if cc, ok := w.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) {
+			children = append(children, child)
+		})
+	}
+	return children
+}
+
+// inspectorRootsLocked returns the trees the inspector walks: the root
+// widgets and the status bar.
+func (u *UIManager) inspectorRootsLocked() []Widget {
+	roots := u.sortedWidgetsLocked()
+	if u.statusBar != nil && u.statusBarEnabled {
+		roots = append(roots, u.statusBar)
+	}
+	return roots
+}
+
+// inspectorPathLocked returns the inspected path, cut where the tree no
+// longer matches it.
+func (u *UIManager) inspectorPathLocked() []Widget {
+	path := u.inspector.path
+	if len(path) == 0 {
+		return nil
+	}
+	found := false
+	for _, root := range u.inspectorRootsLocked() {
+		found = found || root == path[0]
+	}
+	if !found {
+		return nil
+	}
+	for i := 1; i < len(path); i++ {
+		found = false
+		for _, child := range childrenOf(path[i-1]) {
+			found = found || child == path[i]
+		}
+		if !found {
+			return path[:i]
+		}
+	}
+	return path
+}
+
+// pathAtLocked returns the path to the deepest widget under (x, y): the
+// topmost root containing the point, then at each level the child on top.
+func (u *UIManager) pathAtLocked(x, y int) []Widget {
+	roots := u.inspectorRootsLocked()
+	for i := len(roots) - 1; i >= 0; i-- {
+		if !roots[i].HitTest(x, y) {
+			continue
+		}
+		path := []Widget{roots[i]}
+		for {
+			var next Widget
+			for _, child := range childrenOf(path[len(path)-1]) {
+				if child.HitTest(x, y) && (next == nil || getZIndex(child) >= getZIndex(next)) {
+					next = child
+				}
+			}
+			if next == nil {
+				return path
+			}
+			path = append(path, next)
+		}
+	}
+	return nil
+}
+
+// inspectorKeyLocked walks the tree: Up to the parent, Down to the first
+// child, Left/Right to the siblings; L switches to the log viewer, D dumps
+// the tree to the log and shows it there, F toggles the frame stats and Esc
+// closes the inspector. It takes every key
+// so none reaches the widgets while inspecting.
+func (u *UIManager) inspectorKeyLocked(ev *tcell.EventKey) bool {
+	path := u.inspectorPathLocked()
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		u.toggleInspectorLocked()
+		return true
+	case tcell.KeyRune:
+		switch ev.Rune() {
+		case 'l', 'L':
+			u.toggleLogViewerLocked()
+		case 'f', 'F':
+			u.showFrameStatsLocked(u.frameStats == nil)
+		case 'd', 'D':
+			// Dump the whole tree to the log and show it there
+			for _, line := range strings.Split(strings.TrimSuffix(FormatTree(u.dumpTreeLocked()), "\n"), "\n") {
+				Logf(LogInfo, LogLayout, "%s", line)
+			}
+			u.toggleLogViewerLocked()
+		}
+		return true
+	case tcell.KeyUp:
+		if len(path) > 1 {
+			path = path[:len(path)-1]
+		}
+	case tcell.KeyDown:
+		if len(path) > 0 {
+			if children := childrenOf(path[len(path)-1]); len(children) > 0 {
+				path = append(path, children[0])
+			}
+		}
+	case tcell.KeyLeft, tcell.KeyRight:
+		var siblings []Widget
+		switch {
+		case len(path) > 1:
+			siblings = childrenOf(path[len(path)-2])
+		case len(path) == 1:
+			siblings = u.inspectorRootsLocked()
+		}
+		for i, w := range siblings {
+			if w != path[len(path)-1] {
+				continue
+			}
+			step := 1
+			if ev.Key() == tcell.KeyLeft {
+				step = len(siblings) - 1
+			}
+			path = append(path[:len(path)-1:len(path)-1], siblings[(i+step)%len(siblings)])
+			break
+		}
+	default:
+		return true
+	}
+	u.inspector.path = path
+	u.inspector.mouseX, u.inspector.mouseY = -1, -1
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+	return true
+}
+
+// inspectorMouseLocked selects the widget under the mouse.
+func (u *UIManager) inspectorMouseLocked(ev *tcell.EventMouse) {
+	x, y := ev.Position()
+	if path := u.pathAtLocked(x, y); path != nil {
+		u.inspector.path = path
+		u.inspector.mouseX, u.inspector.mouseY = x, y
+	}
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+}
+
+// drawInspectorLocked highlights the inspected widget by reversing its
+// cells and draws the description panel on the half of the screen the
+// widget is not in. Must be called with u.mu held, after everything else
+// is drawn.
+func (u *UIManager) drawInspectorLocked() {
+	path := u.inspectorPathLocked()
+	u.inspector.path = path
+	if len(path) == 0 || u.W <= 0 || u.H <= 0 {
+		return
+	}
+	w := path[len(path)-1]
+	x, y := w.Position()
+	ww, wh := w.Size()
+	rect := Rect{X: x, Y: y, W: ww, H: wh}
+
+	// The style is read before the highlight changes it
+	var style tcell.Style
+	if y >= 0 && y < len(u.buf) && x >= 0 && x < len(u.buf[y]) {
+		style = u.buf[y][x].Style
+	}
+	lines := u.inspectorLines(path, rect, style)
+
+	for yy := max(rect.Y, 0); yy < min(rect.Y+rect.H, len(u.buf)); yy++ {
+		row := u.buf[yy]
+		for xx := max(rect.X, 0); xx < min(rect.X+rect.W, len(row)); xx++ {
+			_, _, attrs := row[xx].Style.Decompose()
+			row[xx].Style = row[xx].Style.Reverse(attrs&tcell.AttrReverse == 0)
+		}
+	}
+
+	pw := 0
+	for _, line := range lines {
+		pw = max(pw, len([]rune(line)))
+	}
+	panel := Rect{W: min(pw+2, u.W), H: min(len(lines)+2, u.H)}
+	if rect.Y+rect.H/2 < u.H/2 {
+		panel.Y = u.H - panel.H
+	}
+	colors := GetWidgetColors()
+	ps := tcell.StyleDefault.Foreground(colors.TextPrimary).Background(colors.SurfaceBg)
+	p := NewPainter(u.buf, Rect{X: 0, Y: 0, W: u.W, H: u.H})
+	p.Fill(panel, ' ', ps)
+	p.DrawBorder(panel, ps.Foreground(colors.BorderFocus), [6]rune{'─', '│', '╭', '╮', '╰', '╯'})
+	p.DrawText(panel.X+2, panel.Y, " Inspector ", ps.Bold(true))
+	for i, line := range lines {
+		if i+1 >= panel.H-1 {
+			break
+		}
+		text := []rune(line)
+		if len(text) > panel.W-2 {
+			text = text[:max(panel.W-2, 0)]
+		}
+		p.DrawText(panel.X+1, panel.Y+1+i, string(text), ps)
+	}
+}
+
+// inspectorLines describes the inspected widget, the last of path.
+func (u *UIManager) inspectorLines(path []Widget, rect Rect, style tcell.Style) []string {
+	w := path[len(path)-1]
+	names := make([]string, len(path))
+	for i, pw := range path {
+		names[i] = typeName(pw)
+	}
+	focus := "not focusable"
+	switch {
+	case IsDisabled(w):
+		focus = "disabled"
+	case w.Focusable():
+		focus = "focusable"
+	}
+	if fs, ok := w.(FocusState); ok && fs.IsFocused() {
+		focus += ", focused"
+	} else if IsDescendantFocused(w) {
+		focus += ", focus within"
+	}
+	fg, bg, attrs := style.Decompose()
+
+	lines := []string{
+		fmt.Sprintf("%T", w),
+		"path   " + strings.Join(names, " > "),
+		fmt.Sprintf("rect   %d,%d %dx%d  z %d  children %d", rect.X, rect.Y, rect.W, rect.H, getZIndex(w), len(childrenOf(w))),
+		"focus  " + focus,
+		fmt.Sprintf("style  fg %v  bg %v  %s", fg, bg, attrNames(attrs)),
+	}
+	if u.inspector.mouseX >= 0 {
+		// Where a click at the mouse would go, for hit-test bugs
+		route := "nothing"
+		if ht, ok := path[0].(HitTester); ok {
+			if target := ht.WidgetAt(u.inspector.mouseX, u.inspector.mouseY); target != nil {
+				route = typeName(target)
+			}
+		} else {
+			route = typeName(path[0])
+		}
+		lines = append(lines, fmt.Sprintf("mouse  %d,%d routes to %s", u.inspector.mouseX, u.inspector.mouseY, route))
+	}
+	return append(lines, "↑ parent  ↓ child  ←→ sibling  L log  D dump  F fps  Esc close")
+}
+
+// typeName returns the type of w without its package, followed by its id
+// if it has one.
+func typeName(w Widget) string {
+	name := fmt.Sprintf("%T", w)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if id := WidgetID(w); id != "" {
+		name += "#" + id
+	}
+	return name
+}
+
+// attrNames lists the text attributes set in attrs.
+func attrNames(attrs tcell.AttrMask) string {
+	var names []string
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		name string
+	}{
+		{tcell.AttrBold, "bold"},
+		{tcell.AttrDim, "dim"},
+		{tcell.AttrItalic, "italic"},
+		{tcell.AttrUnderline, "underline"},
+		{tcell.AttrReverse, "reverse"},
+		{tcell.AttrBlink, "blink"},
+		{tcell.AttrStrikeThrough, "strikethrough"},
+	} {
+		if attrs&a.mask != 0 {
+			names = append(names, a.name)
+		}
+	}
+	if len(names) == 0 {
+		return "no attributes"
+	}
+	return strings.Join(names, ",")
+}
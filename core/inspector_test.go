@@ -0,0 +1,77 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/texeluitest"
+	"github.com/framegrace/texelui/widgets"
+)
+
+func TestUIManagerInspectorWalksTree(t *testing.T) {
+	form := widgets.NewFormWithConfig(widgets.FormConfig{LabelWidth: 6})
+	name := widgets.NewInput()
+	save := widgets.NewButton("Save")
+	form.AddField("Name", name)
+	form.AddField("", save)
+	h := texeluitest.New(t, form, 60, 12)
+
+	h.Press(tcell.KeyF12)
+	if !h.UI.InspectorOpen() {
+		t.Fatal("expected F12 to open the inspector")
+	}
+	h.AssertContains("Inspector")
+	h.AssertContains("path   Form > Input")
+	h.AssertContains("focus  focusable, focused")
+	h.AssertContains("rect   8,0 52x1")
+
+	// Keys walk the tree instead of reaching the widgets
+	h.Type("x")
+	if name.Text != "" {
+		t.Errorf("expected the input to get no keys, got %q", name.Text)
+	}
+	h.Press(tcell.KeyUp)
+	h.AssertContains("path   Form ")
+	h.AssertContains("children 4")
+	h.Press(tcell.KeyDown)
+	h.AssertContains("path   Form > Label")
+	h.Press(tcell.KeyRight)
+	h.AssertContains("path   Form > Input")
+	h.Press(tcell.KeyLeft)
+	h.Press(tcell.KeyLeft)
+	h.AssertContains("path   Form > Button")
+
+	// The mouse selects the widget under it, without clicking it
+	clicked := false
+	save.OnClick = func() { clicked = true }
+	x, y, ok := h.Find("Save")
+	if !ok {
+		t.Fatal("expected the button on screen")
+	}
+	h.Click(x, y)
+	if clicked {
+		t.Error("expected the click not to reach the button")
+	}
+	h.AssertContains("path   Form > Button")
+	h.AssertContains("routes to Button")
+
+	h.Press(tcell.KeyEscape)
+	if h.UI.InspectorOpen() {
+		t.Fatal("expected Esc to close the inspector")
+	}
+	h.AssertNotContains("Inspector")
+	h.Type("x")
+	if name.Text != "x" {
+		t.Errorf("expected keys to reach the input again, got %q", name.Text)
+	}
+}
+
+func TestUIManagerInspectorKeyCanBeDisabled(t *testing.T) {
+	h := texeluitest.New(t, widgets.NewInput(), 20, 3)
+	h.UI.InspectorKey = tcell.KeyNUL
+	h.Press(tcell.KeyF12)
+	if h.UI.InspectorOpen() {
+		t.Error("expected F12 to do nothing with InspectorKey disabled")
+	}
+}
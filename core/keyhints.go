@@ -12,6 +12,14 @@ import "strings"
 type KeyHint struct {
 	Key   string // Display string: "Tab", "↑↓", "Ctrl+S"
 	Label string // Action description: "Next", "Move", "Save"
+
+	// Priority decides which hints are dropped first when space is short:
+	// lower values go first. The default is 0; use negative values for
+	// rarely needed keys.
+	Priority int
+	// Group keeps related hints together (e.g. "edit", "nav") when hints
+	// from several widgets are merged. Empty means ungrouped.
+	Group string
 }
 
 // KeyHintsProvider allows widgets to expose their keyboard shortcuts
@@ -44,6 +52,76 @@ func FormatKeyHintsWithSeparator(hints []KeyHint, separator string) string {
 	return strings.Join(parts, separator)
 }
 
+// MergeKeyHints combines hint lists, innermost widget first. Hints for a
+// key already present in an earlier list are dropped, and hints sharing a
+// Group are moved next to the first hint of that group.
+func MergeKeyHints(lists ...[]KeyHint) []KeyHint {
+	seen := make(map[string]bool)
+	var unique []KeyHint
+	for _, list := range lists {
+		for _, h := range list {
+			if !seen[h.Key] {
+				seen[h.Key] = true
+				unique = append(unique, h)
+			}
+		}
+	}
+
+	merged := make([]KeyHint, 0, len(unique))
+	emitted := make(map[string]bool)
+	for _, h := range unique {
+		if h.Group == "" {
+			merged = append(merged, h)
+			continue
+		}
+		if emitted[h.Group] {
+			continue
+		}
+		emitted[h.Group] = true
+		for _, g := range unique {
+			if g.Group == h.Group {
+				merged = append(merged, g)
+			}
+		}
+	}
+	return merged
+}
+
+// FitKeyHints drops the lowest-priority hints (the last ones among equals)
+// until FormatKeyHints of the rest fits in width columns, keeping their
+// order. If any hint was dropped and more is not nil, more is appended to
+// point at the full list (e.g. "F1:More…") and accounted for in the width.
+func FitKeyHints(hints []KeyHint, width int, more *KeyHint) []KeyHint {
+	fits := func(hs []KeyHint) bool {
+		return len([]rune(FormatKeyHints(hs))) <= width
+	}
+	if fits(hints) {
+		return hints
+	}
+
+	kept := append([]KeyHint(nil), hints...)
+	for len(kept) > 0 {
+		candidate := kept
+		if more != nil {
+			candidate = append(append([]KeyHint(nil), kept...), *more)
+		}
+		if fits(candidate) {
+			return candidate
+		}
+		low := len(kept) - 1
+		for i := len(kept) - 2; i >= 0; i-- {
+			if kept[i].Priority < kept[low].Priority {
+				low = i
+			}
+		}
+		kept = append(kept[:low], kept[low+1:]...)
+	}
+	if more != nil {
+		return []KeyHint{*more}
+	}
+	return nil
+}
+
 // FindDeepFocused finds the most deeply focused widget starting from w.
 // Returns w if no focused descendant is found.
 // Returns nil if w is nil.
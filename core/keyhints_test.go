@@ -0,0 +1,43 @@
+package core
+
+import "testing"
+
+func TestMergeKeyHints(t *testing.T) {
+	inner := []KeyHint{{Key: "Enter", Label: "Edit"}, {Key: "C-c", Label: "Copy", Group: "clip"}, {Key: "↑↓", Label: "Navigate"}}
+	outer := []KeyHint{{Key: "↑↓", Label: "Scroll"}, {Key: "C-v", Label: "Paste", Group: "clip"}, {Key: "Esc", Label: "Back"}}
+
+	got := FormatKeyHints(MergeKeyHints(inner, outer))
+	want := "Enter:Edit │ C-c:Copy │ C-v:Paste │ ↑↓:Navigate │ Esc:Back"
+	if got != want {
+		t.Errorf("MergeKeyHints = %q, want %q", got, want)
+	}
+}
+
+func TestFitKeyHints(t *testing.T) {
+	hints := []KeyHint{
+		{Key: "Enter", Label: "Open"},
+		{Key: "1-9", Label: "Jump", Priority: -1},
+		{Key: "Del", Label: "Delete"},
+		{Key: "Tab", Label: "Next", Priority: -1},
+	}
+	if got := FitKeyHints(hints, 100, nil); len(got) != 4 {
+		t.Errorf("hints that fit should be kept, got %v", got)
+	}
+
+	// Lowest priority goes first, the last one among equals
+	if got := FormatKeyHints(FitKeyHints(hints, 34, nil)); got != "Enter:Open │ 1-9:Jump │ Del:Delete" {
+		t.Errorf("FitKeyHints = %q", got)
+	}
+	if got := FormatKeyHints(FitKeyHints(hints, 23, nil)); got != "Enter:Open │ Del:Delete" {
+		t.Errorf("FitKeyHints = %q", got)
+	}
+
+	// The "more" hint is reserved space only when something was dropped
+	more := &KeyHint{Key: "F1", Label: "More…"}
+	if got := FormatKeyHints(FitKeyHints(hints, 34, more)); got != "Enter:Open │ Del:Delete │ F1:More…" {
+		t.Errorf("FitKeyHints with more = %q", got)
+	}
+	if got := FitKeyHints(hints, 100, more); len(got) != 4 {
+		t.Errorf("more should not be added when everything fits, got %v", got)
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/keymap.go
+// Summary: Keymap profile registry for navigation key bindings.
+// Profiles (e.g. "vi") translate keys into navigation actions that
+// NavigationTarget widgets perform, so widgets don't hardcode alternate bindings.
+
+package core
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// NavAction is an abstract navigation action produced by a keymap profile.
+type NavAction int
+
+const (
+	NavNone NavAction = iota
+	NavUp
+	NavDown
+	NavLeft
+	NavRight
+	NavTop          // First item / start of content
+	NavBottom       // Last item / end of content
+	NavPageUp       // One viewport up
+	NavPageDown     // One viewport down
+	NavHalfPageUp   // Half a viewport up
+	NavHalfPageDown // Half a viewport down
+	NavSearch       // Start an incremental search
+	NavNextTab      // Next tab of the enclosing tab container
+	NavPrevTab      // Previous tab of the enclosing tab container
+	NavTab1         // Jump to tab 1; NavTab1+n jumps to tab n+1 (up to 9)
+)
+
+// IsTabAction reports whether a is a tab switching action. When the
+// focused widget declines one, it is offered to the containers around it,
+// so they work wherever focus is inside a tabbed container.
+func (a NavAction) IsTabAction() bool {
+	return a == NavNextTab || a == NavPrevTab || (a >= NavTab1 && a < NavTab1+9)
+}
+
+// NavigationTarget is implemented by widgets that can perform navigation
+// actions from the active keymap profile (lists, scroll panes, text views).
+type NavigationTarget interface {
+	// HandleNavigation performs the action. Returning false (e.g. a text
+	// widget in edit mode) delivers the original key to HandleKey instead.
+	HandleNavigation(action NavAction) bool
+}
+
+// KeymapProfile maps a key event to a navigation action.
+// It returns false for keys the profile doesn't bind.
+type KeymapProfile func(ev *tcell.EventKey) (NavAction, bool)
+
+// DefaultKeymap is the name of the built-in profile with no extra bindings.
+const DefaultKeymap = "default"
+
+var keymaps = struct {
+	mu       sync.RWMutex
+	profiles map[string]KeymapProfile
+	active   string
+}{
+	profiles: map[string]KeymapProfile{
+		DefaultKeymap: func(*tcell.EventKey) (NavAction, bool) { return NavNone, false },
+		"vi":          viKeymap,
+	},
+	active: DefaultKeymap,
+}
+
+// RegisterKeymap adds or replaces a named keymap profile.
+func RegisterKeymap(name string, profile KeymapProfile) {
+	keymaps.mu.Lock()
+	defer keymaps.mu.Unlock()
+	keymaps.profiles[name] = profile
+}
+
+// SetKeymap activates a registered keymap profile by name.
+func SetKeymap(name string) error {
+	keymaps.mu.Lock()
+	defer keymaps.mu.Unlock()
+	if _, ok := keymaps.profiles[name]; !ok {
+		return fmt.Errorf("keymap: unknown profile %q", name)
+	}
+	keymaps.active = name
+	return nil
+}
+
+// ActiveKeymap returns the name of the active keymap profile.
+func ActiveKeymap() string {
+	keymaps.mu.RLock()
+	defer keymaps.mu.RUnlock()
+	return keymaps.active
+}
+
+// Keymaps returns the names of all registered profiles, sorted.
+func Keymaps() []string {
+	keymaps.mu.RLock()
+	defer keymaps.mu.RUnlock()
+	names := make([]string, 0, len(keymaps.profiles))
+	for name := range keymaps.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupNavAction translates a key with the active profile, falling back
+// to the tab shortcuts every profile shares.
+func LookupNavAction(ev *tcell.EventKey) (NavAction, bool) {
+	keymaps.mu.RLock()
+	profile := keymaps.profiles[keymaps.active]
+	keymaps.mu.RUnlock()
+	if profile != nil {
+		if action, ok := profile(ev); ok {
+			return action, true
+		}
+	}
+	return tabKeymap(ev)
+}
+
+// tabKeymap binds Ctrl+Tab / Ctrl+Shift+Tab to cycle tabs and Alt+1..9
+// to jump to a tab.
+func tabKeymap(ev *tcell.EventKey) (NavAction, bool) {
+	mods := ev.Modifiers()
+	switch ev.Key() {
+	case tcell.KeyTab:
+		if mods&tcell.ModCtrl != 0 {
+			if mods&tcell.ModShift != 0 {
+				return NavPrevTab, true
+			}
+			return NavNextTab, true
+		}
+	case tcell.KeyBacktab:
+		if mods&tcell.ModCtrl != 0 {
+			return NavPrevTab, true
+		}
+	case tcell.KeyRune:
+		if r := ev.Rune(); mods&tcell.ModAlt != 0 && r >= '1' && r <= '9' {
+			return NavTab1 + NavAction(r-'1'), true
+		}
+	}
+	return NavNone, false
+}
+
+// viKeymap binds j/k/h/l, g/G, Ctrl+D/Ctrl+U, Ctrl+F/Ctrl+B and /.
+func viKeymap(ev *tcell.EventKey) (NavAction, bool) {
+	switch ev.Key() {
+	case tcell.KeyCtrlD:
+		return NavHalfPageDown, true
+	case tcell.KeyCtrlU:
+		return NavHalfPageUp, true
+	case tcell.KeyCtrlF:
+		return NavPageDown, true
+	case tcell.KeyCtrlB:
+		return NavPageUp, true
+	case tcell.KeyRune:
+		if ev.Modifiers()&(tcell.ModCtrl|tcell.ModAlt|tcell.ModMeta) != 0 {
+			return NavNone, false
+		}
+		switch ev.Rune() {
+		case 'j':
+			return NavDown, true
+		case 'k':
+			return NavUp, true
+		case 'h':
+			return NavLeft, true
+		case 'l':
+			return NavRight, true
+		case 'g':
+			return NavTop, true
+		case 'G':
+			return NavBottom, true
+		case '/':
+			return NavSearch, true
+		}
+	}
+	return NavNone, false
+}
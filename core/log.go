@@ -0,0 +1,284 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/log.go
+// Summary: Leveled, categorized debug log kept in a ring buffer.
+
+package core
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogLevel is the severity of a log entry.
+type LogLevel int
+
+const (
+	LogDebug LogLevel = iota
+	LogInfo
+	LogWarn
+	LogError
+)
+
+// String returns the level name: debug, info, warn or error.
+func (l LogLevel) String() string {
+	switch l {
+	case LogDebug:
+		return "debug"
+	case LogInfo:
+		return "info"
+	case LogWarn:
+		return "warn"
+	case LogError:
+		return "error"
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// ParseLogLevel parses a level name as returned by LogLevel.String;
+// "warning" is accepted for warn.
+func ParseLogLevel(s string) (LogLevel, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return LogDebug, true
+	case "info":
+		return LogInfo, true
+	case "warn", "warning":
+		return LogWarn, true
+	case "error":
+		return LogError, true
+	}
+	return LogInfo, false
+}
+
+// LogCategory names the subsystem a log entry comes from. The UIManager
+// logs to the categories below at debug level; apps and widgets may use
+// their own.
+type LogCategory string
+
+const (
+	LogFocus      LogCategory = "focus"      // Focus changes
+	LogMouse      LogCategory = "mouse"      // Mouse events and where they went
+	LogInvalidate LogCategory = "invalidate" // Redraw requests
+	LogLayout     LogCategory = "layout"     // Surface and content sizes
+)
+
+// LogEntry is a message of the log.
+type LogEntry struct {
+	Time     time.Time
+	Level    LogLevel
+	Category LogCategory
+	Message  string
+}
+
+// String formats the entry as a line of the log file, without the newline.
+func (e LogEntry) String() string {
+	return fmt.Sprintf("%s %-5s %s: %s", e.Time.Format("15:04:05.000"), e.Level, e.Category, e.Message)
+}
+
+// DefaultLogCapacity is the number of entries the log keeps by default.
+const DefaultLogCapacity = 1000
+
+// LogEnv names the environment variable that configures the log (see
+// ParseLogSpec), and LogFileEnv the one naming a file the log is appended
+// to. Both are applied by ConfigureLogFromEnv.
+const (
+	LogEnv     = "TEXELUI_LOG"
+	LogFileEnv = "TEXELUI_LOG_FILE"
+)
+
+var (
+	logMu       sync.Mutex
+	logRing     = make([]LogEntry, 0, DefaultLogCapacity)
+	logCap      = DefaultLogCapacity
+	logStart    int // Index of the oldest entry once the ring is full
+	logLevel    = LogInfo
+	logCats     map[LogCategory]bool // nil logs every category
+	logFile     io.WriteCloser
+	logListener = make(map[uint64]func())
+	nextLogID   uint64
+)
+
+// SetLogLevel sets the lowest level that is logged. The default is info.
+func SetLogLevel(level LogLevel) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	logLevel = level
+}
+
+// SetLogCategories limits the log to the given categories; with none,
+// every category is logged (the default).
+func SetLogCategories(cats ...LogCategory) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	if len(cats) == 0 {
+		logCats = nil
+		return
+	}
+	logCats = make(map[LogCategory]bool, len(cats))
+	for _, c := range cats {
+		logCats[c] = true
+	}
+}
+
+// SetLogCapacity sets how many entries the log keeps, dropping the oldest
+// ones beyond it.
+func SetLogCapacity(n int) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	entries := logEntriesLocked()
+	if n < 1 {
+		n = 1
+	}
+	if len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	logRing = append(make([]LogEntry, 0, n), entries...)
+	logCap, logStart = n, 0
+}
+
+// SetLogFile appends every logged entry to the file at path, as well as
+// keeping it in memory. "" stops writing to a file.
+func SetLogFile(path string) error {
+	var f *os.File
+	if path != "" {
+		var err error
+		if f, err = os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+			return err
+		}
+	}
+	logMu.Lock()
+	defer logMu.Unlock()
+	if logFile != nil {
+		logFile.Close()
+		logFile = nil
+	}
+	if f != nil {
+		logFile = f
+	}
+	return nil
+}
+
+// LogEnabled reports whether an entry of level in cat would be logged, so
+// callers can skip building expensive messages.
+func LogEnabled(level LogLevel, cat LogCategory) bool {
+	logMu.Lock()
+	defer logMu.Unlock()
+	return level >= logLevel && (logCats == nil || logCats[cat])
+}
+
+// Logf logs a message of level in cat, if enabled.
+func Logf(level LogLevel, cat LogCategory, format string, args ...any) {
+	logMu.Lock()
+	if level < logLevel || logCats != nil && !logCats[cat] {
+		logMu.Unlock()
+		return
+	}
+	e := LogEntry{Time: Now(), Level: level, Category: cat, Message: fmt.Sprintf(format, args...)}
+	if len(logRing) < logCap {
+		logRing = append(logRing, e)
+	} else {
+		logRing[logStart] = e
+		logStart = (logStart + 1) % logCap
+	}
+	if logFile != nil {
+		_, _ = io.WriteString(logFile, e.String()+"\n")
+	}
+	fns := make([]func(), 0, len(logListener))
+	for _, fn := range logListener {
+		fns = append(fns, fn)
+	}
+	logMu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// Debugf logs a debug message in cat.
+func Debugf(cat LogCategory, format string, args ...any) {
+	Logf(LogDebug, cat, format, args...)
+}
+
+// LogEntries returns the entries in the log, oldest first.
+func LogEntries() []LogEntry {
+	logMu.Lock()
+	defer logMu.Unlock()
+	return logEntriesLocked()
+}
+
+func logEntriesLocked() []LogEntry {
+	return slices.Concat(logRing[logStart:], logRing[:logStart])
+}
+
+// ClearLog drops every entry kept in memory.
+func ClearLog() {
+	logMu.Lock()
+	defer logMu.Unlock()
+	logRing, logStart = logRing[:0], 0
+}
+
+// OnLog registers fn to be called after every logged entry. fn must not
+// block or log. The returned function unregisters it.
+func OnLog(fn func()) (cancel func()) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	nextLogID++
+	id := nextLogID
+	logListener[id] = fn
+	return func() {
+		logMu.Lock()
+		defer logMu.Unlock()
+		delete(logListener, id)
+	}
+}
+
+// ParseLogSpec parses a log configuration: a level ("debug"), categories
+// logged at debug level ("focus,mouse"), or both ("debug:focus,mouse").
+func ParseLogSpec(spec string) (LogLevel, []LogCategory, error) {
+	spec = strings.TrimSpace(spec)
+	levelPart, catPart, hasBoth := strings.Cut(spec, ":")
+	level := LogDebug
+	if hasBoth {
+		l, ok := ParseLogLevel(levelPart)
+		if !ok {
+			return 0, nil, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", levelPart)
+		}
+		level = l
+	} else if l, ok := ParseLogLevel(spec); ok {
+		return l, nil, nil
+	} else {
+		catPart = spec
+	}
+	var cats []LogCategory
+	for _, c := range strings.Split(catPart, ",") {
+		if c = strings.TrimSpace(c); c != "" {
+			cats = append(cats, LogCategory(c))
+		}
+	}
+	return level, cats, nil
+}
+
+// ConfigureLogFromEnv applies TEXELUI_LOG and TEXELUI_LOG_FILE, when set.
+func ConfigureLogFromEnv() error {
+	if spec := os.Getenv(LogEnv); spec != "" {
+		level, cats, err := ParseLogSpec(spec)
+		if err != nil {
+			return fmt.Errorf("%s: %w", LogEnv, err)
+		}
+		SetLogLevel(level)
+		SetLogCategories(cats...)
+	}
+	if path := os.Getenv(LogFileEnv); path != "" {
+		if err := SetLogFile(path); err != nil {
+			return fmt.Errorf("%s: %w", LogFileEnv, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package core_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/texeluitest"
+	"github.com/framegrace/texelui/widgets"
+)
+
+// resetLog restores the default log configuration after the test.
+func resetLog(t *testing.T) {
+	t.Helper()
+	core.ClearLog()
+	t.Cleanup(func() {
+		core.SetLogLevel(core.LogInfo)
+		core.SetLogCategories()
+		core.SetLogCapacity(core.DefaultLogCapacity)
+		_ = core.SetLogFile("")
+		core.ClearLog()
+	})
+}
+
+func logMessages() []string {
+	var msgs []string
+	for _, e := range core.LogEntries() {
+		msgs = append(msgs, string(e.Category)+":"+e.Message)
+	}
+	return msgs
+}
+
+func TestLogFiltersByLevelAndCategory(t *testing.T) {
+	resetLog(t)
+
+	core.Debugf(core.LogFocus, "hidden")
+	core.Logf(core.LogInfo, core.LogLayout, "shown %d", 1)
+	core.SetLogLevel(core.LogDebug)
+	core.SetLogCategories(core.LogFocus)
+	core.Debugf(core.LogFocus, "focus")
+	core.Debugf(core.LogMouse, "mouse")
+	if core.LogEnabled(core.LogError, core.LogMouse) {
+		t.Error("expected the mouse category to be disabled")
+	}
+
+	if got := strings.Join(logMessages(), " "); got != "layout:shown 1 focus:focus" {
+		t.Errorf("unexpected log: %q", got)
+	}
+}
+
+func TestLogRingKeepsNewestEntries(t *testing.T) {
+	resetLog(t)
+	core.SetLogCapacity(3)
+	for _, m := range []string{"a", "b", "c", "d", "e"} {
+		core.Logf(core.LogInfo, "test", "%s", m)
+	}
+	if got := strings.Join(logMessages(), " "); got != "test:c test:d test:e" {
+		t.Errorf("unexpected log: %q", got)
+	}
+	core.SetLogCapacity(2)
+	if got := strings.Join(logMessages(), " "); got != "test:d test:e" {
+		t.Errorf("unexpected log after shrinking: %q", got)
+	}
+}
+
+func TestLogWritesFile(t *testing.T) {
+	resetLog(t)
+	path := filepath.Join(t.TempDir(), "ui.log")
+	if err := core.SetLogFile(path); err != nil {
+		t.Fatal(err)
+	}
+	core.Logf(core.LogWarn, core.LogLayout, "too small")
+	if err := core.SetLogFile(""); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(data), " warn  layout: too small\n") {
+		t.Errorf("unexpected log file: %q", data)
+	}
+}
+
+func TestParseLogSpec(t *testing.T) {
+	for _, tc := range []struct {
+		spec  string
+		level core.LogLevel
+		cats  string
+	}{
+		{"warn", core.LogWarn, ""},
+		{"focus, mouse", core.LogDebug, "focus,mouse"},
+		{"info:layout", core.LogInfo, "layout"},
+	} {
+		level, cats, err := core.ParseLogSpec(tc.spec)
+		var names []string
+		for _, c := range cats {
+			names = append(names, string(c))
+		}
+		if err != nil || level != tc.level || strings.Join(names, ",") != tc.cats {
+			t.Errorf("ParseLogSpec(%q) = %v, %v, %v", tc.spec, level, names, err)
+		}
+	}
+	if _, _, err := core.ParseLogSpec("loud:focus"); err == nil {
+		t.Error("expected an unknown level to fail")
+	}
+}
+
+func TestUIManagerLogsFocusAndShowsLogViewer(t *testing.T) {
+	resetLog(t)
+	core.SetLogLevel(core.LogDebug)
+	core.SetLogCategories(core.LogFocus)
+
+	form := widgets.NewForm()
+	form.AddField("Name", widgets.NewInput())
+	form.AddField("Age", widgets.NewInput())
+	h := texeluitest.New(t, form, 60, 12)
+	h.Press(tcell.KeyTab)
+	if got := strings.Join(logMessages(), " "); !strings.Contains(got, "focus:focus on Input") {
+		t.Errorf("expected focus changes logged, got %q", got)
+	}
+
+	h.Press(tcell.KeyF12)
+	h.PressRune('l', tcell.ModNone)
+	if !h.UI.LogViewerOpen() || h.UI.InspectorOpen() {
+		t.Fatal("expected L to switch from the inspector to the log viewer")
+	}
+	h.AssertContains("debug focus: focus on Input")
+
+	// New entries show up while the viewer is open
+	core.Logf(core.LogError, core.LogFocus, "lost it")
+	h.Draw()
+	h.AssertContains("error focus: lost it")
+
+	h.PressRune('c', tcell.ModNone)
+	h.AssertContains("Log (0)")
+	h.Press(tcell.KeyEscape)
+	if h.UI.LogViewerOpen() {
+		t.Fatal("expected Esc to close the log viewer")
+	}
+	h.AssertNotContains("Log (")
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/logviewer.go
+// Summary: Overlay showing the debug log over the lower half of the UI.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/theme"
+)
+
+// logViewer is the state of the log viewer overlay.
+type logViewer struct {
+	scroll int // Entries scrolled back from the newest; 0 follows the log
+	cancel func()
+}
+
+// LogViewerOpen reports whether the log viewer is shown.
+func (u *UIManager) LogViewerOpen() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.logViewer != nil
+}
+
+// ToggleLogViewer shows or hides the log viewer: the newest entries of the
+// debug log (see Logf) over the lower half of the UI, kept up to date as
+// entries arrive. L opens it from the widget inspector.
+func (u *UIManager) ToggleLogViewer() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.toggleLogViewerLocked()
+}
+
+func (u *UIManager) toggleLogViewerLocked() {
+	if u.logViewer != nil {
+		u.logViewer.cancel()
+		u.logViewer = nil
+	} else {
+		u.inspector = nil // One debug overlay at a time
+		// Entries may be logged with u.mu held: only ask for a frame
+		u.logViewer = &logViewer{cancel: OnLog(u.RequestRefresh)}
+	}
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+}
+
+// logViewerKeyLocked scrolls the log with the arrow and page keys; c
+// clears it and Esc closes the viewer. Every key is taken.
+func (u *UIManager) logViewerKeyLocked(ev *tcell.EventKey) bool {
+	page := max(u.logViewerRect().H-3, 1)
+	lv := u.logViewer
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		u.toggleLogViewerLocked()
+		return true
+	case tcell.KeyUp:
+		lv.scroll++
+	case tcell.KeyDown:
+		lv.scroll--
+	case tcell.KeyPgUp:
+		lv.scroll += page
+	case tcell.KeyPgDn:
+		lv.scroll -= page
+	case tcell.KeyHome:
+		lv.scroll = len(LogEntries())
+	case tcell.KeyEnd:
+		lv.scroll = 0
+	case tcell.KeyRune:
+		if ev.Rune() != 'c' {
+			return true
+		}
+		ClearLog()
+		lv.scroll = 0
+	default:
+		return true
+	}
+	lv.scroll = max(lv.scroll, 0)
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+	return true
+}
+
+// logViewerMouseLocked scrolls the log with the wheel. Other mouse events
+// are dropped while the viewer is open.
+func (u *UIManager) logViewerMouseLocked(ev *tcell.EventMouse) {
+	switch {
+	case ev.Buttons()&tcell.WheelUp != 0:
+		u.logViewer.scroll += 3
+	case ev.Buttons()&tcell.WheelDown != 0:
+		u.logViewer.scroll = max(u.logViewer.scroll-3, 0)
+	default:
+		return
+	}
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+}
+
+// logViewerRect is the lower half of the screen, at least 3 rows.
+func (u *UIManager) logViewerRect() Rect {
+	h := min(max(u.H/2, 3), u.H)
+	return Rect{X: 0, Y: u.H - h, W: u.W, H: h}
+}
+
+// drawLogViewerLocked draws the log viewer on top of the frame. Must be
+// called with u.mu held.
+func (u *UIManager) drawLogViewerLocked() {
+	r := u.logViewerRect()
+	if r.W <= 2 || r.H <= 2 {
+		return
+	}
+	colors := GetWidgetColors()
+	tm := theme.Get()
+	base := tcell.StyleDefault.Foreground(colors.TextPrimary).Background(colors.SurfaceBg)
+	levelFG := map[LogLevel]tcell.Color{
+		LogDebug: colors.TextMuted,
+		LogInfo:  colors.TextPrimary,
+		LogWarn:  tm.GetSemanticColor("status.warning"),
+		LogError: tm.GetSemanticColor("status.error"),
+	}
+
+	p := NewPainter(u.buf, Rect{X: 0, Y: 0, W: u.W, H: u.H})
+	p.Fill(r, ' ', base)
+	p.DrawBorder(r, base.Foreground(colors.BorderFocus), [6]rune{'─', '│', '╭', '╮', '╰', '╯'})
+
+	entries := LogEntries()
+	rows := r.H - 2
+	lv := u.logViewer
+	lv.scroll = min(lv.scroll, max(len(entries)-rows, 0))
+	end := len(entries) - lv.scroll
+	start := max(end-rows, 0)
+	for i, e := range entries[start:end] {
+		text := []rune(e.String())
+		if len(text) > r.W-2 {
+			text = text[:r.W-2]
+		}
+		p.DrawText(r.X+1, r.Y+1+i, string(text), base.Foreground(levelFG[e.Level]))
+	}
+
+	title := fmt.Sprintf(" Log (%d) ", len(entries))
+	if lv.scroll > 0 {
+		title = fmt.Sprintf(" Log (%d, %d newer below) ", len(entries), lv.scroll)
+	}
+	p.DrawText(r.X+2, r.Y, title, base.Bold(true))
+	if hints := " ↑↓ scroll  c clear  Esc close "; len([]rune(hints))+4 <= r.W {
+		p.DrawText(r.X+r.W-2-len([]rune(hints)), r.Y+r.H-1, hints, base)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/motion.go
+// Summary: Global reduce-motion switch honored by animated widgets.
+
+package core
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	reduceMotion atomic.Bool
+
+	motionMu        sync.Mutex
+	motionListeners = make(map[uint64]func())
+	nextMotionID    uint64
+)
+
+// SetReduceMotion enables or disables reduced motion globally.
+// When enabled, widgets skip animated transitions (smooth scrolling, etc.)
+// and jump straight to their final state, animated colors hold still,
+// blinking text stops blinking and spinners show a static frame.
+// Safe to toggle at runtime; change listeners are notified.
+func SetReduceMotion(enabled bool) {
+	if reduceMotion.Swap(enabled) == enabled {
+		return
+	}
+
+	motionMu.Lock()
+	fns := make([]func(), 0, len(motionListeners))
+	for _, fn := range motionListeners {
+		fns = append(fns, fn)
+	}
+	motionMu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// ReduceMotion reports whether reduced motion is enabled.
+func ReduceMotion() bool {
+	return reduceMotion.Load()
+}
+
+// OnReduceMotionChange registers fn to be called whenever reduced motion is
+// switched on or off. fn must not block. The returned function unregisters it.
+func OnReduceMotionChange(fn func()) (cancel func()) {
+	motionMu.Lock()
+	defer motionMu.Unlock()
+	nextMotionID++
+	id := nextMotionID
+	motionListeners[id] = fn
+	return func() {
+		motionMu.Lock()
+		defer motionMu.Unlock()
+		delete(motionListeners, id)
+	}
+}
@@ -34,3 +34,21 @@ import "github.com/gdamore/tcell/v2"
 type MouseHandler interface {
 	HandleMouse(ev *tcell.EventMouse)
 }
+
+// AuxClick describes a right (tcell.Button2) or middle (tcell.Button3)
+// button press, in screen coordinates.
+type AuxClick struct {
+	Button    tcell.ButtonMask
+	X, Y      int
+	Modifiers tcell.ModMask
+}
+
+// AuxClickAware is an optional interface for widgets that act on right and
+// middle clicks, e.g. opening a context menu or pasting. UIManager offers
+// the press to the deepest widget under the pointer first, then to its
+// ancestors, and drops the rest of a handled click up to the release. If
+// none handles it, the raw event is routed through the root widget's
+// HandleMouse like a left click, without moving focus.
+type AuxClickAware interface {
+	HandleAuxClick(click AuxClick) bool
+}
@@ -0,0 +1,87 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: core/overlay.go
+// Summary: Overlay layer for popups and their placement on the surface.
+
+package core
+
+// Overlay is implemented by widgets that open a popup (picker, dropdown)
+// outside their layout rect. The UIManager draws open overlays after all
+// widgets, clipped to the content surface so they never cover the status
+// bar, and independent of any container clipping.
+type Overlay interface {
+	// OverlayRect returns the popup's screen rect and whether it is open.
+	OverlayRect() (Rect, bool)
+	// DrawOverlay draws the open popup.
+	DrawOverlay(p *Painter)
+}
+
+// PlacePopup returns the rect for a w x h popup attached to anchor (usually
+// the widget's own rect) on surface:
+//   - below the anchor if it fits there, otherwise above it when there is
+//     more room above (flip)
+//   - moved left/up as needed to stay on the surface (shift)
+//   - shrunk if larger than the surface
+//
+// A surface dimension <= 0 is treated as unbounded.
+func PlacePopup(anchor Rect, w, h int, surface Rect) Rect {
+	r := Rect{X: anchor.X, Y: anchor.Y + anchor.H, W: w, H: h}
+
+	if surface.H > 0 {
+		bottom := surface.Y + surface.H
+		below := bottom - r.Y
+		above := anchor.Y - surface.Y
+		if h > below && above > below {
+			r.Y = anchor.Y - h
+		}
+		if r.H > surface.H {
+			r.H = surface.H
+		}
+		if r.Y+r.H > bottom {
+			r.Y = bottom - r.H
+		}
+		if r.Y < surface.Y {
+			r.Y = surface.Y
+		}
+	}
+
+	if surface.W > 0 {
+		right := surface.X + surface.W
+		if r.W > surface.W {
+			r.W = surface.W
+		}
+		if r.X+r.W > right {
+			r.X = right - r.W
+		}
+		if r.X < surface.X {
+			r.X = surface.X
+		}
+	}
+	return r
+}
+
+// drawOverlaysLocked draws the open overlays in the widget trees on top of
+// the frame, clipped to the content surface. Must be called with u.mu held.
+func (u *UIManager) drawOverlaysLocked() {
+	surface := Rect{X: 0, Y: 0, W: u.W, H: u.contentHeightLocked()}
+	p := NewPainterWithGraphics(u.buf, surface, u.graphicsProvider)
+	p.SetTime(u.animationTime())
+	for _, w := range u.sortedWidgetsLocked() {
+		drawOverlays(w, p)
+	}
+	if p.HasAnimations() && !u.ClientSideAnimations && !ReduceMotion() {
+		u.scheduleAnimationRefreshLocked()
+	}
+}
+
+func drawOverlays(w Widget, p *Painter) {
+	if ov, ok := w.(Overlay); ok {
+		if _, open := ov.OverlayRect(); open {
+			ov.DrawOverlay(p)
+		}
+	}
+	if cc, ok := w.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) { drawOverlays(child, p) })
+	}
+}
@@ -0,0 +1,29 @@
+package core
+
+import "testing"
+
+func TestPlacePopup(t *testing.T) {
+	surface := Rect{W: 80, H: 24}
+	cases := []struct {
+		name   string
+		anchor Rect
+		w, h   int
+		want   Rect
+	}{
+		{"below", Rect{X: 5, Y: 2, W: 20, H: 1}, 30, 10, Rect{X: 5, Y: 3, W: 30, H: 10}},
+		{"flip above", Rect{X: 5, Y: 20, W: 20, H: 1}, 30, 10, Rect{X: 5, Y: 10, W: 30, H: 10}},
+		{"shift left", Rect{X: 70, Y: 2, W: 10, H: 1}, 30, 10, Rect{X: 50, Y: 3, W: 30, H: 10}},
+		{"shift up", Rect{X: 0, Y: 11, W: 10, H: 1}, 30, 14, Rect{X: 0, Y: 10, W: 30, H: 14}},
+		{"shrink", Rect{X: 0, Y: 0, W: 10, H: 1}, 100, 30, Rect{X: 0, Y: 0, W: 80, H: 24}},
+	}
+	for _, tc := range cases {
+		if got := PlacePopup(tc.anchor, tc.w, tc.h, surface); got != tc.want {
+			t.Errorf("%s: got %+v, want %+v", tc.name, got, tc.want)
+		}
+	}
+
+	// Unknown surface: plain drop-down
+	if got := PlacePopup(Rect{X: 70, Y: 20, W: 10, H: 1}, 30, 10, Rect{}); got != (Rect{X: 70, Y: 21, W: 30, H: 10}) {
+		t.Errorf("unbounded: got %+v", got)
+	}
+}
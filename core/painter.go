@@ -85,6 +85,23 @@ func (p *Painter) SetCellKeepBG(x, y int, ch rune, style tcell.Style) {
 	}
 }
 
+// SetForeground recolors the text of the cells in rect, keeping their
+// characters, backgrounds and attributes. Containers use it to mute
+// disabled widgets.
+func (p *Painter) SetForeground(rect Rect, fg tcell.Color) {
+	left := max(max(p.clip.X, rect.X), 0)
+	top := max(max(p.clip.Y, rect.Y), 0)
+	right := min(p.clip.X+p.clip.W, rect.X+rect.W)
+	bottom := min(p.clip.Y+p.clip.H, rect.Y+rect.H)
+	for yy := top; yy < bottom && yy < len(p.buf); yy++ {
+		for xx := left; xx < right && xx < len(p.buf[yy]); xx++ {
+			c := &p.buf[yy][xx]
+			c.Style = c.Style.Foreground(fg)
+			c.DynFG = color.DynamicColorDesc{}
+		}
+	}
+}
+
 func (p *Painter) Fill(rect Rect, ch rune, style tcell.Style) {
 	for yy := rect.Y; yy < rect.Y+rect.H; yy++ {
 		for xx := rect.X; xx < rect.X+rect.W; xx++ {
@@ -0,0 +1,104 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: core/paste.go
+// Summary: Bracketed paste delivery to the focused widget.
+
+package core
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// HandlePasteEvent tracks a bracketed paste: between tcell's start and end
+// EventPaste, HandleKey collects the keys instead of routing them, and the
+// end hands the collected text to HandlePaste.
+func (u *UIManager) HandlePasteEvent(ev *tcell.EventPaste) bool {
+	u.mu.Lock()
+	if ev.Start() {
+		u.pasting = true
+		u.pasteBuf = u.pasteBuf[:0]
+		u.mu.Unlock()
+		return true
+	}
+	data := u.pasteBuf
+	u.pasting = false
+	u.pasteBuf = nil
+	u.mu.Unlock()
+	return u.HandlePaste(data)
+}
+
+// HandlePaste delivers pasted text to the focused widget in one piece when
+// it is PasteAware. Otherwise the text is typed into it key by key, with
+// newlines sent as Enter only to multiline widgets, so focus never moves
+// mid-paste. Returns true if the widget took it.
+func (u *UIManager) HandlePaste(data []byte) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if len(data) == 0 || u.logViewer != nil || u.inspector != nil {
+		return false
+	}
+	if f := u.findDeepestFocusedLocked(); f != nil {
+		u.focused = f
+	}
+	if u.focused == nil {
+		return false
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	var handled bool
+	if pa, ok := u.focused.(PasteAware); ok {
+		handled = pa.HandlePaste(text)
+	} else {
+		handled = typeText(u.focused, text)
+	}
+	if handled {
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+	}
+	return handled
+}
+
+// pasteKeyLocked adds a key that arrived inside a bracketed paste to the
+// paste buffer.
+func (u *UIManager) pasteKeyLocked(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		u.pasteBuf = utf8.AppendRune(u.pasteBuf, ev.Rune())
+	case tcell.KeyEnter, tcell.KeyCtrlJ:
+		u.pasteBuf = append(u.pasteBuf, '\n')
+	case tcell.KeyTab:
+		u.pasteBuf = append(u.pasteBuf, '\t')
+	}
+}
+
+// typeText sends text to w as rune keys. Control characters are dropped,
+// newlines too unless w is multiline.
+func typeText(w Widget, text string) bool {
+	multiline := false
+	if m, ok := w.(MultilineWidget); ok {
+		multiline = m.IsMultiline()
+	}
+	handled := false
+	for _, r := range text {
+		var ev *tcell.EventKey
+		switch {
+		case r == '\n':
+			if !multiline {
+				continue
+			}
+			ev = tcell.NewEventKey(tcell.KeyEnter, '\r', tcell.ModNone)
+		case r < ' ':
+			continue
+		default:
+			ev = tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone)
+		}
+		if w.HandleKey(ev) {
+			handled = true
+		}
+	}
+	return handled
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/core/screenshot.go
+// Summary: Exports the framebuffer as ANSI-escaped or plain text.
+
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Screenshot renders the UI and returns the frame as text with ANSI SGR
+// escapes for colors and attributes, one line per row. Printed to a
+// terminal, it shows the UI as it would appear there.
+func (u *UIManager) Screenshot() string {
+	return CellsToANSI(u.snapshot())
+}
+
+// ScreenshotText renders the UI and returns the frame as plain text, one
+// line per row, without trailing spaces.
+func (u *UIManager) ScreenshotText() string {
+	return CellsToText(u.snapshot())
+}
+
+// snapshot renders the frame and returns a copy of it, overlays included.
+func (u *UIManager) snapshot() [][]Cell {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.renderLocked()
+	if u.frameStats != nil {
+		u.drawFrameStatsLocked()
+	}
+	buf := make([][]Cell, len(u.buf))
+	for y, row := range u.buf {
+		buf[y] = append([]Cell(nil), row...)
+	}
+	return buf
+}
+
+// CellsToText returns the characters of buf, one line per row, without
+// trailing spaces. Empty cells are written as spaces.
+func CellsToText(buf [][]Cell) string {
+	var sb strings.Builder
+	var line []rune
+	for _, row := range buf {
+		line = line[:0]
+		for _, c := range row {
+			line = append(line, cellRune(c))
+		}
+		sb.WriteString(strings.TrimRight(string(line), " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// CellsToANSI returns buf as text with ANSI SGR escapes, one line per row.
+// An escape is written whenever the style changes and each styled row ends
+// with a reset, so rows can be printed on their own. RGB colors are written
+// as 24-bit colors and palette colors as 256-color indexes.
+func CellsToANSI(buf [][]Cell) string {
+	var sb strings.Builder
+	for _, row := range buf {
+		cur := tcell.StyleDefault
+		for _, c := range row {
+			if c.Style != cur {
+				sb.WriteString(sgr(c.Style))
+				cur = c.Style
+			}
+			sb.WriteRune(cellRune(c))
+		}
+		if cur != tcell.StyleDefault {
+			sb.WriteString("\x1b[0m")
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+func cellRune(c Cell) rune {
+	if c.Ch == 0 {
+		return ' '
+	}
+	return c.Ch
+}
+
+// sgr returns the escape that resets the terminal to style.
+func sgr(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+	params := []string{"0"}
+	for _, a := range []struct {
+		mask tcell.AttrMask
+		code string
+	}{
+		{tcell.AttrBold, "1"},
+		{tcell.AttrDim, "2"},
+		{tcell.AttrItalic, "3"},
+		{tcell.AttrUnderline, "4"},
+		{tcell.AttrBlink, "5"},
+		{tcell.AttrReverse, "7"},
+		{tcell.AttrStrikeThrough, "9"},
+	} {
+		if attrs&a.mask != 0 {
+			params = append(params, a.code)
+		}
+	}
+	params = appendSGRColor(params, fg, "38")
+	params = appendSGRColor(params, bg, "48")
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+func appendSGRColor(params []string, c tcell.Color, code string) []string {
+	if !c.Valid() {
+		return params // Terminal default
+	}
+	if !c.IsRGB() {
+		if idx := int(c - tcell.ColorValid); idx < 256 {
+			return append(params, code, "5", strconv.Itoa(idx))
+		}
+	}
+	r, g, b := c.RGB()
+	return append(params, code, "2", strconv.Itoa(int(r)), strconv.Itoa(int(g)), strconv.Itoa(int(b)))
+}
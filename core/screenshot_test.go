@@ -0,0 +1,51 @@
+package core_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/texeluitest"
+	"github.com/framegrace/texelui/widgets"
+)
+
+func TestCellsToText(t *testing.T) {
+	buf := [][]core.Cell{
+		{{Ch: 'h'}, {Ch: 'i'}, {}, {Ch: ' '}},
+		{{}, {Ch: 'x'}, {}, {}},
+	}
+	if got := core.CellsToText(buf); got != "hi\n x\n" {
+		t.Errorf("unexpected text: %q", got)
+	}
+}
+
+func TestCellsToANSI(t *testing.T) {
+	red := tcell.StyleDefault.Foreground(tcell.NewRGBColor(255, 0, 0)).Bold(true)
+	blue := tcell.StyleDefault.Background(tcell.ColorBlue)
+	buf := [][]core.Cell{
+		{{Ch: 'a', Style: red}, {Ch: 'b', Style: red}, {Ch: 'c', Style: blue}},
+		{{Ch: 'd'}, {Ch: 'e'}, {Ch: 'f'}},
+	}
+	want := "\x1b[0;1;38;2;255;0;0mab\x1b[0;48;5;12mc\x1b[0m\ndef\n"
+	if got := core.CellsToANSI(buf); got != want {
+		t.Errorf("unexpected ANSI:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestUIManagerScreenshot(t *testing.T) {
+	form := widgets.NewForm()
+	form.AddField("Name", widgets.NewInput())
+	h := texeluitest.New(t, form, 60, 3)
+	h.Type("Ada")
+
+	text := h.UI.ScreenshotText()
+	if lines := strings.Split(text, "\n"); len(lines) != 4 || !strings.Contains(lines[1], "Name") || !strings.HasSuffix(lines[1], "Ada") {
+		t.Errorf("unexpected screenshot:\n%s", text)
+	}
+	ansi := h.UI.Screenshot()
+	if !strings.Contains(ansi, "\x1b[") || !strings.Contains(ansi, "Ada") {
+		t.Errorf("expected styled text, got %q", ansi)
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -33,12 +34,39 @@ type UIManager struct {
 	dirty    []Rect
 	lay      Layout
 	capture  Widget
+	// auxCapture is the root widget a right or middle click went to; it
+	// gets the rest of that click up to the release
+	auxCapture Widget
+	auxHeld    bool     // An AuxClick was handled; swallow events up to the release
+	hovered    []Widget // HoverAware widgets under the pointer, outermost first
+	pasting    bool     // Inside a bracketed paste, see HandlePasteEvent
+	pasteBuf   []byte
+	shortcuts  map[string]shortcut // Global shortcuts by key sequence, see AddShortcut
+	// Keys of an unfinished shortcut sequence, dropped after pendingUntil
+	pendingKeys  []KeyStroke
+	pendingUntil time.Time
+	// Shortcut or mnemonic matched by handleKeyLocked, run by HandleKey
+	// after it releases mu
+	keyAction func()
+	bus       EventBus // See Emit and Subscribe
 
 	// AdvanceFocusOnEnter controls whether pressing Enter in a widget
 	// automatically advances focus to the next widget. Enabled by default.
 	// Useful for form-style data entry.
 	AdvanceFocusOnEnter bool
 
+	// KeySequenceTimeout is how long a shortcut key sequence waits for its
+	// next key; zero means DefaultKeySequenceTimeout.
+	KeySequenceTimeout time.Duration
+
+	// InspectorKey toggles the widget inspector overlay (see
+	// ToggleInspector). F12 by default; tcell.KeyNUL disables it.
+	InspectorKey tcell.Key
+	inspector    *inspector
+	logViewer    *logViewer
+	loggedFocus  Widget // Focused widget last logged
+	frameStats   *frameStats
+
 	// Status bar support
 	statusBar        StatusBarWidget
 	statusBarEnabled bool
@@ -53,24 +81,78 @@ type UIManager struct {
 	// Graphics provider for image rendering (Kitty protocol, etc.)
 	graphicsProvider GraphicsProvider
 
+	// Clipboard handed to ClipboardAware widgets
+	clipboard ClipboardService
+
 	// ClientSideAnimations suppresses server-side animation refresh scheduling.
 	// When true, HasAnimations() does NOT trigger scheduleAnimationRefreshLocked.
 	// The client handles animation refresh instead.
 	ClientSideAnimations bool
 
+	// Headless makes the UIManager start no goroutines or timers, for a
+	// loop that drives time, refreshes and events itself: the status bar's
+	// ticker is not started and animation refreshes are not scheduled. The
+	// loop calls the status bar's Tick and redraws when it moves the clock
+	// (see SetClock). Set it before SetStatusBar.
+	Headless bool
+
 	// animStart tracks when the UIManager was created, for DynamicColor animation time.
 	animStart time.Time
+
+	// cascadeGen is the cascade generation last pushed down the tree.
+	cascadeGen uint64
+	// themeGen is the theme generation the widgets were last styled for.
+	themeGen uint64
+	unwatch  []func() // Cancel the theme and reduce-motion listeners
 }
 
 func NewUIManager() *UIManager {
+	return &UIManager{
+		bgStyle:             themeBgStyle(),
+		AdvanceFocusOnEnter: true, // Enable by default for form-style data entry
+		InspectorKey:        tcell.KeyF12,
+		statusBarHeight:     2, // Default: 1 separator + 1 content row
+		animStart:           Now(),
+		themeGen:            theme.Generation(),
+	}
+}
+
+// themeBgStyle returns the background style of the current theme.
+func themeBgStyle() tcell.Style {
 	tm := theme.Get()
 	bg := tm.GetColor("ui", "surface_bg", tcell.ColorBlack)
 	fg := tm.GetColor("ui", "surface_fg", tcell.ColorWhite)
-	return &UIManager{
-		bgStyle:             tcell.StyleDefault.Background(bg).Foreground(fg),
-		AdvanceFocusOnEnter: true, // Enable by default for form-style data entry
-		statusBarHeight:     2,    // Default: 1 separator + 1 content row
-		animStart:           time.Now(),
+	return tcell.StyleDefault.Background(bg).Foreground(fg)
+}
+
+// applyThemeChangeLocked restyles the widget tree and forces a full redraw
+// when the theme changed since the last frame. Returns true if it did.
+// Called with u.mu held.
+func (u *UIManager) applyThemeChangeLocked() bool {
+	gen := theme.Generation()
+	if gen == u.themeGen {
+		return false
+	}
+	u.themeGen = gen
+	u.bgStyle = themeBgStyle()
+	for _, w := range u.widgets {
+		NotifyThemeChanged(w)
+	}
+	if u.statusBar != nil {
+		NotifyThemeChanged(u.statusBar)
+	}
+	return true
+}
+
+// NotifyThemeChanged calls OnThemeChanged on w and its descendants that
+// implement ThemeAware. Containers whose VisitChildren skips some children
+// (e.g. inactive tabs) use it to restyle those too.
+func NotifyThemeChanged(w Widget) {
+	if ta, ok := w.(ThemeAware); ok {
+		ta.OnThemeChanged()
+	}
+	if cc, ok := w.(ChildContainer); ok {
+		cc.VisitChildren(NotifyThemeChanged)
 	}
 }
 
@@ -88,6 +170,24 @@ func (u *UIManager) GraphicsProvider() GraphicsProvider {
 	return u.graphicsProvider
 }
 
+// SetClipboardService implements ClipboardAware. The service is passed on
+// to ClipboardAware widgets, e.g. for copy shortcuts, here and when a
+// widget tree is added with AddWidget or SetRootWidget. Widgets added to a
+// container afterwards get it on the next of those calls.
+func (u *UIManager) SetClipboardService(cs ClipboardService) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.clipboard = cs
+	u.propagateClipboardLocked()
+}
+
+// ClipboardService returns the clipboard service, or nil if none.
+func (u *UIManager) ClipboardService() ClipboardService {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.clipboard
+}
+
 // SetStatusBar sets the status bar widget.
 // The status bar is automatically enabled when set.
 // Pass nil to disable the status bar.
@@ -103,20 +203,19 @@ func (u *UIManager) SetStatusBar(sb StatusBarWidget) {
 	u.statusBar = sb
 	u.statusBarEnabled = sb != nil
 
+	// Position status bar at bottom and fit the root widget above it
+	u.layoutStatusBarLocked()
+
 	var notifier chan<- bool
 	if sb != nil {
 		// Set up the status bar
 		sb.SetInvalidator(u.Invalidate)
 		u.addObserverLocked(sb)
 
-		// Position status bar at bottom
-		if u.H > u.statusBarHeight {
-			sb.SetPosition(0, u.H-u.statusBarHeight)
-			sb.Resize(u.W, u.statusBarHeight)
-		}
-
 		// Start the status bar background ticker
-		sb.Start()
+		if !u.Headless {
+			sb.Start()
+		}
 	}
 	u.mu.Unlock()
 
@@ -151,6 +250,7 @@ func (u *UIManager) SetStatusBarEnabled(enabled bool) {
 	}
 
 	u.statusBarEnabled = enabled
+	u.layoutStatusBarLocked()
 
 	u.dirtyMu.Lock()
 	u.invalidateAllLocked()
@@ -164,6 +264,44 @@ func (u *UIManager) StatusBarEnabled() bool {
 	return u.statusBarEnabled && u.statusBar != nil
 }
 
+// SetStatusBarHeight sets the number of rows reserved for the status bar
+// (default 2: separator + one content row). With more rows the status bar
+// stacks wrapped messages below the key hints. The root widget is resized
+// to the remaining content height.
+func (u *UIManager) SetStatusBarHeight(h int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if h < 1 {
+		h = 1
+	}
+	u.statusBarHeight = h
+	u.layoutStatusBarLocked()
+
+	u.dirtyMu.Lock()
+	u.invalidateAllLocked()
+	u.dirtyMu.Unlock()
+}
+
+// StatusBarHeight returns the number of rows reserved for the status bar.
+func (u *UIManager) StatusBarHeight() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.statusBarHeight
+}
+
+// layoutStatusBarLocked places the status bar at the bottom and resizes
+// the root widget to the content area above it.
+// Must be called with u.mu held.
+func (u *UIManager) layoutStatusBarLocked() {
+	if u.statusBar != nil && u.statusBarEnabled && u.H > u.statusBarHeight {
+		u.statusBar.SetPosition(0, u.H-u.statusBarHeight)
+		u.statusBar.Resize(u.W, u.statusBarHeight)
+	}
+	u.resizeRootWidgetLocked()
+	u.propagateSurfaceLocked()
+}
+
 // ContentHeight returns the height available for content (excluding status bar).
 func (u *UIManager) ContentHeight() int {
 	u.mu.Lock()
@@ -178,6 +316,52 @@ func (u *UIManager) contentHeightLocked() int {
 	return u.H
 }
 
+// SurfaceSize returns the size of the content surface available to widgets
+// (full width, height excluding the status bar).
+func (u *UIManager) SurfaceSize() (int, int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.W, u.contentHeightLocked()
+}
+
+// propagateSurfaceLocked informs SurfaceAware widgets of the current
+// content surface size. Must be called with u.mu held.
+func (u *UIManager) propagateSurfaceLocked() {
+	w, h := u.W, u.contentHeightLocked()
+	for _, root := range u.widgets {
+		propagateSurface(root, w, h)
+	}
+}
+
+// propagateClipboardLocked hands the clipboard service to ClipboardAware
+// widgets. Must be called with u.mu held.
+func (u *UIManager) propagateClipboardLocked() {
+	if u.clipboard == nil {
+		return
+	}
+	for _, root := range u.widgets {
+		propagateClipboard(root, u.clipboard)
+	}
+}
+
+func propagateClipboard(w Widget, cs ClipboardService) {
+	if ca, ok := w.(ClipboardAware); ok {
+		ca.SetClipboardService(cs)
+	}
+	if cc, ok := w.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) { propagateClipboard(child, cs) })
+	}
+}
+
+func propagateSurface(w Widget, sw, sh int) {
+	if sa, ok := w.(SurfaceAware); ok {
+		sa.SetSurfaceSize(sw, sh)
+	}
+	if cc, ok := w.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) { propagateSurface(child, sw, sh) })
+	}
+}
+
 // AddFocusObserver adds an observer that will be notified of focus changes.
 func (u *UIManager) AddFocusObserver(obs FocusObserver) {
 	u.mu.Lock()
@@ -213,11 +397,30 @@ func (u *UIManager) removeObserverLocked(obs FocusObserver) {
 // notifyFocusChangedLocked notifies all observers of a focus change.
 // Must be called with u.mu held.
 func (u *UIManager) notifyFocusChangedLocked() {
+	u.logFocusLocked()
 	for _, obs := range u.focusObservers {
 		obs.OnFocusChanged(u.focused)
 	}
 }
 
+// logFocusLocked logs the deepest focused widget when it changed since it
+// was last logged. Must be called with u.mu held.
+func (u *UIManager) logFocusLocked() {
+	if !LogEnabled(LogDebug, LogFocus) {
+		return
+	}
+	w := u.findDeepestFocusedLocked()
+	if w == u.loggedFocus {
+		return
+	}
+	u.loggedFocus = w
+	to := "nothing"
+	if w != nil {
+		to = typeName(w)
+	}
+	Debugf(LogFocus, "focus on %s", to)
+}
+
 func (u *UIManager) SetRefreshNotifier(ch chan<- bool) {
 	u.dirtyMu.Lock()
 	u.notifier = ch
@@ -228,9 +431,29 @@ func (u *UIManager) SetRefreshNotifier(ch chan<- bool) {
 	if u.statusBar != nil {
 		u.statusBar.SetRefreshNotifier(ch)
 	}
+	// Redraw when the theme or reduced motion is switched at runtime
+	if u.unwatch == nil {
+		u.unwatch = []func(){
+			theme.OnChange(u.RequestRefresh),
+			OnReduceMotionChange(u.RequestRefresh),
+		}
+	}
 	u.mu.Unlock()
 }
 
+// Close unregisters the theme and reduce-motion listeners added by
+// SetRefreshNotifier, so a discarded UIManager isn't kept alive by them.
+// Call it when tearing the UI down; it is safe to call more than once.
+func (u *UIManager) Close() {
+	u.mu.Lock()
+	unwatch := u.unwatch
+	u.unwatch = nil
+	u.mu.Unlock()
+	for _, cancel := range unwatch {
+		cancel()
+	}
+}
+
 func (u *UIManager) RequestRefresh() {
 	u.dirtyMu.Lock()
 	ch := u.notifier
@@ -248,6 +471,9 @@ func (u *UIManager) RequestRefresh() {
 // scheduleAnimationRefreshLocked queues a refresh after a short delay so
 // animated dynamic colors keep ticking. Called with u.mu held.
 func (u *UIManager) scheduleAnimationRefreshLocked() {
+	if u.Headless {
+		return // The loop redraws when it moves the clock
+	}
 	go func() {
 		time.Sleep(16 * time.Millisecond) // ~60fps
 		u.dirtyMu.Lock()
@@ -270,6 +496,7 @@ func (u *UIManager) scheduleAnimationRefreshLocked() {
 // BlinkTick was used for caret blinking; deprecated and no-op.
 
 func (u *UIManager) Resize(w, h int) {
+	Debugf(LogLayout, "surface %dx%d", w, h)
 	u.mu.Lock()
 	defer u.mu.Unlock()
 	u.dirtyMu.Lock()
@@ -283,14 +510,8 @@ func (u *UIManager) Resize(w, h int) {
 	}
 	u.W, u.H = w, h
 
-	// Reposition status bar if enabled
-	if u.statusBar != nil && u.statusBarEnabled && h > u.statusBarHeight {
-		u.statusBar.SetPosition(0, h-u.statusBarHeight)
-		u.statusBar.Resize(w, u.statusBarHeight)
-	}
-
-	// Resize root widget to fill content area
-	u.resizeRootWidgetLocked()
+	// Reposition status bar and resize root widget to fill content area
+	u.layoutStatusBarLocked()
 
 	// Resize framebuffer and invalidate all
 	u.buf = nil
@@ -303,6 +524,11 @@ func (u *UIManager) AddWidget(w Widget) {
 
 	u.widgets = append(u.widgets, w)
 	u.propagateInvalidator(w)
+	if u.clipboard != nil {
+		propagateClipboard(w, u.clipboard)
+	}
+	propagateSurface(w, u.W, u.contentHeightLocked())
+	InvalidateCascade()
 	// Ensure a first full draw after adding widgets
 	u.dirtyMu.Lock()
 	u.invalidateAllLocked()
@@ -341,9 +567,14 @@ func (u *UIManager) SetRootWidget(w Widget) {
 		// Add to widgets list
 		u.widgets = append(u.widgets, w)
 		u.propagateInvalidator(w)
+		if u.clipboard != nil {
+			propagateClipboard(w, u.clipboard)
+		}
+		InvalidateCascade()
 
 		// Size to fill content area
 		u.resizeRootWidgetLocked()
+		propagateSurface(w, u.W, u.contentHeightLocked())
 
 		// Invalidate
 		u.dirtyMu.Lock()
@@ -423,8 +654,46 @@ func (u *UIManager) focusLocked(w Widget) {
 }
 
 func (u *UIManager) HandleKey(ev *tcell.EventKey) bool {
+	handled, action := u.routeKey(ev)
+	// Shortcuts and mnemonics run unlocked so they can call back into the
+	// UIManager, e.g. to move the focus
+	if action != nil {
+		action()
+		u.InvalidateAll()
+	}
+	return handled
+}
+
+// routeKey handles ev under u.mu and returns the shortcut or mnemonic
+// action it matched, if any, for HandleKey to run after unlocking.
+func (u *UIManager) routeKey(ev *tcell.EventKey) (bool, func()) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
+	handled := u.handleKeyLocked(ev)
+	action := u.keyAction
+	u.keyAction = nil
+	return handled, action
+}
+
+func (u *UIManager) handleKeyLocked(ev *tcell.EventKey) bool {
+	if u.pasting {
+		u.pasteKeyLocked(ev)
+		return true
+	}
+
+	// The widget inspector takes every key while it is open
+	if u.InspectorKey != tcell.KeyNUL && ev.Key() == u.InspectorKey {
+		u.toggleInspectorLocked()
+		return true
+	}
+	if u.logViewer != nil {
+		return u.logViewerKeyLocked(ev)
+	}
+	// Containers move focus on their own: log where it ended up
+	defer u.logFocusLocked()
+	if u.inspector != nil {
+		return u.inspectorKeyLocked(ev)
+	}
 
 	// Find the actual focused widget - Form.CycleFocus may have changed focus
 	// without updating u.focused
@@ -445,13 +714,42 @@ func (u *UIManager) HandleKey(ev *tcell.EventKey) bool {
 				u.dirtyMu.Unlock()
 				return true
 			}
+			// Shortcuts get what the modal declines, and mnemonics only
+			// reach widgets inside it
+			if used, action := u.acceleratorLocked(ev, u.focused); used {
+				u.keyAction = action
+				u.dirtyMu.Lock()
+				u.invalidateAllLocked()
+				u.dirtyMu.Unlock()
+				return true
+			}
 			// Modal widget didn't handle it, but we still don't do focus traversal
 			return false
 		}
 	}
 
-	// Let focused widget handle the key first
-	if u.focused != nil && u.focused.HandleKey(ev) {
+	// Global shortcuts and mnemonics come before any other widget
+	if used, action := u.acceleratorLocked(ev, nil); used {
+		u.keyAction = action
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
+
+	// An open status bar prompt and modified hotkeys of actionable status
+	// messages take precedence; plain character hotkeys come last
+	if u.statusBarEnabled && u.statusBar != nil && u.statusBar.HandleKey(ev) {
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
+
+	// Let focused widget handle the key first, translating keys bound by
+	// the active keymap profile for widgets that accept navigation actions.
+	// Tab shortcuts it declines go to the enclosing containers.
+	if u.focused != nil && (u.handleNavigationLocked(ev) || u.focused.HandleKey(ev) || u.handleTabNavigationLocked(ev)) {
 		// Widget handled it
 		u.dirtyMu.Lock()
 		if len(u.dirty) == 0 {
@@ -511,6 +809,23 @@ func (u *UIManager) HandleKey(ev *tcell.EventKey) bool {
 		}
 	}
 
+	// Unhandled characters may start a shortcut sequence such as "g g"
+	if used, action := u.plainSequenceLocked(ev); used {
+		u.keyAction = action
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
+
+	// Finally, let the status bar act on unhandled keys (e.g. ':' prompt)
+	if fh, ok := u.statusBar.(FallbackKeyHandler); ok && u.statusBarEnabled && fh.HandleUnhandledKey(ev) {
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
+
 	return false
 }
 
@@ -675,16 +990,107 @@ func (u *UIManager) cycleRootWidgetsLocked(forward bool) bool {
 	return false
 }
 
+// handleNavigationLocked offers the key to the focused widget as a
+// navigation action if the active keymap profile binds it.
+func (u *UIManager) handleNavigationLocked(ev *tcell.EventKey) bool {
+	nt, ok := u.focused.(NavigationTarget)
+	if !ok {
+		return false
+	}
+	action, ok := LookupNavAction(ev)
+	if !ok {
+		return false
+	}
+	return nt.HandleNavigation(action)
+}
+
+// handleTabNavigationLocked offers a tab switching action the focused
+// widget declined to the widget and its ancestors, innermost first.
+func (u *UIManager) handleTabNavigationLocked(ev *tcell.EventKey) bool {
+	action, ok := LookupNavAction(ev)
+	if !ok || !action.IsTabAction() {
+		return false
+	}
+	for _, root := range u.widgets {
+		path := widgetPath(root, u.focused)
+		for i := len(path) - 1; i >= 0; i-- {
+			if nt, ok := path[i].(NavigationTarget); ok && nt.HandleNavigation(action) {
+				return true
+			}
+		}
+		if path != nil {
+			break
+		}
+	}
+	return false
+}
+
+// widgetPath returns the widgets from node down to target, or nil if
+// target is not in node's tree.
+func widgetPath(node, target Widget) []Widget {
+	if node == nil {
+		return nil
+	}
+	if node == target {
+		return []Widget{node}
+	}
+	var path []Widget
+	if cc, ok := node.(ChildContainer); ok {
+		cc.VisitChildren(func(child Widget) {
+			if path == nil {
+				path = widgetPath(child, target)
+			}
+		})
+	}
+	if path == nil {
+		return nil
+	}
+	return append([]Widget{node}, path...)
+}
+
 // HandleMouse routes mouse events for click-to-focus and optional capture drags.
 func (u *UIManager) HandleMouse(ev *tcell.EventMouse) bool {
 	u.mu.Lock()
 	defer u.mu.Unlock()
 
+	if u.logViewer != nil {
+		u.logViewerMouseLocked(ev)
+		return true
+	}
+	if u.inspector != nil {
+		u.inspectorMouseLocked(ev)
+		return true
+	}
+	defer u.logFocusLocked()
+
 	x, y := ev.Position()
 	buttons := ev.Buttons()
+	hoverChanged := u.updateHoverLocked(x, y)
+	if LogEnabled(LogDebug, LogMouse) {
+		under := "nothing"
+		if path := u.pathAtLocked(x, y); path != nil {
+			under = typeName(path[len(path)-1])
+		}
+		Debugf(LogMouse, "%d,%d buttons %#x over %s", x, y, buttons, under)
+	}
 	prevIsDown := u.capture != nil
 	nowDown := buttons&tcell.Button1 != 0
 
+	// A focused widget grabbing the pointer sees everything, with the cell
+	// it is over
+	if g, ok := u.findDeepestFocusedLocked().(PointerGrabber); ok && g.GrabsPointer() {
+		var cell Cell
+		if y >= 0 && y < len(u.buf) && x >= 0 && x < len(u.buf[y]) {
+			cell = u.buf[y][x]
+		}
+		g.HandleGrabbedMouse(ev, cell)
+		u.capture = nil
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
+
 	// Check if focused widget is modal - dismiss on click outside, route to modal on click inside
 	if u.focused != nil && nowDown && !prevIsDown {
 		if modal, ok := u.focused.(Modal); ok && modal.IsModal() {
@@ -707,6 +1113,25 @@ func (u *UIManager) HandleMouse(ev *tcell.EventMouse) bool {
 		}
 	}
 
+	// The status bar handles its own widgets and message actions
+	if !prevIsDown && u.statusBarMouseLocked(ev) {
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
+
+	// Right and middle clicks, unless a left drag is in progress
+	if !prevIsDown && (buttons&auxButtons != 0 || u.auxCapture != nil || u.auxHeld) {
+		if !u.auxMouseLocked(ev) {
+			return false
+		}
+		u.dirtyMu.Lock()
+		u.invalidateAllLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
+
 	// Start capture on press over a widget
 	if !prevIsDown && nowDown {
 		// Find the root container widget at this position
@@ -777,9 +1202,92 @@ func (u *UIManager) HandleMouse(ev *tcell.EventMouse) bool {
 		}
 	}
 
+	if hoverChanged {
+		u.dirtyMu.Lock()
+		u.requestRefreshLocked()
+		u.dirtyMu.Unlock()
+		return true
+	}
 	return false
 }
 
+// updateHoverLocked sends OnHoverLeave and OnHoverEnter to the HoverAware
+// widgets the pointer left and entered. Returns true if any changed.
+func (u *UIManager) updateHoverLocked(x, y int) bool {
+	var now []Widget
+	for _, w := range u.pathAtLocked(x, y) {
+		if _, ok := w.(HoverAware); ok && !IsDisabled(w) {
+			now = append(now, w)
+		}
+	}
+	changed := false
+	for i := len(u.hovered) - 1; i >= 0; i-- {
+		if !slices.Contains(now, u.hovered[i]) {
+			u.hovered[i].(HoverAware).OnHoverLeave()
+			changed = true
+		}
+	}
+	for _, w := range now {
+		if !slices.Contains(u.hovered, w) {
+			w.(HoverAware).OnHoverEnter()
+			changed = true
+		}
+	}
+	u.hovered = now
+	return changed
+}
+
+// auxButtons are the mouse buttons routed as aux clicks.
+const auxButtons = tcell.Button2 | tcell.Button3
+
+// auxMouseLocked routes a right or middle click. The press is offered as an
+// AuxClick to the widgets under the pointer, deepest first, and the rest of
+// a handled click is dropped. Otherwise the press goes raw to the root
+// widget like a left click, and the root receives the rest of the click up
+// to the release. Focus does not move.
+func (u *UIManager) auxMouseLocked(ev *tcell.EventMouse) bool {
+	x, y := ev.Position()
+	buttons := ev.Buttons()
+	if u.auxCapture != nil {
+		if mw, ok := u.auxCapture.(MouseAware); ok {
+			_ = mw.HandleMouse(ev)
+		}
+		if buttons&auxButtons == 0 {
+			u.auxCapture = nil
+		}
+		return true
+	}
+	if u.auxHeld {
+		if buttons&auxButtons == 0 {
+			u.auxHeld = false
+		}
+		return true
+	}
+	path := u.pathAtLocked(x, y)
+	if path == nil {
+		return false
+	}
+	click := AuxClick{Button: tcell.Button3, X: x, Y: y, Modifiers: ev.Modifiers()}
+	if buttons&tcell.Button2 != 0 {
+		click.Button = tcell.Button2
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if IsDisabled(path[i]) {
+			continue
+		}
+		if ac, ok := path[i].(AuxClickAware); ok && ac.HandleAuxClick(click) {
+			Debugf(LogMouse, "aux click %#x handled by %s", click.Button, typeName(path[i]))
+			u.auxHeld = true
+			return true
+		}
+	}
+	u.auxCapture = path[0]
+	if mw, ok := path[0].(MouseAware); ok {
+		_ = mw.HandleMouse(ev)
+	}
+	return true
+}
+
 // rootWidgetAtLocked finds the topmost root-level widget containing the point.
 // Unlike topmostAtLocked, this returns the root container, not the deepest child.
 func (u *UIManager) rootWidgetAtLocked(x, y int) Widget {
@@ -830,6 +1338,7 @@ func deepHit(w Widget, x, y int) Widget {
 // Invalidate marks a region for redraw.
 // Thread-safe.
 func (u *UIManager) Invalidate(r Rect) {
+	Debugf(LogInvalidate, "%d,%d %dx%d", r.X, r.Y, r.W, r.H)
 	u.dirtyMu.Lock()
 	defer u.dirtyMu.Unlock()
 
@@ -843,6 +1352,7 @@ func (u *UIManager) Invalidate(r Rect) {
 // InvalidateAll marks the whole surface for redraw.
 // Public version.
 func (u *UIManager) InvalidateAll() {
+	Debugf(LogInvalidate, "all")
 	u.dirtyMu.Lock()
 	defer u.dirtyMu.Unlock()
 	u.invalidateAllLocked()
@@ -914,13 +1424,40 @@ func (u *UIManager) Render() [][]Cell {
 	u.mu.Lock()
 	defer u.mu.Unlock()
 
+	start := Now()
+	cells := u.renderLocked()
+	if u.frameStats != nil {
+		u.frameStats.record(start, Now(), cells)
+		u.drawFrameStatsLocked()
+	}
+	return u.buf
+}
+
+// renderLocked draws the dirty regions, or the whole frame, into u.buf and
+// returns the number of cells redrawn. Called with u.mu held.
+func (u *UIManager) renderLocked() int {
 	u.ensureBufferLocked()
+	// Container base styles only need pushing down when they or the tree
+	// changed. A tree change may also add popup-owning widgets that haven't
+	// seen the surface size yet.
+	if gen := cascadeGen.Load(); gen != u.cascadeGen {
+		u.cascadeGen = gen
+		for _, root := range u.widgets {
+			CascadeStyles(root, tcell.StyleDefault)
+		}
+		u.propagateSurfaceLocked()
+	}
+
+	themeChanged := u.applyThemeChangeLocked()
 
 	u.dirtyMu.Lock()
 	// Copy dirty list to avoid holding it? No, we consume it.
 	dirtyCopy := u.dirty
 	u.dirty = nil // clear it
 	u.dirtyMu.Unlock()
+	if themeChanged || u.inspector != nil || u.logViewer != nil {
+		dirtyCopy = nil // Everything changed color, or a debug overlay is on top
+	}
 
 	// Get widgets sorted by z-index for correct draw order
 	sorted := u.sortedWidgetsLocked()
@@ -929,25 +1466,34 @@ func (u *UIManager) Render() [][]Cell {
 		// No specific dirty regions requested: compose full frame.
 		full := Rect{X: 0, Y: 0, W: u.W, H: u.H}
 		p := NewPainterWithGraphics(u.buf, full, u.graphicsProvider)
-		p.SetTime(float32(time.Since(u.animStart).Seconds()))
+		p.SetTime(u.animationTime())
 		p.Fill(full, ' ', u.bgStyle)
 		for _, w := range sorted {
 			w.Draw(p)
 		}
-		// Draw modal overlays on top (unclipped) - handles ColorPicker expansion etc.
+		// Draw modal widgets and popups on top of the content
 		u.drawModalOverlaysLocked(p)
+		u.drawOverlaysLocked()
 		// Draw status bar last (on top)
 		u.drawStatusBarLocked(p)
+		if u.inspector != nil {
+			u.drawInspectorLocked()
+		}
+		if u.logViewer != nil {
+			u.drawLogViewerLocked()
+		}
 		// If any widget drew animated colors, schedule another refresh
 		// so the animation keeps ticking.
-		if p.HasAnimations() && !u.ClientSideAnimations {
+		if p.HasAnimations() && !u.ClientSideAnimations && !ReduceMotion() {
 			u.scheduleAnimationRefreshLocked()
 		}
-		return u.buf
+		u.stopBlinkLocked()
+		return u.W * u.H
 	}
 
 	// Merge dirty rects to reduce redraw area, but keep multiple clips
 	merged := mergeRects(dirtyCopy)
+	cells := 0
 	for _, clip := range merged {
 		// Clip against surface bounds
 		if clip.X < 0 {
@@ -967,9 +1513,10 @@ func (u *UIManager) Render() [][]Cell {
 		if clip.W <= 0 || clip.H <= 0 {
 			continue
 		}
+		cells += clip.W * clip.H
 
 		p := NewPainterWithGraphics(u.buf, clip, u.graphicsProvider)
-		p.SetTime(float32(time.Since(u.animStart).Seconds()))
+		p.SetTime(u.animationTime())
 		// Clear dirty region
 		p.Fill(clip, ' ', u.bgStyle)
 		// Draw widgets intersecting clip
@@ -981,19 +1528,46 @@ func (u *UIManager) Render() [][]Cell {
 				w.Draw(p)
 			}
 		}
-		// Draw modal overlays on top (unclipped)
+		// Draw modal widgets and popups on top (unclipped)
 		u.drawModalOverlaysLocked(p)
+		u.drawOverlaysLocked()
 		// Draw status bar if it intersects clip
 		u.drawStatusBarLocked(p)
-		if p.HasAnimations() && !u.ClientSideAnimations {
+		if p.HasAnimations() && !u.ClientSideAnimations && !ReduceMotion() {
 			u.scheduleAnimationRefreshLocked()
 		}
 	}
-	return u.buf
+	u.stopBlinkLocked()
+	return cells
+}
+
+// animationTime returns the time animated colors are drawn at. With
+// reduced motion it stays at zero, so they render as a still frame.
+func (u *UIManager) animationTime() float32 {
+	if ReduceMotion() {
+		return 0
+	}
+	return float32(Now().Sub(u.animStart).Seconds())
 }
 
-// drawModalOverlaysLocked finds and redraws any modal widgets as overlays.
-// This ensures expanded ColorPickers etc. are fully visible even inside ScrollPanes.
+// stopBlinkLocked clears the blink attribute from the frame when reduced
+// motion is enabled. Must be called with u.mu held.
+func (u *UIManager) stopBlinkLocked() {
+	if !ReduceMotion() {
+		return
+	}
+	for _, row := range u.buf {
+		for x := range row {
+			if _, _, attrs := row[x].Style.Decompose(); attrs&tcell.AttrBlink != 0 {
+				row[x].Style = row[x].Style.Blink(false)
+			}
+		}
+	}
+}
+
+// drawModalOverlaysLocked finds and redraws any modal widgets as overlays,
+// so they are fully visible even inside ScrollPanes. Widgets with an
+// Overlay are drawn by drawOverlaysLocked instead.
 // Must be called with u.mu held.
 func (u *UIManager) drawModalOverlaysLocked(p *Painter) {
 	// Create an unclipped painter for overlay drawing
@@ -1009,7 +1583,8 @@ func (u *UIManager) drawModalOverlaysLocked(p *Painter) {
 // drawModalWidgetsRecursive recursively finds and draws modal widgets.
 func (u *UIManager) drawModalWidgetsRecursive(w Widget, p *Painter) {
 	// Check if this widget is modal
-	if modal, ok := w.(Modal); ok && modal.IsModal() {
+	_, hasOverlay := w.(Overlay)
+	if modal, ok := w.(Modal); ok && modal.IsModal() && !hasOverlay {
 		// Redraw the modal widget with unclipped painter
 		w.Draw(p)
 	}
@@ -1022,6 +1597,28 @@ func (u *UIManager) drawModalWidgetsRecursive(w Widget, p *Painter) {
 	}
 }
 
+// statusBarMouseLocked forwards mouse events over the status bar to it, and
+// plain motion elsewhere so it can clear hover state. Returns true if the
+// status bar handled the event.
+// Must be called with u.mu held.
+func (u *UIManager) statusBarMouseLocked(ev *tcell.EventMouse) bool {
+	if u.statusBar == nil || !u.statusBarEnabled {
+		return false
+	}
+	mw, ok := u.statusBar.(MouseAware)
+	if !ok {
+		return false
+	}
+	x, y := ev.Position()
+	if !u.statusBar.HitTest(x, y) {
+		if ev.Buttons() == tcell.ButtonNone {
+			mw.HandleMouse(ev)
+		}
+		return false
+	}
+	return mw.HandleMouse(ev)
+}
+
 // drawStatusBarLocked draws the status bar if enabled.
 // Must be called with u.mu held.
 func (u *UIManager) drawStatusBarLocked(p *Painter) {
@@ -1,6 +1,7 @@
 package core_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
@@ -9,6 +10,7 @@ import (
 	"github.com/framegrace/texelui/core"
 	"github.com/framegrace/texelui/primitives"
 	"github.com/framegrace/texelui/scroll"
+	"github.com/framegrace/texelui/theme"
 	"github.com/framegrace/texelui/widgets"
 )
 
@@ -456,3 +458,548 @@ func TestUIManagerEnterWithAdvanceFocusOnEnter(t *testing.T) {
 		t.Error("Widget should not be modal after Enter")
 	}
 }
+
+func TestUIManagerViKeymapDrivesList(t *testing.T) {
+	if err := core.SetKeymap("vi"); err != nil {
+		t.Fatal(err)
+	}
+	defer core.SetKeymap(core.DefaultKeymap)
+
+	ui := core.NewUIManager()
+	ui.Resize(20, 5)
+	list := primitives.NewScrollableList(0, 0, 20, 5)
+	list.SetItems([]primitives.ListItem{{Text: "alpha"}, {Text: "beta"}, {Text: "gamma"}})
+	ui.AddWidget(list)
+	ui.Focus(list)
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone))
+	if list.SelectedIdx != 1 {
+		t.Errorf("j should move down, got %d", list.SelectedIdx)
+	}
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'G', tcell.ModNone))
+	if list.SelectedIdx != 2 {
+		t.Errorf("G should jump to the end, got %d", list.SelectedIdx)
+	}
+
+	// '/' starts a search; typed letters go to the query, not navigation
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, '/', tcell.ModNone))
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone))
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'l', tcell.ModNone))
+	if list.SelectedIdx != 0 {
+		t.Errorf("search for \"al\" should select alpha, got %d", list.SelectedIdx)
+	}
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone))
+	if list.SelectedIdx != 1 {
+		t.Errorf("j should navigate again after the search ends, got %d", list.SelectedIdx)
+	}
+}
+
+func TestUIManagerViKeymapLeavesEditingTextAlone(t *testing.T) {
+	if err := core.SetKeymap("vi"); err != nil {
+		t.Fatal(err)
+	}
+	defer core.SetKeymap(core.DefaultKeymap)
+
+	ui := core.NewUIManager()
+	ui.Resize(20, 5)
+	ta := widgets.NewTextArea()
+	ta.Resize(20, 5)
+	ui.AddWidget(ta)
+	ui.Focus(ta)
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)) // enter edit mode
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone))
+	if ta.Text() != "j" {
+		t.Errorf("j should be typed while editing, got %q", ta.Text())
+	}
+	if err := core.SetKeymap("nope"); err == nil {
+		t.Error("SetKeymap should reject unknown profiles")
+	}
+}
+
+func TestUIManagerTabShortcutsReachTabLayoutFromContent(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 10)
+	tl := widgets.NewTabLayout([]primitives.TabItem{{Label: "A"}, {Label: "B"}, {Label: "C"}})
+	tl.Resize(40, 10)
+	inA, inB := widgets.NewInput(), widgets.NewInput()
+	tl.SetTabContent(0, inA)
+	tl.SetTabContent(1, inB)
+	tl.SetTabContent(2, widgets.NewInput())
+	ui.AddWidget(tl)
+	ui.Focus(tl)
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)) // into content
+	if !inA.IsFocused() {
+		t.Fatal("expected focus in tab A content")
+	}
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModCtrl))
+	if tl.ActiveIndex() != 1 || !inB.IsFocused() || inA.IsFocused() {
+		t.Errorf("Ctrl+Tab should switch to B and focus its content, active=%d", tl.ActiveIndex())
+	}
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModCtrl|tcell.ModShift))
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModCtrl|tcell.ModShift))
+	if tl.ActiveIndex() != 2 {
+		t.Errorf("Ctrl+Shift+Tab should wrap backwards to C, got %d", tl.ActiveIndex())
+	}
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, '2', tcell.ModAlt))
+	if tl.ActiveIndex() != 1 || inB.Text != "" {
+		t.Errorf("Alt+2 should jump to B without typing, active=%d text=%q", tl.ActiveIndex(), inB.Text)
+	}
+}
+
+// ctrlTabWidget consumes Ctrl+Tab itself, like an editor cycling buffers.
+type ctrlTabWidget struct {
+	core.BaseWidget
+	presses int
+}
+
+func (c *ctrlTabWidget) Draw(p *core.Painter) {}
+
+func (c *ctrlTabWidget) HandleKey(ev *tcell.EventKey) bool {
+	if ev.Key() == tcell.KeyTab && ev.Modifiers() == tcell.ModCtrl {
+		c.presses++
+		return true
+	}
+	return false
+}
+
+func TestUIManagerFocusedWidgetKeepsTabShortcuts(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 10)
+	tl := widgets.NewTabLayout([]primitives.TabItem{{Label: "A"}, {Label: "B"}})
+	tl.Resize(40, 10)
+	editor := &ctrlTabWidget{}
+	editor.SetFocusable(true)
+	tl.SetTabContent(0, editor)
+	tl.SetTabContent(1, widgets.NewInput())
+	ui.AddWidget(tl)
+	ui.Focus(tl)
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModNone)) // into content
+	if !editor.IsFocused() {
+		t.Fatal("expected focus in tab A content")
+	}
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyTab, 0, tcell.ModCtrl))
+	if editor.presses != 1 || tl.ActiveIndex() != 0 {
+		t.Errorf("Ctrl+Tab should reach the focused widget first, presses=%d active=%d", editor.presses, tl.ActiveIndex())
+	}
+
+	// Keys the widget declines still switch tabs
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, '2', tcell.ModAlt))
+	if tl.ActiveIndex() != 1 {
+		t.Errorf("Alt+2 declined by the widget should switch to B, active=%d", tl.ActiveIndex())
+	}
+}
+
+func TestUIManagerRestylesWidgetsOnThemeChange(t *testing.T) {
+	defer theme.Set("mocha")
+	if err := theme.Set("mocha"); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := core.NewUIManager()
+	ui.Resize(20, 4)
+	pane := widgets.NewPane()
+	lbl := widgets.NewLabel("Hello")
+	pane.AddChild(lbl)
+	ui.AddWidget(pane)
+	refresh := make(chan bool, 1)
+	ui.SetRefreshNotifier(refresh)
+	ui.Render()
+
+	before := lbl.Style.FG.Resolve(color.ColorContext{})
+	if err := theme.Set("latte"); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-refresh:
+	default:
+		t.Error("theme change should request a refresh")
+	}
+
+	buf := ui.Render()
+	after := lbl.Style.FG.Resolve(color.ColorContext{})
+	want := theme.Get().GetSemanticColor("text.primary")
+	if after == before || after != want {
+		t.Errorf("label not restyled: before=%v after=%v want=%v", before, after, want)
+	}
+	if fg, _, _ := buf[0][0].Style.Decompose(); fg != want {
+		t.Errorf("frame not redrawn with new colors: fg=%v want=%v", fg, want)
+	}
+}
+
+func TestUIManagerCloseStopsChangeListeners(t *testing.T) {
+	defer core.SetReduceMotion(false)
+
+	ui := core.NewUIManager()
+	refresh := make(chan bool, 1)
+	ui.SetRefreshNotifier(refresh)
+	ui.Close()
+
+	core.SetReduceMotion(true)
+	select {
+	case <-refresh:
+		t.Error("a closed UIManager should not be refreshed on changes")
+	default:
+	}
+}
+
+func TestUIManagerThemeChangeKeepsAppStyles(t *testing.T) {
+	defer theme.Set("mocha")
+	if err := theme.Set("mocha"); err != nil {
+		t.Fatal(err)
+	}
+
+	ui := core.NewUIManager()
+	ui.Resize(20, 4)
+	pane := widgets.NewPane()
+	lbl := widgets.NewLabel("Hello")
+	red := tcell.NewRGBColor(0xff, 0, 0)
+	lbl.Style.FG = color.Solid(red)
+	pane.AddChild(lbl)
+	ui.AddWidget(pane)
+	ui.SetRefreshNotifier(make(chan bool, 1))
+	ui.Render()
+
+	if err := theme.Set("latte"); err != nil {
+		t.Fatal(err)
+	}
+	ui.Render()
+
+	if fg := lbl.Style.FG.Resolve(color.ColorContext{}); fg != red {
+		t.Errorf("app FG replaced by theme switch: got %v, want %v", fg, red)
+	}
+	if bg, want := lbl.Style.BG.Resolve(color.ColorContext{}), theme.Get().GetSemanticColor("bg.surface"); bg != want {
+		t.Errorf("default BG not restyled: got %v, want %v", bg, want)
+	}
+}
+
+type motionWidget struct {
+	core.BaseWidget
+}
+
+func (m *motionWidget) Draw(p *core.Painter) {
+	pulse := color.AnimatedFunc(func(ctx color.ColorContext) tcell.Color {
+		return tcell.NewRGBColor(int32(ctx.T*1000)%256, 0, 0)
+	})
+	p.SetDynamicCell(0, 0, 'A', color.DynamicStyle{FG: pulse, BG: color.Solid(tcell.ColorBlack)})
+	p.SetCell(1, 0, 'B', tcell.StyleDefault.Blink(true))
+}
+
+func TestUIManagerReduceMotion(t *testing.T) {
+	defer core.SetReduceMotion(false)
+
+	ui := core.NewUIManager()
+	ui.Resize(4, 1)
+	w := &motionWidget{}
+	w.Resize(4, 1)
+	ui.AddWidget(w)
+	refresh := make(chan bool, 1)
+	ui.SetRefreshNotifier(refresh)
+
+	core.SetReduceMotion(true)
+	select {
+	case <-refresh:
+	default:
+		t.Error("toggling reduce motion should request a refresh")
+	}
+
+	buf := ui.Render()
+	if fg, _, _ := buf[0][0].Style.Decompose(); fg != tcell.NewRGBColor(0, 0, 0) {
+		t.Errorf("animated color should hold its first frame, got %v", fg)
+	}
+	if _, _, attrs := buf[0][1].Style.Decompose(); attrs&tcell.AttrBlink != 0 {
+		t.Error("blink should be cleared with reduced motion")
+	}
+
+	core.SetReduceMotion(false)
+	buf = ui.Render()
+	if _, _, attrs := buf[0][1].Style.Decompose(); attrs&tcell.AttrBlink == 0 {
+		t.Error("blink should be kept without reduced motion")
+	}
+}
+
+func TestUIManagerCascadesContainerStyle(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(30, 6)
+	blue := tcell.NewRGBColor(0, 0, 0x80)
+	red := tcell.NewRGBColor(0x80, 0, 0)
+
+	pane := widgets.NewPane()
+	pane.SetBaseStyle(tcell.StyleDefault.Background(blue))
+	form := widgets.NewForm()
+	input := widgets.NewInput()
+	form.AddField("Name", input)
+	check := widgets.NewCheckbox("Opt")
+	check.SetBaseStyle(tcell.StyleDefault.Background(red))
+	form.AddField("", check)
+	pane.AddChild(form)
+	pane.Resize(30, 6)
+	form.Resize(30, 6)
+	ui.AddWidget(pane)
+
+	buf := ui.Render()
+	bgAt := func(x, y int) tcell.Color {
+		_, bg, _ := buf[y][x].Style.Decompose()
+		return bg
+	}
+
+	if got := bgAt(0, 0); got != blue {
+		t.Errorf("form label background = %v, want the pane's %v", got, blue)
+	}
+	if got := bgAt(29, 5); got != blue {
+		t.Errorf("form background = %v, want the pane's %v", got, blue)
+	}
+	ix, iy := input.Position()
+	if got := bgAt(ix, iy); got == blue {
+		t.Error("input fields should keep their own background")
+	}
+	cx, cy := check.Position()
+	if got := bgAt(cx, cy); got != red {
+		t.Errorf("checkbox background = %v, want its own %v", got, red)
+	}
+	if fg, _, _ := check.EffectiveStyle(tcell.StyleDefault.Foreground(tcell.ColorWhite)).Decompose(); fg != tcell.ColorWhite {
+		t.Errorf("unset foreground should be kept, got %v", fg)
+	}
+}
+
+func TestUIManagerCascadeKeepsExplicitStyles(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(20, 3)
+	blue := tcell.NewRGBColor(0, 0, 0x80)
+	green := tcell.NewRGBColor(0, 0x80, 0)
+
+	pane := widgets.NewPane()
+	pane.Resize(20, 3)
+	own := widgets.NewLabel("own")
+	own.Style = color.StyleFrom(tcell.StyleDefault.Background(green))
+	pane.AddChild(own)
+	ui.AddWidget(pane)
+	pane.SetBaseStyle(tcell.StyleDefault.Background(blue))
+
+	buf := ui.Render()
+	if _, bg, _ := buf[0][0].Style.Decompose(); bg != green {
+		t.Errorf("label background = %v, want its own %v", bg, green)
+	}
+
+	// A child added later takes on the pane's colors on the next frame
+	late := widgets.NewLabel("late")
+	late.SetPosition(0, 1)
+	pane.AddChild(late)
+	ui.InvalidateAll()
+	buf = ui.Render()
+	if _, bg, _ := buf[1][0].Style.Decompose(); bg != blue {
+		t.Errorf("late label background = %v, want the pane's %v", bg, blue)
+	}
+}
+
+type memClipboard struct{ data []byte }
+
+func (c *memClipboard) SetClipboard(mime string, data []byte) { c.data = data }
+func (c *memClipboard) GetClipboard() (string, []byte, bool) {
+	return "text/plain", c.data, c.data != nil
+}
+
+func TestUIManagerPassesClipboardToWidgets(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 20)
+	pane := widgets.NewPane()
+	picker := widgets.NewColorPicker(widgets.ColorPickerConfig{EnableRGB: true})
+	picker.SetValue("#102030")
+	pane.AddChild(picker)
+	ui.AddWidget(pane)
+
+	// Set after the tree was added: passed down right away
+	clip := &memClipboard{}
+	ui.SetClipboardService(clip)
+
+	picker.HandleKey(tcell.NewEventKey(tcell.KeyCtrlC, 0, 0))
+	if got := string(clip.data); got != "#102030" {
+		t.Errorf("clipboard = %q, want #102030", got)
+	}
+
+	// Tree added after the service was set: passed down by AddWidget
+	late := widgets.NewColorPicker(widgets.ColorPickerConfig{EnableRGB: true})
+	late.SetValue("#405060")
+	ui.AddWidget(late)
+
+	late.HandleKey(tcell.NewEventKey(tcell.KeyCtrlC, 0, 0))
+	if got := string(clip.data); got != "#405060" {
+		t.Errorf("clipboard = %q, want #405060", got)
+	}
+}
+
+// surfaceWidget records the surface sizes it is told about.
+type surfaceWidget struct {
+	core.BaseWidget
+	sizes [][2]int
+}
+
+func (s *surfaceWidget) Draw(p *core.Painter) {}
+
+func (s *surfaceWidget) SetSurfaceSize(w, h int) { s.sizes = append(s.sizes, [2]int{w, h}) }
+
+func TestUIManagerPropagatesSurfaceOnChange(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 20)
+	top := &surfaceWidget{}
+	ui.AddWidget(top)
+	if len(top.sizes) != 1 || top.sizes[0] != [2]int{40, 20} {
+		t.Fatalf("AddWidget should pass the surface size, got %v", top.sizes)
+	}
+
+	// Frames without changes don't walk the tree again
+	ui.Render()
+	n := len(top.sizes)
+	ui.Render()
+	ui.Render()
+	if len(top.sizes) != n {
+		t.Errorf("surface size re-sent on unchanged frames: %v", top.sizes)
+	}
+
+	ui.Resize(50, 10)
+	if last := top.sizes[len(top.sizes)-1]; last != [2]int{50, 10} {
+		t.Errorf("Resize should pass the new size, got %v", last)
+	}
+
+	// Children added to a container later get it on the next frame
+	pane := widgets.NewPane()
+	ui.AddWidget(pane)
+	ui.Render()
+	late := &surfaceWidget{}
+	pane.AddChild(late)
+	ui.Render()
+	if len(late.sizes) == 0 || late.sizes[len(late.sizes)-1] != [2]int{50, 10} {
+		t.Errorf("late child should get the surface size, got %v", late.sizes)
+	}
+}
+
+type solidWidget struct {
+	core.BaseWidget
+	bg tcell.Color
+}
+
+func (s *solidWidget) Draw(p *core.Painter) {
+	p.Fill(s.Rect, ' ', tcell.StyleDefault.Background(s.bg))
+}
+
+func TestUIManagerEyedropperSamplesScreen(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(60, 25)
+	teal := tcell.NewRGBColor(0x10, 0x80, 0x80)
+	swatch := &solidWidget{bg: teal}
+	swatch.SetPosition(40, 0)
+	swatch.Resize(10, 3)
+	picker := widgets.NewColorPicker(widgets.ColorPickerConfig{EnableOKLCH: true})
+	picker.SetValue("#ff0000")
+	var got widgets.ColorPickerResult
+	picker.OnChange = func(r widgets.ColorPickerResult) { got = r }
+	ui.AddWidget(swatch)
+	ui.AddWidget(picker)
+	ui.Focus(picker)
+	picker.Expand()
+	ui.Render()
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyCtrlE, 0, 0))
+	if !picker.IsSampling() {
+		t.Fatal("Ctrl+E should start the eyedropper")
+	}
+	// Hovering previews; Esc cancels
+	ui.HandleMouse(tcell.NewEventMouse(45, 1, tcell.ButtonNone, 0))
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyEsc, 0, 0))
+	if picker.IsSampling() || !picker.IsExpanded() {
+		t.Fatal("Esc should cancel sampling and keep the picker open")
+	}
+
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyCtrlE, 0, 0))
+	ui.HandleMouse(tcell.NewEventMouse(45, 1, tcell.Button1, 0))
+	if picker.IsExpanded() || got.Color != teal || got.Source != "#108080" {
+		t.Errorf("click should commit the sampled color, got %+v", got)
+	}
+}
+
+func TestUIManagerRoutesRightClickToList(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 10)
+	pane := widgets.NewPane()
+	ui.SetRootWidget(pane)
+	list := primitives.NewScrollableList(0, 0, 20, 5)
+	list.SetItems([]primitives.ListItem{{Text: "alpha"}, {Text: "beta"}, {Text: "gamma"}})
+	pane.AddChild(list)
+	ui.Render()
+
+	menuIdx, menus := -1, 0
+	list.OnContextMenu = func(idx, x, y int) { menuIdx, menus = idx, menus+1 }
+	rect := list.Rect
+	ui.HandleMouse(tcell.NewEventMouse(rect.X+1, rect.Y+1, tcell.Button2, 0))
+	// Moving with the button held belongs to the same click
+	ui.HandleMouse(tcell.NewEventMouse(rect.X+1, rect.Y+2, tcell.Button2, 0))
+	ui.HandleMouse(tcell.NewEventMouse(rect.X+1, rect.Y+2, tcell.ButtonNone, 0))
+	if menuIdx != 1 || menus != 1 {
+		t.Fatalf("right click should open the menu once for item 1, got %d after %d calls", menuIdx, menus)
+	}
+	if list.SelectedIdx != 1 {
+		t.Errorf("the rest of a handled click should not reach the list, selected=%d", list.SelectedIdx)
+	}
+	if list.IsFocused() {
+		t.Error("right click should not move focus")
+	}
+}
+
+func TestUIManagerMiddleClickPastesIntoTextArea(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 10)
+	ta := widgets.NewTextArea()
+	ta.SetPosition(0, 0)
+	ta.Resize(30, 4)
+	ta.SetText("ab")
+	ui.AddWidget(ta)
+	ui.SetClipboardService(&memClipboard{data: []byte("XY")})
+	ui.Render()
+
+	ui.HandleMouse(tcell.NewEventMouse(1, 0, tcell.Button3, 0))
+	ui.HandleMouse(tcell.NewEventMouse(1, 0, tcell.ButtonNone, 0))
+	if got := ta.Text(); got != "aXYb" {
+		t.Errorf("text = %q, want aXYb", got)
+	}
+}
+
+type hoverWidget struct {
+	core.BaseWidget
+	events []string
+	name   string
+}
+
+func (h *hoverWidget) Draw(p *core.Painter) {}
+func (h *hoverWidget) OnHoverEnter()        { h.events = append(h.events, "enter "+h.name) }
+func (h *hoverWidget) OnHoverLeave()        { h.events = append(h.events, "leave "+h.name) }
+
+func TestUIManagerHoverEnterLeave(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 10)
+	a := &hoverWidget{name: "a"}
+	a.SetPosition(0, 0)
+	a.Resize(10, 2)
+	b := &hoverWidget{name: "b"}
+	b.SetPosition(20, 0)
+	b.Resize(10, 2)
+	ui.AddWidget(a)
+	ui.AddWidget(b)
+
+	if !ui.HandleMouse(tcell.NewEventMouse(1, 1, tcell.ButtonNone, 0)) {
+		t.Error("entering a widget should request a redraw")
+	}
+	ui.HandleMouse(tcell.NewEventMouse(2, 1, tcell.ButtonNone, 0))
+	ui.HandleMouse(tcell.NewEventMouse(21, 1, tcell.ButtonNone, 0))
+	ui.HandleMouse(tcell.NewEventMouse(35, 1, tcell.ButtonNone, 0))
+	if got := strings.Join(a.events, ","); got != "enter a,leave a" {
+		t.Errorf("a events = %q", got)
+	}
+	if got := strings.Join(b.events, ","); got != "enter b,leave b" {
+		t.Errorf("b events = %q", got)
+	}
+}
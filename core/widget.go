@@ -1,6 +1,10 @@
 package core
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/color"
+)
 
 // Widget is the minimal contract for drawable UI elements.
 type Widget interface {
@@ -29,11 +33,19 @@ type BaseWidget struct {
 	Transparent bool // When true, widget skips background fill (parent shows through)
 	focused     bool
 	focusable   bool
+	disabled    bool
 	zIndex      int // z-ordering: higher values draw on top
 	helpText    string
+	id          string
 	// Optional focus styling: if enabled, widgets may use FocusedStyle when focused.
 	focusStyleEnabled bool
 	focusedStyle      tcell.Style
+	// Style cascade: colors set on this widget and those inherited from its
+	// container (see cascade.go).
+	baseStyle      tcell.Style
+	inheritedStyle tcell.Style
+	// Theme defaults last given to each style field (see SetThemeStyle)
+	themeDefaults map[*color.DynamicStyle]color.DynamicStyle
 }
 
 func (b *BaseWidget) SetPosition(x, y int) { b.Rect.X, b.Rect.Y = x, y }
@@ -48,10 +60,10 @@ func (b *BaseWidget) Resize(w, h int) {
 	b.Rect.W, b.Rect.H = w, h
 }
 func (b *BaseWidget) Size() (int, int)    { return b.Rect.W, b.Rect.H }
-func (b *BaseWidget) Focusable() bool     { return b.focusable }
+func (b *BaseWidget) Focusable() bool     { return b.focusable && !b.disabled }
 func (b *BaseWidget) SetFocusable(f bool) { b.focusable = f }
 func (b *BaseWidget) Focus() {
-    if b.focusable {
+    if b.Focusable() {
         b.focused = true
     }
 }
@@ -63,6 +75,15 @@ func (b *BaseWidget) ZIndex() int                       { return b.zIndex }
 func (b *BaseWidget) SetZIndex(z int)                   { b.zIndex = z }
 func (b *BaseWidget) HelpText() string                  { return b.helpText }
 func (b *BaseWidget) SetHelpText(text string)            { b.helpText = text }
+func (b *BaseWidget) ID() string                        { return b.id }
+func (b *BaseWidget) SetID(id string)                   { b.id = id }
+
+// SetDisabled implements Disableable. Disabling does not blur a focused
+// widget; callers move the focus elsewhere.
+func (b *BaseWidget) SetDisabled(disabled bool) { b.disabled = disabled }
+
+// IsDisabled implements Disableable.
+func (b *BaseWidget) IsDisabled() bool { return b.disabled }
 
 // SetFocusedStyle enables or disables focused styling and sets the focused style value.
 func (b *BaseWidget) SetFocusedStyle(style tcell.Style, enabled bool) {
@@ -70,9 +91,11 @@ func (b *BaseWidget) SetFocusedStyle(style tcell.Style, enabled bool) {
     b.focusStyleEnabled = enabled
 }
 
-// EffectiveStyle returns the style to use given a base style, applying focused style
+// EffectiveStyle returns the style to use given a base style, taking the colors
+// cascaded from containers (see SetBaseStyle) and applying focused style
 // if the widget is focused and focus styling is enabled.
 func (b *BaseWidget) EffectiveStyle(base tcell.Style) tcell.Style {
+    base = b.cascadeColors(base)
     if b.focused && b.focusStyleEnabled {
         // Merge: use focused style's FG/BG but preserve other attributes from base
         fFG, fBG, fAttr := b.focusedStyle.Decompose()
@@ -83,6 +106,20 @@ func (b *BaseWidget) EffectiveStyle(base tcell.Style) tcell.Style {
     return base
 }
 
+// Disableable widgets can be disabled; BaseWidget implements it. A disabled
+// widget is not focusable, so containers route neither keys nor clicks to
+// it, and they draw it muted.
+type Disableable interface {
+	SetDisabled(disabled bool)
+	IsDisabled() bool
+}
+
+// IsDisabled reports whether w is disabled.
+func IsDisabled(w Widget) bool {
+	d, ok := w.(Disableable)
+	return ok && d.IsDisabled()
+}
+
 // MouseAware widgets can consume mouse events directly.
 type MouseAware interface {
 	HandleMouse(ev *tcell.EventMouse) bool
@@ -93,6 +130,15 @@ type InvalidationAware interface {
 	SetInvalidator(func(Rect))
 }
 
+// SurfaceAware widgets receive the size of the UIManager content surface
+// (excluding the status bar). Widgets that open popups (dropdowns, pickers)
+// use it to decide whether to expand downward, flip upward, or shrink.
+// The size is passed when the widget is added and again whenever the
+// surface or the widget tree changes.
+type SurfaceAware interface {
+	SetSurfaceSize(w, h int)
+}
+
 // ChildContainer allows recursive operations over widget trees without
 // depending on concrete widget packages.
 type ChildContainer interface {
@@ -119,6 +165,16 @@ type Modal interface {
 	DismissModal()
 }
 
+// PointerGrabber is an optional interface for focused widgets that take
+// over the mouse for the whole UI, e.g. an eyedropper sampling colors.
+// While GrabsPointer() returns true, every mouse event goes to
+// HandleGrabbedMouse along with the rendered cell under the pointer, before
+// any other routing.
+type PointerGrabber interface {
+	GrabsPointer() bool
+	HandleGrabbedMouse(ev *tcell.EventMouse, cell Cell)
+}
+
 // Expandable is an optional interface for widgets that can expand beyond their
 // normal layout size (e.g., dropdown menus, color pickers). When IsExpanded()
 // returns true, parent containers should skip resizing this widget and let it
@@ -161,6 +217,24 @@ type FocusObserver interface {
 	OnFocusChanged(focused Widget)
 }
 
+// PasteAware is implemented by widgets that take pasted text in one piece,
+// such as text inputs. UIManager hands a paste to the focused widget
+// through HandlePaste when it implements it, instead of typing the text key
+// by key. Line endings are normalized to "\n".
+type PasteAware interface {
+	HandlePaste(text string) bool
+}
+
+// HoverAware is implemented by widgets that react to the pointer entering
+// and leaving them, e.g. to highlight. UIManager tracks the widgets under
+// the pointer on every mouse event and calls OnHoverEnter/OnHoverLeave as
+// they change: a widget and its ancestors are hovered together, entered
+// outermost first and left innermost first. Disabled widgets are skipped.
+type HoverAware interface {
+	OnHoverEnter()
+	OnHoverLeave()
+}
+
 // HelpTextProvider is implemented by widgets that provide hover help text.
 // BaseWidget implements this by default, so all widgets support it.
 // Containers like StatusBar can check for this on mouse hover and display
@@ -169,6 +243,21 @@ type HelpTextProvider interface {
 	HelpText() string
 }
 
+// Identifiable is implemented by widgets that carry an id naming them in
+// tree dumps and the inspector, such as the widgets of a texelui spec.
+// BaseWidget implements it; the id is empty unless set with SetID.
+type Identifiable interface {
+	ID() string
+}
+
+// WidgetID returns the id of w, or "" if it has none.
+func WidgetID(w Widget) string {
+	if i, ok := w.(Identifiable); ok {
+		return i.ID()
+	}
+	return ""
+}
+
 // FocusCycleBlocker is implemented by widgets that may want to prevent
 // automatic focus cycling after Enter even when they handle the key.
 // This is useful for widgets like editable ComboBox that need to validate
@@ -178,3 +267,20 @@ type FocusCycleBlocker interface {
 	// UIManager checks this after a widget handles Enter before cycling focus.
 	ShouldBlockFocusCycle() bool
 }
+
+// FallbackKeyHandler is implemented by widgets that act on keys nobody else
+// handled, such as the StatusBar opening its command prompt on ':'.
+// UIManager offers such keys to the status bar last.
+type FallbackKeyHandler interface {
+	HandleUnhandledKey(ev *tcell.EventKey) bool
+}
+
+// ThemeAware is implemented by widgets that resolve theme colors ahead of
+// drawing (typically in their constructor). UIManager calls OnThemeChanged
+// on every widget in the tree after the theme changes, before redrawing.
+// Widgets resolve their style fields with BaseWidget.SetThemeStyle, so on
+// a switch only the colors still at the old theme's defaults change and
+// the ones the app set are kept.
+type ThemeAware interface {
+	OnThemeChanged()
+}
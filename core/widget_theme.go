@@ -9,6 +9,7 @@ package core
 
 import (
 	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/theme"
 )
 
@@ -127,3 +128,37 @@ func (c WidgetColors) BorderStyle() tcell.Style {
 func (c WidgetColors) FocusedBorderStyle() tcell.Style {
 	return tcell.StyleDefault.Background(c.SurfaceBg).Foreground(c.BorderActive)
 }
+
+// SetThemeStyle sets *field, one of the widget's style fields, to def, the
+// default the current theme gives it. ThemeAware widgets call it again from
+// OnThemeChanged with the new theme's default: then only the colors and
+// attributes still equal to the previous default are replaced, so what the
+// app set on the field survives a theme switch.
+func (b *BaseWidget) SetThemeStyle(field *color.DynamicStyle, def color.DynamicStyle) {
+	if prev, ok := b.themeDefaults[field]; ok {
+		if sameDynamicColor(field.FG, prev.FG) {
+			field.FG = def.FG
+		}
+		if sameDynamicColor(field.BG, prev.BG) {
+			field.BG = def.BG
+		}
+		if field.Attrs == prev.Attrs {
+			field.Attrs = def.Attrs
+		}
+	} else {
+		*field = def
+	}
+	if b.themeDefaults == nil {
+		b.themeDefaults = make(map[*color.DynamicStyle]color.DynamicStyle)
+	}
+	b.themeDefaults[field] = def
+}
+
+// sameDynamicColor reports whether a and b are both unset or the same
+// static color. Animated and computed colors never match.
+func sameDynamicColor(a, b color.DynamicColor) bool {
+	if a.IsZero() || b.IsZero() {
+		return a.IsZero() && b.IsZero()
+	}
+	return a.IsStatic() && b.IsStatic() && a.Resolve(color.ColorContext{}) == b.Resolve(color.ColorContext{})
+}
@@ -56,6 +56,7 @@ func NewExpandableContainer(x, y, collapsedWidth, collapsedHeight int) *Expandab
 func (ec *ExpandableContainer) SetCollapsedChild(w core.Widget) {
 	ec.collapsedChild = w
 	if w != nil {
+		core.InvalidateCascade()
 		w.SetPosition(ec.Rect.X, ec.Rect.Y)
 		w.Resize(ec.Rect.W, ec.Rect.H)
 		if ia, ok := w.(core.InvalidationAware); ok {
@@ -68,6 +69,7 @@ func (ec *ExpandableContainer) SetCollapsedChild(w core.Widget) {
 func (ec *ExpandableContainer) SetExpandedChild(w core.Widget) {
 	ec.expandedChild = w
 	if w != nil {
+		core.InvalidateCascade()
 		w.SetPosition(ec.Rect.X, ec.Rect.Y)
 		w.Resize(ec.expandedWidth, ec.expandedHeight)
 		if ia, ok := w.(core.InvalidationAware); ok {
@@ -75,12 +75,24 @@ func NewGrid(x, y, w, h int) *Grid {
 	g.SetFocusable(true)
 
 	// Configure focus style from theme
+	g.applyTheme()
+
+	return g
+}
+
+// applyTheme resolves the focused style from the current theme.
+func (g *Grid) applyTheme() {
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("text.primary")
 	bg := tm.GetSemanticColor("bg.surface")
 	g.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
+}
 
-	return g
+// OnThemeChanged implements core.ThemeAware, restyling the internal
+// ScrollPane as well.
+func (g *Grid) OnThemeChanged() {
+	g.applyTheme()
+	g.scrollPane.OnThemeChanged()
 }
 
 // SetInvalidator allows the UI manager to inject a dirty-region invalidator.
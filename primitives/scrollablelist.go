@@ -3,10 +3,16 @@
 //
 // File: texelui/primitives/scrollablelist.go
 // Summary: Vertical scrollable list widget with item selection.
+// Items can optionally be laid out in multiple columns (row-major).
 
 package primitives
 
 import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/theme"
 	"github.com/framegrace/texelui/core"
@@ -15,8 +21,46 @@ import (
 
 // ListItem represents a single item in a ScrollableList.
 type ListItem struct {
+	Text      string
+	Value     interface{} // Optional data payload
+	Disabled  bool        // Rendered dimmed; skipped by navigation and not selectable
+	Separator bool        // Rendered as a horizontal rule; never selectable
+
+	// Group places the item under a group header. Consecutive items with
+	// the same non-empty Group form one collapsible group.
+	Group string
+
+	// Columns, when set, are rendered side by side instead of Text,
+	// giving a simple detail list (name, size, date).
+	Columns []ListColumn
+}
+
+// ColumnAlign specifies how a column's text is aligned within its width.
+type ColumnAlign int
+
+const (
+	ColumnAlignLeft ColumnAlign = iota
+	ColumnAlignRight
+	ColumnAlignCenter
+)
+
+// ListColumn is one cell of a columned list item.
+type ListColumn struct {
 	Text  string
-	Value interface{} // Optional data payload
+	Width int // Fixed width in cells; 0 shares the remaining space with other 0-width columns
+	Align ColumnAlign
+}
+
+// doubleClickInterval is the maximum time between two clicks on the same
+// item for them to count as a double-click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// listColumnGap is the number of blank cells between item columns.
+const listColumnGap = 1
+
+// Selectable reports whether the item can receive the selection cursor.
+func (it ListItem) Selectable() bool {
+	return !it.Disabled && !it.Separator
 }
 
 // ListItemRenderer is a custom rendering function for list items.
@@ -30,7 +74,12 @@ type ScrollableList struct {
 	core.BaseWidget
 	Items       []ListItem
 	SelectedIdx int
-	OnChange    func(int) // Called when selection changes
+	OnChange    func(int) // Called when selection changes (highlight moved)
+	OnActivate  func(int) // Called on explicit activation: Enter or double-click
+
+	// OnContextMenu is called on right-click or the Menu key (Shift+F10)
+	// over an item, with the screen position to anchor a menu at.
+	OnContextMenu func(idx, screenX, screenY int)
 
 	// Custom rendering (optional)
 	RenderItem ListItemRenderer
@@ -38,7 +87,43 @@ type ScrollableList struct {
 	// Show scroll indicators when content overflows
 	ShowScrollIndicators bool
 
+	// Columns lays items out row-major across this many equal-width columns
+	// (e.g. color or emoji palettes). 0 or 1 means a single column.
+	// Up/Down move between rows, Left/Right between columns.
+	Columns int
+
+	// MultiSelect enables selecting several items at once: Space toggles the
+	// item under the cursor, Shift+arrows extend a range, Ctrl+A selects all.
+	// Items render with a checkbox. SelectedIdx remains the cursor.
+	MultiSelect       bool
+	OnSelectionChange func([]int) // Called with SelectedIndices() when the multi-selection changes
+
+	// OnNeedMore is called when the viewport comes within NeedMoreThreshold
+	// rows of the end of Items, with the index of the last loaded item.
+	// Load the next page and add it with AppendItems. It is checked when
+	// the selection, scroll position, size or items change, and fires once
+	// per item count, so it won't repeat until new items arrive.
+	OnNeedMore        func(afterIdx int)
+	NeedMoreThreshold int // Rows from the end that trigger OnNeedMore (default 3)
+
 	// Internal state
+	searching   bool            // Incremental search in progress (StartSearch)
+	searchQuery string          // Current search text
+	collapsed   map[string]bool // Collapsed groups
+	// Group collapsed by Left, with the cursor before and after, so Right
+	// can reopen it while the cursor stays put
+	foldedGroup          string
+	foldedFrom, foldedTo int
+
+	mouseDown    bool      // Button 1 is held (press edges only count as clicks)
+	lastClickIdx int       // Item of the previous click, for double-click detection
+	lastClickAt  time.Time // Time of the previous click
+	now          func() time.Time
+
+	needMoreAt int          // len(Items) when OnNeedMore last fired, -1 if never
+	checked    map[int]bool // Multi-selection set
+	rangeBase  map[int]bool // Selection before the current Shift range started
+	rangeStart int          // Anchor of the current Shift range
 	scrollPane *scroll.ScrollPane
 	content    *listContent
 	inv        func(core.Rect)
@@ -57,6 +142,9 @@ func NewScrollableList(x, y, w, h int) *ScrollableList {
 		Items:                []ListItem{},
 		SelectedIdx:          0,
 		ShowScrollIndicators: true,
+		needMoreAt:           -1,
+		lastClickIdx:         -1,
+		now:                  core.Now,
 	}
 
 	// Create internal content widget
@@ -71,12 +159,24 @@ func NewScrollableList(x, y, w, h int) *ScrollableList {
 	sl.SetFocusable(true)
 
 	// Configure focus style from theme
+	sl.applyTheme()
+
+	return sl
+}
+
+// applyTheme resolves the focused style from the current theme.
+func (sl *ScrollableList) applyTheme() {
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("text.primary")
 	bg := tm.GetSemanticColor("bg.surface")
 	sl.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
+}
 
-	return sl
+// OnThemeChanged implements core.ThemeAware, restyling the internal
+// ScrollPane as well.
+func (sl *ScrollableList) OnThemeChanged() {
+	sl.applyTheme()
+	sl.scrollPane.OnThemeChanged()
 }
 
 // SetInvalidator allows the UI manager to inject a dirty-region invalidator.
@@ -88,6 +188,7 @@ func (sl *ScrollableList) SetInvalidator(fn func(core.Rect)) {
 // SetItems replaces the list items.
 func (sl *ScrollableList) SetItems(items []ListItem) {
 	sl.Items = items
+	sl.resetChecked()
 	// Clamp selection to valid range
 	if sl.SelectedIdx >= len(items) {
 		sl.SelectedIdx = len(items) - 1
@@ -95,15 +196,78 @@ func (sl *ScrollableList) SetItems(items []ListItem) {
 	if sl.SelectedIdx < 0 {
 		sl.SelectedIdx = 0
 	}
+	if idx := sl.nearestSelectable(sl.SelectedIdx, 1); idx >= 0 {
+		sl.SelectedIdx = idx
+	}
 	// Update scroll pane content height
 	sl.updateScrollPaneContentHeight()
 	sl.ensureSelectedVisible()
 	sl.invalidate()
+	sl.checkNeedMore()
+}
+
+// AppendItems adds items to the end of the list, keeping the selection
+// and scroll position (unlike SetItems).
+func (sl *ScrollableList) AppendItems(items ...ListItem) {
+	if len(items) == 0 {
+		return
+	}
+	sl.Items = append(sl.Items, items...)
+	sl.updateScrollPaneContentHeight()
+	sl.invalidate()
+	sl.checkNeedMore()
+}
+
+// checkNeedMore fires OnNeedMore when the viewport is near the end of Items.
+func (sl *ScrollableList) checkNeedMore() {
+	if sl.OnNeedMore == nil || len(sl.Items) == 0 || sl.needMoreAt == len(sl.Items) {
+		return
+	}
+	threshold := sl.NeedMoreThreshold
+	if threshold <= 0 {
+		threshold = 3
+	}
+	lastVisibleRow := sl.scrollPane.ScrollOffset() + sl.Rect.H - 1
+	if lastVisibleRow < sl.RowCount()-threshold {
+		return
+	}
+	sl.needMoreAt = len(sl.Items)
+	sl.OnNeedMore(len(sl.Items) - 1)
+}
+
+// selectable reports whether the item at idx exists and can be selected.
+func (sl *ScrollableList) selectable(idx int) bool {
+	return idx >= 0 && idx < len(sl.Items) && sl.Items[idx].Selectable() && !sl.hidden(idx)
+}
+
+// step moves from idx by delta repeatedly until it lands on a selectable
+// item, returning -1 if it runs off the list first.
+func (sl *ScrollableList) step(idx, delta int) int {
+	for idx += delta; idx >= 0 && idx < len(sl.Items); idx += delta {
+		if sl.selectable(idx) {
+			return idx
+		}
+	}
+	return -1
+}
+
+// nearestSelectable returns idx if selectable, otherwise the closest
+// selectable item searching in dir first, then the opposite way.
+// Returns -1 if nothing in the list is selectable.
+func (sl *ScrollableList) nearestSelectable(idx, dir int) int {
+	if sl.selectable(idx) {
+		return idx
+	}
+	if next := sl.step(idx, dir); next >= 0 {
+		return next
+	}
+	return sl.step(idx, -dir)
 }
 
 // SetSelected changes the selected item by index.
+// Disabled items and separators cannot be selected.
 func (sl *ScrollableList) SetSelected(idx int) {
-	if idx < 0 || idx >= len(sl.Items) {
+	if !sl.selectable(idx) {
 		return
 	}
 	if idx == sl.SelectedIdx {
@@ -115,6 +279,7 @@ func (sl *ScrollableList) SetSelected(idx int) {
 	if sl.OnChange != nil {
 		sl.OnChange(idx)
 	}
+	sl.checkNeedMore()
 }
 
 // SelectedItem returns the currently selected item, or nil if none.
@@ -125,10 +290,157 @@ func (sl *ScrollableList) SelectedItem() *ListItem {
 	return nil
 }
 
+// SelectedIndices returns the selected item indices in ascending order.
+// Without MultiSelect this is just the cursor item.
+func (sl *ScrollableList) SelectedIndices() []int {
+	if !sl.MultiSelect {
+		if sl.SelectedIdx >= 0 && sl.SelectedIdx < len(sl.Items) {
+			return []int{sl.SelectedIdx}
+		}
+		return nil
+	}
+	indices := make([]int, 0, len(sl.checked))
+	for idx := range sl.checked {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// SelectedItems returns the selected items in list order.
+func (sl *ScrollableList) SelectedItems() []ListItem {
+	indices := sl.SelectedIndices()
+	items := make([]ListItem, 0, len(indices))
+	for _, idx := range indices {
+		items = append(items, sl.Items[idx])
+	}
+	return items
+}
+
+// IsSelected reports whether the item at idx is part of the multi-selection.
+// Custom renderers can use it to draw their own check marks.
+func (sl *ScrollableList) IsSelected(idx int) bool {
+	if !sl.MultiSelect {
+		return idx == sl.SelectedIdx
+	}
+	return sl.checked[idx]
+}
+
+// SetSelectedIndices replaces the multi-selection. Out-of-range indices are ignored.
+func (sl *ScrollableList) SetSelectedIndices(indices []int) {
+	checked := make(map[int]bool, len(indices))
+	for _, idx := range indices {
+		if sl.selectable(idx) {
+			checked[idx] = true
+		}
+	}
+	sl.rangeBase = nil
+	sl.setChecked(checked)
+}
+
+// SelectAll adds every item to the multi-selection.
+func (sl *ScrollableList) SelectAll() {
+	checked := make(map[int]bool, len(sl.Items))
+	for i, item := range sl.Items {
+		if item.Selectable() {
+			checked[i] = true
+		}
+	}
+	sl.rangeBase = nil
+	sl.setChecked(checked)
+}
+
+// ClearSelection empties the multi-selection.
+func (sl *ScrollableList) ClearSelection() {
+	sl.rangeBase = nil
+	sl.setChecked(map[int]bool{})
+}
+
+// toggleChecked flips the item at idx in the multi-selection.
+func (sl *ScrollableList) toggleChecked(idx int) {
+	if !sl.selectable(idx) {
+		return
+	}
+	checked := sl.copyChecked()
+	if checked[idx] {
+		delete(checked, idx)
+	} else {
+		checked[idx] = true
+	}
+	sl.rangeBase = nil
+	sl.setChecked(checked)
+}
+
+// selectRange selects the items between the range anchor and the cursor,
+// on top of whatever was selected before the range started.
+func (sl *ScrollableList) selectRange() {
+	lo, hi := sl.rangeStart, sl.SelectedIdx
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	checked := make(map[int]bool, len(sl.rangeBase)+hi-lo+1)
+	for idx := range sl.rangeBase {
+		checked[idx] = true
+	}
+	for idx := lo; idx <= hi && idx < len(sl.Items); idx++ {
+		if sl.Items[idx].Selectable() {
+			checked[idx] = true
+		}
+	}
+	sl.setChecked(checked)
+}
+
+// beginRange anchors a Shift range at the cursor unless one is in progress.
+func (sl *ScrollableList) beginRange() {
+	if sl.rangeBase == nil {
+		sl.rangeBase = sl.copyChecked()
+		sl.rangeStart = sl.SelectedIdx
+	}
+}
+
+// copyChecked returns a copy of the multi-selection set.
+func (sl *ScrollableList) copyChecked() map[int]bool {
+	checked := make(map[int]bool, len(sl.checked))
+	for idx := range sl.checked {
+		checked[idx] = true
+	}
+	return checked
+}
+
+// setChecked installs a new multi-selection set, firing OnSelectionChange
+// if it differs from the current one.
+func (sl *ScrollableList) setChecked(checked map[int]bool) {
+	changed := len(checked) != len(sl.checked)
+	if !changed {
+		for idx := range checked {
+			if !sl.checked[idx] {
+				changed = true
+				break
+			}
+		}
+	}
+	sl.checked = checked
+	if !changed {
+		return
+	}
+	sl.invalidate()
+	if sl.OnSelectionChange != nil {
+		sl.OnSelectionChange(sl.SelectedIndices())
+	}
+}
+
+// resetChecked drops the multi-selection without firing callbacks
+// (used when the items are replaced).
+func (sl *ScrollableList) resetChecked() {
+	sl.checked = nil
+	sl.rangeBase = nil
+}
+
 // Clear removes all items from the list.
 func (sl *ScrollableList) Clear() {
 	sl.Items = []ListItem{}
 	sl.SelectedIdx = 0
+	sl.resetChecked()
 	sl.updateScrollPaneContentHeight()
 	sl.invalidate()
 }
@@ -139,8 +451,9 @@ func (sl *ScrollableList) Resize(w, h int) {
 	sl.scrollPane.SetPosition(sl.Rect.X, sl.Rect.Y)
 	sl.scrollPane.Resize(w, h)
 	// Update content size
-	sl.content.Resize(w, len(sl.Items))
+	sl.content.Resize(w, sl.RowCount())
 	sl.updateScrollPaneContentHeight()
+	sl.checkNeedMore()
 }
 
 // SetPosition updates the list position.
@@ -149,9 +462,188 @@ func (sl *ScrollableList) SetPosition(x, y int) {
 	sl.scrollPane.SetPosition(x, y)
 }
 
+// SetColumns sets the number of columns and relays out the list.
+func (sl *ScrollableList) SetColumns(n int) {
+	sl.Columns = n
+	sl.updateScrollPaneContentHeight()
+	sl.ensureSelectedVisible()
+	sl.invalidate()
+	sl.checkNeedMore()
+}
+
+// columns returns the effective number of columns (at least 1).
+// Grouped lists always use a single column.
+func (sl *ScrollableList) columns() int {
+	if sl.Columns < 1 || sl.grouped() {
+		return 1
+	}
+	return sl.Columns
+}
+
+// RowCount returns the number of rows needed to display all items,
+// including group header rows.
+func (sl *ScrollableList) RowCount() int {
+	if sl.grouped() {
+		return len(sl.displayRows())
+	}
+	cols := sl.columns()
+	return (len(sl.Items) + cols - 1) / cols
+}
+
+// listRow is one display row of a grouped list: a group header
+// (item == -1) or an item.
+type listRow struct {
+	item  int
+	group string
+	start int // For headers: index of the group's first item
+}
+
+// grouped reports whether any item has a Group, enabling header rows.
+func (sl *ScrollableList) grouped() bool {
+	for _, item := range sl.Items {
+		if item.Group != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// displayRows lays out a grouped list: a header row before each run of
+// items sharing a Group, followed by the items unless the group is collapsed.
+func (sl *ScrollableList) displayRows() []listRow {
+	rows := make([]listRow, 0, len(sl.Items)+4)
+	prev := ""
+	for i, item := range sl.Items {
+		if item.Group != "" && (i == 0 || item.Group != prev) {
+			rows = append(rows, listRow{item: -1, group: item.Group, start: i})
+		}
+		prev = item.Group
+		if item.Group == "" || !sl.collapsed[item.Group] {
+			rows = append(rows, listRow{item: i, group: item.Group})
+		}
+	}
+	return rows
+}
+
+// rowOf returns the display row of item idx, or -1 if it is hidden.
+func (sl *ScrollableList) rowOf(idx int) int {
+	if !sl.grouped() {
+		return idx / sl.columns()
+	}
+	for row, r := range sl.displayRows() {
+		if r.item == idx {
+			return row
+		}
+	}
+	return -1
+}
+
+// hidden reports whether item idx is inside a collapsed group.
+func (sl *ScrollableList) hidden(idx int) bool {
+	g := sl.Items[idx].Group
+	return g != "" && sl.collapsed[g]
+}
+
+// groupSize returns the number of items in the group run starting at start.
+func (sl *ScrollableList) groupSize(start int) int {
+	n := 0
+	for i := start; i < len(sl.Items) && sl.Items[i].Group == sl.Items[start].Group; i++ {
+		n++
+	}
+	return n
+}
+
+// SetGroupCollapsed collapses or expands the named group. When the cursor
+// is inside a group being collapsed it moves to the nearest visible item.
+func (sl *ScrollableList) SetGroupCollapsed(group string, collapsed bool) {
+	if sl.collapsed[group] == collapsed {
+		return
+	}
+	if sl.collapsed == nil {
+		sl.collapsed = make(map[string]bool)
+	}
+	if collapsed {
+		sl.collapsed[group] = true
+	} else {
+		delete(sl.collapsed, group)
+	}
+	sl.updateScrollPaneContentHeight()
+	if len(sl.Items) > 0 && !sl.selectable(sl.SelectedIdx) {
+		if idx := sl.nearestSelectable(sl.SelectedIdx, 1); idx >= 0 {
+			sl.SetSelected(idx)
+		}
+	}
+	sl.ensureSelectedVisible()
+	sl.invalidate()
+	sl.checkNeedMore()
+}
+
+// ToggleGroup flips the collapsed state of the named group.
+func (sl *ScrollableList) ToggleGroup(group string) {
+	sl.SetGroupCollapsed(group, !sl.collapsed[group])
+}
+
+// IsGroupCollapsed reports whether the named group is collapsed.
+func (sl *ScrollableList) IsGroupCollapsed(group string) bool {
+	return sl.collapsed[group]
+}
+
+// expandCursorGroup expands the group under the cursor: its own group if
+// the cursor is hidden in a collapsed one, or the group Left just collapsed
+// if the cursor hasn't moved since, putting the cursor back into it.
+func (sl *ScrollableList) expandCursorGroup() bool {
+	if sl.SelectedIdx < 0 || sl.SelectedIdx >= len(sl.Items) {
+		return false
+	}
+	if g := sl.Items[sl.SelectedIdx].Group; g != "" && sl.collapsed[g] {
+		sl.SetGroupCollapsed(g, false)
+		return true
+	}
+	if g := sl.foldedGroup; g != "" && sl.collapsed[g] && sl.SelectedIdx == sl.foldedTo {
+		sl.foldedGroup = ""
+		sl.SetGroupCollapsed(g, false)
+		sl.SetSelected(sl.foldedFrom)
+		return true
+	}
+	return false
+}
+
+// expandNextGroup expands the first collapsed group below the cursor.
+func (sl *ScrollableList) expandNextGroup() bool {
+	cur := ""
+	if sl.SelectedIdx >= 0 && sl.SelectedIdx < len(sl.Items) {
+		cur = sl.Items[sl.SelectedIdx].Group
+	}
+	for i := sl.SelectedIdx + 1; i < len(sl.Items); i++ {
+		if g := sl.Items[i].Group; g != "" && g != cur && sl.collapsed[g] {
+			sl.SetGroupCollapsed(g, false)
+			return true
+		}
+	}
+	return false
+}
+
+// stickyHeader returns the group whose header should be pinned to the top
+// row: the group of the first visible row when its own header has scrolled away.
+func (sl *ScrollableList) stickyHeader(rows []listRow, offset int) (string, int, bool) {
+	if offset <= 0 || offset >= len(rows) {
+		return "", 0, false
+	}
+	top := rows[offset]
+	if top.item < 0 || top.group == "" {
+		return "", 0, false
+	}
+	// Find the first item of the group to report its size
+	start := top.item
+	for start > 0 && sl.Items[start-1].Group == top.group {
+		start--
+	}
+	return top.group, start, true
+}
+
 // updateScrollPaneContentHeight updates the scroll pane's content height.
 func (sl *ScrollableList) updateScrollPaneContentHeight() {
-	sl.scrollPane.SetContentHeight(len(sl.Items))
+	sl.scrollPane.SetContentHeight(sl.RowCount())
 }
 
 // ensureSelectedVisible scrolls to make the selected item visible.
@@ -159,21 +651,33 @@ func (sl *ScrollableList) ensureSelectedVisible() {
 	if len(sl.Items) == 0 {
 		return
 	}
-	// Center the selected item in the viewport
-	sl.scrollPane.ScrollToCentered(sl.SelectedIdx)
+	// Center the selected item's row in the viewport
+	if row := sl.rowOf(sl.SelectedIdx); row >= 0 {
+		sl.scrollPane.ScrollToCentered(row)
+	}
 }
 
 // Draw renders the scrollable list via the scroll pane.
 func (sl *ScrollableList) Draw(painter *core.Painter) {
-	// Ensure content size matches items count
-	sl.content.Resize(sl.Rect.W, len(sl.Items))
+	// Ensure content size matches row count
+	sl.content.Resize(sl.Rect.W, sl.RowCount())
 	sl.scrollPane.ShowIndicators(sl.ShowScrollIndicators)
 	sl.scrollPane.Draw(painter)
+
+	// Search prompt on the bottom row
+	if sl.searching && sl.Rect.H > 0 {
+		tm := theme.Get()
+		style := tcell.StyleDefault.Foreground(tm.GetSemanticColor("text.primary")).
+			Background(tm.GetSemanticColor("bg.surface")).Reverse(true)
+		row := core.Rect{X: sl.Rect.X, Y: sl.Rect.Y + sl.Rect.H - 1, W: sl.Rect.W, H: 1}
+		painter.Fill(row, ' ', style)
+		painter.DrawText(row.X, row.Y, "/"+sl.searchQuery, style)
+	}
 }
 
 // ContentHeight implements scroll.ContentHeightProvider for listContent.
 func (lc *listContent) ContentHeight() int {
-	return len(lc.parent.Items)
+	return lc.parent.RowCount()
 }
 
 // HandlePageNavigation implements scroll.PageNavigator for selection-based page navigation.
@@ -188,8 +692,8 @@ func (lc *listContent) HandlePageNavigation(direction int, pageSize int) bool {
 		pageSize = 1
 	}
 
-	// Calculate target index
-	targetIdx := sl.SelectedIdx + (direction * pageSize)
+	// Calculate target index (a page is pageSize rows)
+	targetIdx := sl.SelectedIdx + (direction * pageSize * sl.columns())
 
 	// Clamp to valid range
 	if targetIdx < 0 {
@@ -198,6 +702,10 @@ func (lc *listContent) HandlePageNavigation(direction int, pageSize int) bool {
 	if targetIdx >= len(sl.Items) {
 		targetIdx = len(sl.Items) - 1
 	}
+	targetIdx = sl.nearestSelectable(targetIdx, direction)
+	if targetIdx < 0 {
+		return false
+	}
 
 	// Only act if we're actually moving
 	if targetIdx == sl.SelectedIdx {
@@ -224,49 +732,113 @@ func (lc *listContent) Draw(painter *core.Painter) {
 	scrollOffset := sl.scrollPane.ScrollOffset()
 
 	// Calculate content width (leave room for scrollbar if needed)
-	contentW := sl.Rect.W
-	if sl.ShowScrollIndicators && sl.scrollPane.CanScroll() {
-		contentW-- // Reserve 1 column for scrollbar
-	}
+	contentW := sl.contentWidth()
 
 	// Draw items, accounting for scroll offset
 	// Note: Use sl.Rect (parent's rect) for screen positions since ScrollPane
 	// manages clipping. lc.Rect is adjusted by ScrollPane during Draw which
 	// we don't want to use here.
+	if sl.grouped() {
+		lc.drawGrouped(painter, contentW, scrollOffset, baseStyle)
+		return
+	}
+
+	cols := sl.columns()
+	colW := contentW / cols
 	for i, item := range sl.Items {
+		row, col := i/cols, i%cols
 		// Skip items above viewport
-		if i < scrollOffset {
+		if row < scrollOffset {
 			continue
 		}
 		// Stop if below viewport
-		if i >= scrollOffset+sl.Rect.H {
+		if row >= scrollOffset+sl.Rect.H {
 			break
 		}
 
 		// Calculate screen position relative to parent's viewport
-		y := sl.Rect.Y + (i - scrollOffset)
+		y := sl.Rect.Y + (row - scrollOffset)
 		selected := i == sl.SelectedIdx
 
 		itemRect := core.Rect{
-			X: sl.Rect.X,
+			X: sl.Rect.X + col*colW,
 			Y: y,
-			W: contentW,
+			W: colW,
 			H: 1,
 		}
+		if col == cols-1 {
+			// Last column absorbs the remainder
+			itemRect.W = contentW - col*colW
+		}
 
 		if sl.RenderItem != nil {
 			// Custom rendering
 			sl.RenderItem(painter, itemRect, item, selected)
 		} else {
 			// Default rendering
-			lc.drawDefaultItem(painter, itemRect, item, selected, baseStyle)
+			lc.drawDefaultItem(painter, itemRect, i, item, selected, baseStyle)
 		}
 	}
 }
 
+// drawGrouped renders a grouped list: header rows interleaved with items,
+// with the current group's header pinned to the top row.
+func (lc *listContent) drawGrouped(painter *core.Painter, contentW, scrollOffset int, baseStyle tcell.Style) {
+	sl := lc.parent
+	rows := sl.displayRows()
+	for row := scrollOffset; row < len(rows) && row < scrollOffset+sl.Rect.H; row++ {
+		r := rows[row]
+		rect := core.Rect{X: sl.Rect.X, Y: sl.Rect.Y + row - scrollOffset, W: contentW, H: 1}
+		if r.item < 0 {
+			lc.drawGroupHeader(painter, rect, r.group, sl.groupSize(r.start), baseStyle)
+			continue
+		}
+		item := sl.Items[r.item]
+		selected := r.item == sl.SelectedIdx
+		if sl.RenderItem != nil {
+			sl.RenderItem(painter, rect, item, selected)
+		} else {
+			lc.drawDefaultItem(painter, rect, r.item, item, selected, baseStyle)
+		}
+	}
+	if group, start, ok := sl.stickyHeader(rows, scrollOffset); ok {
+		rect := core.Rect{X: sl.Rect.X, Y: sl.Rect.Y, W: contentW, H: 1}
+		lc.drawGroupHeader(painter, rect, group, sl.groupSize(start), baseStyle)
+	}
+}
+
+// drawGroupHeader renders a group header row.
+func (lc *listContent) drawGroupHeader(painter *core.Painter, rect core.Rect, group string, count int, baseStyle tcell.Style) {
+	style := baseStyle.Bold(true)
+	if muted := theme.Get().GetSemanticColor("text.muted"); muted != tcell.ColorDefault {
+		style = style.Foreground(muted)
+	}
+	painter.Fill(rect, ' ', style)
+	text := "▾ " + group
+	if lc.parent.collapsed[group] {
+		text = "▸ " + group + " (" + strconv.Itoa(count) + ")"
+	}
+	if runes := []rune(text); len(runes) > rect.W && rect.W > 0 {
+		text = string(runes[:rect.W])
+	}
+	painter.DrawText(rect.X, rect.Y, text, style)
+}
+
 // drawDefaultItem renders a list item with default styling.
-func (lc *listContent) drawDefaultItem(painter *core.Painter, rect core.Rect, item ListItem, selected bool, baseStyle tcell.Style) {
+func (lc *listContent) drawDefaultItem(painter *core.Painter, rect core.Rect, idx int, item ListItem, selected bool, baseStyle tcell.Style) {
 	style := baseStyle
+	if item.Separator || item.Disabled {
+		muted := theme.Get().GetSemanticColor("text.muted")
+		if muted != tcell.ColorDefault {
+			style = style.Foreground(muted)
+		} else {
+			style = style.Dim(true)
+		}
+	}
+	if item.Separator {
+		painter.Fill(rect, '─', style)
+		return
+	}
 	if selected {
 		style = style.Reverse(true)
 	}
@@ -276,6 +848,23 @@ func (lc *listContent) drawDefaultItem(painter *core.Painter, rect core.Rect, it
 
 	// Draw text (truncate if needed)
 	text := item.Text
+	if lc.parent.MultiSelect {
+		box := "[ ] "
+		if lc.parent.checked[idx] {
+			box = "[x] "
+		}
+		if len(item.Columns) > 0 {
+			painter.DrawText(rect.X, rect.Y, box, style)
+			rect.X += len(box)
+			rect.W -= len(box)
+		} else {
+			text = box + text
+		}
+	}
+	if len(item.Columns) > 0 {
+		drawListColumns(painter, rect, item.Columns, style)
+		return
+	}
 	maxLen := rect.W
 	if len(text) > maxLen && maxLen > 0 {
 		text = text[:maxLen]
@@ -284,38 +873,286 @@ func (lc *listContent) drawDefaultItem(painter *core.Painter, rect core.Rect, it
 	painter.DrawText(rect.X, rect.Y, text, style)
 }
 
+// drawListColumns renders columns side by side within rect.
+func drawListColumns(painter *core.Painter, rect core.Rect, columns []ListColumn, style tcell.Style) {
+	widths := listColumnWidths(columns, rect.W)
+	x := rect.X
+	for i, col := range columns {
+		w := widths[i]
+		if w > 0 {
+			painter.DrawText(x, rect.Y, alignColumnText(col.Text, w, col.Align), style)
+		}
+		x += w + listColumnGap
+		if x >= rect.X+rect.W {
+			break
+		}
+	}
+}
+
+// listColumnWidths resolves column widths for the available width.
+// Fixed columns keep their width; 0-width columns split what remains.
+func listColumnWidths(columns []ListColumn, avail int) []int {
+	widths := make([]int, len(columns))
+	remaining := avail - listColumnGap*(len(columns)-1)
+	flex := 0
+	for i, col := range columns {
+		if col.Width > 0 {
+			widths[i] = col.Width
+			remaining -= col.Width
+		} else {
+			flex++
+		}
+	}
+	if flex == 0 || remaining <= 0 {
+		return widths
+	}
+	share, extra := remaining/flex, remaining%flex
+	for i, col := range columns {
+		if col.Width > 0 {
+			continue
+		}
+		widths[i] = share
+		if extra > 0 {
+			widths[i]++
+			extra--
+		}
+	}
+	return widths
+}
+
+// alignColumnText truncates or pads text to exactly w cells.
+func alignColumnText(text string, w int, align ColumnAlign) string {
+	runes := []rune(text)
+	if len(runes) >= w {
+		return string(runes[:w])
+	}
+	pad := w - len(runes)
+	switch align {
+	case ColumnAlignRight:
+		return strings.Repeat(" ", pad) + text
+	case ColumnAlignCenter:
+		left := pad / 2
+		return strings.Repeat(" ", left) + text + strings.Repeat(" ", pad-left)
+	default:
+		return text + strings.Repeat(" ", pad)
+	}
+}
+
+// HandleNavigation implements core.NavigationTarget for keymap profiles.
+func (sl *ScrollableList) HandleNavigation(action core.NavAction) bool {
+	if sl.searching || len(sl.Items) == 0 {
+		return false
+	}
+	key := func(k tcell.Key) bool {
+		return sl.HandleKey(tcell.NewEventKey(k, 0, tcell.ModNone))
+	}
+	switch action {
+	case core.NavUp:
+		return key(tcell.KeyUp)
+	case core.NavDown:
+		return key(tcell.KeyDown)
+	case core.NavLeft:
+		return key(tcell.KeyLeft)
+	case core.NavRight:
+		return key(tcell.KeyRight)
+	case core.NavTop:
+		return key(tcell.KeyHome)
+	case core.NavBottom:
+		return key(tcell.KeyEnd)
+	case core.NavPageUp:
+		return key(tcell.KeyPgUp)
+	case core.NavPageDown:
+		return key(tcell.KeyPgDn)
+	case core.NavHalfPageUp:
+		return sl.content.HandlePageNavigation(-1, sl.Rect.H/2)
+	case core.NavHalfPageDown:
+		return sl.content.HandlePageNavigation(1, sl.Rect.H/2)
+	case core.NavSearch:
+		sl.StartSearch()
+		return true
+	}
+	return false
+}
+
+// StartSearch begins an incremental search: typed text moves the cursor to
+// the next item containing it (case-insensitive). Enter or Esc ends it.
+func (sl *ScrollableList) StartSearch() {
+	sl.searching = true
+	sl.searchQuery = ""
+	sl.invalidate()
+}
+
+// handleSearchKey processes a key while searching. Keys other than text
+// editing end the search and are then handled normally.
+func (sl *ScrollableList) handleSearchKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		sl.searchQuery += string(ev.Rune())
+		sl.searchFrom(sl.SelectedIdx)
+		sl.invalidate()
+		return true
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if q := []rune(sl.searchQuery); len(q) > 0 {
+			sl.searchQuery = string(q[:len(q)-1])
+			sl.invalidate()
+			return true
+		}
+		sl.searching = false
+		sl.invalidate()
+		return true
+	case tcell.KeyEnter, tcell.KeyEsc:
+		sl.searching = false
+		sl.invalidate()
+		return true
+	}
+	sl.searching = false
+	sl.invalidate()
+	return false
+}
+
+// searchFrom selects the first selectable item at or after start (wrapping)
+// whose text contains the search query.
+func (sl *ScrollableList) searchFrom(start int) {
+	q := strings.ToLower(sl.searchQuery)
+	if q == "" {
+		return
+	}
+	n := len(sl.Items)
+	for i := 0; i < n; i++ {
+		idx := (start + i) % n
+		if sl.selectable(idx) && strings.Contains(strings.ToLower(sl.Items[idx].Text), q) {
+			sl.SetSelected(idx)
+			return
+		}
+	}
+}
+
 // HandleKey processes keyboard input for list navigation.
 func (sl *ScrollableList) HandleKey(ev *tcell.EventKey) bool {
 	if len(sl.Items) == 0 {
 		return false
 	}
 
+	if sl.searching && sl.handleSearchKey(ev) {
+		return true
+	}
+
+	if !sl.MultiSelect {
+		return sl.navigate(ev)
+	}
+
+	switch {
+	case ev.Key() == tcell.KeyRune && ev.Rune() == ' ':
+		sl.toggleChecked(sl.SelectedIdx)
+		return true
+	case ev.Key() == tcell.KeyCtrlA:
+		if len(sl.checked) == len(sl.Items) {
+			sl.ClearSelection()
+		} else {
+			sl.SelectAll()
+		}
+		return true
+	}
+
+	// Shift+navigation extends a range from where the cursor was
+	shift := ev.Modifiers()&tcell.ModShift != 0
+	if shift {
+		sl.beginRange()
+	}
+	if !sl.navigate(ev) {
+		return false
+	}
+	if shift {
+		sl.selectRange()
+	} else {
+		sl.rangeBase = nil
+	}
+	return true
+}
+
+// navigate moves the cursor for navigation keys.
+func (sl *ScrollableList) navigate(ev *tcell.EventKey) bool {
+	cols := sl.columns()
 	switch ev.Key() {
 	case tcell.KeyUp:
-		if sl.SelectedIdx > 0 {
-			sl.SetSelected(sl.SelectedIdx - 1)
+		if idx := sl.step(sl.SelectedIdx, -cols); idx >= 0 {
+			sl.SetSelected(idx)
 			return true
 		}
 		return false
 
 	case tcell.KeyDown:
-		if sl.SelectedIdx < len(sl.Items)-1 {
-			sl.SetSelected(sl.SelectedIdx + 1)
+		if idx := sl.step(sl.SelectedIdx, cols); idx >= 0 {
+			sl.SetSelected(idx)
+			return true
+		}
+		// Partial last row: move to the last item if it's on a lower row
+		if lastIdx := sl.step(len(sl.Items), -1); lastIdx/cols > sl.SelectedIdx/cols {
+			sl.SetSelected(lastIdx)
 			return true
 		}
 		return false
 
+	case tcell.KeyLeft:
+		if sl.grouped() {
+			if g := sl.Items[sl.SelectedIdx].Group; g != "" {
+				from := sl.SelectedIdx
+				sl.SetGroupCollapsed(g, true)
+				sl.foldedGroup, sl.foldedFrom, sl.foldedTo = g, from, sl.SelectedIdx
+				return true
+			}
+			return false
+		}
+		if cols > 1 {
+			rowStart := sl.SelectedIdx - sl.SelectedIdx%cols
+			if idx := sl.step(sl.SelectedIdx, -1); idx >= rowStart {
+				sl.SetSelected(idx)
+				return true
+			}
+		}
+		return false
+
+	case tcell.KeyRight:
+		if sl.grouped() {
+			return sl.expandCursorGroup() || sl.expandNextGroup()
+		}
+		if cols > 1 {
+			rowEnd := sl.SelectedIdx - sl.SelectedIdx%cols + cols
+			if idx := sl.step(sl.SelectedIdx, 1); idx >= 0 && idx < rowEnd {
+				sl.SetSelected(idx)
+				return true
+			}
+		}
+		return false
+
 	case tcell.KeyHome:
-		if sl.SelectedIdx != 0 {
-			sl.SetSelected(0)
+		if idx := sl.step(-1, 1); idx >= 0 && idx != sl.SelectedIdx {
+			sl.SetSelected(idx)
 			return true
 		}
 		return false
 
 	case tcell.KeyEnd:
-		lastIdx := len(sl.Items) - 1
-		if sl.SelectedIdx != lastIdx {
-			sl.SetSelected(lastIdx)
+		if idx := sl.step(len(sl.Items), -1); idx >= 0 && idx != sl.SelectedIdx {
+			sl.SetSelected(idx)
+			return true
+		}
+		return false
+
+	case tcell.KeyMenu, tcell.KeyF10:
+		if ev.Key() == tcell.KeyF10 && ev.Modifiers()&tcell.ModShift == 0 {
+			return false
+		}
+		if sl.OnContextMenu != nil && sl.selectable(sl.SelectedIdx) {
+			x, y := sl.itemScreenPos(sl.SelectedIdx)
+			sl.OnContextMenu(sl.SelectedIdx, x, y)
+			return true
+		}
+		return false
+
+	case tcell.KeyEnter:
+		if sl.OnActivate != nil && sl.selectable(sl.SelectedIdx) {
+			sl.OnActivate(sl.SelectedIdx)
 			return true
 		}
 		return false
@@ -334,6 +1171,9 @@ func (sl *ScrollableList) HandleMouse(ev *tcell.EventMouse) bool {
 	x, y := ev.Position()
 	buttons := ev.Buttons()
 
+	pressed := buttons&tcell.Button1 != 0 && !sl.mouseDown
+	sl.mouseDown = buttons&tcell.Button1 != 0
+
 	if len(sl.Items) == 0 && buttons&(tcell.WheelUp|tcell.WheelDown) == 0 {
 		return false
 	}
@@ -344,33 +1184,56 @@ func (sl *ScrollableList) HandleMouse(ev *tcell.EventMouse) bool {
 
 	// Handle scroll wheel - moves selection, not viewport
 	if buttons&tcell.WheelUp != 0 {
-		if sl.SelectedIdx > 0 {
-			sl.SetSelected(sl.SelectedIdx - 1)
+		if idx := sl.step(sl.SelectedIdx, -1); idx >= 0 {
+			sl.SetSelected(idx)
 		}
 		return true
 	}
 	if buttons&tcell.WheelDown != 0 {
-		if sl.SelectedIdx < len(sl.Items)-1 {
-			sl.SetSelected(sl.SelectedIdx + 1)
+		if idx := sl.step(sl.SelectedIdx, 1); idx >= 0 {
+			sl.SetSelected(idx)
 		}
 		return true
 	}
 
 	// Let scroll pane handle scrollbar interactions
 	if sl.scrollPane.HandleMouse(ev) {
+		sl.checkNeedMore()
 		return true
 	}
 
 	// Handle click on list item
 	if buttons == tcell.Button1 {
-		scrollOffset := sl.scrollPane.ScrollOffset()
-		relY := y - sl.Rect.Y
-		clickedIdx := scrollOffset + relY
+		clickedIdx, header := sl.itemAt(x, y)
+		if header != "" {
+			if pressed {
+				sl.ToggleGroup(header)
+			}
+			return true
+		}
 
 		if clickedIdx >= 0 && clickedIdx < len(sl.Items) {
+			if !sl.selectable(clickedIdx) {
+				return true // Swallow clicks on disabled items and separators
+			}
+			mods := ev.Modifiers()
+			if sl.MultiSelect && mods&tcell.ModShift != 0 {
+				sl.beginRange()
+				sl.SetSelected(clickedIdx)
+				sl.selectRange()
+				return true
+			}
 			if clickedIdx != sl.SelectedIdx {
 				sl.SetSelected(clickedIdx)
 			}
+			if pressed && mods == tcell.ModNone {
+				sl.registerClick(clickedIdx)
+			}
+			if sl.MultiSelect && mods&tcell.ModCtrl != 0 {
+				sl.toggleChecked(clickedIdx)
+			} else {
+				sl.rangeBase = nil
+			}
 			return true
 		}
 	}
@@ -378,6 +1241,89 @@ func (sl *ScrollableList) HandleMouse(ev *tcell.EventMouse) bool {
 	return false
 }
 
+// HandleAuxClick implements core.AuxClickAware: a right-click over an
+// item selects it and opens its context menu.
+func (sl *ScrollableList) HandleAuxClick(click core.AuxClick) bool {
+	if click.Button != tcell.Button2 || sl.OnContextMenu == nil || !sl.HitTest(click.X, click.Y) {
+		return false
+	}
+	idx, _ := sl.itemAt(click.X, click.Y)
+	if !sl.selectable(idx) {
+		return false
+	}
+	sl.SetSelected(idx)
+	sl.OnContextMenu(idx, click.X, click.Y)
+	return true
+}
+
+// itemAt resolves a screen point to an item index, or -1. For group
+// header rows (including the sticky one) it returns -1 and the group name.
+func (sl *ScrollableList) itemAt(x, y int) (int, string) {
+	scrollOffset := sl.scrollPane.ScrollOffset()
+	relY := y - sl.Rect.Y
+	if sl.grouped() {
+		rows := sl.displayRows()
+		if group, _, ok := sl.stickyHeader(rows, scrollOffset); ok && relY == 0 {
+			return -1, group
+		}
+		row := scrollOffset + relY
+		if row < 0 || row >= len(rows) {
+			return -1, ""
+		}
+		if rows[row].item < 0 {
+			return -1, rows[row].group
+		}
+		return rows[row].item, ""
+	}
+	cols := sl.columns()
+	col := 0
+	if cols > 1 {
+		colW := sl.contentWidth() / cols
+		if colW > 0 {
+			col = (x - sl.Rect.X) / colW
+		}
+		if col >= cols {
+			col = cols - 1
+		}
+	}
+	idx := (scrollOffset+relY)*cols + col
+	if idx < 0 || idx >= len(sl.Items) {
+		return -1, ""
+	}
+	return idx, ""
+}
+
+// itemScreenPos returns the screen position of item idx's first cell.
+func (sl *ScrollableList) itemScreenPos(idx int) (int, int) {
+	cols := sl.columns()
+	x := sl.Rect.X + (idx%cols)*(sl.contentWidth()/cols)
+	y := sl.Rect.Y + sl.rowOf(idx) - sl.scrollPane.ScrollOffset()
+	return x, y
+}
+
+// contentWidth returns the width available to items (minus the scrollbar).
+func (sl *ScrollableList) contentWidth() int {
+	if sl.ShowScrollIndicators && sl.scrollPane.CanScroll() {
+		return sl.Rect.W - 1
+	}
+	return sl.Rect.W
+}
+
+// registerClick records a click on idx and fires OnActivate if it
+// completes a double-click.
+func (sl *ScrollableList) registerClick(idx int) {
+	now := sl.now()
+	if idx == sl.lastClickIdx && now.Sub(sl.lastClickAt) <= doubleClickInterval {
+		sl.lastClickIdx = -1
+		if sl.OnActivate != nil {
+			sl.OnActivate(idx)
+		}
+		return
+	}
+	sl.lastClickIdx = idx
+	sl.lastClickAt = now
+}
+
 // invalidate marks the widget as needing redraw.
 func (sl *ScrollableList) invalidate() {
 	if sl.inv != nil {
@@ -387,9 +1333,27 @@ func (sl *ScrollableList) invalidate() {
 
 // GetKeyHints implements KeyHintsProvider from core package.
 func (sl *ScrollableList) GetKeyHints() []core.KeyHint {
-	return []core.KeyHint{
-		{Key: "↑↓", Label: "Navigate"},
+	nav := "↑↓"
+	if sl.columns() > 1 {
+		nav = "↑↓←→"
+	}
+	hints := []core.KeyHint{
+		{Key: nav, Label: "Navigate"},
 		{Key: "PgUp/Dn", Label: "Page"},
 		{Key: "Home/End", Label: "Jump"},
 	}
+	if sl.grouped() {
+		hints = append(hints, core.KeyHint{Key: "←→", Label: "Fold"})
+	}
+	if sl.OnActivate != nil {
+		hints = append(hints, core.KeyHint{Key: "Enter", Label: "Open"})
+	}
+	if sl.MultiSelect {
+		hints = append(hints,
+			core.KeyHint{Key: "Space", Label: "Toggle"},
+			core.KeyHint{Key: "Shift+↑↓", Label: "Range"},
+			core.KeyHint{Key: "Ctrl+A", Label: "All"},
+		)
+	}
+	return hints
 }
@@ -1,7 +1,9 @@
 package primitives
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/core"
@@ -540,3 +542,443 @@ func TestScrollableList_FilteringPattern(t *testing.T) {
 		t.Errorf("Selected item %d not visible with offset %d after filter", sl.SelectedIdx, offset)
 	}
 }
+
+func TestScrollableList_MultiColumnNavigation(t *testing.T) {
+	sl := NewScrollableList(0, 0, 12, 4)
+	items := make([]ListItem, 10)
+	for i := range items {
+		items[i] = ListItem{Text: string(rune('A' + i))}
+	}
+	sl.SetItems(items)
+	sl.SetColumns(3)
+
+	if got := sl.RowCount(); got != 4 {
+		t.Fatalf("expected 4 rows for 10 items in 3 columns, got %d", got)
+	}
+
+	tests := []struct {
+		key  tcell.Key
+		want int
+	}{
+		{tcell.KeyRight, 1},
+		{tcell.KeyRight, 2},
+		{tcell.KeyRight, 2}, // end of row
+		{tcell.KeyDown, 5},
+		{tcell.KeyDown, 8},
+		{tcell.KeyDown, 9}, // partial last row clamps to last item
+		{tcell.KeyLeft, 9}, // first column
+		{tcell.KeyUp, 6},
+	}
+	for i, tc := range tests {
+		sl.HandleKey(tcell.NewEventKey(tc.key, 0, tcell.ModNone))
+		if sl.SelectedIdx != tc.want {
+			t.Fatalf("step %d: expected selection %d, got %d", i, tc.want, sl.SelectedIdx)
+		}
+	}
+}
+
+func TestScrollableList_MultiColumnRenderAndClick(t *testing.T) {
+	sl := NewScrollableList(0, 0, 12, 4)
+	items := make([]ListItem, 6)
+	for i := range items {
+		items[i] = ListItem{Text: string(rune('A' + i))}
+	}
+	sl.SetItems(items)
+	sl.SetColumns(3)
+
+	var rects []core.Rect
+	sl.RenderItem = func(p *core.Painter, rect core.Rect, item ListItem, selected bool) {
+		rects = append(rects, rect)
+	}
+	buf := make([][]core.Cell, 4)
+	for i := range buf {
+		buf[i] = make([]core.Cell, 12)
+	}
+	sl.Draw(core.NewPainter(buf, core.Rect{W: 12, H: 4}))
+
+	if len(rects) != 6 {
+		t.Fatalf("expected 6 items drawn, got %d", len(rects))
+	}
+	if rects[4].X != 4 || rects[4].Y != 1 || rects[4].W != 4 {
+		t.Errorf("item 4 drawn at unexpected rect %+v", rects[4])
+	}
+
+	sl.HandleMouse(tcell.NewEventMouse(9, 1, tcell.Button1, tcell.ModNone))
+	if sl.SelectedIdx != 5 {
+		t.Errorf("expected click at (9,1) to select item 5, got %d", sl.SelectedIdx)
+	}
+}
+
+func TestScrollableList_MultiSelectKeys(t *testing.T) {
+	sl := NewScrollableList(0, 0, 20, 10)
+	sl.SetItems([]ListItem{{Text: "A"}, {Text: "B"}, {Text: "C"}, {Text: "D"}, {Text: "E"}})
+	sl.MultiSelect = true
+
+	var changes [][]int
+	sl.OnSelectionChange = func(indices []int) { changes = append(changes, indices) }
+
+	space := tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone)
+	sl.HandleKey(space)
+	if got := sl.SelectedIndices(); len(got) != 1 || got[0] != 0 {
+		t.Fatalf("Space should toggle item 0, got %v", got)
+	}
+
+	// Move to item 2, then Shift+Down twice: range 2..4 on top of {0}
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModShift))
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModShift))
+	want := []int{0, 2, 3, 4}
+	if got := sl.SelectedIndices(); len(got) != len(want) {
+		t.Fatalf("expected %v after range select, got %v", want, got)
+	} else {
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %v after range select, got %v", want, got)
+			}
+		}
+	}
+
+	// Shrinking the range back with Shift+Up drops item 4
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModShift))
+	if sl.IsSelected(4) || !sl.IsSelected(3) {
+		t.Errorf("Shift+Up should shrink the range to 2..3, got %v", sl.SelectedIndices())
+	}
+
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyCtrlA, 0, tcell.ModCtrl))
+	if items := sl.SelectedItems(); len(items) != 5 || items[4].Text != "E" {
+		t.Errorf("Ctrl+A should select all items, got %v", items)
+	}
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyCtrlA, 0, tcell.ModCtrl))
+	if len(sl.SelectedIndices()) != 0 {
+		t.Errorf("second Ctrl+A should clear the selection, got %v", sl.SelectedIndices())
+	}
+
+	if len(changes) != 6 {
+		t.Errorf("expected 6 OnSelectionChange calls, got %d", len(changes))
+	}
+}
+
+func TestScrollableList_MultiSelectRendersCheckboxes(t *testing.T) {
+	sl := NewScrollableList(0, 0, 10, 2)
+	sl.SetItems([]ListItem{{Text: "A"}, {Text: "B"}})
+	sl.MultiSelect = true
+	sl.SetSelectedIndices([]int{1})
+
+	buf := make([][]core.Cell, 2)
+	for i := range buf {
+		buf[i] = make([]core.Cell, 10)
+	}
+	sl.Draw(core.NewPainter(buf, core.Rect{W: 10, H: 2}))
+
+	row := func(y int) string {
+		var s []rune
+		for x := 0; x < 5; x++ {
+			s = append(s, buf[y][x].Ch)
+		}
+		return string(s)
+	}
+	if row(0) != "[ ] A" || row(1) != "[x] B" {
+		t.Errorf("unexpected checkbox rendering: %q / %q", row(0), row(1))
+	}
+}
+
+func TestScrollableList_OnNeedMoreAndAppend(t *testing.T) {
+	sl := NewScrollableList(0, 0, 20, 5)
+	items := make([]ListItem, 10)
+	for i := range items {
+		items[i] = ListItem{Text: string(rune('a' + i))}
+	}
+	sl.SetItems(items)
+
+	var calls []int
+	sl.OnNeedMore = func(afterIdx int) { calls = append(calls, afterIdx) }
+
+	// Viewport shows rows 0..4 of 10: not near the end yet
+	sl.SetSelected(2)
+	if len(calls) != 0 {
+		t.Fatalf("OnNeedMore should not fire far from the end, got %v", calls)
+	}
+
+	sl.SetSelected(8)
+	if len(calls) != 1 || calls[0] != 9 {
+		t.Fatalf("expected OnNeedMore(9), got %v", calls)
+	}
+	sl.SetSelected(9)
+	if len(calls) != 1 {
+		t.Errorf("OnNeedMore should fire once per item count, got %v", calls)
+	}
+
+	offset := sl.scrollPane.ScrollOffset()
+	sl.AppendItems(ListItem{Text: "k"}, ListItem{Text: "l"})
+	if sl.SelectedIdx != 9 || sl.scrollPane.ScrollOffset() != offset {
+		t.Errorf("AppendItems should keep selection/scroll, got idx=%d offset=%d (was %d)",
+			sl.SelectedIdx, sl.scrollPane.ScrollOffset(), offset)
+	}
+	if len(sl.Items) != 12 {
+		t.Errorf("expected 12 items after append, got %d", len(sl.Items))
+	}
+
+	sl.SetSelected(11)
+	if len(calls) != 2 || calls[1] != 11 {
+		t.Errorf("expected OnNeedMore(11) after new items, got %v", calls)
+	}
+}
+
+func TestScrollableList_OnNeedMoreNotFromDraw(t *testing.T) {
+	sl := NewScrollableList(0, 0, 20, 5)
+	sl.SetItems([]ListItem{{Text: "a"}, {Text: "b"}})
+
+	var calls []int
+	sl.OnNeedMore = func(afterIdx int) { calls = append(calls, afterIdx) }
+	buf := make([][]core.Cell, 5)
+	for i := range buf {
+		buf[i] = make([]core.Cell, 20)
+	}
+	sl.Draw(core.NewPainter(buf, core.Rect{W: 20, H: 5}))
+	if len(calls) != 0 {
+		t.Fatalf("Draw should not call OnNeedMore, got %v", calls)
+	}
+
+	// A short page leaves the viewport near the end, so ask for the next
+	sl.AppendItems(ListItem{Text: "c"})
+	if len(calls) != 1 || calls[0] != 2 {
+		t.Errorf("expected OnNeedMore(2) after AppendItems, got %v", calls)
+	}
+}
+
+func TestScrollableList_SkipsDisabledAndSeparators(t *testing.T) {
+	sl := NewScrollableList(0, 0, 10, 5)
+	sl.SetItems([]ListItem{
+		{Text: "Open"},
+		{Separator: true},
+		{Text: "Save", Disabled: true},
+		{Text: "Quit"},
+	})
+
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	if sl.SelectedIdx != 3 {
+		t.Errorf("Down should skip separator and disabled item, got %d", sl.SelectedIdx)
+	}
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone))
+	if sl.SelectedIdx != 0 {
+		t.Errorf("Up should skip back to item 0, got %d", sl.SelectedIdx)
+	}
+
+	// Clicking a disabled item is consumed but doesn't select it
+	if !sl.HandleMouse(tcell.NewEventMouse(1, 2, tcell.Button1, tcell.ModNone)) {
+		t.Error("click on disabled item should be consumed")
+	}
+	if sl.SelectedIdx != 0 {
+		t.Errorf("disabled item should not be selectable by mouse, got %d", sl.SelectedIdx)
+	}
+
+	buf := make([][]core.Cell, 5)
+	for i := range buf {
+		buf[i] = make([]core.Cell, 10)
+	}
+	sl.Draw(core.NewPainter(buf, core.Rect{W: 10, H: 5}))
+	if buf[1][0].Ch != '─' || buf[1][9].Ch != '─' {
+		t.Errorf("separator should render as a rule, got %q", buf[1][0].Ch)
+	}
+}
+
+func TestScrollableList_ColumnedItems(t *testing.T) {
+	sl := NewScrollableList(0, 0, 20, 1)
+	sl.SetItems([]ListItem{{
+		Text: "notes.txt",
+		Columns: []ListColumn{
+			{Text: "notes.txt"},
+			{Text: "12K", Width: 5, Align: ColumnAlignRight},
+			{Text: "Jan", Width: 4, Align: ColumnAlignCenter},
+		},
+	}})
+
+	buf := make([][]core.Cell, 1)
+	buf[0] = make([]core.Cell, 20)
+	sl.Draw(core.NewPainter(buf, core.Rect{W: 20, H: 1}))
+
+	var got []rune
+	for _, c := range buf[0] {
+		got = append(got, c.Ch)
+	}
+	// 20 cells: flex name gets 20-5-4-2 = 9, then gap, right-aligned size, gap, centered date
+	if want := "notes.txt   12K Jan "; string(got) != want {
+		t.Errorf("columned item rendered as %q, want %q", string(got), want)
+	}
+}
+
+func TestScrollableList_OnActivateSeparateFromOnChange(t *testing.T) {
+	sl := NewScrollableList(0, 0, 10, 5)
+	sl.SetItems([]ListItem{{Text: "a"}, {Text: "b"}, {Text: "c"}})
+
+	clock := time.Unix(0, 0)
+	sl.now = func() time.Time { return clock }
+
+	var changed, activated []int
+	sl.OnChange = func(i int) { changed = append(changed, i) }
+	sl.OnActivate = func(i int) { activated = append(activated, i) }
+
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	if len(changed) != 1 || len(activated) != 0 {
+		t.Fatalf("arrow should only fire OnChange, got changed=%v activated=%v", changed, activated)
+	}
+	if !sl.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)) {
+		t.Error("Enter should be consumed when OnActivate is set")
+	}
+	if len(activated) != 1 || activated[0] != 1 {
+		t.Fatalf("Enter should activate item 1, got %v", activated)
+	}
+
+	click := func(y int) {
+		sl.HandleMouse(tcell.NewEventMouse(1, y, tcell.Button1, tcell.ModNone))
+		sl.HandleMouse(tcell.NewEventMouse(1, y, tcell.ButtonNone, tcell.ModNone))
+	}
+
+	// Two slow clicks: selection only
+	click(2)
+	clock = clock.Add(time.Second)
+	click(2)
+	if len(activated) != 1 {
+		t.Errorf("slow clicks should not activate, got %v", activated)
+	}
+
+	// Quick second click: double-click activates
+	clock = clock.Add(100 * time.Millisecond)
+	click(2)
+	if len(activated) != 2 || activated[1] != 2 {
+		t.Errorf("double-click should activate item 2, got %v", activated)
+	}
+}
+
+func TestScrollableList_GroupHeaders(t *testing.T) {
+	sl := NewScrollableList(0, 0, 12, 10)
+	sl.SetItems([]ListItem{
+		{Text: "A", Group: "one"},
+		{Text: "B", Group: "one"},
+		{Text: "C", Group: "two"},
+		{Text: "D", Group: "two"},
+	})
+	if sl.RowCount() != 6 {
+		t.Fatalf("expected 6 rows with 2 headers, got %d", sl.RowCount())
+	}
+
+	// Down skips over the "two" header
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	if sl.SelectedIdx != 2 {
+		t.Fatalf("expected cursor on C, got %d", sl.SelectedIdx)
+	}
+
+	// Left collapses the current group and moves the cursor out of it
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+	if !sl.IsGroupCollapsed("two") || sl.RowCount() != 4 {
+		t.Fatalf("expected group two collapsed (4 rows), got collapsed=%v rows=%d",
+			sl.IsGroupCollapsed("two"), sl.RowCount())
+	}
+	if sl.SelectedIdx != 1 {
+		t.Errorf("cursor should move to the nearest visible item, got %d", sl.SelectedIdx)
+	}
+
+	buf := make([][]core.Cell, 10)
+	for i := range buf {
+		buf[i] = make([]core.Cell, 12)
+	}
+	sl.Draw(core.NewPainter(buf, core.Rect{W: 12, H: 10}))
+	line := func(y int) string {
+		var r []rune
+		for _, c := range buf[y] {
+			if c.Ch != 0 {
+				r = append(r, c.Ch)
+			}
+		}
+		return strings.TrimRight(string(r), " ")
+	}
+	if line(0) != "▾ one" || line(3) != "▸ two (2)" {
+		t.Errorf("unexpected headers: %q / %q", line(0), line(3))
+	}
+
+	// Right expands the next collapsed group; clicking a header toggles it
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+	if sl.IsGroupCollapsed("two") {
+		t.Error("Right should expand the next collapsed group")
+	}
+	sl.HandleMouse(tcell.NewEventMouse(1, 0, tcell.Button1, tcell.ModNone))
+	if !sl.IsGroupCollapsed("one") {
+		t.Error("clicking a header should collapse its group")
+	}
+}
+
+func TestScrollableList_CollapseThenExpand(t *testing.T) {
+	sl := NewScrollableList(0, 0, 12, 10)
+	sl.SetItems([]ListItem{
+		{Text: "A", Group: "one"},
+		{Text: "B", Group: "one"},
+		{Text: "C", Group: "two"},
+	})
+	sl.SetSelected(1)
+
+	// Collapsing the first group moves the cursor down into "two"
+	sl.HandleKey(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+	if !sl.IsGroupCollapsed("one") || sl.SelectedIdx != 2 {
+		t.Fatalf("expected group one collapsed with the cursor on C, got %v/%d",
+			sl.IsGroupCollapsed("one"), sl.SelectedIdx)
+	}
+
+	// Right reopens the group above the cursor and returns to B
+	if !sl.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone)) {
+		t.Fatal("expected Right to be handled")
+	}
+	if sl.IsGroupCollapsed("one") || sl.SelectedIdx != 1 {
+		t.Errorf("expected group one expanded with the cursor on B, got %v/%d",
+			sl.IsGroupCollapsed("one"), sl.SelectedIdx)
+	}
+}
+
+func TestScrollableList_StickyGroupHeader(t *testing.T) {
+	sl := NewScrollableList(0, 0, 12, 3)
+	items := make([]ListItem, 6)
+	for i := range items {
+		items[i] = ListItem{Text: string(rune('a' + i)), Group: "all"}
+	}
+	sl.SetItems(items)
+	sl.SetSelected(5)
+
+	buf := make([][]core.Cell, 3)
+	for i := range buf {
+		buf[i] = make([]core.Cell, 12)
+	}
+	sl.Draw(core.NewPainter(buf, core.Rect{W: 12, H: 3}))
+	if buf[0][0].Ch != '▾' {
+		t.Errorf("group header should stick to the top row, got %q", buf[0][0].Ch)
+	}
+}
+
+func TestScrollableList_OnContextMenu(t *testing.T) {
+	sl := NewScrollableList(2, 3, 10, 5)
+	sl.SetItems([]ListItem{{Text: "a"}, {Text: "b"}, {Text: "c"}})
+
+	var gotIdx, gotX, gotY int = -1, -1, -1
+	sl.OnContextMenu = func(idx, x, y int) { gotIdx, gotX, gotY = idx, x, y }
+
+	if !sl.HandleAuxClick(core.AuxClick{Button: tcell.Button2, X: 4, Y: 5}) {
+		t.Fatal("right-click on an item should be handled")
+	}
+	if gotIdx != 2 || gotX != 4 || gotY != 5 || sl.SelectedIdx != 2 {
+		t.Errorf("right-click: got idx=%d at (%d,%d), selected=%d", gotIdx, gotX, gotY, sl.SelectedIdx)
+	}
+	if sl.HandleAuxClick(core.AuxClick{Button: tcell.Button3, X: 4, Y: 5}) {
+		t.Error("middle click should not open the context menu")
+	}
+
+	sl.SetSelected(1)
+	if !sl.HandleKey(tcell.NewEventKey(tcell.KeyF10, 0, tcell.ModShift)) {
+		t.Fatal("Shift+F10 should be consumed")
+	}
+	if gotIdx != 1 || gotX != 2 || gotY != 4 {
+		t.Errorf("menu key: got idx=%d at (%d,%d), want 1 at (2,4)", gotIdx, gotX, gotY)
+	}
+	if sl.HandleKey(tcell.NewEventKey(tcell.KeyF10, 0, tcell.ModNone)) {
+		t.Error("plain F10 should not open the context menu")
+	}
+}
@@ -151,6 +151,33 @@ type TabItem struct {
 	Label string
 	ID    string             // Optional identifier for the tab
 	Color color.DynamicColor // Optional per-tab accent color (zero = use style defaults)
+	Badge string             // Optional badge after the label, e.g. a count or BadgeDot
+
+	// Modified marks unsaved changes with a "•" before the label.
+	Modified bool
+}
+
+// BadgeDot is a badge that flags a tab without a count.
+const BadgeDot = "●"
+
+// modifiedMark prefixes the label of modified tabs.
+const modifiedMark = "• "
+
+// tabText returns the padded label of a tab, including the modified mark.
+func tabText(tab TabItem) string {
+	if tab.Modified {
+		return " " + modifiedMark + tab.Label + " "
+	}
+	return " " + tab.Label + " "
+}
+
+// tabWidth returns the cells a tab takes: its text plus "Badge " if set.
+func tabWidth(tab TabItem) int {
+	w := len([]rune(tabText(tab)))
+	if tab.Badge != "" {
+		w += len([]rune(tab.Badge)) + 1
+	}
+	return w
 }
 
 // TabBar is a horizontal tab navigation widget.
@@ -161,6 +188,14 @@ type TabBar struct {
 	ActiveIdx int
 	OnChange  func(int) // Called when active tab changes
 
+	// OnReorder is called after a tab is moved from one index to another,
+	// by dragging it along the bar or with Alt+Left/Right.
+	OnReorder func(from, to int)
+
+	// OnContextMenu is called on right-click over a tab, or the Menu key
+	// (Shift+F10) on the active tab, with the screen position to open at.
+	OnContextMenu func(idx, screenX, screenY int)
+
 	// Edit mode callbacks
 	OnRename     func(index int, newName string) // Called when edit confirmed via Enter
 	OnEditCancel func(index int)                 // Called when edit cancelled via Escape
@@ -176,6 +211,10 @@ type TabBar struct {
 	// Mouse hover state
 	hoverIdx int // Index of tab under mouse cursor (-1 if none)
 
+	// Drag state
+	mouseDown bool // Button 1 is held (press edges only count as clicks)
+	dragIdx   int  // Index of the tab being dragged (-1 if none)
+
 	// Edit mode state
 	editIdx      int        // Index being edited; -1 when not editing
 	editInput    *tabEditor // Inline text editor for renaming
@@ -202,6 +241,7 @@ func NewTabBar(x, y, w int, tabs []TabItem) *TabBar {
 		ActiveIdx:       0,
 		ShowFocusMarker: true,
 		hoverIdx:        -1,
+		dragIdx:         -1,
 		editIdx:         -1,
 	}
 
@@ -210,14 +250,22 @@ func NewTabBar(x, y, w int, tabs []TabItem) *TabBar {
 	tb.SetFocusable(true)
 
 	// Configure focus style from theme
+	tb.applyTheme()
+
+	return tb
+}
+
+// applyTheme resolves the focused style from the current theme.
+func (tb *TabBar) applyTheme() {
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("text.primary")
 	bg := tm.GetSemanticColor("bg.surface")
 	tb.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
-
-	return tb
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (tb *TabBar) OnThemeChanged() { tb.applyTheme() }
+
 // SetInvalidator allows the UI manager to inject a dirty-region invalidator.
 func (tb *TabBar) SetInvalidator(fn func(core.Rect)) {
 	tb.inv = fn
@@ -238,6 +286,52 @@ func (tb *TabBar) SetActive(idx int) {
 	}
 }
 
+// MoveTab moves the tab at from to index to, shifting the tabs in between.
+// The active tab stays active at its new index.
+func (tb *TabBar) MoveTab(from, to int) {
+	if from < 0 || from >= len(tb.Tabs) || to < 0 || to >= len(tb.Tabs) || from == to {
+		return
+	}
+	tab := tb.Tabs[from]
+	if from < to {
+		copy(tb.Tabs[from:to], tb.Tabs[from+1:to+1])
+	} else {
+		copy(tb.Tabs[to+1:from+1], tb.Tabs[to:from])
+	}
+	tb.Tabs[to] = tab
+
+	switch {
+	case tb.ActiveIdx == from:
+		tb.ActiveIdx = to
+	case from < tb.ActiveIdx && tb.ActiveIdx <= to:
+		tb.ActiveIdx--
+	case to <= tb.ActiveIdx && tb.ActiveIdx < from:
+		tb.ActiveIdx++
+	}
+	tb.invalidate()
+	if tb.OnReorder != nil {
+		tb.OnReorder(from, to)
+	}
+}
+
+// SetBadge sets the badge shown on tab idx. Pass "" to remove it.
+func (tb *TabBar) SetBadge(idx int, badge string) {
+	if idx < 0 || idx >= len(tb.Tabs) || tb.Tabs[idx].Badge == badge {
+		return
+	}
+	tb.Tabs[idx].Badge = badge
+	tb.invalidate()
+}
+
+// SetModified marks tab idx as having unsaved changes.
+func (tb *TabBar) SetModified(idx int, modified bool) {
+	if idx < 0 || idx >= len(tb.Tabs) || tb.Tabs[idx].Modified == modified {
+		return
+	}
+	tb.Tabs[idx].Modified = modified
+	tb.invalidate()
+}
+
 // ActiveTab returns the currently active tab item.
 func (tb *TabBar) ActiveTab() TabItem {
 	if tb.ActiveIdx >= 0 && tb.ActiveIdx < len(tb.Tabs) {
@@ -291,6 +385,8 @@ func (tb *TabBar) Draw(painter *core.Painter) {
 		inactFG = activeBG
 	}
 
+	badgeFG := color.Solid(tm.GetSemanticColor("action.danger"))
+
 	// tabDynBG returns the DynamicColor for tab i's background.
 	tabDynBG := func(i int) color.DynamicColor {
 		if i == tb.ActiveIdx {
@@ -310,7 +406,7 @@ func (tb *TabBar) Draw(painter *core.Painter) {
 	}
 
 	for i, tab := range tb.Tabs {
-		tabLabel := " " + tab.Label + " "
+		tabLabel := tabText(tab)
 		isActive := i == tb.ActiveIdx
 		isHover := i == tb.hoverIdx && !isActive
 
@@ -367,6 +463,18 @@ func (tb *TabBar) Draw(painter *core.Painter) {
 			}
 		}
 
+		// Badge after the label, in the danger color to stand out
+		if tab.Badge != "" {
+			badgeDS := color.DynamicStyle{FG: badgeFG, BG: ds.BG, Attrs: tcell.AttrBold}
+			for _, ch := range tab.Badge + " " {
+				if x >= maxX {
+					break
+				}
+				painter.SetDynamicCell(x, y, ch, badgeDS)
+				x++
+			}
+		}
+
 		// Powerline separator between tabs
 		if i < len(tb.Tabs)-1 && x < maxX {
 			curBG := tabDynBG(i)
@@ -447,6 +555,24 @@ func (tb *TabBar) HandleKey(ev *tcell.EventKey) bool {
 		}
 	}
 
+	// Alt+Left/Right moves the active tab
+	if ev.Modifiers()&tcell.ModAlt != 0 {
+		switch ev.Key() {
+		case tcell.KeyLeft:
+			if tb.ActiveIdx > 0 {
+				tb.MoveTab(tb.ActiveIdx, tb.ActiveIdx-1)
+				return true
+			}
+			return false
+		case tcell.KeyRight:
+			if tb.ActiveIdx < len(tb.Tabs)-1 {
+				tb.MoveTab(tb.ActiveIdx, tb.ActiveIdx+1)
+				return true
+			}
+			return false
+		}
+	}
+
 	switch ev.Key() {
 	case tcell.KeyLeft:
 		if tb.ActiveIdx > 0 {
@@ -488,11 +614,36 @@ func (tb *TabBar) HandleKey(ev *tcell.EventKey) bool {
 			}
 		}
 		return false
+
+	case tcell.KeyMenu, tcell.KeyF10:
+		if ev.Key() == tcell.KeyF10 && ev.Modifiers()&tcell.ModShift == 0 {
+			return false
+		}
+		if tb.OnContextMenu != nil {
+			tb.OnContextMenu(tb.ActiveIdx, tb.tabStartX(tb.ActiveIdx), tb.Rect.Y)
+			return true
+		}
+		return false
 	}
 
 	return false
 }
 
+// HandleAuxClick implements core.AuxClickAware: a right-click over a tab
+// opens its context menu.
+func (tb *TabBar) HandleAuxClick(click core.AuxClick) bool {
+	if click.Button != tcell.Button2 || tb.OnContextMenu == nil || !tb.HitTest(click.X, click.Y) {
+		return false
+	}
+	idx := tb.TabAtX(click.X)
+	if idx < 0 {
+		return false
+	}
+	tb.CancelEdit()
+	tb.OnContextMenu(idx, click.X, click.Y)
+	return true
+}
+
 // HandleMouse processes mouse input for tab selection and hover.
 func (tb *TabBar) HandleMouse(ev *tcell.EventMouse) bool {
 	if len(tb.Tabs) == 0 {
@@ -503,10 +654,7 @@ func (tb *TabBar) HandleMouse(ev *tcell.EventMouse) bool {
 
 	// Check if mouse left the tab bar area
 	if !tb.HitTest(x, y) {
-		if tb.hoverIdx != -1 {
-			tb.hoverIdx = -1
-			tb.invalidate()
-		}
+		tb.ClearHover()
 		return false
 	}
 
@@ -519,7 +667,26 @@ func (tb *TabBar) HandleMouse(ev *tcell.EventMouse) bool {
 		tb.invalidate()
 	}
 
+	held := ev.Buttons()&tcell.Button1 != 0
+	pressed := held && !tb.mouseDown
+	tb.mouseDown = held
+	if !held {
+		tb.dragIdx = -1
+		return true
+	}
+
+	// Dragging a tab over another one moves it there
+	if !pressed {
+		if tb.dragIdx >= 0 && tabIdx >= 0 && tabIdx != tb.dragIdx {
+			tb.CancelEdit()
+			tb.MoveTab(tb.dragIdx, tabIdx)
+			tb.dragIdx = tabIdx
+		}
+		return true
+	}
+
 	// Handle click for tab selection and edit mode
+	tb.dragIdx = tabIdx
 	if ev.Buttons() == tcell.Button1 {
 		if tb.IsEditing() && tabIdx != tb.editIdx {
 			// Click outside editing tab confirms the edit
@@ -539,8 +706,17 @@ func (tb *TabBar) HandleMouse(ev *tcell.EventMouse) bool {
 	return true
 }
 
+// tabStartX returns the absolute x position where tab idx begins.
+func (tb *TabBar) tabStartX(idx int) int {
+	col := tb.Rect.X + 1 // Leading left triangle
+	for i := 0; i < idx && i < len(tb.Tabs); i++ {
+		col += tabWidth(tb.Tabs[i]) + 1 // Tab plus separator
+	}
+	return col
+}
+
 // TabAtX returns the tab index at the given absolute x position, or -1 if none.
-// Layout: [leftTri][" Label "][sep][" Label "][sep]...[rightTri][barBG...]
+// Layout: [leftTri][" • Label Badge "][sep][" Label "][sep]...[rightTri][barBG...]
 func (tb *TabBar) TabAtX(x int) int {
 	col := tb.Rect.X
 
@@ -551,12 +727,12 @@ func (tb *TabBar) TabAtX(x int) int {
 	col++
 
 	for i, tab := range tb.Tabs {
-		tabWidth := len(" " + tab.Label + " ")
+		w := tabWidth(tab)
 
-		if x >= col && x < col+tabWidth {
+		if x >= col && x < col+w {
 			return i
 		}
-		col += tabWidth
+		col += w
 
 		// Separator after each tab (except the last, which has trailing triangle)
 		if i < len(tb.Tabs)-1 {
@@ -571,8 +747,23 @@ func (tb *TabBar) TabAtX(x int) int {
 	return -1
 }
 
-// ClearHover resets the hover state (e.g., when mouse leaves or focus changes).
+// OnHoverEnter implements core.HoverAware. The hovered tab is tracked in
+// HandleMouse.
+func (tb *TabBar) OnHoverEnter() {}
+
+// OnHoverLeave implements core.HoverAware. A tab drag carries on.
+func (tb *TabBar) OnHoverLeave() {
+	if tb.hoverIdx != -1 {
+		tb.hoverIdx = -1
+		tb.invalidate()
+	}
+}
+
+// ClearHover resets the hover and drag state (e.g., when mouse leaves or
+// focus changes).
 func (tb *TabBar) ClearHover() {
+	tb.mouseDown = false
+	tb.dragIdx = -1
 	if tb.hoverIdx != -1 {
 		tb.hoverIdx = -1
 		tb.invalidate()
@@ -644,7 +835,8 @@ func (tb *TabBar) confirmEdit(text string) {
 func (tb *TabBar) GetKeyHints() []core.KeyHint {
 	return []core.KeyHint{
 		{Key: "←→", Label: "Switch"},
-		{Key: "1-9", Label: "Jump"},
+		{Key: "A-←→", Label: "Move", Priority: -1},
+		{Key: "1-9", Label: "Jump", Priority: -1},
 		{Key: "↓", Label: "Content"},
 	}
 }
@@ -579,3 +579,106 @@ func TestTabBar_EditTab_OutOfRange(t *testing.T) {
 		t.Error("expected EditTab(-1) to be a no-op, but IsEditing is true")
 	}
 }
+
+func TestTabBar_ReorderByDragAndAltArrows(t *testing.T) {
+	tabs := []TabItem{{Label: "A"}, {Label: "B"}, {Label: "C"}}
+	tb := NewTabBar(0, 0, 40, tabs)
+
+	var moves [][2]int
+	tb.OnReorder = func(from, to int) { moves = append(moves, [2]int{from, to}) }
+
+	// Layout: [tri][ A ][sep][ B ][sep][ C ][tri] -> A at 1-3, B at 5-7, C at 9-11
+	tb.HandleMouse(tcell.NewEventMouse(2, 0, tcell.Button1, tcell.ModNone))
+	tb.HandleMouse(tcell.NewEventMouse(6, 0, tcell.Button1, tcell.ModNone))
+	tb.HandleMouse(tcell.NewEventMouse(10, 0, tcell.Button1, tcell.ModNone))
+	tb.HandleMouse(tcell.NewEventMouse(10, 0, tcell.ButtonNone, tcell.ModNone))
+
+	if got := tb.Tabs[0].Label + tb.Tabs[1].Label + tb.Tabs[2].Label; got != "BCA" {
+		t.Fatalf("expected A dragged to the end, got %s", got)
+	}
+	if tb.ActiveIdx != 2 || tb.IsEditing() {
+		t.Errorf("dragged active tab should stay active without editing, idx=%d", tb.ActiveIdx)
+	}
+	if len(moves) != 2 {
+		t.Errorf("expected 2 reorder callbacks, got %v", moves)
+	}
+
+	tb.HandleKey(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModAlt))
+	if tb.Tabs[1].Label != "A" || tb.ActiveIdx != 1 {
+		t.Errorf("Alt+Left should move the active tab left, got %v idx=%d", tb.Tabs, tb.ActiveIdx)
+	}
+}
+
+func TestTabBar_Badge(t *testing.T) {
+	tb := NewTabBar(0, 0, 40, []TabItem{{Label: "Inbox"}, {Label: "Logs"}})
+	tb.SetBadge(0, "3")
+
+	buf := makeBuf(40, 2)
+	tb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 2}))
+
+	// Col 1-9: " Inbox 3 ", then the separator at col 10
+	for i, ch := range " Inbox 3 " {
+		if buf[0][1+i].Ch != ch {
+			t.Errorf("expected %c at col %d, got %c", ch, 1+i, buf[0][1+i].Ch)
+		}
+	}
+	if buf[0][10].Ch != plRightTriangle {
+		t.Errorf("expected separator after badge at col 10, got %c", buf[0][10].Ch)
+	}
+
+	// Hit testing accounts for the badge
+	if got := tb.TabAtX(9); got != 0 {
+		t.Errorf("badge cell should belong to tab 0, got %d", got)
+	}
+	if got := tb.TabAtX(11); got != 1 {
+		t.Errorf("expected tab 1 after the badge, got %d", got)
+	}
+
+	tb.SetBadge(0, "")
+	if got := tb.TabAtX(9); got != 1 {
+		t.Errorf("removing the badge should shrink the tab, got %d at col 9", got)
+	}
+}
+
+func TestTabBar_ModifiedMark(t *testing.T) {
+	tb := NewTabBar(0, 0, 40, []TabItem{{Label: "main.go"}, {Label: "b"}})
+	tb.SetModified(0, true)
+
+	buf := makeBuf(40, 2)
+	tb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 2}))
+
+	for i, ch := range []rune(" • main.go ") {
+		if buf[0][1+i].Ch != ch {
+			t.Errorf("expected %c at col %d, got %c", ch, 1+i, buf[0][1+i].Ch)
+		}
+	}
+	if got := tb.TabAtX(11); got != 0 {
+		t.Errorf("modified tab should be wider, got tab %d at col 11", got)
+	}
+}
+
+func TestTabBar_ContextMenuHook(t *testing.T) {
+	tabs := []TabItem{{Label: "A"}, {Label: "B"}, {Label: "C"}}
+	tb := NewTabBar(0, 0, 40, tabs)
+
+	gotIdx, gotX := -1, -1
+	tb.OnContextMenu = func(idx, x, y int) { gotIdx, gotX = idx, x }
+
+	// Right-click on B (columns 5-7) reports it without activating it
+	if !tb.HandleAuxClick(core.AuxClick{Button: tcell.Button2, X: 6, Y: 0}) {
+		t.Fatal("right-click on a tab should be handled")
+	}
+	if gotIdx != 1 || gotX != 6 {
+		t.Fatalf("expected right-click on tab 1 at x=6, got idx=%d x=%d", gotIdx, gotX)
+	}
+	if tb.ActiveIdx != 0 {
+		t.Errorf("right-click should not switch tabs, active=%d", tb.ActiveIdx)
+	}
+
+	// Shift+F10 opens the menu for the active tab at its start column
+	tb.SetActive(2)
+	tb.HandleKey(tcell.NewEventKey(tcell.KeyF10, 0, tcell.ModShift))
+	if gotIdx != 2 || gotX != 9 {
+		t.Errorf("expected Shift+F10 on tab 2 at x=9, got idx=%d x=%d", gotIdx, gotX)
+	}
+}
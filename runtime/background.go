@@ -0,0 +1,82 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: runtime/background.go
+// Summary: Detects the terminal background (light/dark) before the screen starts.
+
+package runtime
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/framegrace/texelui/theme"
+	"golang.org/x/term"
+)
+
+// backgroundQueryTimeout bounds how long we wait for the terminal to answer.
+const backgroundQueryTimeout = 150 * time.Millisecond
+
+// detectBackground decides the background to theme for, in order: the
+// Options.Background override, TEXELUI_BACKGROUND, the terminal's answer
+// to OSC 11 and finally COLORFGBG. The terminal isn't queried when the
+// theme names its palette, since the answer wouldn't be used.
+func detectBackground(opts Options) theme.Background {
+	if opts.Background != theme.BackgroundUnknown {
+		return opts.Background
+	}
+	if bg := theme.BackgroundOverride(); bg != theme.BackgroundUnknown {
+		return bg
+	}
+	if theme.HasExplicitPalette() {
+		return theme.BackgroundUnknown
+	}
+	if bg := queryTerminalBackground(); bg != theme.BackgroundUnknown {
+		return bg
+	}
+	return theme.BackgroundFromColorFGBG(os.Getenv("COLORFGBG"))
+}
+
+// queryTerminalBackground sends OSC 11 followed by a primary device
+// attributes request (DA1) to the controlling terminal. Every terminal
+// answers DA1, so its reply ends the wait early on terminals that ignore
+// OSC 11. Must run before the screen takes over the tty.
+func queryTerminalBackground() theme.Background {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return theme.BackgroundUnknown
+	}
+	defer tty.Close()
+
+	// Without a deadline a silent terminal would block startup
+	if err := tty.SetReadDeadline(time.Now().Add(backgroundQueryTimeout)); err != nil {
+		return theme.BackgroundUnknown
+	}
+	state, err := term.MakeRaw(int(tty.Fd()))
+	if err != nil {
+		return theme.BackgroundUnknown
+	}
+	defer term.Restore(int(tty.Fd()), state)
+
+	if _, err := tty.WriteString("\x1b]11;?\x1b\\\x1b[c"); err != nil {
+		return theme.BackgroundUnknown
+	}
+
+	var resp strings.Builder
+	buf := make([]byte, 256)
+	for {
+		n, err := tty.Read(buf)
+		resp.Write(buf[:n])
+		if err != nil || isDA1Reply(resp.String()) {
+			break
+		}
+	}
+	return theme.ParseOSC11(resp.String())
+}
+
+// isDA1Reply reports whether s contains a complete "\x1b[?...c" reply.
+func isDA1Reply(s string) bool {
+	i := strings.Index(s, "\x1b[?")
+	return i >= 0 && strings.IndexByte(s[i:], 'c') >= 0
+}
@@ -26,6 +26,15 @@ type Options struct {
 	DisableMouse bool
 	OnInit       func(screen tcell.Screen)
 	OnExit       func()
+
+	// Background forces the light or dark palette variant. The default,
+	// theme.BackgroundUnknown, detects it from the terminal unless
+	// TEXELUI_BACKGROUND is set. Themes that name a palette are kept.
+	Background theme.Background
+
+	// ClipboardTools also copies text through wl-copy, xclip, xsel or
+	// pbcopy, for terminals that ignore OSC 52.
+	ClipboardTools bool
 }
 
 var (
@@ -128,6 +137,13 @@ func runApp(app core.App, opts Options) error {
 		exitMu.Unlock()
 	}()
 
+	if err := core.ConfigureLogFromEnv(); err != nil {
+		return err
+	}
+
+	// Query before the screen owns the tty, or tcell would eat the reply
+	background := detectBackground(opts)
+
 	screen, err := screenFactory()
 	if err != nil {
 		return fmt.Errorf("init screen: %w", err)
@@ -137,8 +153,13 @@ func runApp(app core.App, opts Options) error {
 	}
 	defer screen.Fini()
 
+	// Map truecolor styles to what the terminal can show ourselves; tcell's
+	// own fallback may pick from the user-remapped 16 colors
+	theme.SetColorDepth(theme.DetectColorDepth(screen.Colors()))
+
 	// Provide clipboard service to apps that support it (after screen init)
-	clipboard := newStandaloneClipboard(screen)
+	clipboard := core.NewScreenClipboard(screen)
+	clipboard.UseTools = opts.ClipboardTools
 	if ca, ok := app.(core.ClipboardAware); ok {
 		ca.SetClipboardService(clipboard)
 	}
@@ -156,6 +177,9 @@ func runApp(app core.App, opts Options) error {
 	if err := theme.GetLoadError(); err != nil {
 		return fmt.Errorf("theme: %w", err)
 	}
+	if err := theme.UseBackground(background); err != nil {
+		return fmt.Errorf("theme: %w", err)
+	}
 
 	// Detect graphics capability via environment variables
 	var graphicsProvider core.GraphicsProvider
@@ -193,7 +217,7 @@ func runApp(app core.App, opts Options) error {
 				row := buffer[y]
 				for x := 0; x < len(row); x++ {
 					cell := row[x]
-					screen.SetContent(x, y, cell.Ch, nil, cell.Style)
+					screen.SetContent(x, y, cell.Ch, nil, theme.DegradeStyle(cell.Style))
 				}
 			}
 		}
@@ -260,6 +284,18 @@ func runApp(app core.App, opts Options) error {
 				pasteBuffer = nil
 			}
 		case *tcell.EventKey:
+			// Pasted keys are collected first, so a pasted exit key doesn't quit
+			if inPaste {
+				switch tev.Key() {
+				case tcell.KeyRune:
+					pasteBuffer = append(pasteBuffer, []byte(string(tev.Rune()))...)
+				case tcell.KeyEnter, tcell.KeyCtrlJ:
+					pasteBuffer = append(pasteBuffer, '\n')
+				case tcell.KeyTab:
+					pasteBuffer = append(pasteBuffer, '\t')
+				}
+				continue
+			}
 			// Only check ExitKey - don't hardcode Ctrl+C (apps like terminals need it)
 			if opts.ExitKey != tcell.Key(-1) && tev.Key() == opts.ExitKey {
 				if opts.OnExit != nil {
@@ -267,16 +303,8 @@ func runApp(app core.App, opts Options) error {
 				}
 				return nil
 			}
-			if inPaste {
-				if tev.Key() == tcell.KeyRune {
-					pasteBuffer = append(pasteBuffer, []byte(string(tev.Rune()))...)
-				} else if tev.Key() == tcell.KeyEnter || tev.Key() == 10 {
-					pasteBuffer = append(pasteBuffer, '\n')
-				}
-			} else {
-				app.HandleKey(tev)
-				draw()
-			}
+			app.HandleKey(tev)
+			draw()
 		case *tcell.EventMouse:
 			if mh, ok := app.(interface{ HandleMouse(*tcell.EventMouse) }); ok {
 				mh.HandleMouse(tev)
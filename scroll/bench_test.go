@@ -0,0 +1,38 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package scroll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/texeluitest"
+)
+
+// BenchmarkScrollPaneLargeContent scrolls a 80x25 pane over 10,000 rows of
+// content and draws it.
+func BenchmarkScrollPaneLargeContent(b *testing.B) {
+	sp := newTestScrollPane(80, 25)
+	sp.SetChild(newMockWidget(0, 0, 80, 10000, false))
+	buf := make([][]core.Cell, 25)
+	for y := range buf {
+		buf[y] = make([]core.Cell, 80)
+	}
+	p := core.NewPainter(buf, core.Rect{W: 80, H: 25})
+	b.ReportAllocs()
+	dir := 7
+	for b.Loop() {
+		if !sp.ScrollBy(dir) {
+			dir = -dir
+		}
+		sp.Draw(p)
+	}
+}
+
+func TestPerfBudgets(t *testing.T) {
+	texeluitest.CheckBudgets(t, []texeluitest.Budget{
+		{Name: "ScrollPaneLargeContent", Bench: BenchmarkScrollPaneLargeContent, MaxAllocs: 4, MaxTime: 8 * time.Millisecond},
+	})
+}
@@ -30,10 +30,24 @@ const (
 
 // Default scrollbar characters.
 const (
-	DefaultThumbChar = '█' // Solid block for thumb
-	DefaultTrackChar = '░' // Light shade for track
+	DefaultThumbChar  = '█' // Solid block for thumb
+	DefaultTrackChar  = '░' // Light shade for track
+	DefaultMarkerChar = '━' // Heavy bar for track markers
 )
 
+// ScrollbarMarker annotates a content row on the scrollbar track,
+// e.g. a search hit, an error line or a bookmark.
+type ScrollbarMarker struct {
+	// Row is the content row the marker points at.
+	Row int
+
+	// Style is the marker style, typically a distinct foreground color.
+	Style tcell.Style
+
+	// Char is the marker glyph (default DefaultMarkerChar).
+	Char rune
+}
+
 // ScrollbarConfig configures the appearance of the scrollbar.
 type ScrollbarConfig struct {
 	// Position specifies where the scrollbar is drawn (left or right edge).
@@ -269,6 +283,52 @@ func DrawScrollbar(painter *core.Painter, rect core.Rect, state State, config Sc
 	}
 }
 
+// MarkerTrackRow maps a content row onto a scrollbar track of trackHeight rows.
+// Rows outside the content are clamped to the ends of the track.
+func MarkerTrackRow(row, contentHeight, trackHeight int) int {
+	if trackHeight <= 0 || contentHeight <= 0 {
+		return 0
+	}
+	pos := (row * trackHeight) / contentHeight
+	if pos < 0 {
+		pos = 0
+	}
+	if pos > trackHeight-1 {
+		pos = trackHeight - 1
+	}
+	return pos
+}
+
+// DrawScrollbarMarkers renders markers over a scrollbar drawn by DrawScrollbar.
+// Each marker is placed at the track row proportional to its content row,
+// on top of both track and thumb. Nothing is drawn when the content fits.
+func DrawScrollbarMarkers(painter *core.Painter, rect core.Rect, state State, config ScrollbarConfig, markers []ScrollbarMarker) {
+	if rect.W <= 0 || rect.H <= 0 || len(markers) == 0 || !state.CanScroll() {
+		return
+	}
+	trackHeight := rect.H - 2
+	if trackHeight <= 0 {
+		return
+	}
+
+	var x int
+	switch config.Position {
+	case IndicatorLeft:
+		x = rect.X
+	default:
+		x = rect.X + rect.W - 1
+	}
+
+	for _, m := range markers {
+		ch := m.Char
+		if ch == 0 {
+			ch = DefaultMarkerChar
+		}
+		row := MarkerTrackRow(m.Row, state.ContentHeight, trackHeight)
+		painter.SetCell(x, rect.Y+1+row, ch, m.Style)
+	}
+}
+
 // DrawIndicatorsSimple is a convenience function that draws indicators with default config.
 func DrawIndicatorsSimple(painter *core.Painter, rect core.Rect, state State, style tcell.Style) {
 	DrawIndicators(painter, rect, state, DefaultIndicatorConfig(style))
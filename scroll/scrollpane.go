@@ -8,12 +8,37 @@
 package scroll
 
 import (
+	"time"
+
 	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/animation"
 	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/theme"
 	"github.com/framegrace/texelui/core"
 )
 
+// Smooth scrolling tuning.
+const (
+	smoothScrollDuration = 120 * time.Millisecond // Time to reach the target offset
+	wheelMomentumWindow  = 80 * time.Millisecond  // Max gap between wheel events to build momentum
+	wheelStep            = 3                      // Rows per wheel notch
+	wheelMaxStreak       = 3                      // Momentum cap: step grows up to wheelStep*(1+wheelMaxStreak)
+)
+
+// ScrollAnchor selects what keeps its visual position when the content
+// height of a ScrollPane changes.
+type ScrollAnchor int
+
+const (
+	// AnchorTop keeps the scroll offset, so rows inserted above the
+	// viewport push the visible rows down (default).
+	AnchorTop ScrollAnchor = iota
+	// AnchorBottom keeps the distance to the end of the content, so rows
+	// prepended above the viewport (chat history, log backfill) leave the
+	// visible rows where they are.
+	AnchorBottom
+)
+
 // ScrollPane is a container widget that scrolls its child when content exceeds the viewport.
 // It handles vertical scrolling with keyboard and mouse wheel input.
 type ScrollPane struct {
@@ -28,11 +53,27 @@ type ScrollPane struct {
 	indicatorConfig IndicatorConfig
 	lastFocused     core.Widget // Track focused widget for auto-scroll on focus change
 	trapsFocus      bool        // If true, wraps focus at boundaries instead of returning false
+	anchor          ScrollAnchor
+	followTail      bool // Follow-tail mode enabled
+	tailing         bool // Currently pinned to the bottom (follow-tail engaged)
 
 	// Scrollbar mouse interaction state
 	draggingThumb   bool // True when thumb is being dragged
 	dragStartY      int  // Y position where drag started
 	dragStartOffset int  // Scroll offset when drag started
+
+	// Scrollbar annotations (search hits, errors, bookmarks)
+	markers []ScrollbarMarker
+
+	// Smooth scrolling: state.Offset always holds the target offset; the
+	// rendered offset is interpolated towards it by scrollAnim.
+	smoothScroll bool
+	scrollAnim   *animation.Timeline
+	drawnOffset  int // Offset the child was last positioned with
+	lastWheel    time.Time
+	wheelDir     int
+	wheelStreak  int
+	now          func() time.Time // Clock, replaceable in tests
 }
 
 // NewScrollPane creates a new scroll pane.
@@ -40,15 +81,21 @@ type ScrollPane struct {
 func NewScrollPane() *ScrollPane {
 	sp := &ScrollPane{
 		showIndicators: true,
+		now:            core.Now,
 	}
 	sp.Resize(1, 1)
 	sp.SetFocusable(true) // ScrollPane must be focusable to receive key events
+	sp.applyTheme()
 
-	// Resolve default colors from theme
+	return sp
+}
+
+// applyTheme resolves the default and scrollbar styles from the current theme.
+func (sp *ScrollPane) applyTheme() {
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("text.primary")
 	bg := tm.GetSemanticColor("bg.surface")
-	sp.Style = color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)}
+	sp.SetThemeStyle(&sp.Style, color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)})
 
 	// Set up scrollbar with text.primary color for thumb
 	thumbStyle := tcell.StyleDefault.Foreground(fg).Background(bg)
@@ -63,15 +110,17 @@ func NewScrollPane() *ScrollPane {
 
 	// Also set IndicatorStyle for backwards compatibility
 	sp.IndicatorStyle = thumbStyle
-
-	return sp
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (sp *ScrollPane) OnThemeChanged() { sp.applyTheme() }
+
 // SetChild sets the child widget to be scrolled.
 // The child's position will be managed by the scroll pane.
 func (sp *ScrollPane) SetChild(child core.Widget) {
 	sp.child = child
 	if child != nil {
+		core.InvalidateCascade()
 		// Propagate invalidator if set
 		if sp.inv != nil {
 			if ia, ok := child.(core.InvalidationAware); ok {
@@ -93,9 +142,7 @@ func (sp *ScrollPane) GetChild() core.Widget {
 // SetContentHeight explicitly sets the content height.
 // Use this when the child widget doesn't report its full height.
 func (sp *ScrollPane) SetContentHeight(h int) {
-	sp.contentHeight = h
-	// Preserve existing offset when updating content height
-	sp.state = sp.state.WithContentHeight(h).WithViewportHeight(sp.Rect.H)
+	sp.applyContentHeight(h)
 	// Resize child to match viewport width and new content height
 	if sp.child != nil {
 		sp.child.Resize(sp.Rect.W, h)
@@ -125,8 +172,75 @@ func (sp *ScrollPane) updateContentHeight() {
 		return
 	}
 	_, h := sp.child.Size()
+	sp.applyContentHeight(h)
+}
+
+// applyContentHeight updates the content height, adjusting the offset
+// according to the scroll anchor.
+func (sp *ScrollPane) applyContentHeight(h int) {
+	delta := h - sp.contentHeight
 	sp.contentHeight = h
-	sp.state = sp.state.WithContentHeight(h).WithViewportHeight(sp.Rect.H)
+	state := sp.state.WithViewportHeight(sp.Rect.H)
+	if sp.followTail && sp.tailing {
+		// Pinned to the bottom: new content scrolls into view
+		state = state.WithContentHeight(h).ScrollToBottom()
+		if sp.scrollAnim != nil {
+			sp.scrollAnim.Clear()
+		}
+	} else if sp.anchor == AnchorBottom && delta != 0 && sp.state.ContentHeight > 0 {
+		// Shift by the growth so the same rows stay on screen. This is a
+		// jump, not a scroll: any running transition is dropped.
+		state = state.WithContentHeight(h).WithOffset(sp.state.Offset + delta)
+		if sp.scrollAnim != nil {
+			sp.scrollAnim.Clear()
+		}
+	} else {
+		// Preserve existing offset when updating content height
+		state = state.WithContentHeight(h)
+	}
+	sp.state = state
+}
+
+// SetScrollAnchor sets how the viewport reacts to content height changes.
+// Use AnchorBottom for content that grows above the viewport.
+func (sp *ScrollPane) SetScrollAnchor(anchor ScrollAnchor) {
+	sp.anchor = anchor
+}
+
+// ScrollAnchor returns the current scroll anchor.
+func (sp *ScrollPane) ScrollAnchor() ScrollAnchor {
+	return sp.anchor
+}
+
+// SetFollowTail enables or disables follow-tail mode. While engaged the pane
+// stays pinned to the bottom as the content grows. Scrolling up disengages
+// it; ScrollToBottom (or scrolling back down to the end) re-engages it.
+// Enabling the mode scrolls to the bottom.
+func (sp *ScrollPane) SetFollowTail(enabled bool) {
+	sp.followTail = enabled
+	sp.tailing = enabled
+	if enabled {
+		sp.ScrollToBottom()
+	}
+}
+
+// FollowTail returns whether follow-tail mode is enabled.
+func (sp *ScrollPane) FollowTail() bool {
+	return sp.followTail
+}
+
+// IsFollowingTail returns true if follow-tail mode is enabled and currently
+// engaged, i.e. the pane will track new content at the bottom.
+func (sp *ScrollPane) IsFollowingTail() bool {
+	return sp.followTail && sp.tailing
+}
+
+// trackTail updates follow-tail engagement after a scroll: it is engaged
+// exactly when the viewport shows the end of the content.
+func (sp *ScrollPane) trackTail() {
+	if sp.followTail {
+		sp.tailing = !sp.state.CanScrollDown()
+	}
 }
 
 // SetInvalidator sets the invalidation callback.
@@ -190,24 +304,166 @@ func (sp *ScrollPane) Draw(painter *core.Painter) {
 	// Position child relative to scroll offset.
 	// Child's Y position is adjusted by scroll offset to simulate scrolling.
 	// When offset > 0, child Y becomes negative, moving content "up" out of view.
+	drawState := sp.state
+	if offset, animating := sp.renderOffset(); animating {
+		drawState.Offset = offset
+		painter.MarkAnimated() // Keep frames coming until the animation settles
+	}
+	sp.drawnOffset = drawState.Offset
 	childX := rect.X
-	childY := rect.Y - sp.state.Offset
+	childY := rect.Y - drawState.Offset
 	sp.child.SetPosition(childX, childY)
 
 	// Create a clipped painter for the child so it doesn't draw outside bounds
 	clipped := painter.WithClip(rect)
 	sp.child.Draw(clipped)
+	if core.IsDisabled(sp.child) {
+		cw, ch := sp.child.Size()
+		clipped.SetForeground(core.Rect{X: childX, Y: childY, W: cw, H: ch}, theme.Get().GetSemanticColor("text.muted"))
+	}
 
 	// Draw scroll indicators
 	if sp.showIndicators {
-		DrawIndicators(painter, rect, sp.state, sp.indicatorConfig)
+		DrawIndicators(painter, rect, drawState, sp.indicatorConfig)
+		if sp.indicatorConfig.ShowScrollbar {
+			DrawScrollbarMarkers(painter, rect, drawState, sp.indicatorConfig.Scrollbar, sp.markers)
+		}
 	}
 }
 
+// SetMarkers replaces the scrollbar markers. Each marker is drawn on the
+// track at the position proportional to its content row; clicking it
+// scrolls that row into the center of the viewport.
+func (sp *ScrollPane) SetMarkers(markers []ScrollbarMarker) {
+	sp.markers = append([]ScrollbarMarker(nil), markers...)
+	sp.invalidate()
+}
+
+// AddMarker adds a single scrollbar marker.
+func (sp *ScrollPane) AddMarker(m ScrollbarMarker) {
+	sp.markers = append(sp.markers, m)
+	sp.invalidate()
+}
+
+// ClearMarkers removes all scrollbar markers.
+func (sp *ScrollPane) ClearMarkers() {
+	if len(sp.markers) == 0 {
+		return
+	}
+	sp.markers = nil
+	sp.invalidate()
+}
+
+// Markers returns a copy of the current scrollbar markers.
+func (sp *ScrollPane) Markers() []ScrollbarMarker {
+	return append([]ScrollbarMarker(nil), sp.markers...)
+}
+
+// markerAt returns the marker drawn at the given track row.
+// When several markers share a track row, the topmost content row wins.
+func (sp *ScrollPane) markerAt(trackY, trackHeight int) (ScrollbarMarker, bool) {
+	var found ScrollbarMarker
+	ok := false
+	for _, m := range sp.markers {
+		if MarkerTrackRow(m.Row, sp.state.ContentHeight, trackHeight) != trackY {
+			continue
+		}
+		if !ok || m.Row < found.Row {
+			found, ok = m, true
+		}
+	}
+	return found, ok
+}
+
+// SetSmoothScroll enables or disables animated scrolling.
+// When enabled, ScrollBy/ScrollTo and friends update the logical offset
+// immediately but the viewport glides to it over a few frames, and repeated
+// wheel events build momentum. Honors core.ReduceMotion().
+func (sp *ScrollPane) SetSmoothScroll(enabled bool) {
+	sp.smoothScroll = enabled
+	if !enabled && sp.scrollAnim != nil {
+		sp.scrollAnim.Clear()
+	}
+}
+
+// SmoothScroll returns whether animated scrolling is enabled.
+func (sp *ScrollPane) SmoothScroll() bool {
+	return sp.smoothScroll
+}
+
+// smoothActive returns true if scroll changes should be animated.
+func (sp *ScrollPane) smoothActive() bool {
+	return sp.smoothScroll && !core.ReduceMotion()
+}
+
+// animateFrom starts a transition from the given offset to the current
+// target offset. If a transition is already running it continues from the
+// currently rendered position.
+func (sp *ScrollPane) animateFrom(oldOffset int) {
+	if !sp.smoothActive() {
+		if sp.scrollAnim != nil {
+			sp.scrollAnim.Clear()
+		}
+		return
+	}
+	if sp.scrollAnim == nil {
+		sp.scrollAnim = animation.NewTimeline(0)
+	}
+	now := sp.now()
+	if !sp.scrollAnim.IsAnimating(sp, now) {
+		sp.scrollAnim.AnimateTo(sp, float32(oldOffset), 0, now)
+	}
+	sp.scrollAnim.AnimateTo(sp, float32(sp.state.Offset), smoothScrollDuration, now)
+}
+
+// renderOffset returns the offset to draw with and whether a smooth
+// scroll transition is still in progress.
+func (sp *ScrollPane) renderOffset() (int, bool) {
+	if sp.scrollAnim == nil || !sp.smoothActive() {
+		return sp.state.Offset, false
+	}
+	now := sp.now()
+	if !sp.scrollAnim.IsAnimating(sp, now) {
+		return sp.state.Offset, false
+	}
+	v := sp.scrollAnim.Get(sp, now)
+	offset := int(v + 0.5)
+	// Content may have shrunk mid-animation
+	if offset > sp.state.MaxOffset() {
+		offset = sp.state.MaxOffset()
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return offset, true
+}
+
+// wheelDelta returns the scroll delta for a wheel notch in the given
+// direction (-1 up, +1 down), growing with momentum for rapid repeats.
+func (sp *ScrollPane) wheelDelta(dir int) int {
+	if !sp.smoothActive() {
+		return dir * wheelStep
+	}
+	now := sp.now()
+	if dir == sp.wheelDir && now.Sub(sp.lastWheel) < wheelMomentumWindow {
+		if sp.wheelStreak < wheelMaxStreak {
+			sp.wheelStreak++
+		}
+	} else {
+		sp.wheelStreak = 0
+	}
+	sp.wheelDir = dir
+	sp.lastWheel = now
+	return dir * wheelStep * (1 + sp.wheelStreak)
+}
+
 // Resize updates the viewport dimensions and recalculates scroll state.
 func (sp *ScrollPane) Resize(w, h int) {
 	sp.BaseWidget.Resize(w, h)
 	sp.state = sp.state.WithViewportHeight(h)
+	if sp.followTail && sp.tailing {
+		sp.state = sp.state.ScrollToBottom()
+	}
 	// Resize child width to match viewport; preserve content height for scrolling
 	if sp.child != nil {
 		sp.child.Resize(w, sp.contentHeight)
@@ -219,8 +475,10 @@ func (sp *ScrollPane) Resize(w, h int) {
 func (sp *ScrollPane) ScrollBy(delta int) bool {
 	oldOffset := sp.state.Offset
 	sp.state = sp.state.ScrollBy(delta)
+	sp.trackTail()
 	changed := sp.state.Offset != oldOffset
 	if changed {
+		sp.animateFrom(oldOffset)
 		sp.invalidate()
 	}
 	return changed
@@ -230,7 +488,9 @@ func (sp *ScrollPane) ScrollBy(delta int) bool {
 func (sp *ScrollPane) ScrollTo(row int) {
 	oldOffset := sp.state.Offset
 	sp.state = sp.state.ScrollTo(row)
+	sp.trackTail()
 	if sp.state.Offset != oldOffset {
+		sp.animateFrom(oldOffset)
 		sp.invalidate()
 	}
 }
@@ -239,7 +499,9 @@ func (sp *ScrollPane) ScrollTo(row int) {
 func (sp *ScrollPane) ScrollToCentered(row int) {
 	oldOffset := sp.state.Offset
 	sp.state = sp.state.ScrollToCentered(row)
+	sp.trackTail()
 	if sp.state.Offset != oldOffset {
+		sp.animateFrom(oldOffset)
 		sp.invalidate()
 	}
 }
@@ -248,7 +510,9 @@ func (sp *ScrollPane) ScrollToCentered(row int) {
 func (sp *ScrollPane) ScrollToTop() {
 	oldOffset := sp.state.Offset
 	sp.state = sp.state.ScrollToTop()
+	sp.trackTail()
 	if sp.state.Offset != oldOffset {
+		sp.animateFrom(oldOffset)
 		sp.invalidate()
 	}
 }
@@ -257,7 +521,9 @@ func (sp *ScrollPane) ScrollToTop() {
 func (sp *ScrollPane) ScrollToBottom() {
 	oldOffset := sp.state.Offset
 	sp.state = sp.state.ScrollToBottom()
+	sp.trackTail()
 	if sp.state.Offset != oldOffset {
+		sp.animateFrom(oldOffset)
 		sp.invalidate()
 	}
 }
@@ -284,8 +550,8 @@ func (sp *ScrollPane) EnsureFocusedVisible() {
 	_, widgetH := focused.Size()
 
 	// Calculate widget position relative to scroll pane content
-	// widgetY is screen position, we need content position
-	contentY := widgetY - sp.Rect.Y + sp.state.Offset
+	// widgetY is screen position from the last Draw, we need content position
+	contentY := widgetY - sp.Rect.Y + sp.drawnOffset
 
 	// Check if widget is already fully visible
 	if sp.state.IsRowVisible(contentY) && sp.state.IsRowVisible(contentY+widgetH-1) {
@@ -476,6 +742,32 @@ func (sp *ScrollPane) HandleKey(ev *tcell.EventKey) bool {
 	return false
 }
 
+// HandleNavigation implements core.NavigationTarget for keymap profiles.
+// Only applies when the pane itself has focus (its content isn't focusable).
+func (sp *ScrollPane) HandleNavigation(action core.NavAction) bool {
+	switch action {
+	case core.NavUp:
+		return sp.ScrollBy(-1)
+	case core.NavDown:
+		return sp.ScrollBy(1)
+	case core.NavTop:
+		sp.ScrollToTop()
+		return true
+	case core.NavBottom:
+		sp.ScrollToBottom()
+		return true
+	case core.NavPageUp:
+		return sp.ScrollBy(-sp.Rect.H)
+	case core.NavPageDown:
+		return sp.ScrollBy(sp.Rect.H)
+	case core.NavHalfPageUp:
+		return sp.ScrollBy(-sp.Rect.H / 2)
+	case core.NavHalfPageDown:
+		return sp.ScrollBy(sp.Rect.H / 2)
+	}
+	return false
+}
+
 // scrollbarGeometry returns the scrollbar's X position and thumb start/end rows (relative to rect).
 // Returns scrollbarX, thumbStart, thumbEnd, trackHeight.
 // thumbStart and thumbEnd are relative to the track area (excluding arrows).
@@ -573,9 +865,9 @@ func (sp *ScrollPane) HandleMouse(ev *tcell.EventMouse) bool {
 		}
 		// Child didn't handle it (or no child), ScrollPane handles it
 		if buttons&tcell.WheelUp != 0 {
-			return sp.ScrollBy(-3)
+			return sp.ScrollBy(sp.wheelDelta(-1))
 		}
-		return sp.ScrollBy(3)
+		return sp.ScrollBy(sp.wheelDelta(1))
 	}
 
 	// For non-wheel events, require HitTest
@@ -622,6 +914,11 @@ func (sp *ScrollPane) HandleMouse(ev *tcell.EventMouse) bool {
 					sp.dragStartOffset = sp.state.Offset
 					restoreFocus()
 					return true
+				} else if m, ok := sp.markerAt(trackY, trackHeight); ok {
+					// Click on a marker - jump to its row
+					sp.ScrollToCentered(m.Row)
+					restoreFocus()
+					return true
 				} else if trackY < thumbStart {
 					// Click above thumb - page up
 					sp.ScrollBy(-sp.Rect.H)
@@ -637,8 +934,8 @@ func (sp *ScrollPane) HandleMouse(ev *tcell.EventMouse) bool {
 		}
 	}
 
-	// Route other mouse events to child
-	if sp.child != nil {
+	// Route other mouse events to child, unless it is disabled
+	if sp.child != nil && !core.IsDisabled(sp.child) {
 		if ma, ok := sp.child.(core.MouseAware); ok {
 			return ma.HandleMouse(ev)
 		}
@@ -700,6 +997,7 @@ func (sp *ScrollPane) handleThumbDrag(currentY int) {
 
 	if newOffset != sp.state.Offset {
 		sp.state = sp.state.WithOffset(newOffset)
+		sp.trackTail()
 		sp.invalidate()
 	}
 }
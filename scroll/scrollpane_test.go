@@ -5,6 +5,7 @@ package scroll
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/core"
@@ -953,3 +954,182 @@ func TestScrollPane_MouseScrollbarThumbDrag(t *testing.T) {
 		t.Error("Expected draggingThumb to be false after release")
 	}
 }
+
+// TestScrollPane_ScrollbarMarkers verifies markers are drawn on the track and clicking jumps.
+func TestScrollPane_ScrollbarMarkers(t *testing.T) {
+	buf := createTestBuffer(20, 10)
+	painter := core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 20, H: 10})
+
+	sp := newTestScrollPane(20, 10)
+	child := newMockWidget(0, 0, 19, 100, false)
+	sp.SetChild(child)
+	sp.SetContentHeight(100)
+
+	// Track is 8 rows (1..8): row 75 maps to track row 6 (screen y=7);
+	// out-of-range rows clamp to the last track row (screen y=8).
+	sp.SetMarkers([]ScrollbarMarker{{Row: 75, Char: '!'}, {Row: 500, Char: '?'}})
+	sp.Draw(painter)
+
+	if buf[7][19].Ch != '!' {
+		t.Errorf("expected marker at track row 6, got %q", buf[7][19].Ch)
+	}
+	if buf[8][19].Ch != '?' {
+		t.Errorf("expected out-of-range marker clamped to last track row, got %q", buf[8][19].Ch)
+	}
+
+	sp.HandleMouse(tcell.NewEventMouse(19, 7, tcell.Button1, 0))
+	if got := sp.ScrollOffset(); got != 70 {
+		t.Errorf("expected click on marker to center row 75 (offset 70), got %d", got)
+	}
+
+	sp.ClearMarkers()
+	if len(sp.Markers()) != 0 {
+		t.Error("expected no markers after ClearMarkers")
+	}
+}
+
+func TestScrollPane_AnchorBottomPreservesVisibleRows(t *testing.T) {
+	sp := newTestScrollPane(20, 10)
+	child := newMockWidget(0, 0, 20, 100, false)
+	sp.SetChild(child)
+	sp.ScrollBy(50)
+
+	// Default anchor keeps the offset
+	sp.SetContentHeight(120)
+	if got := sp.ScrollOffset(); got != 50 {
+		t.Errorf("AnchorTop should keep offset 50, got %d", got)
+	}
+
+	// 30 rows prepended: the previously visible rows are now 30 rows further down
+	sp.SetScrollAnchor(AnchorBottom)
+	sp.SetContentHeight(150)
+	if got := sp.ScrollOffset(); got != 80 {
+		t.Errorf("AnchorBottom should shift offset to 80, got %d", got)
+	}
+
+	// Shrinking above the viewport shifts back
+	sp.SetContentHeight(140)
+	if got := sp.ScrollOffset(); got != 70 {
+		t.Errorf("AnchorBottom should shift offset to 70 on shrink, got %d", got)
+	}
+}
+
+func TestScrollPane_FollowTail(t *testing.T) {
+	sp := newTestScrollPane(20, 10)
+	child := newMockWidget(0, 0, 20, 50, false)
+	sp.SetChild(child)
+	sp.SetFollowTail(true)
+	if got := sp.ScrollOffset(); got != 40 {
+		t.Fatalf("enabling follow-tail should scroll to bottom (40), got %d", got)
+	}
+
+	sp.SetContentHeight(60)
+	if got := sp.ScrollOffset(); got != 50 {
+		t.Errorf("pane should stay pinned to the bottom (50), got %d", got)
+	}
+
+	// Scrolling up disengages
+	sp.ScrollBy(-5)
+	if sp.IsFollowingTail() {
+		t.Error("scrolling up should disengage follow-tail")
+	}
+	sp.SetContentHeight(70)
+	if got := sp.ScrollOffset(); got != 45 {
+		t.Errorf("disengaged pane should keep offset 45, got %d", got)
+	}
+
+	// ScrollToBottom re-engages
+	sp.ScrollToBottom()
+	if !sp.IsFollowingTail() {
+		t.Error("ScrollToBottom should re-engage follow-tail")
+	}
+	sp.SetContentHeight(80)
+	if got := sp.ScrollOffset(); got != 70 {
+		t.Errorf("re-engaged pane should follow to 70, got %d", got)
+	}
+}
+
+func TestScrollPane_SmoothScrollInterpolates(t *testing.T) {
+	buf := createTestBuffer(40, 10)
+	painter := core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 10})
+
+	sp := newTestScrollPane(40, 10)
+	child := newMockWidget(0, 0, 40, 100, false)
+	sp.SetChild(child)
+	sp.SetSmoothScroll(true)
+
+	clock := time.Unix(0, 0)
+	sp.now = func() time.Time { return clock }
+
+	sp.ScrollBy(20)
+	if sp.ScrollOffset() != 20 {
+		t.Fatalf("logical offset should jump to target, got %d", sp.ScrollOffset())
+	}
+
+	sp.Draw(painter)
+	if _, y := child.Position(); y != 0 {
+		t.Errorf("first frame should render the start offset, child y = %d", y)
+	}
+
+	clock = clock.Add(smoothScrollDuration / 2)
+	sp.Draw(painter)
+	if _, y := child.Position(); y >= 0 || y <= -20 {
+		t.Errorf("mid-animation child y should be between 0 and -20, got %d", y)
+	}
+
+	clock = clock.Add(smoothScrollDuration)
+	sp.Draw(painter)
+	if _, y := child.Position(); y != -20 {
+		t.Errorf("after animation child y should be -20, got %d", y)
+	}
+}
+
+func TestScrollPane_SmoothScrollHonorsReduceMotion(t *testing.T) {
+	core.SetReduceMotion(true)
+	defer core.SetReduceMotion(false)
+
+	buf := createTestBuffer(40, 10)
+	painter := core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 10})
+
+	sp := newTestScrollPane(40, 10)
+	child := newMockWidget(0, 0, 40, 100, false)
+	sp.SetChild(child)
+	sp.SetSmoothScroll(true)
+
+	sp.ScrollBy(20)
+	sp.Draw(painter)
+	if _, y := child.Position(); y != -20 {
+		t.Errorf("reduce motion should jump straight to the target, child y = %d", y)
+	}
+	if painter.HasAnimations() {
+		t.Error("reduce motion should not request animation frames")
+	}
+}
+
+func TestScrollPane_WheelMomentum(t *testing.T) {
+	sp := newTestScrollPane(40, 10)
+	sp.SetContentHeight(200)
+	sp.SetSmoothScroll(true)
+
+	clock := time.Unix(0, 0)
+	sp.now = func() time.Time { return clock }
+
+	wheel := func() {
+		sp.HandleMouse(tcell.NewEventMouse(5, 5, tcell.WheelDown, tcell.ModNone))
+		clock = clock.Add(20 * time.Millisecond)
+	}
+
+	wheel() // 3
+	wheel() // +6
+	wheel() // +9
+	if sp.ScrollOffset() != 18 {
+		t.Errorf("rapid wheel events should accelerate, offset = %d, want 18", sp.ScrollOffset())
+	}
+
+	// A pause resets momentum
+	clock = clock.Add(time.Second)
+	wheel()
+	if sp.ScrollOffset() != 21 {
+		t.Errorf("momentum should reset after a pause, offset = %d, want 21", sp.ScrollOffset())
+	}
+}
@@ -4,6 +4,7 @@
 // File: texelui/scroll/state.go
 // Summary: Immutable scroll state calculator for viewport scrolling.
 // Provides stateless scroll math that can be reused by any scrollable widget.
+// State2D (state2d.go) combines two of these for viewports that scroll both ways.
 
 package scroll
 
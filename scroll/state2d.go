@@ -0,0 +1,166 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/scroll/state2d.go
+// Summary: Immutable two-axis scroll state for viewports that scroll both ways.
+// Each axis is a State, so the clamp and visibility math is shared with 1D scrolling.
+
+package scroll
+
+// State2D represents the scroll state of a viewport that scrolls both
+// horizontally and vertically (tables, code viewers, unwrapped text).
+// All methods return new State2D values (immutable pattern).
+type State2D struct {
+	ContentWidth   int // Total columns in scrollable content
+	ContentHeight  int // Total rows in scrollable content
+	ViewportWidth  int // Visible columns in viewport
+	ViewportHeight int // Visible rows in viewport
+	OffsetX        int // First visible column
+	OffsetY        int // First visible row
+}
+
+// NewState2D creates a two-axis scroll state with the given dimensions.
+func NewState2D(contentWidth, contentHeight, viewportWidth, viewportHeight int) State2D {
+	s := State2D{
+		ContentWidth:   contentWidth,
+		ContentHeight:  contentHeight,
+		ViewportWidth:  viewportWidth,
+		ViewportHeight: viewportHeight,
+	}
+	return s.Clamp()
+}
+
+// Horizontal returns the horizontal axis as a 1D State
+// (ContentHeight/ViewportHeight/Offset hold the column values).
+func (s State2D) Horizontal() State {
+	return State{ContentHeight: s.ContentWidth, ViewportHeight: s.ViewportWidth, Offset: s.OffsetX}
+}
+
+// Vertical returns the vertical axis as a 1D State.
+func (s State2D) Vertical() State {
+	return State{ContentHeight: s.ContentHeight, ViewportHeight: s.ViewportHeight, Offset: s.OffsetY}
+}
+
+// fromAxes builds a State2D from horizontal and vertical 1D states.
+func fromAxes(h, v State) State2D {
+	return State2D{
+		ContentWidth:   h.ContentHeight,
+		ContentHeight:  v.ContentHeight,
+		ViewportWidth:  h.ViewportHeight,
+		ViewportHeight: v.ViewportHeight,
+		OffsetX:        h.Offset,
+		OffsetY:        v.Offset,
+	}
+}
+
+// Clamp returns a new state with both offsets clamped to valid bounds.
+func (s State2D) Clamp() State2D {
+	return fromAxes(s.Horizontal().Clamp(), s.Vertical().Clamp())
+}
+
+// MaxOffsetX returns the maximum valid horizontal offset.
+func (s State2D) MaxOffsetX() int {
+	return s.Horizontal().MaxOffset()
+}
+
+// MaxOffsetY returns the maximum valid vertical offset.
+func (s State2D) MaxOffsetY() int {
+	return s.Vertical().MaxOffset()
+}
+
+// CanScrollLeft returns true if there is content left of the viewport.
+func (s State2D) CanScrollLeft() bool {
+	return s.Horizontal().CanScrollUp()
+}
+
+// CanScrollRight returns true if there is content right of the viewport.
+func (s State2D) CanScrollRight() bool {
+	return s.Horizontal().CanScrollDown()
+}
+
+// CanScrollUp returns true if there is content above the viewport.
+func (s State2D) CanScrollUp() bool {
+	return s.Vertical().CanScrollUp()
+}
+
+// CanScrollDown returns true if there is content below the viewport.
+func (s State2D) CanScrollDown() bool {
+	return s.Vertical().CanScrollDown()
+}
+
+// CanScrollX returns true if the content is wider than the viewport.
+func (s State2D) CanScrollX() bool {
+	return s.Horizontal().CanScroll()
+}
+
+// CanScrollY returns true if the content is taller than the viewport.
+func (s State2D) CanScrollY() bool {
+	return s.Vertical().CanScroll()
+}
+
+// VisibleCols returns the range of visible columns [start, end).
+func (s State2D) VisibleCols() (start, end int) {
+	return s.Horizontal().VisibleRange()
+}
+
+// VisibleRows returns the range of visible rows [start, end).
+func (s State2D) VisibleRows() (start, end int) {
+	return s.Vertical().VisibleRange()
+}
+
+// IsCellVisible returns true if the given cell is inside the viewport.
+func (s State2D) IsCellVisible(col, row int) bool {
+	return s.Horizontal().IsRowVisible(col) && s.Vertical().IsRowVisible(row)
+}
+
+// ScrollBy returns a new state scrolled by the given deltas.
+// Positive dx scrolls right, positive dy scrolls down.
+func (s State2D) ScrollBy(dx, dy int) State2D {
+	return fromAxes(s.Horizontal().ScrollBy(dx), s.Vertical().ScrollBy(dy))
+}
+
+// ScrollTo returns a new state scrolled to make the given cell visible
+// with minimal movement on each axis.
+func (s State2D) ScrollTo(col, row int) State2D {
+	return fromAxes(s.Horizontal().ScrollTo(col), s.Vertical().ScrollTo(row))
+}
+
+// ScrollToCentered returns a new state with the given cell centered in the viewport.
+func (s State2D) ScrollToCentered(col, row int) State2D {
+	return fromAxes(s.Horizontal().ScrollToCentered(col), s.Vertical().ScrollToCentered(row))
+}
+
+// ScrollToTop returns a new state scrolled to the top, keeping the horizontal offset.
+func (s State2D) ScrollToTop() State2D {
+	return fromAxes(s.Horizontal(), s.Vertical().ScrollToTop())
+}
+
+// ScrollToBottom returns a new state scrolled to the bottom, keeping the horizontal offset.
+func (s State2D) ScrollToBottom() State2D {
+	return fromAxes(s.Horizontal(), s.Vertical().ScrollToBottom())
+}
+
+// ScrollToLineStart returns a new state scrolled fully left, keeping the vertical offset.
+func (s State2D) ScrollToLineStart() State2D {
+	return fromAxes(s.Horizontal().ScrollToTop(), s.Vertical())
+}
+
+// ScrollToLineEnd returns a new state scrolled fully right, keeping the vertical offset.
+func (s State2D) ScrollToLineEnd() State2D {
+	return fromAxes(s.Horizontal().ScrollToBottom(), s.Vertical())
+}
+
+// WithContentSize returns a new state with updated content dimensions.
+func (s State2D) WithContentSize(width, height int) State2D {
+	return fromAxes(s.Horizontal().WithContentHeight(width), s.Vertical().WithContentHeight(height))
+}
+
+// WithViewportSize returns a new state with updated viewport dimensions.
+func (s State2D) WithViewportSize(width, height int) State2D {
+	return fromAxes(s.Horizontal().WithViewportHeight(width), s.Vertical().WithViewportHeight(height))
+}
+
+// WithOffset returns a new state with updated offsets.
+func (s State2D) WithOffset(x, y int) State2D {
+	return fromAxes(s.Horizontal().WithOffset(x), s.Vertical().WithOffset(y))
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package scroll
+
+import "testing"
+
+func TestNewState2D(t *testing.T) {
+	s := NewState2D(200, 100, 40, 20)
+	if s.OffsetX != 0 || s.OffsetY != 0 {
+		t.Errorf("NewState2D offsets = (%d,%d), want (0,0)", s.OffsetX, s.OffsetY)
+	}
+	if s.MaxOffsetX() != 160 || s.MaxOffsetY() != 80 {
+		t.Errorf("max offsets = (%d,%d), want (160,80)", s.MaxOffsetX(), s.MaxOffsetY())
+	}
+	if !s.CanScrollX() || !s.CanScrollY() {
+		t.Error("content larger than viewport should scroll on both axes")
+	}
+}
+
+func TestState2D_Clamp(t *testing.T) {
+	s := State2D{ContentWidth: 50, ContentHeight: 30, ViewportWidth: 20, ViewportHeight: 10, OffsetX: 100, OffsetY: -5}.Clamp()
+	if s.OffsetX != 30 || s.OffsetY != 0 {
+		t.Errorf("Clamp offsets = (%d,%d), want (30,0)", s.OffsetX, s.OffsetY)
+	}
+}
+
+func TestState2D_ScrollToCell(t *testing.T) {
+	s := NewState2D(200, 100, 40, 20)
+
+	s = s.ScrollTo(50, 30)
+	if s.OffsetX != 11 || s.OffsetY != 11 {
+		t.Errorf("ScrollTo(50,30) offsets = (%d,%d), want (11,11)", s.OffsetX, s.OffsetY)
+	}
+	if !s.IsCellVisible(50, 30) {
+		t.Error("target cell should be visible after ScrollTo")
+	}
+	if s.IsCellVisible(5, 30) {
+		t.Error("column left of the viewport should not be visible")
+	}
+
+	s = s.ScrollToCentered(100, 50)
+	if s.OffsetX != 80 || s.OffsetY != 40 {
+		t.Errorf("ScrollToCentered offsets = (%d,%d), want (80,40)", s.OffsetX, s.OffsetY)
+	}
+}
+
+func TestState2D_AxisMovesAreIndependent(t *testing.T) {
+	s := NewState2D(200, 100, 40, 20).WithOffset(10, 10)
+
+	if got := s.ScrollToBottom(); got.OffsetX != 10 || got.OffsetY != 80 {
+		t.Errorf("ScrollToBottom = (%d,%d), want (10,80)", got.OffsetX, got.OffsetY)
+	}
+	if got := s.ScrollToLineEnd(); got.OffsetX != 160 || got.OffsetY != 10 {
+		t.Errorf("ScrollToLineEnd = (%d,%d), want (160,10)", got.OffsetX, got.OffsetY)
+	}
+	if got := s.ScrollBy(-20, 5); got.OffsetX != 0 || got.OffsetY != 15 {
+		t.Errorf("ScrollBy(-20,5) = (%d,%d), want (0,15)", got.OffsetX, got.OffsetY)
+	}
+
+	// Shrinking the content clamps each axis separately
+	if got := s.WithContentSize(45, 100); got.OffsetX != 5 || got.OffsetY != 10 {
+		t.Errorf("WithContentSize = (%d,%d), want (5,10)", got.OffsetX, got.OffsetY)
+	}
+}
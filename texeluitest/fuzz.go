@@ -0,0 +1,92 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/texeluitest/fuzz.go
+// Summary: Plays fuzzer input as a sequence of user actions.
+
+package texeluitest
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// fuzzKeys are the special keys Fuzz presses.
+var fuzzKeys = []tcell.Key{
+	tcell.KeyTab, tcell.KeyBacktab, tcell.KeyEnter, tcell.KeyEscape,
+	tcell.KeyUp, tcell.KeyDown, tcell.KeyLeft, tcell.KeyRight,
+	tcell.KeyHome, tcell.KeyEnd, tcell.KeyPgUp, tcell.KeyPgDn,
+	tcell.KeyBackspace2, tcell.KeyDelete, tcell.KeyInsert, tcell.KeyF12,
+	tcell.KeyCtrlA, tcell.KeyCtrlC, tcell.KeyCtrlV, tcell.KeyCtrlX, tcell.KeyCtrlZ,
+}
+
+// fuzzRunes are typed besides printable ASCII: accented, wide and
+// combining characters.
+var fuzzRunes = []rune{'é', 'ß', '世', '界', '😀', '\u0301'}
+
+var fuzzMods = []tcell.ModMask{tcell.ModNone, tcell.ModShift, tcell.ModCtrl, tcell.ModAlt}
+
+// Fuzz plays data as a sequence of actions: each byte picks an action and
+// the bytes after it its arguments (key, position, size, duration). The
+// actions press keys, type characters, press, release and scroll the mouse
+// anywhere on screen or just outside it, resize the screen, advance the
+// clock and draw requested frames. The same data always plays the same
+// way, so a failing input found by go test -fuzz reproduces:
+//
+//	func FuzzForm(f *testing.F) {
+//		f.Add([]byte("\x01\x41\x00\x00\x00\x07")) // a, Tab, draw
+//		f.Fuzz(func(t *testing.T, data []byte) {
+//			h := texeluitest.New(t, newForm(), 40, 10)
+//			h.Fuzz(data)
+//		})
+//	}
+//
+// A panic in a widget fails the target; check any further invariants
+// after Fuzz returns.
+func (h *Harness) Fuzz(data []byte) {
+	arg := func() int {
+		if len(data) == 0 {
+			return 0
+		}
+		b := data[0]
+		data = data[1:]
+		return int(b)
+	}
+	pos := func() (int, int) {
+		w, ht := h.Size()
+		return arg()%(w+2) - 1, arg()%(ht+2) - 1
+	}
+	for len(data) > 0 {
+		switch arg() % 8 {
+		case 0:
+			key := fuzzKeys[arg()%len(fuzzKeys)]
+			h.PressMod(key, fuzzMods[arg()%len(fuzzMods)])
+		case 1:
+			r := rune(' ' + arg())
+			if r > '~' {
+				if i := int(r - '~' - 1); i < len(fuzzRunes) {
+					r = fuzzRunes[i]
+				} else {
+					r = ' ' + (r-' ')%95
+				}
+			}
+			h.PressRune(r, tcell.ModNone)
+		case 2:
+			x, y := pos()
+			buttons := []tcell.ButtonMask{tcell.Button1, tcell.Button2, tcell.Button3}
+			h.MouseDown(x, y, buttons[arg()%len(buttons)])
+		case 3:
+			h.MouseUp(pos())
+		case 4:
+			x, y := pos()
+			h.Wheel(x, y, arg()%3-1)
+		case 5:
+			h.Resize(1+arg()%100, 1+arg()%40)
+		case 6:
+			h.Advance(time.Duration(arg()) * 10 * time.Millisecond)
+		case 7:
+			h.Step()
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package texeluitest
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/framegrace/texelui/widgets"
+)
+
+func TestHarness_HeadlessStartsNoGoroutines(t *testing.T) {
+	form, _, _ := newTestForm()
+	before := runtime.NumGoroutine()
+	h := New(t, form, 30, 6)
+	sb := widgets.NewStatusBar()
+	h.UI.SetStatusBar(sb)
+	if n := runtime.NumGoroutine(); n != before {
+		t.Errorf("expected no goroutines started, got %d more", n-before)
+	}
+	h.Draw()
+
+	// Changes outside events are drawn when the loop steps
+	sb.ShowMessageWithDuration("Saved", time.Second)
+	h.AssertNotContains("Saved")
+	if !h.Step() {
+		t.Fatal("expected the message to request a frame")
+	}
+	h.AssertContains("Saved")
+	if h.Step() {
+		t.Error("expected no frame requested after drawing")
+	}
+
+	// Messages only expire when the clock is advanced
+	h.Advance(500 * time.Millisecond)
+	h.AssertContains("Saved")
+	h.Advance(time.Second)
+	h.AssertNotContains("Saved")
+}
+
+func FuzzHarness_Form(f *testing.F) {
+	f.Add([]byte("\x01\x41\x00\x00\x00\x07"))
+	f.Add([]byte("\x02\x08\x01\x00\x03\x08\x01\x05\x09\x02\x04\x05\x01\x02"))
+	f.Add([]byte("\x00\x0f\x00\x00\x04\x00\x01\x60\x01\x61\x06\xff\x07"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		form, _, _ := newTestForm()
+		h := New(t, form, 30, 4)
+		h.Fuzz(data)
+		if w, ht := h.Size(); len(h.UI.Render()) != ht || len(h.UI.Render()[0]) != w {
+			t.Errorf("frame does not match the %dx%d screen", w, ht)
+		}
+	})
+}
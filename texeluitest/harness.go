@@ -0,0 +1,435 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/texeluitest/harness.go
+// Summary: UIManager on a tcell SimulationScreen for interaction tests.
+
+// Package texeluitest runs a widget tree in a UIManager drawn to a tcell
+// SimulationScreen, so widgets and apps can be tested the way a user drives
+// them: send keys and mouse events, move a fake clock forward, and check
+// what ended up on screen.
+//
+//	h := texeluitest.New(t, form, 40, 10)
+//	h.Type("alice")
+//	h.ClickText("Save")
+//	h.AssertContains("Saved")
+//
+// The UIManager runs headless (see core.UIManager.Headless): nothing
+// happens behind the test's back, so a run depends only on the events sent,
+// which makes the harness suitable for fuzzing event sequences (see Fuzz).
+package texeluitest
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/core"
+)
+
+// Epoch is the time the harness clock starts at.
+var Epoch = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// Harness drives a UIManager drawn to a SimulationScreen. Every event
+// helper redraws the screen after the event is handled, so assertions
+// always see the current frame.
+type Harness struct {
+	T      testing.TB
+	UI     *core.UIManager
+	Screen tcell.SimulationScreen
+
+	mu      sync.Mutex
+	now     time.Time
+	refresh chan bool
+}
+
+// New creates a w x h screen showing root, focused, and installs the
+// harness clock as core's clock until the test ends. Tests using a harness
+// must not run in parallel with other tests reading the clock.
+func New(t testing.TB, root core.Widget, w, h int) *Harness {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("UTF-8")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("texeluitest: init screen: %v", err)
+	}
+	screen.SetSize(w, h)
+	t.Cleanup(screen.Fini)
+
+	hr := &Harness{T: t, Screen: screen, now: Epoch, refresh: make(chan bool, 1)}
+	core.SetClock(hr.Now)
+	t.Cleanup(func() { core.SetClock(nil) })
+
+	// Frames are drawn by the harness, never by a refresh goroutine
+	hr.UI = core.NewUIManager()
+	hr.UI.Headless = true
+	hr.UI.SetRefreshNotifier(hr.refresh)
+	t.Cleanup(hr.UI.Close)
+	hr.UI.Resize(w, h)
+	if root != nil {
+		hr.UI.SetRootWidget(root)
+		hr.UI.Focus(root)
+	}
+	hr.Draw()
+	return hr
+}
+
+// Draw renders the dirty regions of the UI to the screen.
+func (h *Harness) Draw() {
+	h.RefreshRequested()
+	buf := h.UI.Render()
+	for y, row := range buf {
+		for x, cell := range row {
+			h.Screen.SetContent(x, y, cell.Ch, nil, cell.Style)
+		}
+	}
+	h.Screen.Show()
+}
+
+// RefreshRequested reports whether the UI asked to be redrawn since the
+// last frame, and forgets the request.
+func (h *Harness) RefreshRequested() bool {
+	select {
+	case <-h.refresh:
+		return true
+	default:
+		return false
+	}
+}
+
+// Step draws a frame if the UI asked for one since the last frame, as the
+// runtime does when its refresh notifier fires, and reports whether it
+// did. Use it after changing widgets outside of an event.
+func (h *Harness) Step() bool {
+	if !h.RefreshRequested() {
+		return false
+	}
+	h.Draw()
+	return true
+}
+
+// Redraw repaints the whole UI, as after a theme change or a resize.
+func (h *Harness) Redraw() {
+	h.UI.InvalidateAll()
+	h.Draw()
+}
+
+// Resize changes the screen size and lays the UI out again.
+func (h *Harness) Resize(w, ht int) {
+	h.Screen.SetSize(w, ht)
+	h.UI.Resize(w, ht)
+	h.Draw()
+}
+
+// Size returns the screen size.
+func (h *Harness) Size() (int, int) {
+	return h.Screen.Size()
+}
+
+// Focused returns the deepest focused widget, or nil.
+func (h *Harness) Focused() core.Widget {
+	root := h.UI.RootWidget()
+	if root == nil {
+		return nil
+	}
+	return core.FindDeepFocused(root)
+}
+
+// Time
+
+// Now returns the harness clock, which only moves with Advance.
+func (h *Harness) Now() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.now
+}
+
+// Advance moves the clock forward by d, runs one tick of a status bar
+// (expiring its messages and refreshing its clock and info segments) and
+// redraws everything, so animations are drawn at the new time.
+func (h *Harness) Advance(d time.Duration) {
+	h.mu.Lock()
+	h.now = h.now.Add(d)
+	h.mu.Unlock()
+	if sb, ok := h.UI.StatusBar().(interface{ Tick() }); ok {
+		sb.Tick()
+	}
+	h.Redraw()
+}
+
+// Send dispatches a key, mouse, paste, resize or interrupt event the way
+// the runtime does and redraws. It reports whether the UI handled it.
+func (h *Harness) Send(ev tcell.Event) bool {
+	switch ev := ev.(type) {
+	case *tcell.EventKey:
+		return h.SendKey(ev)
+	case *tcell.EventMouse:
+		return h.SendMouse(ev)
+	case *tcell.EventPaste:
+		handled := h.UI.HandlePasteEvent(ev)
+		h.Draw()
+		return handled
+	case *tcell.EventResize:
+		w, ht := ev.Size()
+		h.Resize(w, ht)
+		return true
+	case *tcell.EventInterrupt:
+		return h.Step()
+	}
+	return false
+}
+
+// Keyboard
+
+// SendKey dispatches ev and redraws. It reports whether the UI handled it.
+func (h *Harness) SendKey(ev *tcell.EventKey) bool {
+	handled := h.UI.HandleKey(ev)
+	h.Draw()
+	return handled
+}
+
+// Press sends a special key, such as tcell.KeyTab or tcell.KeyEnter.
+func (h *Harness) Press(key tcell.Key) bool {
+	return h.SendKey(tcell.NewEventKey(key, 0, tcell.ModNone))
+}
+
+// PressMod sends a key with modifiers, such as Shift+Tab.
+func (h *Harness) PressMod(key tcell.Key, mod tcell.ModMask) bool {
+	return h.SendKey(tcell.NewEventKey(key, 0, mod))
+}
+
+// PressRune sends a character key with modifiers, such as Alt+f.
+func (h *Harness) PressRune(r rune, mod tcell.ModMask) bool {
+	return h.SendKey(tcell.NewEventKey(tcell.KeyRune, r, mod))
+}
+
+// Type sends text one character at a time; '\n' is sent as Enter and
+// '\t' as Tab.
+func (h *Harness) Type(text string) {
+	for _, r := range text {
+		switch r {
+		case '\n':
+			h.Press(tcell.KeyEnter)
+		case '\t':
+			h.Press(tcell.KeyTab)
+		default:
+			h.PressRune(r, tcell.ModNone)
+		}
+	}
+}
+
+// Paste sends text as a bracketed paste: a start EventPaste, the text
+// typed as by Type, and an end EventPaste. It reports whether the focused
+// widget took the text.
+func (h *Harness) Paste(text string) bool {
+	h.Send(tcell.NewEventPaste(true))
+	h.Type(text)
+	return h.Send(tcell.NewEventPaste(false))
+}
+
+// Mouse
+
+// SendMouse dispatches ev and redraws. It reports whether the UI handled it.
+func (h *Harness) SendMouse(ev *tcell.EventMouse) bool {
+	handled := h.UI.HandleMouse(ev)
+	h.Draw()
+	return handled
+}
+
+// MouseDown presses buttons at (x, y).
+func (h *Harness) MouseDown(x, y int, buttons tcell.ButtonMask) bool {
+	return h.SendMouse(tcell.NewEventMouse(x, y, buttons, tcell.ModNone))
+}
+
+// MouseUp releases all buttons at (x, y).
+func (h *Harness) MouseUp(x, y int) bool {
+	return h.SendMouse(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+}
+
+// Hover moves the mouse to (x, y) with no button pressed.
+func (h *Harness) Hover(x, y int) bool {
+	return h.MouseUp(x, y)
+}
+
+// Click presses and releases the primary button at (x, y).
+func (h *Harness) Click(x, y int) {
+	h.MouseDown(x, y, tcell.Button1)
+	h.MouseUp(x, y)
+}
+
+// Drag presses the primary button at (x1, y1), moves to (x2, y2) and
+// releases it there.
+func (h *Harness) Drag(x1, y1, x2, y2 int) {
+	h.MouseDown(x1, y1, tcell.Button1)
+	h.MouseDown(x2, y2, tcell.Button1)
+	h.MouseUp(x2, y2)
+}
+
+// Wheel scrolls at (x, y): down by dy notches, or up when dy is negative.
+func (h *Harness) Wheel(x, y, dy int) {
+	btn := tcell.WheelDown
+	if dy < 0 {
+		btn, dy = tcell.WheelUp, -dy
+	}
+	for range dy {
+		h.MouseDown(x, y, btn)
+	}
+}
+
+// ClickText clicks the first character of the first occurrence of text on
+// screen, failing the test if it is not shown.
+func (h *Harness) ClickText(text string) {
+	h.T.Helper()
+	x, y, ok := h.Find(text)
+	if !ok {
+		h.T.Fatalf("texeluitest: %q not on screen:\n%s", text, h.ScreenText())
+	}
+	h.Click(x, y)
+}
+
+// Screen contents
+
+// Cell returns the text and style of the cell at (x, y).
+func (h *Harness) Cell(x, y int) (string, tcell.Style) {
+	str, style, _ := h.Screen.Get(x, y)
+	if str == "" {
+		str = " "
+	}
+	return str, style
+}
+
+// Text returns the text of region r, one line per row, with trailing
+// spaces trimmed.
+func (h *Harness) Text(r core.Rect) string {
+	lines := make([]string, 0, r.H)
+	for y := r.Y; y < r.Y+r.H; y++ {
+		var sb strings.Builder
+		for x := r.X; x < r.X+r.W; x++ {
+			str, _ := h.Cell(x, y)
+			sb.WriteString(str)
+		}
+		lines = append(lines, strings.TrimRight(sb.String(), " "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Row returns the text of screen row y, with trailing spaces trimmed.
+func (h *Harness) Row(y int) string {
+	w, _ := h.Size()
+	return h.Text(core.Rect{X: 0, Y: y, W: w, H: 1})
+}
+
+// ScreenText returns the text of the whole screen.
+func (h *Harness) ScreenText() string {
+	return h.Text(h.bounds())
+}
+
+// Find returns the position of the first occurrence of text on screen,
+// searching row by row. Text does not match across rows.
+func (h *Harness) Find(text string) (x, y int, ok bool) {
+	want := []rune(text)
+	if len(want) == 0 {
+		return 0, 0, false
+	}
+	w, ht := h.Size()
+	for y := 0; y < ht; y++ {
+		for x := 0; x+len(want) <= w; x++ {
+			match := true
+			for i, r := range want {
+				if str, _ := h.Cell(x+i, y); str != string(r) {
+					match = false
+					break
+				}
+			}
+			if match {
+				return x, y, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (h *Harness) bounds() core.Rect {
+	w, ht := h.Size()
+	return core.Rect{W: w, H: ht}
+}
+
+// Assertions
+
+// AssertText fails the test unless region r shows want, compared as Text
+// returns it.
+func (h *Harness) AssertText(r core.Rect, want string) {
+	h.T.Helper()
+	if got := h.Text(r); got != want {
+		h.T.Errorf("texeluitest: text at %+v:\n got: %q\nwant: %q", r, got, want)
+	}
+}
+
+// AssertRow fails the test unless screen row y shows want.
+func (h *Harness) AssertRow(y int, want string) {
+	h.T.Helper()
+	if got := h.Row(y); got != want {
+		h.T.Errorf("texeluitest: row %d:\n got: %q\nwant: %q", y, got, want)
+	}
+}
+
+// AssertContains fails the test unless text is on screen.
+func (h *Harness) AssertContains(text string) {
+	h.T.Helper()
+	if _, _, ok := h.Find(text); !ok {
+		h.T.Errorf("texeluitest: %q not on screen:\n%s", text, h.ScreenText())
+	}
+}
+
+// AssertNotContains fails the test if text is on screen.
+func (h *Harness) AssertNotContains(text string) {
+	h.T.Helper()
+	if x, y, ok := h.Find(text); ok {
+		h.T.Errorf("texeluitest: %q on screen at (%d, %d):\n%s", text, x, y, h.ScreenText())
+	}
+}
+
+// AssertStyle fails the test unless check accepts the style of every cell
+// of region r. what describes the expectation in the failure message.
+func (h *Harness) AssertStyle(r core.Rect, what string, check func(tcell.Style) bool) {
+	h.T.Helper()
+	for y := r.Y; y < r.Y+r.H; y++ {
+		for x := r.X; x < r.X+r.W; x++ {
+			if _, style := h.Cell(x, y); !check(style) {
+				fg, bg, attrs := style.Decompose()
+				h.T.Errorf("texeluitest: cell (%d, %d) is not %s (fg %v, bg %v, attrs %v)", x, y, what, fg, bg, attrs)
+				return
+			}
+		}
+	}
+}
+
+// AssertFG fails the test unless every cell of region r has foreground fg.
+func (h *Harness) AssertFG(r core.Rect, fg tcell.Color) {
+	h.T.Helper()
+	h.AssertStyle(r, "foreground "+fg.String(), func(s tcell.Style) bool {
+		got, _, _ := s.Decompose()
+		return got == fg
+	})
+}
+
+// AssertBG fails the test unless every cell of region r has background bg.
+func (h *Harness) AssertBG(r core.Rect, bg tcell.Color) {
+	h.T.Helper()
+	h.AssertStyle(r, "background "+bg.String(), func(s tcell.Style) bool {
+		_, got, _ := s.Decompose()
+		return got == bg
+	})
+}
+
+// AssertAttrs fails the test unless every cell of region r has all of
+// attrs set, such as tcell.AttrBold.
+func (h *Harness) AssertAttrs(r core.Rect, attrs tcell.AttrMask) {
+	h.T.Helper()
+	h.AssertStyle(r, "styled with the requested attributes", func(s tcell.Style) bool {
+		_, _, got := s.Decompose()
+		return got&attrs == attrs
+	})
+}
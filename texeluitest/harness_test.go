@@ -0,0 +1,103 @@
+package texeluitest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/widgets"
+)
+
+func newTestForm() (*widgets.Form, *widgets.Input, *widgets.Button) {
+	form := widgets.NewFormWithConfig(widgets.FormConfig{LabelWidth: 6})
+	name := widgets.NewInput()
+	save := widgets.NewButton("Save")
+	form.AddField("Name", name)
+	form.AddField("", save)
+	return form, name, save
+}
+
+func TestHarness_TypeAndClick(t *testing.T) {
+	form, name, save := newTestForm()
+	saved := ""
+	save.OnClick = func() { saved = name.Text }
+
+	h := New(t, form, 30, 4)
+	if h.Focused() != name {
+		t.Fatalf("expected the input focused first, got %T", h.Focused())
+	}
+	h.Type("alice")
+	h.AssertContains("alice")
+	if x, y, ok := h.Find("alice"); !ok || y != 0 || x < 6 {
+		t.Errorf("expected alice in the field on row 0, got (%d, %d) ok=%v", x, y, ok)
+	}
+
+	h.ClickText("Save")
+	if saved != "alice" {
+		t.Errorf("expected the click to save %q, got %q", "alice", saved)
+	}
+
+	if h.Focused() != save {
+		t.Fatalf("expected the click to focus the button, got %T", h.Focused())
+	}
+	h.Press(tcell.KeyBacktab)
+	if h.Focused() != name {
+		t.Errorf("expected Shift+Tab to go back to the input, got %T", h.Focused())
+	}
+}
+
+func TestHarness_TextAndStyles(t *testing.T) {
+	label := widgets.NewLabel("Hello")
+	h := New(t, label, 10, 3)
+
+	// Labels center their text vertically
+	h.AssertRow(1, "Hello")
+	h.AssertText(core.Rect{X: 1, Y: 1, W: 3, H: 2}, "ell\n")
+	if str, _ := h.Cell(4, 1); str != "o" {
+		t.Errorf("expected o at (4, 1), got %q", str)
+	}
+	_, style := h.Cell(0, 1)
+	fg, bg, _ := style.Decompose()
+	h.AssertFG(core.Rect{Y: 1, W: 5, H: 1}, fg)
+	h.AssertBG(core.Rect{Y: 1, W: 5, H: 1}, bg)
+
+	h.Resize(3, 1)
+	h.AssertRow(0, "Hel")
+}
+
+func TestHarness_AdvanceExpiresStatusMessages(t *testing.T) {
+	form, _, _ := newTestForm()
+	h := New(t, form, 40, 6)
+	sb := widgets.NewStatusBar()
+	h.UI.SetStatusBar(sb)
+	t.Cleanup(sb.Stop)
+
+	if got := h.Now(); !got.Equal(Epoch) || !core.Now().Equal(Epoch) {
+		t.Fatalf("expected the clock at the epoch, got %v", got)
+	}
+	sb.ShowMessageWithDuration("Saved", 2*time.Second)
+	h.Draw()
+	h.AssertContains("Saved")
+
+	h.Advance(time.Second)
+	h.AssertContains("Saved")
+	h.Advance(time.Second)
+	h.AssertNotContains("Saved")
+}
+
+func TestHarness_PasteKeepsFocus(t *testing.T) {
+	form, name, _ := newTestForm()
+	h := New(t, form, 30, 4)
+
+	if !h.Paste("bob\nsmith") {
+		t.Fatal("expected the input to take the paste")
+	}
+	if name.Text != "bob smith" {
+		t.Errorf("expected %q, got %q", "bob smith", name.Text)
+	}
+	if h.Focused() != name {
+		t.Errorf("expected a multi-line paste to keep focus, got %T", h.Focused())
+	}
+}
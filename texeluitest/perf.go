@@ -0,0 +1,78 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/texeluitest/perf.go
+// Summary: Fails tests when benchmarks exceed their allocation and time budgets.
+
+package texeluitest
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// PerfEnv names the environment variable that enables CheckBudgets. Set it
+// to 1, or to a factor time budgets are scaled by on slow machines (2
+// doubles them). Allocation budgets are never scaled.
+const PerfEnv = "TEXELUI_PERF"
+
+// Budget is the most a benchmark may cost per operation. Zero fields are
+// not checked.
+type Budget struct {
+	Name      string
+	Bench     func(b *testing.B)
+	MaxAllocs int64         // Allocations per operation
+	MaxBytes  int64         // Bytes allocated per operation
+	MaxTime   time.Duration // Time per operation, before scaling
+}
+
+// CheckBudgets runs each benchmark and fails t for every budget it exceeds.
+// Benchmarks take about a second each, so CheckBudgets skips t unless
+// TEXELUI_PERF is set, as make perf does:
+//
+//	func TestPerfBudgets(t *testing.T) {
+//		texeluitest.CheckBudgets(t, []texeluitest.Budget{
+//			{Name: "Render", Bench: BenchmarkRender, MaxAllocs: 10, MaxTime: time.Millisecond},
+//		})
+//	}
+func CheckBudgets(t *testing.T, budgets []Budget) {
+	t.Helper()
+	scale, ok := perfScale()
+	if !ok {
+		t.Skipf("set %s=1 to check performance budgets", PerfEnv)
+	}
+	for _, bg := range budgets {
+		t.Run(bg.Name, func(t *testing.T) {
+			r := testing.Benchmark(bg.Bench)
+			if r.N == 0 {
+				t.Fatal("benchmark failed")
+			}
+			t.Logf("%s %s", r, r.MemString())
+			if bg.MaxAllocs > 0 && r.AllocsPerOp() > bg.MaxAllocs {
+				t.Errorf("%d allocs/op, budget %d", r.AllocsPerOp(), bg.MaxAllocs)
+			}
+			if bg.MaxBytes > 0 && r.AllocedBytesPerOp() > bg.MaxBytes {
+				t.Errorf("%d B/op, budget %d", r.AllocedBytesPerOp(), bg.MaxBytes)
+			}
+			limit := time.Duration(float64(bg.MaxTime) * scale)
+			if took := time.Duration(r.NsPerOp()); bg.MaxTime > 0 && took > limit {
+				t.Errorf("%v/op, budget %v", took, limit)
+			}
+		})
+	}
+}
+
+// perfScale parses TEXELUI_PERF: false when unset or off, else the time
+// budget factor.
+func perfScale() (float64, bool) {
+	v := os.Getenv(PerfEnv)
+	if v == "" || v == "0" {
+		return 0, false
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil && f > 0 {
+		return f, true
+	}
+	return 1, true
+}
@@ -0,0 +1,161 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texel/theme/background.go
+// Summary: Light/dark terminal background detection and palette variants.
+// Usage: The runtime runner detects the background at startup and calls
+// UseBackground; apps can force a variant with TEXELUI_BACKGROUND.
+
+package theme
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// BackgroundEnv names the environment variable that overrides background
+// detection. Values: "light", "dark" or "auto" (the default).
+const BackgroundEnv = "TEXELUI_BACKGROUND"
+
+// Background is the brightness of the terminal background.
+type Background int
+
+const (
+	// BackgroundUnknown means the background couldn't be determined (or, as
+	// an option, that it should be detected).
+	BackgroundUnknown Background = iota
+	BackgroundDark
+	BackgroundLight
+)
+
+// String returns "dark", "light" or "auto".
+func (b Background) String() string {
+	switch b {
+	case BackgroundDark:
+		return "dark"
+	case BackgroundLight:
+		return "light"
+	}
+	return "auto"
+}
+
+// ParseBackground parses "light" or "dark" (case-insensitive). Anything
+// else, including "auto", is BackgroundUnknown.
+func ParseBackground(s string) Background {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "dark":
+		return BackgroundDark
+	case "light":
+		return BackgroundLight
+	}
+	return BackgroundUnknown
+}
+
+// BackgroundOverride returns the background forced by TEXELUI_BACKGROUND,
+// or BackgroundUnknown if it is unset or "auto".
+func BackgroundOverride() Background {
+	return ParseBackground(os.Getenv(BackgroundEnv))
+}
+
+// BackgroundFromColorFGBG interprets a COLORFGBG value ("fg;bg" or
+// "fg;default;bg", as set by rxvt, Konsole and others). The last field is
+// the ANSI background color: 7 (white) and 9-15 except 8 are light.
+func BackgroundFromColorFGBG(value string) Background {
+	if value == "" {
+		return BackgroundUnknown
+	}
+	fields := strings.Split(value, ";")
+	bg, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || bg < 0 || bg > 15 {
+		return BackgroundUnknown
+	}
+	if bg == 7 || bg > 8 {
+		return BackgroundLight
+	}
+	return BackgroundDark
+}
+
+// ParseOSC11 extracts the background from a terminal's reply to the OSC 11
+// query ("\x1b]11;rgb:rrrr/gggg/bbbb\x07"). resp may contain other output
+// around the reply. Returns BackgroundUnknown if there is no valid reply.
+func ParseOSC11(resp string) Background {
+	start := strings.Index(resp, "]11;")
+	if start < 0 {
+		return BackgroundUnknown
+	}
+	spec := resp[start+len("]11;"):]
+	if end := strings.IndexAny(spec, "\x07\x1b"); end >= 0 {
+		spec = spec[:end]
+	}
+
+	switch {
+	case strings.HasPrefix(spec, "rgb:"):
+		spec = spec[len("rgb:"):]
+	case strings.HasPrefix(spec, "rgba:"):
+		spec = spec[len("rgba:"):]
+	default:
+		return BackgroundUnknown
+	}
+	parts := strings.Split(spec, "/")
+	if len(parts) < 3 {
+		return BackgroundUnknown
+	}
+	var rgb [3]float64
+	for i := range rgb {
+		v, ok := parseColorComponent(parts[i])
+		if !ok {
+			return BackgroundUnknown
+		}
+		rgb[i] = v
+	}
+	return backgroundFromLuminance(0.2126*rgb[0] + 0.7152*rgb[1] + 0.0722*rgb[2])
+}
+
+// parseColorComponent parses a 1-4 digit hex X11 color component into 0..1.
+func parseColorComponent(s string) (float64, bool) {
+	if len(s) < 1 || len(s) > 4 {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, false
+	}
+	return float64(v) / float64(uint64(1)<<(4*len(s))-1), true
+}
+
+func backgroundFromLuminance(lum float64) Background {
+	if lum > 0.5 {
+		return BackgroundLight
+	}
+	return BackgroundDark
+}
+
+// PaletteForBackground returns the built-in palette variant for bg:
+// "latte" for light backgrounds and "mocha" otherwise.
+func PaletteForBackground(bg Background) string {
+	if bg == BackgroundLight {
+		return "latte"
+	}
+	return "mocha"
+}
+
+// HasExplicitPalette reports whether the current theme names its palette
+// (meta.palette). Explicit palettes are never replaced by UseBackground.
+func HasExplicitPalette() bool {
+	return Get().GetString("meta", "palette", "") != ""
+}
+
+// UseBackground switches to the palette variant for bg unless the theme
+// names a palette explicitly or bg is unknown. The theme file is not written.
+func UseBackground(bg Background) error {
+	if bg == BackgroundUnknown || HasExplicitPalette() {
+		return nil
+	}
+	name := PaletteForBackground(bg)
+	if name == "mocha" {
+		// Already the implicit default
+		return nil
+	}
+	return Set(name)
+}
@@ -0,0 +1,74 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package theme
+
+import "testing"
+
+func TestDetectBackgroundSources(t *testing.T) {
+	osc := []struct {
+		resp string
+		want Background
+	}{
+		{"\x1b]11;rgb:ffff/ffff/ffff\x1b\\", BackgroundLight},
+		{"\x1b]11;rgb:1e1e/1e1e/2e2e\x07", BackgroundDark},
+		{"\x1b]11;rgb:ef/f1/f5\x07\x1b[?62;22c", BackgroundLight},
+		{"\x1b]11;rgba:0000/0000/0000/ffff\x07", BackgroundDark},
+		{"\x1b[?62;22c", BackgroundUnknown},
+		{"\x1b]11;rgb:zz/00/00\x07", BackgroundUnknown},
+	}
+	for _, tc := range osc {
+		if got := ParseOSC11(tc.resp); got != tc.want {
+			t.Errorf("ParseOSC11(%q) = %v, want %v", tc.resp, got, tc.want)
+		}
+	}
+
+	fgbg := map[string]Background{
+		"15;0":         BackgroundDark,
+		"0;15":         BackgroundLight,
+		"0;7":          BackgroundLight,
+		"7;8":          BackgroundDark,
+		"12;default;0": BackgroundDark,
+		"":             BackgroundUnknown,
+		"0;default":    BackgroundUnknown,
+	}
+	for value, want := range fgbg {
+		if got := BackgroundFromColorFGBG(value); got != want {
+			t.Errorf("BackgroundFromColorFGBG(%q) = %v, want %v", value, got, want)
+		}
+	}
+
+	t.Setenv(BackgroundEnv, "Light")
+	if got := BackgroundOverride(); got != BackgroundLight {
+		t.Errorf("override = %v, want light", got)
+	}
+	t.Setenv(BackgroundEnv, "auto")
+	if got := BackgroundOverride(); got != BackgroundUnknown {
+		t.Errorf("override = %v, want auto", got)
+	}
+}
+
+func TestUseBackgroundKeepsExplicitPalette(t *testing.T) {
+	defer Apply(Config{})
+
+	if err := Apply(Config{}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := UseBackground(BackgroundLight); err != nil {
+		t.Fatalf("UseBackground: %v", err)
+	}
+	if got := Get().GetString("meta", "palette", ""); got != "latte" {
+		t.Errorf("light background palette = %q, want latte", got)
+	}
+
+	if err := Apply(Config{"meta": Section{"palette": "frappe"}}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	gen := Generation()
+	if err := UseBackground(BackgroundLight); err != nil {
+		t.Fatalf("UseBackground: %v", err)
+	}
+	if got := Get().GetString("meta", "palette", ""); got != "frappe" || Generation() != gen {
+		t.Errorf("explicit palette replaced: %q", got)
+	}
+}
@@ -19,10 +19,13 @@ import (
 // HexColor is a string type for hex color codes, e.g., "#RRGGBB".
 type HexColor string
 
-// ToTcell converts a HexColor string to a tcell.Color.
-// It returns tcell.ColorDefault if the hex string is invalid.
+// ToTcell converts a HexColor string ("#rrggbb" or the short "#rgb") to a
+// tcell.Color. It returns tcell.ColorDefault if the hex string is invalid.
 func (hc HexColor) ToTcell() tcell.Color {
 	s := strings.TrimPrefix(string(hc), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
 	if len(s) != 6 {
 		return tcell.ColorDefault
 	}
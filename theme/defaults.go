@@ -12,9 +12,20 @@ import "log"
 
 // ApplyDefaults ensures all built-in theme keys exist and persists new values.
 func ApplyDefaults(cfg Config) {
-	if cfg == nil {
+	if !fillDefaults(cfg) {
 		return
 	}
+	if err := cfg.Save(); err != nil {
+		log.Printf("theme: failed to save defaults: %v", err)
+	}
+}
+
+// fillDefaults adds the missing built-in theme keys to cfg without saving
+// it. Returns true if any key was added.
+func fillDefaults(cfg Config) bool {
+	if cfg == nil {
+		return false
+	}
 	changed := false
 
 	// Load standard semantic definitions first to ensure "ui" section has basics
@@ -88,12 +99,7 @@ func ApplyDefaults(cfg Config) {
 		changed = true
 	}
 
-	if !changed {
-		return
-	}
-	if err := cfg.Save(); err != nil {
-		log.Printf("theme: failed to save defaults: %v", err)
-	}
+	return changed
 }
 
 func applySectionDefaults(cfg Config, section string, defaults Section) bool {
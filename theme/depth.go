@@ -0,0 +1,145 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texel/theme/depth.go
+// Summary: Color depth detection and truecolor to 256/16 color degradation.
+// Usage: The runtime runner sets the depth at startup and degrades each
+// cell's style before output; TEXELUI_COLORS overrides the detection.
+
+package theme
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// ColorsEnv names the environment variable that overrides color depth
+// detection. Values: "truecolor" (or "24bit"), "256" or "16".
+const ColorsEnv = "TEXELUI_COLORS"
+
+// ColorDepth is the number of colors the terminal can show.
+type ColorDepth int
+
+const (
+	ColorDepthTrue ColorDepth = iota
+	ColorDepth256
+	ColorDepth16
+)
+
+// String returns "truecolor", "256" or "16".
+func (d ColorDepth) String() string {
+	switch d {
+	case ColorDepth256:
+		return "256"
+	case ColorDepth16:
+		return "16"
+	}
+	return "truecolor"
+}
+
+var (
+	depth atomic.Int32
+
+	degradeMu    sync.Mutex
+	degradeCache = make(map[tcell.Color]tcell.Color)
+)
+
+// ParseColorDepth parses a TEXELUI_COLORS value. ok is false if s is not
+// a known depth.
+func ParseColorDepth(s string) (d ColorDepth, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "truecolor", "24bit", "16m":
+		return ColorDepthTrue, true
+	case "256":
+		return ColorDepth256, true
+	case "16", "8":
+		return ColorDepth16, true
+	}
+	return ColorDepthTrue, false
+}
+
+// DetectColorDepth picks the depth from TEXELUI_COLORS, or else from the
+// color count the screen reports (tcell.Screen.Colors). A count of 0
+// (unknown, or NO_COLOR) leaves colors alone.
+func DetectColorDepth(screenColors int) ColorDepth {
+	if d, ok := ParseColorDepth(os.Getenv(ColorsEnv)); ok {
+		return d
+	}
+	switch {
+	case screenColors == 0 || screenColors >= 1<<24:
+		return ColorDepthTrue
+	case screenColors >= 256:
+		return ColorDepth256
+	}
+	return ColorDepth16
+}
+
+// SetColorDepth sets the depth Degrade maps colors to.
+func SetColorDepth(d ColorDepth) {
+	if ColorDepth(depth.Swap(int32(d))) == d {
+		return
+	}
+	degradeMu.Lock()
+	clear(degradeCache)
+	degradeMu.Unlock()
+}
+
+// GetColorDepth returns the current color depth (truecolor by default).
+func GetColorDepth() ColorDepth {
+	return ColorDepth(depth.Load())
+}
+
+// Degrade maps an RGB color to the nearest color the current depth can
+// show. 256-color terminals get the 6x6x6 cube or the gray ramp (16-255),
+// whose values are fixed, rather than the user-configurable first 16.
+// Palette and default colors are returned unchanged.
+func Degrade(c tcell.Color) tcell.Color {
+	d := GetColorDepth()
+	if d == ColorDepthTrue || !c.IsRGB() {
+		return c
+	}
+
+	degradeMu.Lock()
+	defer degradeMu.Unlock()
+	if mapped, ok := degradeCache[c]; ok {
+		return mapped
+	}
+	lo, hi := 16, 255
+	if d == ColorDepth16 {
+		lo, hi = 0, 15
+	}
+	mapped := nearestPaletteColor(c, lo, hi)
+	degradeCache[c] = mapped
+	return mapped
+}
+
+// DegradeStyle applies Degrade to the foreground and background of style.
+func DegradeStyle(style tcell.Style) tcell.Style {
+	if GetColorDepth() == ColorDepthTrue {
+		return style
+	}
+	fg, bg, _ := style.Decompose()
+	return style.Foreground(Degrade(fg)).Background(Degrade(bg))
+}
+
+// nearestPaletteColor returns the palette color in [lo, hi] closest to c,
+// using the "redmean" weighted RGB distance, which tracks perceived
+// difference far better than plain Euclidean distance at little cost.
+func nearestPaletteColor(c tcell.Color, lo, hi int) tcell.Color {
+	r1, g1, b1 := c.RGB()
+	best, bestDist := lo, int64(-1)
+	for i := lo; i <= hi; i++ {
+		r2, g2, b2 := tcell.PaletteColor(i).RGB()
+		rmean := int64(r1+r2) / 2
+		dr, dg, db := int64(r1-r2), int64(g1-g2), int64(b1-b2)
+		dist := ((512+rmean)*dr*dr)>>8 + 4*dg*dg + ((767-rmean)*db*db)>>8
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return tcell.PaletteColor(best)
+}
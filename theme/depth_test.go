@@ -0,0 +1,62 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package theme
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestDegradeColorDepth(t *testing.T) {
+	defer SetColorDepth(ColorDepthTrue)
+
+	red := tcell.NewRGBColor(255, 0, 0)
+	gray := tcell.NewRGBColor(128, 128, 128)
+	if got := Degrade(red); got != red {
+		t.Errorf("truecolor should keep colors, got %v", got)
+	}
+
+	SetColorDepth(ColorDepth256)
+	if got := Degrade(red); got != tcell.PaletteColor(196) {
+		t.Errorf("256: red -> %v, want palette 196", got)
+	}
+	if got := Degrade(gray); got != tcell.PaletteColor(244) {
+		t.Errorf("256: gray -> %v, want palette 244", got)
+	}
+	if got := Degrade(tcell.ColorDefault); got != tcell.ColorDefault {
+		t.Errorf("default color changed to %v", got)
+	}
+
+	SetColorDepth(ColorDepth16)
+	if got := Degrade(red); got != tcell.PaletteColor(9) {
+		t.Errorf("16: red -> %v, want palette 9", got)
+	}
+	style := DegradeStyle(tcell.StyleDefault.Foreground(gray).Background(red).Bold(true))
+	fg, bg, attrs := style.Decompose()
+	if fg != tcell.PaletteColor(8) || bg != tcell.PaletteColor(9) || attrs&tcell.AttrBold == 0 {
+		t.Errorf("16: style -> fg %v bg %v attrs %v", fg, bg, attrs)
+	}
+}
+
+func TestDetectColorDepth(t *testing.T) {
+	t.Setenv(ColorsEnv, "")
+	cases := map[int]ColorDepth{
+		1 << 24: ColorDepthTrue,
+		256:     ColorDepth256,
+		88:      ColorDepth16,
+		8:       ColorDepth16,
+		0:       ColorDepthTrue,
+	}
+	for colors, want := range cases {
+		if got := DetectColorDepth(colors); got != want {
+			t.Errorf("DetectColorDepth(%d) = %v, want %v", colors, got, want)
+		}
+	}
+
+	t.Setenv(ColorsEnv, "256")
+	if got := DetectColorDepth(1 << 24); got != ColorDepth256 {
+		t.Errorf("override: got %v, want 256", got)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texel/theme/file.go
+// Summary: Loads themes from TOML/JSON files, with optional hot reload.
+// Usage: Apps call ApplyFile or WatchFile; see docs/texelui/core-concepts/theming.md
+// for the file format.
+
+package theme
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce coalesces the bursts of events editors produce on save.
+const watchDebounce = 50 * time.Millisecond
+
+// LoadFile reads a theme file. The format is chosen by extension: ".toml"
+// or ".json". Each top-level table is a section ("meta", "palette", "ui",
+// "pane", ...); nested tables are flattened into dotted keys, so
+// `text.primary = "@text"` under [ui] is the semantic key "text.primary".
+//
+// A theme can extend another with meta.extends: a theme file path
+// (relative to this file) or "default" for the built-in theme. Only the
+// keys it sets replace the base's; see Derive.
+func LoadFile(path string) (Config, error) {
+	return loadFile(path, nil)
+}
+
+// loadFile loads path and the chain of themes it extends. seen holds the
+// files already in the chain, to reject cycles.
+func loadFile(path string, seen map[string]bool) (Config, error) {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if seen[path] {
+		return nil, fmt.Errorf("theme %s: extends cycle", path)
+	}
+	if seen == nil {
+		seen = make(map[string]bool)
+	}
+	seen[path] = true
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw := make(map[string]interface{})
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("theme %s: %w", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("theme %s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("theme %s: unsupported format %q (want .toml or .json)", path, ext)
+	}
+
+	cfg := make(Config, len(raw))
+	for name, value := range raw {
+		table, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("theme %s: %q must be a table of keys", path, name)
+		}
+		section := make(Section)
+		flattenInto(section, "", table)
+		cfg[name] = section
+	}
+	if err := validatePalette(cfg["palette"]); err != nil {
+		return nil, fmt.Errorf("theme %s: %w", path, err)
+	}
+
+	switch base := cfg.GetString("meta", "extends", ""); base {
+	case "":
+	case "default":
+		cfg = Derive(Default(), cfg)
+	default:
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(path), base)
+		}
+		baseCfg, err := loadFile(base, seen)
+		if err != nil {
+			return nil, err
+		}
+		cfg = Derive(baseCfg, cfg)
+	}
+	return cfg, nil
+}
+
+// ApplyFile loads the theme file at path and makes it the current theme.
+// The current theme is kept if the file can't be loaded.
+func ApplyFile(path string) error {
+	cfg, err := LoadFile(path)
+	if err != nil {
+		return err
+	}
+	return Apply(cfg)
+}
+
+// WatchFile applies the theme file at path and then re-applies it whenever
+// it changes on disk, so colors can be tweaked live. Errors while reloading
+// (e.g. a half-written file) keep the current theme and are passed to
+// onError, or logged if onError is nil. Call stop to end watching.
+func WatchFile(path string, onError func(error)) (stop func(), err error) {
+	if err := ApplyFile(path); err != nil {
+		return nil, err
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// Watch the directory: editors often save by replacing the file
+	path = filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	if onError == nil {
+		onError = func(err error) { log.Printf("Theme: Failed to reload %s: %v", path, err) }
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		var timer *time.Timer
+		reload := func() {
+			if err := ApplyFile(path); err != nil {
+				onError(err)
+			}
+		}
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					if timer != nil {
+						timer.Stop()
+					}
+					return
+				}
+				if filepath.Clean(ev.Name) != path || !ev.Has(fsnotify.Write|fsnotify.Create) {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(watchDebounce, reload)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				onError(err)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			watcher.Close()
+			wg.Wait()
+		})
+	}, nil
+}
+
+// flattenInto copies table into section, joining nested table keys with
+// dots. TOML integers are stored as int, like the other loaders' numbers.
+func flattenInto(section Section, prefix string, table map[string]interface{}) {
+	for key, value := range table {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenInto(section, key, v)
+		case int64:
+			section[key] = int(v)
+		default:
+			section[key] = v
+		}
+	}
+}
+
+// validatePalette checks that palette overrides are "#rrggbb" colors.
+func validatePalette(section Section) error {
+	for name, value := range section {
+		s, ok := value.(string)
+		if !ok || !isHexColor(s) {
+			return fmt.Errorf("palette color %q must be \"#rrggbb\", got %v", name, value)
+		}
+	}
+	return nil
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, c := range s[1:] {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+	return true
+}
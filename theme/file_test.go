@@ -0,0 +1,165 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const testThemeTOML = `
+[meta]
+palette = "latte"
+
+[palette]
+base = "#101018"
+brand = "#ff8800"
+
+[ui]
+accent = "@brand"
+text.primary = "#e0e0e0"
+
+[pane]
+border_width = 2
+`
+
+func TestLoadAndApplyThemeFile(t *testing.T) {
+	defer Apply(Config{})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "custom.toml")
+	if err := os.WriteFile(path, []byte(testThemeTOML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if got := cfg["ui"]["text.primary"]; got != "#e0e0e0" {
+		t.Errorf("dotted keys should be flattened, ui[text.primary] = %v", got)
+	}
+	if got := cfg.GetInt("pane", "border_width", 0); got != 2 {
+		t.Errorf("pane.border_width = %d, want 2", got)
+	}
+
+	if err := ApplyFile(path); err != nil {
+		t.Fatalf("ApplyFile: %v", err)
+	}
+	tm := Get()
+	if got := tm.GetSemanticColor("accent"); got != tcell.NewRGBColor(0xff, 0x88, 0x00) {
+		t.Errorf("accent = %v, want the added palette color", got)
+	}
+	if got := tm.GetSemanticColor("bg.base"); got != tcell.NewRGBColor(0x10, 0x10, 0x18) {
+		t.Errorf("bg.base = %v, want the overridden base color", got)
+	}
+	if got := tm.GetSemanticColor("text.secondary"); got == tcell.ColorDefault {
+		t.Error("standard semantics should still come from the base palette")
+	}
+
+	bad := filepath.Join(dir, "bad.toml")
+	os.WriteFile(bad, []byte("[palette]\nbase = \"blue\"\n"), 0644)
+	if err := ApplyFile(bad); err == nil {
+		t.Error("expected error for a non-hex palette color")
+	}
+	if _, err := LoadFile(filepath.Join(dir, "theme.yaml")); err == nil {
+		t.Error("expected error for an unsupported format")
+	}
+}
+
+func TestWatchFileReloadsOnChange(t *testing.T) {
+	defer Apply(Config{})
+	path := filepath.Join(t.TempDir(), "live.toml")
+	write := func(accent string) {
+		data := "[ui]\naccent = \"" + accent + "\"\n"
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("#112233")
+
+	errs := make(chan error, 4)
+	stop, err := WatchFile(path, func(err error) { errs <- err })
+	if err != nil {
+		t.Fatalf("WatchFile: %v", err)
+	}
+	defer stop()
+	if got := Get().GetSemanticColor("accent"); got != tcell.NewRGBColor(0x11, 0x22, 0x33) {
+		t.Fatalf("initial accent = %v", got)
+	}
+
+	want := tcell.NewRGBColor(0x44, 0x55, 0x66)
+	write("#445566")
+	deadline := time.Now().Add(2 * time.Second)
+	for Get().GetSemanticColor("accent") != want {
+		if time.Now().After(deadline) {
+			t.Fatal("theme was not reloaded after the file changed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A broken file keeps the current theme and reports the error
+	os.WriteFile(path, []byte("[ui\n"), 0644)
+	select {
+	case <-errs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a reload error")
+	}
+	if got := Get().GetSemanticColor("accent"); got != want {
+		t.Errorf("broken file changed the theme: accent = %v", got)
+	}
+}
+
+func TestThemeFileExtends(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("base.toml", testThemeTOML)
+	brand := write("brand.toml", `
+[meta]
+extends = "base.toml"
+
+[ui]
+accent = "@red"
+`)
+
+	cfg, err := LoadFile(brand)
+	if err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if got := cfg["ui"]["accent"]; got != "@red" {
+		t.Errorf("ui.accent = %v, want the derived override", got)
+	}
+	if got := cfg["ui"]["text.primary"]; got != "#e0e0e0" {
+		t.Errorf("ui.text.primary = %v, want the inherited value", got)
+	}
+	if got := cfg.GetString("meta", "palette", ""); got != "latte" {
+		t.Errorf("meta.palette = %q, want the inherited latte", got)
+	}
+	if _, ok := cfg["meta"]["extends"]; ok {
+		t.Error("meta.extends should not be kept in the derived theme")
+	}
+
+	cfg, err = LoadFile(write("plain.toml", "[meta]\nextends = \"default\"\n[ui]\naccent = \"@blue\"\n"))
+	if err != nil {
+		t.Fatalf("LoadFile(default base): %v", err)
+	}
+	if cfg["ui"]["accent"] != "@blue" || cfg["ui"]["text.muted"] == nil || cfg["pane"] == nil {
+		t.Errorf("theme extending default should have the built-in keys: %v", cfg["ui"])
+	}
+
+	write("a.toml", "[meta]\nextends = \"b.toml\"\n")
+	write("b.toml", "[meta]\nextends = \"a.toml\"\n")
+	if _, err := LoadFile(filepath.Join(dir, "a.toml")); err == nil {
+		t.Error("expected an error for an extends cycle")
+	}
+}
@@ -55,6 +55,31 @@ func WithOverrides(base Config, overrides Config) Config {
 	return merged
 }
 
+// Derive returns a new theme that extends base: every key of overrides
+// replaces or adds to base's, everything else is inherited. Neither argument
+// is modified, so app-branded variants are cheap to build:
+//
+//	brand := theme.Derive(theme.Default(), theme.Config{
+//		"ui": {"accent": "#ff8800"},
+//	})
+func Derive(base Config, overrides Config) Config {
+	derived := WithOverrides(Clone(base), overrides)
+	if derived == nil {
+		derived = make(Config)
+	}
+	delete(derived["meta"], "extends")
+	return derived
+}
+
+// Default returns a fresh copy of the built-in theme: the standard
+// semantics and defaults over the default palette, without the user's
+// theme file.
+func Default() Config {
+	cfg := make(Config)
+	fillDefaults(cfg)
+	return cfg
+}
+
 // ParseOverrides converts raw config data into a theme config override map.
 func ParseOverrides(raw interface{}) Config {
 	switch v := raw.(type) {
@@ -60,3 +60,27 @@ func TestParseOverridesFromMapAndJSON(t *testing.T) {
 		t.Fatalf("expected text.primary, got %v", got)
 	}
 }
+
+func TestDeriveLeavesBaseUntouched(t *testing.T) {
+	base := Default()
+	derived := Derive(base, Config{
+		"meta": Section{"extends": "default", "palette": "latte"},
+		"ui":   Section{"accent": "#ff8800"},
+	})
+
+	if got := derived["ui"]["accent"]; got != "#ff8800" {
+		t.Errorf("derived accent = %v, want override", got)
+	}
+	if got := derived["ui"]["text.primary"]; got != base["ui"]["text.primary"] {
+		t.Errorf("derived text.primary = %v, want inherited %v", got, base["ui"]["text.primary"])
+	}
+	if _, ok := derived["meta"]["extends"]; ok {
+		t.Error("extends should be dropped from the derived theme")
+	}
+	if got := base["ui"]["accent"]; got == "#ff8800" {
+		t.Errorf("base modified: accent = %v", got)
+	}
+	if _, ok := base["meta"]; ok {
+		t.Error("base gained a meta section")
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -38,6 +40,48 @@ var (
 // LoadPalette loads a palette by name.
 // It searches in the user config directory first, then falls back to embedded defaults.
 func LoadPalette(name string) error {
+	data, err := readPalette(name)
+	if err != nil {
+		return err
+	}
+	return loadPaletteData(data)
+}
+
+// PaletteColors returns the colors of the named palette without making it
+// current, e.g. to preview it.
+func PaletteColors(name string) (Palette, error) {
+	data, err := readPalette(name)
+	if err != nil {
+		return nil, err
+	}
+	return parsePalette(data)
+}
+
+// Palettes returns the names of the available palettes, built-in and from
+// the user config directory, sorted.
+func Palettes() []string {
+	seen := make(map[string]bool)
+	if entries, err := embeddedPalettes.ReadDir("palettes"); err == nil {
+		for _, e := range entries {
+			seen[strings.TrimSuffix(e.Name(), ".json")] = true
+		}
+	}
+	if configDir, err := os.UserConfigDir(); err == nil {
+		matches, _ := filepath.Glob(filepath.Join(configDir, "texelation", "palettes", "*.json"))
+		for _, m := range matches {
+			seen[strings.TrimSuffix(filepath.Base(m), ".json")] = true
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// readPalette returns the JSON of the named palette.
+func readPalette(name string) ([]byte, error) {
 	// 1. Try loading from user config dir
 	configDir, err := os.UserConfigDir()
 	var data []byte
@@ -58,21 +102,28 @@ func LoadPalette(name string) error {
 	}
 
 	if data == nil {
-		return fmt.Errorf("palette '%s' not found", name)
+		return nil, fmt.Errorf("palette '%s' not found", name)
 	}
-
-	return loadPaletteData(data)
+	return data, nil
 }
 
-func loadPaletteData(data []byte) error {
+func parsePalette(data []byte) (Palette, error) {
 	var cfg PaletteConfig
 	if err := json.Unmarshal(data, &cfg); err != nil {
-		return err
+		return nil, err
 	}
 
-	newPalette := make(Palette)
+	palette := make(Palette)
 	for name, hex := range cfg {
-		newPalette[name] = HexColor(hex).ToTcell()
+		palette[name] = HexColor(hex).ToTcell()
+	}
+	return palette, nil
+}
+
+func loadPaletteData(data []byte) error {
+	newPalette, err := parsePalette(data)
+	if err != nil {
+		return err
 	}
 	
 	paletteMu.Lock()
@@ -82,6 +133,26 @@ func loadPaletteData(data []byte) error {
 	return nil
 }
 
+// overlayPalette overrides or adds colors of the current palette from a
+// theme's "palette" section of "#rrggbb" values.
+func overlayPalette(section Section) {
+	if len(section) == 0 {
+		return
+	}
+	paletteMu.Lock()
+	defer paletteMu.Unlock()
+	merged := make(Palette, len(CurrentPalette)+len(section))
+	for name, c := range CurrentPalette {
+		merged[name] = c
+	}
+	for name, value := range section {
+		if hex, ok := value.(string); ok {
+			merged[name] = HexColor(hex).ToTcell()
+		}
+	}
+	CurrentPalette = merged
+}
+
 // ResolveColorName looks up a color name in the current palette.
 // Returns tcell.ColorDefault if not found.
 func ResolveColorName(name string) tcell.Color {
@@ -7,7 +7,12 @@
 
 package theme
 
-import "github.com/gdamore/tcell/v2"
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
 
 // StandardSemantics defines the default mappings from semantic names to palette colors.
 // These align with the Catppuccin Style Guide.
@@ -56,6 +61,40 @@ var StandardSemantics = Section{
 	"caret": "@rosewater", // Input caret
 }
 
+// appSemantics holds the semantic keys registered by applications.
+var (
+	appSemantics   = make(map[string]string)
+	appSemanticsMu sync.RWMutex
+)
+
+// RegisterSemantic adds an application-defined semantic color key, such as
+// "diff.added" or "status.building", with its default value: a hex color,
+// a "@palette" reference or another semantic key. It resolves through
+// GetSemanticColor like the standard keys, and a theme's "ui" section can
+// override it. Registered defaults are not written to the theme file, so
+// changing them in a later app version takes effect. Registering a key
+// again replaces its default; standard keys can't be registered.
+func RegisterSemantic(key, defaultValue string) error {
+	if key == "" || defaultValue == "" {
+		return fmt.Errorf("theme: semantic key and default must not be empty")
+	}
+	if _, ok := StandardSemantics[key]; ok {
+		return fmt.Errorf("theme: %q is a standard semantic key", key)
+	}
+	appSemanticsMu.Lock()
+	appSemantics[key] = defaultValue
+	appSemanticsMu.Unlock()
+	return nil
+}
+
+// registeredSemantic returns the registered default of an app semantic key.
+func registeredSemantic(key string) (string, bool) {
+	appSemanticsMu.RLock()
+	defer appSemanticsMu.RUnlock()
+	value, ok := appSemantics[key]
+	return value, ok
+}
+
 // LoadStandardSemantics registers the standard semantic definitions into the "ui" section.
 // This ensures that even if the user hasn't defined them, they are available.
 func (c Config) LoadStandardSemantics() {
@@ -64,6 +103,23 @@ func (c Config) LoadStandardSemantics() {
 
 // GetSemanticColor retrieves a color from the "ui" section by its semantic name.
 // Example: GetSemanticColor("text.primary") -> resolves to @text -> #cdd6f4
+// Keys registered with RegisterSemantic fall back to their default.
 func (c Config) GetSemanticColor(key string) tcell.Color {
-	return c.GetColor("ui", key, tcell.ColorDefault)
+	mu.RLock()
+	defer mu.RUnlock()
+	value, ok := c.semanticValue(key)
+	if !ok {
+		return tcell.ColorDefault
+	}
+	return c.resolveColorString(value, 0)
+}
+
+// semanticValue returns the raw value of a semantic key: the theme's "ui"
+// entry, or else the registered app default.
+func (c Config) semanticValue(key string) (string, bool) {
+	if val, ok := c.getRawValue("ui", key); ok {
+		s, ok := val.(string)
+		return s, ok
+	}
+	return registeredSemantic(key)
 }
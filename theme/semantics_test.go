@@ -0,0 +1,75 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package theme
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRegisterSemantic(t *testing.T) {
+	defer func() {
+		appSemanticsMu.Lock()
+		delete(appSemantics, "diff.added")
+		delete(appSemantics, "diff.header")
+		appSemanticsMu.Unlock()
+		Apply(Config{})
+	}()
+	if err := Apply(Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RegisterSemantic("diff.added", "@green"); err != nil {
+		t.Fatalf("RegisterSemantic: %v", err)
+	}
+	if err := RegisterSemantic("diff.header", "diff.added"); err != nil {
+		t.Fatalf("RegisterSemantic: %v", err)
+	}
+	green := ResolveColorName("green")
+	if got := Get().GetSemanticColor("diff.added"); got != green {
+		t.Errorf("diff.added = %v, want palette green %v", got, green)
+	}
+	if got := Get().GetSemanticColor("diff.header"); got != green {
+		t.Errorf("diff.header = %v, want it to follow diff.added", got)
+	}
+	if got := Get().GetColor("statusbar", "nonexistent", tcell.ColorDefault); got != tcell.ColorDefault {
+		t.Errorf("unregistered key resolved to %v", got)
+	}
+
+	// Theme files override registered defaults
+	if err := Apply(Config{"ui": Section{"diff.added": "#123456"}}); err != nil {
+		t.Fatal(err)
+	}
+	want := tcell.NewRGBColor(0x12, 0x34, 0x56)
+	if got := Get().GetSemanticColor("diff.added"); got != want {
+		t.Errorf("overridden diff.added = %v, want %v", got, want)
+	}
+	if got := Get().GetSemanticColor("diff.header"); got != want {
+		t.Errorf("diff.header = %v, want the overridden diff.added", got)
+	}
+	if _, saved := Get()["ui"]["diff.header"]; saved {
+		t.Error("registered defaults should not be copied into the theme")
+	}
+
+	if err := RegisterSemantic("text.primary", "#ffffff"); err == nil {
+		t.Error("expected an error when registering a standard key")
+	}
+	if err := RegisterSemantic("status.building", ""); err == nil {
+		t.Error("expected an error for an empty default")
+	}
+}
+
+func TestNamedColorValues(t *testing.T) {
+	defer Apply(Config{})
+	if err := Apply(Config{"ui": Section{"accent": "DodgerBlue", "text.accent": "#abc"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := Get().GetSemanticColor("accent"); got != tcell.NewRGBColor(0x1e, 0x90, 0xff) {
+		t.Errorf("accent = %v, want dodgerblue #1e90ff", got)
+	}
+	if got := Get().GetSemanticColor("text.accent"); got != tcell.NewRGBColor(0xaa, 0xbb, 0xcc) {
+		t.Errorf("text.accent = %v, want #aabbcc", got)
+	}
+}
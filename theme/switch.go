@@ -0,0 +1,102 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texel/theme/switch.go
+// Summary: Runtime theme switching and change notification.
+// Usage: Apps call Set or Apply; UIManager and widgets re-resolve their styles.
+
+package theme
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	generation atomic.Uint64
+
+	listenersMu sync.Mutex
+	listeners   = make(map[uint64]func())
+	nextID      uint64
+)
+
+// Set switches to the named palette (e.g. "latte", "mocha" or a palette
+// file in the user config directory), keeping the rest of the current
+// theme. The current theme is left unchanged if the palette is not found.
+func Set(name string) error {
+	cfg := Clone(Get())
+	if cfg["meta"] == nil {
+		cfg["meta"] = make(Section)
+	}
+	cfg["meta"]["palette"] = name
+	return Apply(cfg)
+}
+
+// Apply makes cfg the current theme: its palette (meta.palette, default
+// "mocha") is loaded, with the colors of a "palette" section overriding or
+// adding to it, and the standard semantics and defaults are filled in.
+// Change listeners are notified so the UI re-renders with the new colors.
+// The theme file is not written; use Save to persist the change.
+func Apply(cfg Config) error {
+	_ = Get() // Make sure the initial load doesn't overwrite cfg later
+
+	cfg = Clone(cfg)
+	if cfg == nil {
+		cfg = make(Config)
+	}
+	if err := validatePalette(cfg["palette"]); err != nil {
+		return err
+	}
+	if err := LoadPalette(cfg.GetString("meta", "palette", "mocha")); err != nil {
+		return err
+	}
+	overlayPalette(cfg["palette"])
+	cfg.LoadStandardSemantics()
+	fillDefaults(cfg)
+
+	mu.Lock()
+	instance = cfg
+	loadErr = nil
+	mu.Unlock()
+
+	notifyChanged()
+	return nil
+}
+
+// Generation returns a counter that increases on every theme change.
+// Widgets that cache resolved colors can compare it to know they are stale.
+func Generation() uint64 {
+	return generation.Load()
+}
+
+// OnChange registers fn to be called after every theme change (Set, Apply,
+// Reload). fn runs on the goroutine that changed the theme and must not
+// block. The returned function unregisters it.
+func OnChange(fn func()) (cancel func()) {
+	listenersMu.Lock()
+	defer listenersMu.Unlock()
+	nextID++
+	id := nextID
+	listeners[id] = fn
+	return func() {
+		listenersMu.Lock()
+		defer listenersMu.Unlock()
+		delete(listeners, id)
+	}
+}
+
+// notifyChanged bumps the generation and calls the change listeners.
+func notifyChanged() {
+	generation.Add(1)
+
+	listenersMu.Lock()
+	fns := make([]func(), 0, len(listeners))
+	for _, fn := range listeners {
+		fns = append(fns, fn)
+	}
+	listenersMu.Unlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
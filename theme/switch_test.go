@@ -0,0 +1,95 @@
+// Copyright © 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+
+package theme
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSetSwitchesPaletteAndNotifies(t *testing.T) {
+	before := Get().GetSemanticColor("text.primary")
+	gen := Generation()
+	calls := 0
+	cancel := OnChange(func() { calls++ })
+	defer func() {
+		cancel()
+		_ = Set("mocha")
+	}()
+
+	if err := Set("latte"); err != nil {
+		t.Fatalf("Set(latte): %v", err)
+	}
+	if got := Get().GetSemanticColor("text.primary"); got == before {
+		t.Errorf("text.primary unchanged after switching palette: %v", got)
+	}
+	if got := Get().GetString("meta", "palette", ""); got != "latte" {
+		t.Errorf("meta.palette = %q, want latte", got)
+	}
+	if calls != 1 || Generation() != gen+1 {
+		t.Errorf("expected one notification, calls=%d generation=%d->%d", calls, gen, Generation())
+	}
+
+	// Unknown palettes leave the theme alone
+	if err := Set("no-such-palette"); err == nil {
+		t.Error("expected error for unknown palette")
+	}
+	if calls != 1 || Get().GetString("meta", "palette", "") != "latte" {
+		t.Error("failed Set should not change the theme")
+	}
+
+	cancel()
+	if err := Apply(Config{"meta": Section{"palette": "frappe"}}); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if calls != 1 {
+		t.Error("cancelled listener was called")
+	}
+	if Get().GetSemanticColor("accent") == 0 {
+		t.Error("Apply should fill in the standard semantics")
+	}
+}
+
+func TestHighContrastPalette(t *testing.T) {
+	defer Apply(Config{})
+	if err := Set("highcontrast"); err != nil {
+		t.Fatalf("Set(highcontrast): %v", err)
+	}
+
+	// WCAG AAA asks for 7:1 between text and its background
+	tm := Get()
+	for _, pair := range [][2]string{
+		{"text.primary", "bg.base"},
+		{"text.muted", "bg.surface"},
+		{"text.primary", "selection"},
+		{"text.inverse", "action.primary"},
+		{"text.inverse", "border.focus"},
+	} {
+		if ratio := contrastRatio(tm.GetSemanticColor(pair[0]), tm.GetSemanticColor(pair[1])); ratio < 7 {
+			t.Errorf("%s on %s: contrast %.1f:1, want >= 7:1", pair[0], pair[1], ratio)
+		}
+	}
+}
+
+func contrastRatio(a, b tcell.Color) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+func relativeLuminance(c tcell.Color) float64 {
+	r, g, b := c.RGB()
+	lin := func(v int32) float64 {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*lin(r) + 0.7152*lin(g) + 0.0722*lin(b)
+}
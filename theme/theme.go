@@ -97,6 +97,7 @@ func Reload() error {
 	instance = newInstance
 	mu.Unlock()
 
+	notifyChanged()
 	return nil
 }
 
@@ -211,10 +212,9 @@ func (c Config) resolveColorString(s string, depth int) tcell.Color {
 
 	// 4. Implicit "ui" section Indirection
 	// If "s" is something like "bg.base", check "ui.bg.base"
-	if refVal, ok := c.getRawValue("ui", s); ok {
-		if refStr, ok := refVal.(string); ok {
-			return c.resolveColorString(refStr, depth+1)
-		}
+	// (or the default of a registered app key)
+	if refStr, ok := c.semanticValue(s); ok {
+		return c.resolveColorString(refStr, depth+1)
 	}
 
 	// 5. Try parsing as hex if it looks like one but missed # (legacy/fallback)
@@ -225,6 +225,11 @@ func (c Config) resolveColorString(s string, depth int) tcell.Color {
 		}
 	}
 
+	// 6. Named X11/web color ("dodgerblue"), by its RGB value
+	if named, ok := tcell.ColorNames[strings.ToLower(s)]; ok {
+		return tcell.NewRGBColor(named.RGB())
+	}
+
 	return tcell.ColorDefault
 }
 
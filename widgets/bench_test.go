@@ -0,0 +1,33 @@
+package widgets
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/framegrace/texelui/texeluitest"
+)
+
+// BenchmarkFormLayout lays out a form of 100 fields at alternating widths.
+func BenchmarkFormLayout(b *testing.B) {
+	form := NewFormWithConfig(FormConfig{LabelWidth: 12, TwoColumnMinWidth: 100})
+	for i := range 100 {
+		if i%2 == 0 {
+			form.AddField(fmt.Sprintf("Field %d", i), NewInput())
+		} else {
+			form.AddField("", NewCheckbox(fmt.Sprintf("Option %d", i)))
+		}
+	}
+	b.ReportAllocs()
+	w := 80
+	for b.Loop() {
+		w = 180 - w // Switches between one and two columns
+		form.Resize(w, 50)
+	}
+}
+
+func TestPerfBudgets(t *testing.T) {
+	texeluitest.CheckBudgets(t, []texeluitest.Budget{
+		{Name: "FormLayout", Bench: BenchmarkFormLayout, MaxAllocs: 4, MaxTime: 20 * time.Microsecond},
+	})
+}
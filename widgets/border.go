@@ -30,6 +30,10 @@ type Border struct {
     // Neighbors specifies which edges have neighboring widgets.
     // Only used when SeparatorMode is true.
     Neighbors core.NeighborInfo
+
+    // baseStyle is the constructor style; its default colors are resolved
+    // from the theme again when it changes.
+    baseStyle tcell.Style
 }
 
 // NewBorder creates a border with default theme styling.
@@ -47,11 +51,21 @@ func NewBorderWithStyle(style tcell.Style) *Border {
 }
 
 func newBorderWithStyle(style tcell.Style) *Border {
-	b := &Border{}
+	b := &Border{baseStyle: style}
+	b.applyTheme()
+
+	// Default rounded corner charset
+	b.Charset = [6]rune{'─', '│', '╭', '╮', '╰', '╯'}
+	b.SetPosition(0, 0)
+	b.Resize(1, 1)
+	return b
+}
 
-	// Resolve default colors from theme
+// applyTheme resolves the styles from the theme, filling in the default
+// colors of the constructor style.
+func (b *Border) applyTheme() {
 	tm := theme.Get()
-	fg, bg, attr := style.Decompose()
+	fg, bg, attr := b.baseStyle.Decompose()
 	if fg == tcell.ColorDefault {
 		fg = tm.GetSemanticColor("text.primary")
 	}
@@ -59,18 +73,18 @@ func newBorderWithStyle(style tcell.Style) *Border {
 		bg = tm.GetSemanticColor("bg.surface")
 	}
 	// Update style with resolved colors
-	b.Style = color.DynamicStyle{
+	b.SetThemeStyle(&b.Style, color.DynamicStyle{
 		FG:    color.Solid(fg),
 		BG:    color.Solid(bg),
 		Attrs: attr,
-	}
+	})
 
 	// Focused style uses border.active foreground
 	ffg := tm.GetSemanticColor("border.active")
-	b.FocusedStyle = color.DynamicStyle{
+	b.SetThemeStyle(&b.FocusedStyle, color.DynamicStyle{
 		FG: color.Solid(ffg),
 		BG: color.Solid(bg),
-	}
+	})
 	b.SetFocusedStyle(tcell.StyleDefault.Foreground(ffg).Background(bg), true)
 
 	// Resizing style uses border.resizing semantic color
@@ -78,18 +92,15 @@ func newBorderWithStyle(style tcell.Style) *Border {
 	if rfg == tcell.ColorDefault {
 		rfg = ffg
 	}
-	b.ResizingStyle = color.DynamicStyle{
+	b.SetThemeStyle(&b.ResizingStyle, color.DynamicStyle{
 		FG: color.Solid(rfg),
 		BG: color.Solid(bg),
-	}
-
-	// Default rounded corner charset
-	b.Charset = [6]rune{'─', '│', '╭', '╮', '╰', '╯'}
-	b.SetPosition(0, 0)
-	b.Resize(1, 1)
-	return b
+	})
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (b *Border) OnThemeChanged() { b.applyTheme() }
+
 // SetRoundedCorners configures the border to use rounded corner characters (╭╮╰╯).
 // This is the default.
 func (b *Border) SetRoundedCorners() {
@@ -111,6 +122,7 @@ func (b *Border) ClientRect() core.Rect {
 
 func (b *Border) SetChild(w core.Widget) {
 	b.Child = w
+	core.InvalidateCascade()
 	cr := b.ClientRect()
 	if b.Child != nil {
 		b.Child.SetPosition(cr.X, cr.Y)
@@ -159,6 +171,9 @@ func (b *Border) Draw(p *core.Painter) {
 
 	if b.Child != nil {
 		b.Child.Draw(p)
+		if core.IsDisabled(b.Child) {
+			muteWidget(p, b.Child)
+		}
 	}
 }
 
@@ -364,8 +379,8 @@ func (b *Border) HandleMouse(ev *tcell.EventMouse) bool {
 		}
 	}
 
-	// Forward to child if it handles mouse
-	if b.Child != nil && b.Child.HitTest(x, y) {
+	// Forward to child if it handles mouse and isn't disabled
+	if b.Child != nil && b.Child.HitTest(x, y) && !core.IsDisabled(b.Child) {
 		if ma, ok := b.Child.(core.MouseAware); ok {
 			return ma.HandleMouse(ev)
 		}
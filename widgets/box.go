@@ -10,7 +10,6 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/core"
-	"github.com/framegrace/texelui/theme"
 )
 
 // BoxAlign specifies how children are aligned within available space.
@@ -46,28 +45,28 @@ type boxBase struct {
 }
 
 func newBoxBase(vertical bool) *boxBase {
-	tm := theme.Get()
-	bg := tm.GetSemanticColor("bg.surface")
-	fg := tm.GetSemanticColor("text.primary")
-
 	b := &boxBase{
-		Style:          color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)},
 		Spacing:        0,
 		Align:          BoxAlignStart,
 		lastFocusedIdx: -1,
 		vertical:       vertical,
 	}
+	b.SetThemeStyle(&b.Style, themeSurfaceStyle())
 	b.SetPosition(0, 0)
 	b.Resize(1, 1)
 	b.SetFocusable(true)
 	return b
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (b *boxBase) OnThemeChanged() { b.SetThemeStyle(&b.Style, themeSurfaceStyle()) }
+
 // AddChild adds a child widget with its natural size.
 func (b *boxBase) AddChild(w core.Widget) {
 	nw, nh := w.Size() // Capture natural size before layout modifies it
 	b.children = append(b.children, boxChild{widget: w, size: 0, naturalW: nw, naturalH: nh})
 	b.layout()
+	core.InvalidateCascade()
 	if b.inv != nil {
 		if ia, ok := w.(core.InvalidationAware); ok {
 			ia.SetInvalidator(b.inv)
@@ -80,6 +79,7 @@ func (b *boxBase) AddChildWithSize(w core.Widget, size int) {
 	nw, nh := w.Size() // Capture natural size before layout modifies it
 	b.children = append(b.children, boxChild{widget: w, size: size, naturalW: nw, naturalH: nh})
 	b.layout()
+	core.InvalidateCascade()
 	if b.inv != nil {
 		if ia, ok := w.(core.InvalidationAware); ok {
 			ia.SetInvalidator(b.inv)
@@ -117,7 +117,7 @@ func (b *boxBase) SetInvalidator(fn func(core.Rect)) {
 
 // Draw renders all children.
 func (b *boxBase) Draw(painter *core.Painter) {
-	ds := b.Style
+	ds := b.EffectiveDynamic(b.Style)
 	if b.IsFocused() {
 		ds.Attrs |= tcell.AttrBold
 	}
@@ -127,6 +127,9 @@ func (b *boxBase) Draw(painter *core.Painter) {
 
 	for _, child := range b.children {
 		child.widget.Draw(painter)
+		if core.IsDisabled(child.widget) {
+			muteWidget(painter, child.widget)
+		}
 	}
 }
 
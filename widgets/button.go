@@ -1,6 +1,8 @@
 package widgets
 
 import (
+	"unicode"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/core"
@@ -15,8 +17,16 @@ type Button struct {
 	Style   color.DynamicStyle
 	OnClick func()
 
+	// Disabled buttons render muted, can't be focused and ignore activation.
+	Disabled bool
+
+	// Mnemonic is a letter of Text that activates the button with Alt+letter
+	// from anywhere in the UI; it is drawn underlined. 0 for none.
+	Mnemonic rune
+
 	// Visual state
 	pressed bool
+	hovered bool
 
 	// Invalidation callback
 	inv func(core.Rect)
@@ -30,29 +40,37 @@ func NewButton(text string) *Button {
 		Text: text,
 	}
 
-	// Get default style from theme
+	// Get default and focused styles from theme
+	b.applyTheme()
+
+	// Auto-size with padding: [ Text ]
+	b.Resize(len(text)+4, 1)
+
+	// Buttons are focusable by default
+	b.SetFocusable(true)
+
+	return b
+}
+
+// applyTheme resolves the default and focused styles from the current theme.
+func (b *Button) applyTheme() {
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("text.inverse")
 	bg := tm.GetSemanticColor("action.primary")
-	b.Style = color.DynamicStyle{
+	b.SetThemeStyle(&b.Style, color.DynamicStyle{
 		FG: color.Solid(fg),
 		BG: color.Solid(bg),
-	}
+	})
 
 	// Configure focused style
 	focusFg := tm.GetSemanticColor("text.inverse")
 	focusBg := tm.GetSemanticColor("border.focus")
 	b.SetFocusedStyle(tcell.StyleDefault.Foreground(focusFg).Background(focusBg), true)
-
-	// Auto-size with padding: [ Text ]
-	b.Resize(len(text)+4, 1)
-
-	// Buttons are focusable by default
-	b.SetFocusable(true)
-
-	return b
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (b *Button) OnThemeChanged() { b.applyTheme() }
+
 // Draw renders the button with text centered and optional brackets.
 func (b *Button) Draw(painter *core.Painter) {
 	ds := b.Style
@@ -60,9 +78,19 @@ func (b *Button) Draw(painter *core.Painter) {
 		ds.Attrs |= tcell.AttrBold
 	}
 
-	// Invert colors when pressed for visual feedback
+	if b.Disabled {
+		tm := theme.Get()
+		ds = color.DynamicStyle{
+			FG: color.Solid(tm.GetSemanticColor("text.muted")),
+			BG: color.Solid(tm.GetSemanticColor("bg.surface")),
+		}
+	}
+
+	// Invert colors when pressed for visual feedback, underline on hover
 	if b.pressed {
 		ds.FG, ds.BG = ds.BG, ds.FG
+	} else if b.hovered && !b.Disabled {
+		ds.Attrs |= tcell.AttrUnderline
 	}
 
 	// Fill background
@@ -92,6 +120,34 @@ func (b *Button) Draw(painter *core.Painter) {
 	} else {
 		painter.DrawDynamicText(x, y, displayText, ds)
 	}
+
+	// Underline the first occurrence of the mnemonic
+	if b.Mnemonic != 0 && !b.Disabled {
+		for i, r := range []rune(displayText) {
+			if i >= 2 && unicode.ToLower(r) == unicode.ToLower(b.Mnemonic) {
+				uds := ds
+				uds.Attrs |= tcell.AttrUnderline
+				if b.Transparent {
+					painter.DrawDynamicTextKeepBG(x+i, y, string(r), uds)
+				} else {
+					painter.SetDynamicCell(x+i, y, r, uds)
+				}
+				break
+			}
+		}
+	}
+}
+
+// MnemonicRune implements core.MnemonicTarget.
+func (b *Button) MnemonicRune() rune { return b.Mnemonic }
+
+// ActivateMnemonic implements core.MnemonicTarget: the button is clicked.
+func (b *Button) ActivateMnemonic() bool {
+	if b.Disabled {
+		return false
+	}
+	b.activate()
+	return true
 }
 
 // HandleKey processes keyboard input. Enter or Space activates the button.
@@ -126,8 +182,45 @@ func (b *Button) HandleMouse(ev *tcell.EventMouse) bool {
 	return false
 }
 
+// OnHoverEnter implements core.HoverAware.
+func (b *Button) OnHoverEnter() {
+	b.hovered = true
+	b.invalidate()
+}
+
+// OnHoverLeave implements core.HoverAware. A press dragged off the button
+// no longer activates it.
+func (b *Button) OnHoverLeave() {
+	b.hovered = false
+	b.pressed = false
+	b.invalidate()
+}
+
+// SetDisabled enables or disables the button. A disabled button gives up
+// focus and can't be activated.
+func (b *Button) SetDisabled(disabled bool) {
+	if b.Disabled == disabled {
+		return
+	}
+	b.Disabled = disabled
+	b.BaseWidget.SetDisabled(disabled)
+	if disabled {
+		b.pressed = false
+		if b.IsFocused() {
+			b.Blur()
+		}
+	}
+	b.invalidate()
+}
+
+// IsDisabled implements core.Disableable.
+func (b *Button) IsDisabled() bool { return b.Disabled }
+
 // activate triggers the OnClick callback if set.
 func (b *Button) activate() {
+	if b.Disabled {
+		return
+	}
 	if b.OnClick != nil {
 		b.OnClick()
 	}
@@ -29,17 +29,8 @@ func NewCheckbox(label string) *Checkbox {
 		Checked: false,
 	}
 
-	// Get default style from theme
-	tm := theme.Get()
-	fg := tm.GetSemanticColor("text.primary")
-	bg := tm.GetSemanticColor("bg.surface")
-	c.Style = color.DynamicStyle{
-		FG: color.Solid(fg),
-		BG: color.Solid(bg),
-	}
-
-	// Configure focused style - reverse colors for clear visibility
-	c.SetFocusedStyle(tcell.StyleDefault.Foreground(bg).Background(fg), true)
+	// Get default and focused styles from theme
+	c.applyTheme()
 
 	c.SetPosition(0, 0)
 
@@ -53,9 +44,26 @@ func NewCheckbox(label string) *Checkbox {
 	return c
 }
 
+// applyTheme resolves the default and focused styles from the current theme.
+func (c *Checkbox) applyTheme() {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	c.SetThemeStyle(&c.Style, color.DynamicStyle{
+		FG: color.Solid(fg),
+		BG: color.Solid(bg),
+	})
+
+	// Configure focused style - reverse colors for clear visibility
+	c.SetFocusedStyle(tcell.StyleDefault.Foreground(bg).Background(fg), true)
+}
+
+// OnThemeChanged implements core.ThemeAware.
+func (c *Checkbox) OnThemeChanged() { c.applyTheme() }
+
 // Draw renders the checkbox with its current state.
 func (c *Checkbox) Draw(painter *core.Painter) {
-	ds := c.Style
+	ds := c.EffectiveDynamic(c.Style)
 	if c.IsFocused() {
 		ds.Attrs |= tcell.AttrReverse
 	}
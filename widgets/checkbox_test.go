@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -107,3 +108,24 @@ func TestCheckbox_Render(t *testing.T) {
 		}
 	}
 }
+
+func TestCheckbox_DisabledInPaneIgnoresClickAndDrawsMuted(t *testing.T) {
+	pane := NewPane()
+	pane.SetPosition(0, 0)
+	pane.Resize(20, 2)
+	cb := NewCheckbox("Enable")
+	cb.SetPosition(0, 0)
+	pane.AddChild(cb)
+	cb.SetDisabled(true)
+
+	pane.HandleMouse(tcell.NewEventMouse(1, 0, tcell.Button1, tcell.ModNone))
+	if cb.Checked {
+		t.Error("clicking a disabled checkbox in a pane should not toggle it")
+	}
+
+	buf := newTestFormBuffer(20, 2)
+	pane.Draw(core.NewPainter(buf, core.Rect{W: 20, H: 2}))
+	if fg, _, _ := buf[0][0].Style.Decompose(); fg != theme.Get().GetSemanticColor("text.muted") {
+		t.Error("a disabled checkbox in a pane should be drawn muted")
+	}
+}
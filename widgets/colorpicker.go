@@ -2,12 +2,14 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 //
 // File: texelui/widgets/colorpicker.go
-// Summary: Color picker widget with semantic, palette, and OKLCH modes.
+// Summary: Color picker widget with semantic, palette, OKLCH, RGB and HSL modes.
 // Uses OKLCHEditor widget for custom color selection.
 
 package widgets
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/theme"
@@ -24,6 +26,9 @@ const (
 	ColorModeSemantic                 // Semantic color names (text.primary, etc.)
 	ColorModePalette                  // Palette colors (@mauve, etc.)
 	ColorModeOKLCH                    // Custom OKLCH picker
+	ColorModeRGB                      // Red/green/blue sliders
+	ColorModeHSL                      // Hue/saturation/lightness sliders
+	ColorModeNamed                    // Named X11/web colors (dodgerblue, etc.)
 )
 
 func (m ColorPickerMode) String() string {
@@ -34,6 +39,12 @@ func (m ColorPickerMode) String() string {
 		return "Palette"
 	case ColorModeOKLCH:
 		return "Custom"
+	case ColorModeRGB:
+		return "RGB"
+	case ColorModeHSL:
+		return "HSL"
+	case ColorModeNamed:
+		return "Named"
 	default:
 		return ""
 	}
@@ -44,6 +55,9 @@ type ColorPickerConfig struct {
 	EnableSemantic bool
 	EnablePalette  bool
 	EnableOKLCH    bool
+	EnableRGB      bool
+	EnableHSL      bool
+	EnableNamed    bool
 	Label          string // Label shown in collapsed state
 }
 
@@ -61,6 +75,16 @@ type focusArea int
 const (
 	focusTabBar  focusArea = iota // Focus is on the mode tab bar
 	focusContent                  // Focus is on the mode content
+	focusHex                      // Focus is on the hex input field
+)
+
+// hexFieldOffset and hexFieldW place the hex input field on the bottom
+// border, after the live preview, followed by the eyedropper button:
+// └─[█T] #rrggbb ◎─┘
+const (
+	hexFieldOffset = 7
+	hexFieldW      = 9
+	eyedropperX    = hexFieldOffset + hexFieldW
 )
 
 // ColorPicker is a comprehensive color selection widget.
@@ -87,11 +111,36 @@ type ColorPicker struct {
 	// New widget-based OKLCH editor
 	oklchEditor *OKLCHEditor
 
+	// Hex input field: text being typed and whether it parses
+	hexText  []rune
+	hexValid bool
+
+	// Clipboard for the copy shortcut (Ctrl+C), set by the UIManager
+	clipboard core.ClipboardService
+
+	// Eyedropper: while sampling, the picker grabs the mouse over the whole
+	// UI; restore holds the selection to return to on cancel.
+	sampling     bool
+	restoreMode  ColorPickerMode
+	restoreColor tcell.Color
+
 	// Callbacks
 	OnChange func(ColorPickerResult)
+	// OnPreview is called as the user moves through colors in the expanded
+	// picker, before committing. When the picker closes without committing
+	// (Esc, click outside), it is called once more with the unchanged
+	// result, so live-applied previews revert.
+	OnPreview func(ColorPickerResult)
+	previewed ColorPickerResult // Last result sent to OnPreview
 
 	// Invalidation
 	inv func(core.Rect)
+
+	// Placement: the expanded panel is an overlay attached to the picker's
+	// row, kept within the surface reported by the UIManager.
+	popup    core.Rect
+	surfaceW int
+	surfaceH int
 }
 
 // NewColorPicker creates a color picker with the given configuration.
@@ -125,6 +174,21 @@ func NewColorPicker(config ColorPickerConfig) *ColorPicker {
 		cp.modeOrder = append(cp.modeOrder, ColorModeOKLCH)
 		tabItems = append(tabItems, primitives.TabItem{Label: ColorModeOKLCH.String(), ID: "oklch"})
 	}
+	if config.EnableRGB {
+		cp.modes[ColorModeRGB] = colorpicker.NewRGBPicker()
+		cp.modeOrder = append(cp.modeOrder, ColorModeRGB)
+		tabItems = append(tabItems, primitives.TabItem{Label: ColorModeRGB.String(), ID: "rgb"})
+	}
+	if config.EnableHSL {
+		cp.modes[ColorModeHSL] = colorpicker.NewHSLPicker()
+		cp.modeOrder = append(cp.modeOrder, ColorModeHSL)
+		tabItems = append(tabItems, primitives.TabItem{Label: ColorModeHSL.String(), ID: "hsl"})
+	}
+	if config.EnableNamed {
+		cp.modes[ColorModeNamed] = colorpicker.NewNamedPicker()
+		cp.modeOrder = append(cp.modeOrder, ColorModeNamed)
+		tabItems = append(tabItems, primitives.TabItem{Label: ColorModeNamed.String(), ID: "named"})
+	}
 
 	// Ensure at least one mode is enabled - default to OKLCH if none specified
 	if len(cp.modeOrder) == 0 {
@@ -158,6 +222,13 @@ func NewColorPicker(config ColorPickerConfig) *ColorPicker {
 	focusBg := tm.GetSemanticColor("bg.surface")
 	cp.SetFocusedStyle(tcell.StyleDefault.Foreground(focusFg).Background(focusBg), true)
 
+	// Collapsed: [█A] source
+	w := 5                         // [█A]
+	w += len(cp.result.Source) + 1 // " source"
+	if w < 20 {
+		w = 20
+	}
+	cp.Resize(w, 1)
 	cp.calculateSize()
 
 	return cp
@@ -174,6 +245,61 @@ func (cp *ColorPicker) SetInvalidator(fn func(core.Rect)) {
 	}
 }
 
+// SetSurfaceSize implements core.SurfaceAware.
+// The expanded panel flips above the picker's row, or shifts, to stay on
+// the surface.
+func (cp *ColorPicker) SetSurfaceSize(w, h int) {
+	if cp.surfaceW == w && cp.surfaceH == h {
+		return
+	}
+	cp.surfaceW, cp.surfaceH = w, h
+	cp.place()
+}
+
+// SetPosition moves the picker's row and the expanded panel with it.
+func (cp *ColorPicker) SetPosition(x, y int) {
+	cp.BaseWidget.SetPosition(x, y)
+	cp.place()
+}
+
+// place attaches the expanded panel to the picker's row with
+// core.PlacePopup: below it, or above when there is more room there.
+func (cp *ColorPicker) place() {
+	cp.popup = core.PlacePopup(cp.Rect, cp.popup.W, cp.popup.H,
+		core.Rect{W: cp.surfaceW, H: cp.surfaceH})
+}
+
+// OverlayRect implements core.Overlay: the expanded panel.
+func (cp *ColorPicker) OverlayRect() (core.Rect, bool) {
+	return cp.popup, cp.expanded
+}
+
+// DrawOverlay implements core.Overlay.
+func (cp *ColorPicker) DrawOverlay(painter *core.Painter) {
+	if cp.expanded {
+		cp.drawExpanded(painter)
+	}
+}
+
+// HitTest covers the picker's row and, when expanded, its panel.
+func (cp *ColorPicker) HitTest(x, y int) bool {
+	return cp.BaseWidget.HitTest(x, y) || cp.expanded && cp.popup.Contains(x, y)
+}
+
+// contentRect returns the area inside the panel border, below the tabs.
+func (cp *ColorPicker) contentRect() core.Rect {
+	tbH := 0
+	if cp.tabBar != nil {
+		tbH = cp.tabBar.TabBarHeight()
+	}
+	return core.Rect{
+		X: cp.popup.X + 1,
+		Y: cp.popup.Y + tbH + 1,
+		W: cp.popup.W - 2,
+		H: cp.popup.H - tbH - 2,
+	}
+}
+
 // getResultFromCurrentMode returns a ColorPickerResult from the active mode.
 func (cp *ColorPicker) getResultFromCurrentMode() ColorPickerResult {
 	if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
@@ -203,7 +329,8 @@ func (cp *ColorPicker) getResultFromCurrentMode() ColorPickerResult {
 }
 
 // SetValue sets the current color by parsing a color string.
-// Supported formats: "text.primary" (semantic), "@mauve" (palette), "#ff00ff" (hex)
+// Supported formats: "text.primary" (semantic), "@mauve" (palette), "#ff00ff" (hex),
+// "dodgerblue" (named)
 func (cp *ColorPicker) SetValue(colorStr string) {
 	tm := theme.Get()
 
@@ -218,9 +345,9 @@ func (cp *ColorPicker) SetValue(colorStr string) {
 		mode = ColorModePalette
 		source = colorStr
 	} else if len(colorStr) > 0 && colorStr[0] == '#' {
-		// Hex color -> use OKLCH mode
+		// Hex color -> use OKLCH mode, or RGB/HSL if OKLCH is off
 		resolvedColor = theme.HexColor(colorStr).ToTcell()
-		mode = ColorModeOKLCH
+		mode = cp.customMode()
 		source = colorStr
 	} else {
 		// Try as semantic color
@@ -228,10 +355,14 @@ func (cp *ColorPicker) SetValue(colorStr string) {
 		if resolvedColor != tcell.ColorDefault {
 			mode = ColorModeSemantic
 			source = colorStr
+		} else if named, ok := colorpicker.NamedColor(colorStr); ok {
+			resolvedColor = named
+			mode = ColorModeNamed
+			source = strings.ToLower(colorStr)
 		} else {
 			// Fallback to hex
 			resolvedColor = theme.HexColor(colorStr).ToTcell()
-			mode = ColorModeOKLCH
+			mode = cp.customMode()
 			source = colorStr
 		}
 	}
@@ -254,7 +385,22 @@ func (cp *ColorPicker) SetValue(colorStr string) {
 	} else if _, ok := cp.modes[mode]; ok {
 		cp.currentMode = mode
 		cp.activeMode = cp.modes[mode]
-		cp.activeMode.SetColor(resolvedColor)
+		if np, ok := cp.activeMode.(*colorpicker.NamedPicker); ok {
+			np.SetName(source) // Keep the exact name among equal colors
+		} else {
+			cp.activeMode.SetColor(resolvedColor)
+		}
+	} else if mode == ColorModeNamed {
+		// No named mode: edit it as a custom color
+		if custom := cp.customMode(); custom == ColorModeOKLCH && cp.oklchEditor != nil {
+			cp.currentMode = custom
+			cp.activeMode = nil
+			cp.oklchEditor.SetColor(resolvedColor)
+		} else if picker, ok := cp.modes[custom]; ok {
+			cp.currentMode = custom
+			cp.activeMode = picker
+			picker.SetColor(resolvedColor)
+		}
 	}
 
 	cp.invalidate()
@@ -267,12 +413,17 @@ func (cp *ColorPicker) GetResult() ColorPickerResult {
 
 // Toggle expands or collapses the picker.
 func (cp *ColorPicker) Toggle() {
+	cp.invalidate() // Previous extent, which may differ after placement
 	cp.expanded = !cp.expanded
-	// When expanded, raise z-index so picker draws on top of other widgets
+	cp.sampling = false
 	if cp.expanded {
-		cp.SetZIndex(100) // High z-index for overlay
-	} else {
-		cp.SetZIndex(0) // Normal z-index when collapsed
+		cp.previewed = cp.result
+	} else if cp.previewed != cp.result {
+		// Closed without committing: revert the preview
+		cp.previewed = cp.result
+		if cp.OnPreview != nil {
+			cp.OnPreview(cp.result)
+		}
 	}
 	cp.calculateSize()
 	cp.invalidate()
@@ -310,13 +461,10 @@ func (cp *ColorPicker) IsExpanded() bool {
 	return cp.expanded
 }
 
-// Draw renders the color picker.
+// Draw renders the picker's row; the expanded panel is drawn by the
+// UIManager's overlay pass (DrawOverlay).
 func (cp *ColorPicker) Draw(painter *core.Painter) {
-	if cp.expanded {
-		cp.drawExpanded(painter)
-	} else {
-		cp.drawCollapsed(painter)
-	}
+	cp.drawCollapsed(painter)
 }
 
 // drawCollapsed renders: [█A] source
@@ -375,8 +523,8 @@ func (cp *ColorPicker) drawExpanded(painter *core.Painter) {
 	if cp.tabBar != nil {
 		tbH = cp.tabBar.TabBarHeight()
 		tabBarFocused := cp.focus == focusTabBar
-		cp.tabBar.SetPosition(cp.Rect.X, cp.Rect.Y)
-		cp.tabBar.Resize(cp.Rect.W, tbH)
+		cp.tabBar.SetPosition(cp.popup.X, cp.popup.Y)
+		cp.tabBar.Resize(cp.popup.W, tbH)
 		if tabBarFocused {
 			cp.tabBar.Focus()
 		} else {
@@ -387,22 +535,17 @@ func (cp *ColorPicker) drawExpanded(painter *core.Painter) {
 
 	// Border below the tab bar
 	borderRect := core.Rect{
-		X: cp.Rect.X,
-		Y: cp.Rect.Y + tbH,
-		W: cp.Rect.W,
-		H: cp.Rect.H - tbH,
+		X: cp.popup.X,
+		Y: cp.popup.Y + tbH,
+		W: cp.popup.W,
+		H: cp.popup.H - tbH,
 	}
 	borderStyle := cp.EffectiveStyle(baseStyle)
 	painter.Fill(borderRect, ' ', baseStyle)
 	painter.DrawBorder(borderRect, borderStyle, [6]rune{'─', '│', '┌', '┐', '└', '┘'})
 
 	// Content inside border
-	contentRect := core.Rect{
-		X: borderRect.X + 1,
-		Y: borderRect.Y + 1,
-		W: borderRect.W - 2,
-		H: borderRect.H - 2,
-	}
+	contentRect := cp.contentRect()
 
 	if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
 		// Use new widget-based OKLCHEditor
@@ -414,8 +557,8 @@ func (cp *ColorPicker) drawExpanded(painter *core.Painter) {
 	}
 
 	// Draw live preview in bottom-left corner
-	previewX := cp.Rect.X + 2
-	previewY := cp.Rect.Y + cp.Rect.H - 1
+	previewX := cp.popup.X + 2
+	previewY := cp.popup.Y + cp.popup.H - 1
 	r := cp.getResultFromCurrentMode()
 	globalBg := tm.GetSemanticColor("bg.base")
 
@@ -424,10 +567,52 @@ func (cp *ColorPicker) drawExpanded(painter *core.Painter) {
 	painter.SetCell(previewX+1, previewY, ' ', tcell.StyleDefault.Background(r.Color))
 	painter.SetCell(previewX+2, previewY, 'T', tcell.StyleDefault.Foreground(r.Color).Background(globalBg))
 	painter.SetCell(previewX+3, previewY, ']', baseStyle)
+
+	cp.drawHexField(painter, r.Color, baseStyle)
+}
+
+// drawHexField renders the hex input on the bottom border: the current
+// color's hex, or the text being typed while the field has focus.
+func (cp *ColorPicker) drawHexField(painter *core.Painter, current tcell.Color, baseStyle tcell.Style) {
+	if cp.popup.W < hexFieldOffset+hexFieldW+1 {
+		return
+	}
+	x := cp.popup.X + hexFieldOffset
+	y := cp.popup.Y + cp.popup.H - 1
+	text := colorHex(current)
+	style := baseStyle
+	if cp.focus == focusHex {
+		text = string(cp.hexText)
+		style = style.Reverse(true)
+		if !cp.hexValid {
+			style = style.Foreground(theme.Get().GetSemanticColor("action.danger"))
+		}
+	}
+	painter.Fill(core.Rect{X: x, Y: y, W: hexFieldW, H: 1}, ' ', style)
+	painter.DrawText(x+1, y, text, style)
+
+	if cp.popup.W >= eyedropperX+2 {
+		dropStyle := baseStyle
+		if cp.sampling {
+			dropStyle = dropStyle.Foreground(theme.Get().GetSemanticColor("accent")).Bold(true)
+		}
+		painter.SetCell(cp.popup.X+eyedropperX, y, '◎', dropStyle)
+	}
 }
 
 // HandleKey processes keyboard input.
 func (cp *ColorPicker) HandleKey(ev *tcell.EventKey) bool {
+	defer cp.notifyPreview()
+	if ev.Key() == tcell.KeyCtrlC {
+		return cp.copyHex()
+	}
+	if cp.sampling {
+		if ev.Key() == tcell.KeyEsc {
+			cp.cancelSampling()
+		}
+		return true // Keys wait until the eyedropper is done
+	}
+
 	if !cp.expanded {
 		// Collapsed: Space only to expand (Enter validates/cycles, like other widgets)
 		if ev.Rune() == ' ' {
@@ -438,15 +623,25 @@ func (cp *ColorPicker) HandleKey(ev *tcell.EventKey) bool {
 		return false
 	}
 
-	// Esc always closes
-	if ev.Key() == tcell.KeyEsc {
+	// Esc closes, unless it cancels a value being typed in OKLCH mode
+	entering := cp.focus == focusContent && cp.currentMode == ColorModeOKLCH &&
+		cp.oklchEditor != nil && cp.oklchEditor.IsEntering()
+	if ev.Key() == tcell.KeyEsc && !entering {
 		cp.Collapse()
 		return true
 	}
 
+	if ev.Key() == tcell.KeyCtrlE {
+		cp.StartEyedropper()
+		return true
+	}
+
 	// Handle based on focus area
-	if cp.focus == focusTabBar {
+	switch cp.focus {
+	case focusTabBar:
 		return cp.handleTabBarKey(ev)
+	case focusHex:
+		return cp.handleHexKey(ev)
 	}
 
 	// Content focus: let mode handle first, then check for commit
@@ -528,22 +723,247 @@ func (cp *ColorPicker) handleContentKey(ev *tcell.EventKey) bool {
 		cp.invalidate()
 		return true
 
+	case tcell.KeyDown:
+		// Mode didn't handle Down, go to the hex field
+		cp.enterHexField()
+		return true
+
 	case tcell.KeyEnter:
 		// Mode didn't handle Enter, commit current selection and close
-		cp.result = cp.getResultFromCurrentMode()
-		if cp.OnChange != nil {
-			cp.OnChange(cp.result)
-		}
-		cp.Collapse()
+		cp.commit(cp.getResultFromCurrentMode())
 		return true
 	}
 
 	return false
 }
 
+// commit stores the selection, notifies OnChange and closes the picker.
+func (cp *ColorPicker) commit(result ColorPickerResult) {
+	cp.result = result
+	cp.previewed = result
+	if cp.OnChange != nil {
+		cp.OnChange(cp.result)
+	}
+	cp.Collapse()
+}
+
+// notifyPreview calls OnPreview if the expanded picker's color changed
+// since the last call.
+func (cp *ColorPicker) notifyPreview() {
+	if !cp.expanded {
+		return
+	}
+	r := cp.getResultFromCurrentMode()
+	if r == cp.previewed {
+		return
+	}
+	cp.previewed = r
+	if cp.OnPreview != nil {
+		cp.OnPreview(r)
+	}
+}
+
+// enterHexField focuses the hex input, starting from the current color.
+func (cp *ColorPicker) enterHexField() {
+	cp.focus = focusHex
+	if cp.oklchEditor != nil {
+		cp.oklchEditor.Blur()
+	}
+	cp.hexText = []rune(colorHex(cp.getResultFromCurrentMode().Color))
+	cp.hexValid = len(cp.hexText) > 0
+	cp.invalidate()
+}
+
+// handleHexKey edits the hex field. Valid entries (#rrggbb or #rgb) are
+// previewed as they are typed; Enter commits them exactly as entered.
+func (cp *ColorPicker) handleHexKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyTab, tcell.KeyBacktab:
+		cp.CycleFocus(ev.Key() == tcell.KeyTab && ev.Modifiers()&tcell.ModShift == 0)
+		return true
+	case tcell.KeyUp:
+		cp.focus = focusContent
+		cp.resetContentFocus()
+		cp.invalidate()
+		return true
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if len(cp.hexText) > 0 {
+			cp.hexText = cp.hexText[:len(cp.hexText)-1]
+			cp.updateHex()
+		}
+		return true
+	case tcell.KeyEnter:
+		if c, ok := parseHexInput(string(cp.hexText)); ok {
+			cp.commitColor(c)
+		}
+		return true // Invalid entries stay open for correction
+	case tcell.KeyRune:
+		r := ev.Rune()
+		isHex := strings.ContainsRune("0123456789abcdefABCDEF", r)
+		if (isHex || r == '#' && len(cp.hexText) == 0) && len(cp.hexText) < 7 {
+			cp.hexText = append(cp.hexText, r)
+			cp.updateHex()
+		}
+		return true
+	}
+	return false
+}
+
+// updateHex validates the hex field and previews a valid color.
+func (cp *ColorPicker) updateHex() {
+	c, ok := parseHexInput(string(cp.hexText))
+	cp.hexValid = ok
+	if ok {
+		cp.previewColor(c)
+	}
+	cp.invalidate()
+}
+
+// previewColor shows c in the custom mode (OKLCH, RGB or HSL), switching
+// to it if needed.
+func (cp *ColorPicker) previewColor(c tcell.Color) {
+	mode := cp.customMode()
+	if _, has := cp.modes[mode]; has || mode == ColorModeOKLCH && cp.oklchEditor != nil {
+		if cp.currentMode != mode {
+			cp.selectMode(mode)
+		}
+	}
+	cp.showColor(c)
+}
+
+// showColor sets c on the current mode's editor.
+func (cp *ColorPicker) showColor(c tcell.Color) {
+	if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
+		cp.oklchEditor.SetColor(c)
+	} else if cp.activeMode != nil {
+		cp.activeMode.SetColor(c)
+	}
+}
+
+// commitColor commits c exactly, with its hex as the source.
+func (cp *ColorPicker) commitColor(c tcell.Color) {
+	r, g, b := c.RGB()
+	cp.commit(ColorPickerResult{
+		Color:  c,
+		Mode:   cp.currentMode,
+		Source: colorHex(c),
+		R:      r,
+		G:      g,
+		B:      b,
+	})
+}
+
+// StartEyedropper lets the user pick a color from anywhere on screen: the
+// cell under the mouse is previewed as it moves and committed on click.
+// Shift+click (or Ctrl+click) takes the cell's foreground instead of its
+// background. Esc or a right-click cancels. Requires the picker to be
+// expanded and focused in a UIManager.
+func (cp *ColorPicker) StartEyedropper() {
+	if !cp.expanded || cp.sampling {
+		return
+	}
+	cp.sampling = true
+	cp.restoreMode = cp.currentMode
+	cp.restoreColor = cp.getResultFromCurrentMode().Color
+	cp.invalidate()
+}
+
+// IsSampling reports whether the eyedropper is active.
+func (cp *ColorPicker) IsSampling() bool {
+	return cp.sampling
+}
+
+// cancelSampling ends the eyedropper and restores the previous selection.
+func (cp *ColorPicker) cancelSampling() {
+	cp.sampling = false
+	cp.selectMode(cp.restoreMode)
+	if cp.restoreColor != tcell.ColorDefault {
+		cp.showColor(cp.restoreColor)
+	}
+	cp.invalidate()
+}
+
+// GrabsPointer implements core.PointerGrabber.
+func (cp *ColorPicker) GrabsPointer() bool {
+	return cp.sampling
+}
+
+// HandleGrabbedMouse implements core.PointerGrabber: previews the sampled
+// cell color, commits it on click and cancels on right-click.
+func (cp *ColorPicker) HandleGrabbedMouse(ev *tcell.EventMouse, cell core.Cell) {
+	defer cp.notifyPreview()
+	if ev.Buttons()&tcell.Button2 != 0 {
+		cp.cancelSampling()
+		return
+	}
+	fg, bg, _ := cell.Style.Decompose()
+	c := bg
+	if ev.Modifiers()&(tcell.ModShift|tcell.ModCtrl) != 0 {
+		c = fg
+	}
+	if c == tcell.ColorDefault {
+		return // Terminal default: nothing to sample
+	}
+	cp.previewColor(c)
+	if ev.Buttons()&tcell.Button1 != 0 {
+		cp.sampling = false
+		cp.commitColor(c)
+	}
+	cp.invalidate()
+}
+
+// copyHex puts the current color's hex value on the clipboard.
+func (cp *ColorPicker) copyHex() bool {
+	if cp.clipboard == nil {
+		return false
+	}
+	c := cp.result.Color
+	if cp.expanded {
+		c = cp.getResultFromCurrentMode().Color
+		if cp.focus == focusHex {
+			if typed, ok := parseHexInput(string(cp.hexText)); ok {
+				c = typed
+			}
+		}
+	}
+	hex := colorHex(c)
+	if hex == "" {
+		return false
+	}
+	cp.clipboard.SetClipboard("text/plain", []byte(hex))
+	return true
+}
+
+// SetClipboardService implements core.ClipboardAware.
+func (cp *ColorPicker) SetClipboardService(cs core.ClipboardService) {
+	cp.clipboard = cs
+}
+
+// colorHex returns c as "#rrggbb", or "" for the default color.
+func colorHex(c tcell.Color) string {
+	if c == tcell.ColorDefault || !c.Valid() {
+		return ""
+	}
+	return string(theme.FromTcell(c))
+}
+
+// parseHexInput parses "#rrggbb" or "#rgb" (the # is optional).
+func parseHexInput(s string) (tcell.Color, bool) {
+	s = strings.TrimPrefix(s, "#")
+	if len(s) != 3 && len(s) != 6 {
+		return tcell.ColorDefault, false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return tcell.ColorDefault, false
+		}
+	}
+	return theme.HexColor("#" + s).ToTcell(), true
+}
 
 // HandleMouse processes mouse input.
 func (cp *ColorPicker) HandleMouse(ev *tcell.EventMouse) bool {
+	defer cp.notifyPreview()
 	x, y := ev.Position()
 	if !cp.HitTest(x, y) {
 		return false
@@ -558,8 +978,16 @@ func (cp *ColorPicker) HandleMouse(ev *tcell.EventMouse) bool {
 		return false
 	}
 
-	// Check if clicking on tabs (top border row)
-	if y == cp.Rect.Y && cp.tabBar != nil {
+	// Clicking the picker's own row closes the panel
+	if cp.BaseWidget.HitTest(x, y) {
+		if ev.Buttons() == tcell.Button1 {
+			cp.Collapse()
+		}
+		return true
+	}
+
+	// Check if clicking on tabs (top row of the panel)
+	if y == cp.popup.Y && cp.tabBar != nil {
 		// Delegate to TabBar
 		if cp.tabBar.HandleMouse(ev) {
 			cp.focus = focusTabBar
@@ -569,14 +997,23 @@ func (cp *ColorPicker) HandleMouse(ev *tcell.EventMouse) bool {
 		return true
 	}
 
-	// Delegate to active mode (content area)
-	contentRect := core.Rect{
-		X: cp.Rect.X + 1,
-		Y: cp.Rect.Y + 1,
-		W: cp.Rect.W - 2,
-		H: cp.Rect.H - 2,
+	// Click on the hex field (bottom border) focuses it
+	if y == cp.popup.Y+cp.popup.H-1 && x >= cp.popup.X+hexFieldOffset && x < cp.popup.X+hexFieldOffset+hexFieldW {
+		if ev.Buttons() == tcell.Button1 && cp.focus != focusHex {
+			cp.enterHexField()
+		}
+		return true
+	}
+	if y == cp.popup.Y+cp.popup.H-1 && x == cp.popup.X+eyedropperX {
+		if ev.Buttons() == tcell.Button1 {
+			cp.StartEyedropper()
+		}
+		return true
 	}
 
+	// Delegate to active mode (content area)
+	contentRect := cp.contentRect()
+
 	if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
 		// Ensure OKLCHEditor is positioned correctly for hit testing
 		cp.oklchEditor.SetPosition(contentRect.X, contentRect.Y)
@@ -607,6 +1044,12 @@ func (cp *ColorPicker) selectModeByIndex(idx int) {
 		cp.currentMode = mode
 		cp.activeMode = nil
 	} else if picker, ok := cp.modes[mode]; ok {
+		if mode == ColorModeRGB || mode == ColorModeHSL {
+			// Slider modes start from the color picked so far
+			if prev := cp.getResultFromCurrentMode(); prev.Color != tcell.ColorDefault {
+				picker.SetColor(prev.Color)
+			}
+		}
 		cp.currentMode = mode
 		cp.activeMode = picker
 	}
@@ -614,6 +1057,19 @@ func (cp *ColorPicker) selectModeByIndex(idx int) {
 	cp.invalidate()
 }
 
+// customMode returns the mode that edits raw colors: OKLCH, or RGB/HSL
+// when OKLCH is not enabled.
+func (cp *ColorPicker) customMode() ColorPickerMode {
+	if cp.oklchEditor == nil {
+		for _, m := range []ColorPickerMode{ColorModeRGB, ColorModeHSL} {
+			if _, ok := cp.modes[m]; ok {
+				return m
+			}
+		}
+	}
+	return ColorModeOKLCH
+}
+
 // selectMode switches to a different mode.
 func (cp *ColorPicker) selectMode(mode ColorPickerMode) {
 	// Find index of mode and update TabBar
@@ -628,70 +1084,64 @@ func (cp *ColorPicker) selectMode(mode ColorPickerMode) {
 	}
 }
 
-// calculateSize updates widget size based on state.
+// calculateSize sizes the expanded panel for the active mode and places it.
 func (cp *ColorPicker) calculateSize() {
-	if !cp.expanded {
-		// Collapsed: [█A] source
-		w := 5                         // [█A]
-		w += len(cp.result.Source) + 1 // " source"
-		if w < 20 {
-			w = 20
-		}
-		cp.Resize(w, 1)
-	} else {
-		// Calculate minimum width for powerline tabs:
-		// [leftTri] + [" Label " + sep] per tab + [rightTri]
-		tabsWidth := 2 // leading + trailing triangles
-		if cp.tabBar != nil {
-			for _, mode := range cp.modeOrder {
-				tabsWidth += len(" "+mode.String()+" ") + 1 // label + separator
-			}
+	// Calculate minimum width for powerline tabs:
+	// [leftTri] + [" Label " + sep] per tab + [rightTri]
+	tabsWidth := 2 // leading + trailing triangles
+	if cp.tabBar != nil {
+		for _, mode := range cp.modeOrder {
+			tabsWidth += len(" "+mode.String()+" ") + 1 // label + separator
 		}
+	}
 
-		// Expanded: get preferred size from active mode
-		w, h := 30, 15 // Default minimum (increased for OKLCHEditor)
-		if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
-			// OKLCHEditor needs more space for HCPlane + slider + preview
-			mw, mh := 28, 13
-			if mw+2 > w {
-				w = mw + 2 // +2 for border
-			}
-			if mh+2 > h {
-				h = mh + 2 // +2 for border
-			}
-		} else if cp.activeMode != nil {
-			mw, mh := cp.activeMode.PreferredSize()
-			if mw+2 > w {
-				w = mw + 2 // +2 for border
-			}
-			if mh+2 > h {
-				h = mh + 2 // +2 for border
-			}
+	// Get preferred size from active mode
+	w, h := 30, 15 // Default minimum (increased for OKLCHEditor)
+	if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
+		// OKLCHEditor needs more space for HCPlane + slider + preview
+		mw, mh := 28, 13
+		if mw+2 > w {
+			w = mw + 2 // +2 for border
 		}
-
-		// Ensure width is at least enough for all tabs
-		if tabsWidth > w {
-			w = tabsWidth
+		if mh+2 > h {
+			h = mh + 2 // +2 for border
 		}
-
-		// Add tab bar height (tabs sit above the border)
-		if cp.tabBar != nil {
-			h += cp.tabBar.TabBarHeight()
+	} else if cp.activeMode != nil {
+		mw, mh := cp.activeMode.PreferredSize()
+		if mw+2 > w {
+			w = mw + 2 // +2 for border
+		}
+		if mh+2 > h {
+			h = mh + 2 // +2 for border
 		}
+	}
+
+	// Ensure width is at least enough for all tabs
+	if tabsWidth > w {
+		w = tabsWidth
+	}
 
-		cp.Resize(w, h)
+	// Add tab bar height (tabs sit above the border)
+	if cp.tabBar != nil {
+		h += cp.tabBar.TabBarHeight()
 	}
+
+	cp.popup.W, cp.popup.H = w, h
+	cp.place()
 }
 
 // invalidate marks the widget as needing redraw.
 func (cp *ColorPicker) invalidate() {
 	if cp.inv != nil {
 		cp.inv(cp.Rect)
+		if cp.expanded {
+			cp.inv(cp.popup)
+		}
 	}
 }
 
 // CycleFocus implements core.FocusCycler.
-// When expanded, cycles focus between tab bar and content.
+// When expanded, cycles focus between tab bar, content and hex field.
 func (cp *ColorPicker) CycleFocus(forward bool) bool {
 	if !cp.expanded {
 		return false
@@ -713,16 +1163,26 @@ func (cp *ColorPicker) CycleFocus(forward bool) bool {
 					return true
 				}
 			}
-			// Content exhausted, wrap to tab bar
+			// Content exhausted, move to the hex field
+			cp.enterHexField()
+			return true
+		case focusHex:
+			// Wrap to tab bar
 			cp.focus = focusTabBar
-			if cp.oklchEditor != nil {
-				cp.oklchEditor.Blur()
-			}
 			cp.invalidate()
 			return true
 		}
 	} else {
 		switch cp.focus {
+		case focusHex:
+			// Back to content (last element)
+			cp.focus = focusContent
+			cp.resetContentFocus()
+			if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
+				cp.oklchEditor.CycleFocus(true) // plane -> slider
+			}
+			cp.invalidate()
+			return true
 		case focusContent:
 			// Try to cycle backward within content first
 			if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
@@ -739,15 +1199,8 @@ func (cp *ColorPicker) CycleFocus(forward bool) bool {
 			cp.invalidate()
 			return true
 		case focusTabBar:
-			// Wrap to content (last element)
-			cp.focus = focusContent
-			cp.resetContentFocus()
-			// Move to last element in content
-			if cp.currentMode == ColorModeOKLCH && cp.oklchEditor != nil {
-				// Move to slider (last element)
-				cp.oklchEditor.CycleFocus(true) // plane -> slider
-			}
-			cp.invalidate()
+			// Wrap to the hex field
+			cp.enterHexField()
 			return true
 		}
 	}
@@ -773,9 +1226,26 @@ func (cp *ColorPicker) GetKeyHints() []core.KeyHint {
 		hints = append(hints, core.KeyHint{Key: "↓/Tab", Label: "Edit"})
 		return hints
 	}
-	// Content focus
-	return []core.KeyHint{
+	if cp.sampling {
+		return []core.KeyHint{
+			{Key: "Click", Label: "Pick"},
+			{Key: "Shift+Click", Label: "Pick text"},
+			{Key: "Esc", Label: "Cancel"},
+		}
+	}
+	// Content or hex field focus
+	hints := []core.KeyHint{
 		{Key: "Enter", Label: "Apply"},
 		{Key: "Esc", Label: "Close"},
 	}
+	if cp.focus == focusContent && cp.currentMode == ColorModeOKLCH {
+		hints = append(hints,
+			core.KeyHint{Key: "l/c/h", Label: "Type value"},
+			core.KeyHint{Key: "+/-", Label: "Fine step"})
+	}
+	hints = append(hints, core.KeyHint{Key: "Ctrl+E", Label: "Eyedropper"})
+	if cp.clipboard != nil {
+		hints = append(hints, core.KeyHint{Key: "Ctrl+C", Label: "Copy hex"})
+	}
+	return hints
 }
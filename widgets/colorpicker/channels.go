@@ -0,0 +1,273 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/colorpicker/channels.go
+// Summary: RGB and HSL color selection modes with one slider per channel.
+
+package colorpicker
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/theme"
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+)
+
+// colorChannel describes one slider of a ChannelPicker.
+type colorChannel struct {
+	label    string
+	max      float64
+	step     float64 // Left/Right step
+	fineStep float64 // Shift+Left/Right step
+	format   func(v float64) string
+}
+
+// ChannelPicker selects a color with a horizontal slider per channel of a
+// color model (RGB or HSL), each with a numeric readout. Every slider
+// previews the colors it would produce with the other channels fixed.
+// Layout:
+//
+//	R [◆████████████████] 255
+//	G [█████◆███████████]  80
+//	B [██████████◆██████] 160
+//	[██] #ff50a0
+type ChannelPicker struct {
+	channels []colorChannel
+	values   []float64
+	active   int
+
+	toColor   func(v []float64) tcell.Color
+	fromColor func(c tcell.Color) []float64
+	source    func(v []float64) string
+}
+
+// channelReadoutW is the width of the numeric readout after each slider.
+const channelReadoutW = 5
+
+// NewRGBPicker creates a picker with red, green and blue sliders (0-255).
+func NewRGBPicker() *ChannelPicker {
+	channel := func(label string) colorChannel {
+		return colorChannel{label: label, max: 255, step: 5, fineStep: 1,
+			format: func(v float64) string { return fmt.Sprintf("%3.0f", v) }}
+	}
+	cp := &ChannelPicker{
+		channels: []colorChannel{channel("R"), channel("G"), channel("B")},
+		toColor: func(v []float64) tcell.Color {
+			return tcell.NewRGBColor(int32(v[0]+0.5), int32(v[1]+0.5), int32(v[2]+0.5))
+		},
+		fromColor: func(c tcell.Color) []float64 {
+			r, g, b := c.RGB()
+			return []float64{float64(r), float64(g), float64(b)}
+		},
+		source: func(v []float64) string {
+			return fmt.Sprintf("#%02x%02x%02x", int32(v[0]+0.5), int32(v[1]+0.5), int32(v[2]+0.5))
+		},
+	}
+	cp.values = []float64{203, 166, 247} // Mauve-ish, like the OKLCH default
+	return cp
+}
+
+// NewHSLPicker creates a picker with hue (0-360°), saturation and
+// lightness (0-100%) sliders.
+func NewHSLPicker() *ChannelPicker {
+	percent := func(label string) colorChannel {
+		return colorChannel{label: label, max: 100, step: 5, fineStep: 1,
+			format: func(v float64) string { return fmt.Sprintf("%3.0f%%", v) }}
+	}
+	cp := &ChannelPicker{
+		channels: []colorChannel{
+			{label: "H", max: 360, step: 5, fineStep: 1,
+				format: func(v float64) string { return fmt.Sprintf("%3.0f°", v) }},
+			percent("S"),
+			percent("L"),
+		},
+		toColor: func(v []float64) tcell.Color {
+			rgb := color.HSLToRGB(v[0], v[1]/100, v[2]/100)
+			return tcell.NewRGBColor(rgb.R, rgb.G, rgb.B)
+		},
+		fromColor: func(c tcell.Color) []float64 {
+			r, g, b := c.RGB()
+			h, s, l := color.RGBToHSL(r, g, b)
+			return []float64{h, s * 100, l * 100}
+		},
+		source: func(v []float64) string {
+			return fmt.Sprintf("hsl(%.0f,%.0f%%,%.0f%%)", v[0], v[1], v[2])
+		},
+	}
+	cp.values = []float64{270, 80, 80}
+	return cp
+}
+
+// Value returns the current value of channel i.
+func (cp *ChannelPicker) Value(i int) float64 {
+	return cp.values[i]
+}
+
+// SetValue sets channel i, clamped to its range.
+func (cp *ChannelPicker) SetValue(i int, v float64) {
+	if v < 0 {
+		v = 0
+	}
+	if v > cp.channels[i].max {
+		v = cp.channels[i].max
+	}
+	cp.values[i] = v
+}
+
+func (cp *ChannelPicker) Draw(painter *core.Painter, rect core.Rect) {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	baseStyle := tcell.StyleDefault.Foreground(fg).Background(bg)
+	painter.Fill(rect, ' ', baseStyle)
+
+	for i, ch := range cp.channels {
+		y := rect.Y + i*2
+		if y >= rect.Y+rect.H {
+			break
+		}
+		labelStyle := baseStyle
+		if i == cp.active {
+			labelStyle = labelStyle.Foreground(tm.GetSemanticColor("accent")).Bold(true)
+		}
+		painter.SetCell(rect.X, y, []rune(ch.label)[0], labelStyle)
+		track := cp.trackRect(rect, i)
+		cp.drawTrack(painter, track, i, baseStyle)
+		painter.DrawText(track.X+track.W+2, y, ch.format(cp.values[i]), baseStyle)
+	}
+
+	// Swatch and source of the current color
+	y := rect.Y + len(cp.channels)*2
+	if y < rect.Y+rect.H {
+		x := rect.X + DrawColorSwatch(painter, rect.X, y, cp.toColor(cp.values), baseStyle) + 1
+		painter.DrawText(x, y, cp.source(cp.values), baseStyle)
+	}
+}
+
+// trackRect returns the slider track of channel i: between "X [" and
+// "] readout".
+func (cp *ChannelPicker) trackRect(rect core.Rect, i int) core.Rect {
+	w := rect.W - 3 - 1 - channelReadoutW
+	if w < 4 {
+		w = 4
+	}
+	return core.Rect{X: rect.X + 3, Y: rect.Y + i*2, W: w, H: 1}
+}
+
+// drawTrack renders channel i's gradient with the thumb at its value.
+func (cp *ChannelPicker) drawTrack(painter *core.Painter, track core.Rect, i int, baseStyle tcell.Style) {
+	painter.SetCell(track.X-1, track.Y, '[', baseStyle)
+	painter.SetCell(track.X+track.W, track.Y, ']', baseStyle)
+
+	vals := append([]float64(nil), cp.values...)
+	thumb := cp.cellOf(track, i)
+	for x := 0; x < track.W; x++ {
+		vals[i] = cp.valueAt(track, i, track.X+x)
+		c := cp.toColor(vals)
+		ch := ' '
+		style := tcell.StyleDefault.Background(c)
+		if x == thumb {
+			ch = '◇'
+			if i == cp.active {
+				ch = '◆'
+			}
+			// Keep the thumb readable on light and dark cells
+			r, g, b := c.RGB()
+			if _, _, l := color.RGBToHSL(r, g, b); l > 0.5 {
+				style = style.Foreground(tcell.NewRGBColor(0, 0, 0))
+			} else {
+				style = style.Foreground(tcell.NewRGBColor(255, 255, 255))
+			}
+		}
+		painter.SetCell(track.X+x, track.Y, ch, style)
+	}
+}
+
+// cellOf returns the track cell showing channel i's current value.
+func (cp *ChannelPicker) cellOf(track core.Rect, i int) int {
+	if track.W <= 1 {
+		return 0
+	}
+	return int(cp.values[i]/cp.channels[i].max*float64(track.W-1) + 0.5)
+}
+
+// valueAt returns the value of channel i at screen column x of its track.
+func (cp *ChannelPicker) valueAt(track core.Rect, i, x int) float64 {
+	if track.W <= 1 {
+		return 0
+	}
+	return float64(x-track.X) / float64(track.W-1) * cp.channels[i].max
+}
+
+func (cp *ChannelPicker) HandleKey(ev *tcell.EventKey) bool {
+	ch := cp.channels[cp.active]
+	step := ch.step
+	if ev.Modifiers()&tcell.ModShift != 0 {
+		step = ch.fineStep
+	}
+
+	switch ev.Key() {
+	case tcell.KeyUp:
+		if cp.active == 0 {
+			return false // Let the picker move to the tab bar
+		}
+		cp.active--
+		return true
+	case tcell.KeyDown:
+		if cp.active == len(cp.channels)-1 {
+			return false
+		}
+		cp.active++
+		return true
+	case tcell.KeyLeft:
+		cp.SetValue(cp.active, cp.values[cp.active]-step)
+		return true
+	case tcell.KeyRight:
+		cp.SetValue(cp.active, cp.values[cp.active]+step)
+		return true
+	case tcell.KeyHome:
+		cp.SetValue(cp.active, 0)
+		return true
+	case tcell.KeyEnd:
+		cp.SetValue(cp.active, ch.max)
+		return true
+	}
+	return false
+}
+
+func (cp *ChannelPicker) HandleMouse(ev *tcell.EventMouse, rect core.Rect) bool {
+	if ev.Buttons() != tcell.Button1 {
+		return false
+	}
+	x, y := ev.Position()
+	for i := range cp.channels {
+		track := cp.trackRect(rect, i)
+		if y == track.Y && x >= track.X && x < track.X+track.W {
+			cp.active = i
+			cp.SetValue(i, cp.valueAt(track, i, x))
+			return true
+		}
+	}
+	return false
+}
+
+func (cp *ChannelPicker) GetResult() PickerResult {
+	return MakeResult(cp.toColor(cp.values), cp.source(cp.values))
+}
+
+func (cp *ChannelPicker) PreferredSize() (int, int) {
+	return 30, len(cp.channels)*2 + 1
+}
+
+func (cp *ChannelPicker) SetColor(c tcell.Color) {
+	if c == tcell.ColorDefault {
+		return
+	}
+	cp.values = cp.fromColor(c)
+}
+
+func (cp *ChannelPicker) ResetFocus() {
+	cp.active = 0
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/colorpicker/named.go
+// Summary: Named (X11/web) color selection mode with type-ahead filtering.
+
+package colorpicker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/theme"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/primitives"
+)
+
+// NamedPicker allows selection from the named X11/web colors known to
+// tcell ("dodgerblue", "salmon", ...). Typing filters the list; the
+// result's Source is the color name.
+type NamedPicker struct {
+	names []string // All names, sorted
+	query string   // Type-ahead filter
+	list  *primitives.ScrollableList
+}
+
+// NewNamedPicker creates a named color picker.
+func NewNamedPicker() *NamedPicker {
+	np := &NamedPicker{}
+	for name := range tcell.ColorNames {
+		np.names = append(np.names, name)
+	}
+	sort.Strings(np.names)
+
+	np.list = primitives.NewScrollableList(0, 0, 28, 10)
+	np.list.RenderItem = np.renderColorItem
+	np.filter()
+	return np
+}
+
+// NamedColor returns the RGB value of a named color, and whether the name
+// is known. Names are case-insensitive.
+func NamedColor(name string) (tcell.Color, bool) {
+	c, ok := tcell.ColorNames[strings.ToLower(name)]
+	if !ok {
+		return tcell.ColorDefault, false
+	}
+	// Use the RGB value rather than the palette index some names map to,
+	// which the terminal may remap
+	return tcell.NewRGBColor(c.RGB()), true
+}
+
+// filter rebuilds the list from the names containing the query, keeping
+// the selection when it still matches.
+func (np *NamedPicker) filter() {
+	current := ""
+	if item := np.list.SelectedItem(); item != nil {
+		current = item.Text
+	}
+	var items []primitives.ListItem
+	selected := 0
+	for _, name := range np.names {
+		if !strings.Contains(name, np.query) {
+			continue
+		}
+		if name == current {
+			selected = len(items)
+		}
+		items = append(items, primitives.ListItem{Text: name, Value: name})
+	}
+	np.list.SetItems(items)
+	np.list.SetSelected(selected)
+}
+
+// renderColorItem renders a named color item with swatch and name.
+func (np *NamedPicker) renderColorItem(p *core.Painter, rect core.Rect, item primitives.ListItem, selected bool) {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	style := tcell.StyleDefault.Foreground(fg).Background(bg)
+	if selected {
+		style = style.Reverse(true)
+	}
+	p.Fill(rect, ' ', style)
+
+	// Draw: [██] name
+	c, _ := NamedColor(item.Text)
+	DrawColorSwatch(p, rect.X, rect.Y, c, style)
+	name := item.Text
+	if maxLen := rect.W - 6; len(name) > maxLen && maxLen > 0 {
+		name = name[:maxLen]
+	}
+	p.DrawText(rect.X+5, rect.Y, name, style)
+}
+
+// Draw renders the filter line above the list.
+func (np *NamedPicker) Draw(painter *core.Painter, rect core.Rect) {
+	tm := theme.Get()
+	bg := tm.GetSemanticColor("bg.surface")
+	style := tcell.StyleDefault.Foreground(tm.GetSemanticColor("text.primary")).Background(bg)
+	painter.Fill(core.Rect{X: rect.X, Y: rect.Y, W: rect.W, H: 1}, ' ', style)
+	if np.query == "" {
+		muted := style.Foreground(tm.GetSemanticColor("text.muted"))
+		painter.DrawText(rect.X, rect.Y, "Type to filter", muted)
+	} else {
+		painter.DrawText(rect.X, rect.Y, "/"+np.query, style)
+	}
+
+	np.layoutList(rect)
+	np.list.Draw(painter)
+}
+
+// layoutList places the list below the filter line.
+func (np *NamedPicker) layoutList(rect core.Rect) {
+	np.list.SetPosition(rect.X, rect.Y+1)
+	np.list.Resize(rect.W, rect.H-1)
+}
+
+// HandleKey filters with typed letters (Backspace and Ctrl+U edit the
+// filter) and navigates the list with the other keys.
+func (np *NamedPicker) HandleKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		r := ev.Rune()
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			np.query += strings.ToLower(string(r))
+			np.filter()
+			return true
+		}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if q := []rune(np.query); len(q) > 0 {
+			np.query = string(q[:len(q)-1])
+			np.filter()
+			return true
+		}
+	case tcell.KeyCtrlU:
+		if np.query != "" {
+			np.query = ""
+			np.filter()
+			return true
+		}
+	}
+	return np.list.HandleKey(ev)
+}
+
+func (np *NamedPicker) HandleMouse(ev *tcell.EventMouse, rect core.Rect) bool {
+	np.layoutList(rect)
+	return np.list.HandleMouse(ev)
+}
+
+func (np *NamedPicker) GetResult() PickerResult {
+	item := np.list.SelectedItem()
+	if item == nil {
+		return PickerResult{Color: tcell.ColorDefault}
+	}
+	c, _ := NamedColor(item.Text)
+	return MakeResult(c, item.Text)
+}
+
+func (np *NamedPicker) PreferredSize() (int, int) {
+	// Width: "[██] lightgoldenrodyellow" = ~28 chars
+	// Height: filter line + ~10 items
+	return 30, 11
+}
+
+// SetColor selects the first name with the same RGB value, clearing the
+// filter.
+func (np *NamedPicker) SetColor(color tcell.Color) {
+	r, g, b := color.RGB()
+	for _, name := range np.names {
+		c, _ := NamedColor(name)
+		if cr, cg, cb := c.RGB(); cr == r && cg == g && cb == b {
+			np.query = ""
+			np.filter()
+			for i, item := range np.list.Items {
+				if item.Text == name {
+					np.list.SetSelected(i)
+				}
+			}
+			return
+		}
+	}
+}
+
+// SetName selects a color by name, clearing the filter. It returns false
+// if the name is unknown.
+func (np *NamedPicker) SetName(name string) bool {
+	name = strings.ToLower(name)
+	np.query = ""
+	np.filter()
+	for i, item := range np.list.Items {
+		if item.Text == name {
+			np.list.SetSelected(i)
+			return true
+		}
+	}
+	return false
+}
+
+// ResetFocus clears the filter.
+func (np *NamedPicker) ResetFocus() {
+	if np.query != "" {
+		np.query = ""
+		np.filter()
+	}
+}
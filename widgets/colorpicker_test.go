@@ -1,6 +1,7 @@
 package widgets
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -105,3 +106,191 @@ func TestColorPickerEnterWithSlowOnChange(t *testing.T) {
 		t.Error("ColorPicker should be collapsed after Enter")
 	}
 }
+
+func TestColorPickerFlipsAboveNearSurfaceBottom(t *testing.T) {
+	cp := NewColorPicker(ColorPickerConfig{EnableSemantic: true})
+	cp.SetPosition(70, 20)
+	cp.SetSurfaceSize(80, 24)
+
+	cp.Expand()
+	popup, open := cp.OverlayRect()
+	if !open {
+		t.Fatal("expanded picker should have an open overlay")
+	}
+	if popup.Y+popup.H != 20 {
+		t.Errorf("expected the panel to end above the picker's row, got %+v", popup)
+	}
+	if popup.X+popup.W != 80 {
+		t.Errorf("expected the panel to shift left onto the surface, got %+v", popup)
+	}
+	if x, y := cp.Position(); x != 70 || y != 20 {
+		t.Errorf("the picker's row should stay in place, got %d,%d", x, y)
+	}
+	if !cp.HitTest(popup.X, popup.Y) {
+		t.Error("the panel should be part of the picker's hit area")
+	}
+
+	cp.Collapse()
+	if _, open := cp.OverlayRect(); open || cp.HitTest(popup.X, popup.Y) {
+		t.Error("collapsed picker should close its overlay")
+	}
+}
+
+func TestColorPickerRGBAndHSLModes(t *testing.T) {
+	cp := NewColorPicker(ColorPickerConfig{EnableRGB: true, EnableHSL: true})
+	cp.SetValue("#ff0000")
+	if cp.currentMode != ColorModeRGB {
+		t.Fatalf("hex value without OKLCH should open RGB, got %v", cp.currentMode)
+	}
+
+	cp.Expand()
+	cp.focus = focusContent
+	// Down to green, End maxes it out: red + green = yellow
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, 0))
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyEnd, 0, 0))
+	res := cp.getResultFromCurrentMode()
+	if res.R != 255 || res.G != 255 || res.B != 0 || res.Source != "#ffff00" {
+		t.Fatalf("RGB result = %+v, want #ffff00", res)
+	}
+
+	// HSL starts from the RGB color
+	cp.selectMode(ColorModeHSL)
+	res = cp.getResultFromCurrentMode()
+	if res.Mode != ColorModeHSL || res.Source != "hsl(60,100%,50%)" {
+		t.Fatalf("HSL result = %+v, want hsl(60,100%%,50%%)", res)
+	}
+	// Hue +5° per Right
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, 0))
+	if res = cp.getResultFromCurrentMode(); res.Source != "hsl(65,100%,50%)" {
+		t.Errorf("after Right: %s", res.Source)
+	}
+}
+
+func TestColorPickerOKLCHNumericEntry(t *testing.T) {
+	cp := NewColorPicker(ColorPickerConfig{EnableOKLCH: true})
+	cp.Expand()
+	cp.selectMode(ColorModeOKLCH)
+	cp.focus = focusContent
+
+	for _, r := range "l0.5" {
+		cp.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, 0))
+	}
+	// Esc cancels the entry, not the picker
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyEsc, 0, 0))
+	if !cp.IsExpanded() {
+		t.Fatal("Esc during numeric entry should not close the picker")
+	}
+
+	// Enter applies the entry without committing
+	for _, r := range "l0.5" {
+		cp.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, 0))
+	}
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, 0))
+	if !cp.IsExpanded() {
+		t.Fatal("Enter during numeric entry should not commit")
+	}
+	if src := cp.getResultFromCurrentMode().Source; !strings.HasPrefix(src, "oklch(0.50,") {
+		t.Errorf("source = %s, want lightness 0.50", src)
+	}
+}
+
+type testClipboard struct{ data string }
+
+func (c *testClipboard) SetClipboard(mime string, data []byte) { c.data = string(data) }
+func (c *testClipboard) GetClipboard() (string, []byte, bool) {
+	return "text/plain", []byte(c.data), c.data != ""
+}
+
+func TestColorPickerHexField(t *testing.T) {
+	cp := NewColorPicker(ColorPickerConfig{EnablePalette: true, EnableRGB: true})
+	var committed ColorPickerResult
+	cp.OnChange = func(r ColorPickerResult) { committed = r }
+	cp.Expand()
+	cp.enterHexField()
+
+	key := func(k tcell.Key, r rune) { cp.HandleKey(tcell.NewEventKey(k, r, 0)) }
+	for range cp.hexText {
+		key(tcell.KeyBackspace2, 0)
+	}
+	for _, r := range "#0f8" {
+		key(tcell.KeyRune, r)
+	}
+	if !cp.hexValid || cp.currentMode != ColorModeRGB {
+		t.Fatalf("#0f8 should be valid and previewed in RGB mode (valid=%v mode=%v)", cp.hexValid, cp.currentMode)
+	}
+	if got := cp.getResultFromCurrentMode().Source; got != "#00ff88" {
+		t.Errorf("preview = %s, want #00ff88", got)
+	}
+
+	key(tcell.KeyRune, 'z') // Ignored
+	key(tcell.KeyRune, '1') // "#0f81": invalid
+	if cp.hexValid {
+		t.Error("#0f81 should be invalid")
+	}
+	key(tcell.KeyEnter, 0)
+	if !cp.IsExpanded() {
+		t.Fatal("Enter on an invalid value should keep the picker open")
+	}
+
+	key(tcell.KeyBackspace2, 0)
+	clip := &testClipboard{}
+	cp.SetClipboardService(clip)
+	key(tcell.KeyCtrlC, 0)
+	if clip.data != "#00ff88" {
+		t.Errorf("clipboard = %q, want #00ff88", clip.data)
+	}
+
+	key(tcell.KeyEnter, 0)
+	if cp.IsExpanded() || committed.Source != "#00ff88" {
+		t.Errorf("Enter should commit #00ff88, got %+v", committed)
+	}
+}
+
+func TestColorPickerPreviewAndRevert(t *testing.T) {
+	cp := NewColorPicker(ColorPickerConfig{EnableRGB: true})
+	cp.SetValue("#102030")
+	var previews []string
+	cp.OnPreview = func(r ColorPickerResult) { previews = append(previews, r.Source) }
+	cp.OnChange = func(ColorPickerResult) { t.Error("OnChange should not fire on Esc") }
+
+	cp.Expand()
+	cp.focus = focusContent
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, 0))
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, 0)) // Channel change only: no preview
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, 0))
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyEsc, 0, 0))
+
+	want := []string{"#152030", "#152530", "#102030"}
+	if len(previews) != len(want) {
+		t.Fatalf("previews = %v, want %v", previews, want)
+	}
+	for i := range want {
+		if previews[i] != want[i] {
+			t.Errorf("preview %d = %s, want %s", i, previews[i], want[i])
+		}
+	}
+}
+
+func TestColorPickerNamedMode(t *testing.T) {
+	cp := NewColorPicker(ColorPickerConfig{EnableNamed: true})
+	cp.SetValue("DodgerBlue")
+	res := cp.getResultFromCurrentMode()
+	if cp.currentMode != ColorModeNamed || res.Source != "dodgerblue" || res.R != 0x1e || res.G != 0x90 || res.B != 0xff {
+		t.Fatalf("SetValue(DodgerBlue): mode %v, result %+v", cp.currentMode, res)
+	}
+
+	// Type-ahead narrows the list
+	cp.Expand()
+	cp.focus = focusContent
+	for _, r := range "salm" {
+		cp.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, 0))
+	}
+	if got := cp.getResultFromCurrentMode().Source; got != "darksalmon" {
+		t.Errorf("after typing salm: %s, want darksalmon", got)
+	}
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyDown, 0, 0))
+	cp.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, 0))
+	if got := cp.GetResult().Source; got != "lightsalmon" {
+		t.Errorf("committed %s, want lightsalmon", got)
+	}
+}
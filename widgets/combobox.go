@@ -36,12 +36,23 @@ type ComboBox struct {
 	// OnChange is called when the value changes
 	OnChange func(string)
 
+	// Clearable shows a ✕ button while a value is set and enables
+	// Ctrl+U to empty the value, and Del when the combo isn't editable.
+	Clearable bool
+
+	// OnClear is called after the value is cleared (before OnChange("")).
+	OnClear func()
+
 	// Internal state
 	expanded  bool
 	cursorPos int
 	filtered  []string // Filtered items based on Text
 	inv       func(core.Rect)
 
+	// Surface size reported by the UIManager (0 = unknown, always drop down)
+	surfaceW int
+	surfaceH int
+
 	// Dropdown list widget
 	list *primitives.ScrollableList
 }
@@ -60,10 +71,7 @@ func NewComboBox(items []string, editable bool) *ComboBox {
 	cb.SetFocusable(true)
 
 	// Configure focus style from theme
-	tm := theme.Get()
-	fg := tm.GetSemanticColor("text.primary")
-	bg := tm.GetSemanticColor("bg.surface")
-	cb.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
+	cb.applyTheme()
 
 	// Create dropdown list (position will be set when expanded)
 	cb.list = primitives.NewScrollableList(0, 1, 20, 8)
@@ -73,6 +81,21 @@ func NewComboBox(items []string, editable bool) *ComboBox {
 	return cb
 }
 
+// applyTheme resolves the focused style from the current theme.
+func (cb *ComboBox) applyTheme() {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	cb.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
+}
+
+// OnThemeChanged implements core.ThemeAware, restyling the dropdown list
+// as well since it is not one of the visited children.
+func (cb *ComboBox) OnThemeChanged() {
+	cb.applyTheme()
+	core.NotifyThemeChanged(cb.list)
+}
+
 // SetInvalidator sets the invalidation callback.
 func (cb *ComboBox) SetInvalidator(fn func(core.Rect)) {
 	cb.inv = fn
@@ -81,27 +104,55 @@ func (cb *ComboBox) SetInvalidator(fn func(core.Rect)) {
 	}
 }
 
+// SetSurfaceSize implements core.SurfaceAware.
+// The dropdown uses the surface size to flip above the field, shrink
+// when there isn't enough room below it, and stay on screen.
+func (cb *ComboBox) SetSurfaceSize(w, h int) {
+	cb.surfaceW, cb.surfaceH = w, h
+}
+
+// SetColumns lays the dropdown items out in n columns, which suits many
+// short items such as color or emoji palettes. 0 or 1 means a single column.
+// Left/Right navigate between columns while the dropdown is open.
+func (cb *ComboBox) SetColumns(n int) {
+	cb.list.SetColumns(n)
+	cb.invalidate()
+}
+
 // GetKeyHints implements core.KeyHintsProvider.
 func (cb *ComboBox) GetKeyHints() []core.KeyHint {
 	if cb.expanded {
+		if cb.list.Columns > 1 {
+			return []core.KeyHint{
+				{Key: "↑↓←→", Label: "Navigate"},
+				{Key: "Enter", Label: "Select"},
+				{Key: "Esc", Label: "Close"},
+			}
+		}
 		return []core.KeyHint{
 			{Key: "↑↓", Label: "Navigate"},
 			{Key: "Enter", Label: "Select"},
 			{Key: "Esc", Label: "Close"},
 		}
 	}
+	var hints []core.KeyHint
 	if cb.Editable {
-		return []core.KeyHint{
+		hints = []core.KeyHint{
 			{Key: "Enter", Label: "Open"},
 			{Key: "Tab", Label: "Complete"},
 			{Key: "←→", Label: "Move"},
 			{Key: "↑↓", Label: "Navigate"},
 		}
+	} else {
+		hints = []core.KeyHint{
+			{Key: "Enter", Label: "Open"},
+			{Key: "↑↓", Label: "Navigate"},
+		}
 	}
-	return []core.KeyHint{
-		{Key: "Enter", Label: "Open"},
-		{Key: "↑↓", Label: "Navigate"},
+	if cb.Clearable && cb.Text != "" {
+		hints = append(hints, core.KeyHint{Key: "Ctrl+U", Label: "Clear"})
 	}
+	return hints
 }
 
 // syncListItems updates the ScrollableList items from filtered.
@@ -165,23 +216,66 @@ func (cb *ComboBox) Value() string {
 	return cb.Text
 }
 
-// dropdownRect returns the rectangle for the dropdown list.
-func (cb *ComboBox) dropdownRect() core.Rect {
-	maxHeight := 8
-	if len(cb.filtered) < maxHeight {
-		maxHeight = len(cb.filtered)
+// Clear empties the value, restoring the placeholder, and fires OnClear
+// followed by OnChange(""). Unlike deleting typed text, this commits the
+// empty value. Does nothing if the value is already empty.
+func (cb *ComboBox) Clear() {
+	if cb.Text == "" {
+		return
 	}
-	if maxHeight < 1 {
-		maxHeight = 1
+	cb.Text = ""
+	cb.cursorPos = 0
+	cb.updateFilter()
+	cb.invalidate()
+	if cb.OnClear != nil {
+		cb.OnClear()
 	}
-	return core.Rect{
-		X: cb.Rect.X,
-		Y: cb.Rect.Y + 1,
-		W: cb.Rect.W,
-		H: maxHeight,
+	if cb.OnChange != nil {
+		cb.OnChange("")
 	}
 }
 
+// showsClearButton returns true when the ✕ clear button is visible.
+func (cb *ComboBox) showsClearButton() bool {
+	return cb.Clearable && cb.Text != "" && cb.Rect.W > 5
+}
+
+// inputWidth returns the width of the text area, excluding the buttons.
+func (cb *ComboBox) inputWidth() int {
+	w := cb.Rect.W - 3 // Reserve 3 chars for button " ▼ "
+	if cb.showsClearButton() {
+		w -= 2 // Reserve 2 chars for clear button "✕ "
+	}
+	return w
+}
+
+// dropdownRect returns the rectangle for the dropdown list.
+// Y is the row of the dropdown's top border; the list occupies the H rows
+// below it, followed by the bottom border. The dropdown is placed with
+// core.PlacePopup after shrinking it to the larger of the room below and
+// above the field, so it never covers the field itself.
+func (cb *ComboBox) dropdownRect() core.Rect {
+	maxHeight := 8
+	if rows := cb.list.RowCount(); rows < maxHeight {
+		maxHeight = rows
+	}
+	if cb.surfaceH > 0 {
+		// Rows available for list content, excluding the two border rows
+		below := cb.surfaceH - (cb.Rect.Y + cb.Rect.H) - 2
+		above := cb.Rect.Y - 2
+		maxHeight = min(maxHeight, max(below, above))
+	}
+	maxHeight = max(maxHeight, 1)
+	r := core.PlacePopup(cb.Rect, cb.Rect.W, maxHeight+2,
+		core.Rect{W: cb.surfaceW, H: cb.surfaceH})
+	return core.Rect{X: r.X, Y: r.Y, W: r.W, H: max(r.H-2, 1)}
+}
+
+// dropsUp returns true when the dropdown is placed above the field.
+func (cb *ComboBox) dropsUp() bool {
+	return cb.dropdownRect().Y < cb.Rect.Y
+}
+
 // updateFilter updates the filtered list based on current text.
 func (cb *ComboBox) updateFilter() {
 	// Non-editable combos don't filter - always show all items
@@ -258,11 +352,11 @@ func (cb *ComboBox) Draw(p *core.Painter) {
 	}
 
 	// Fill background (with underline when focused for input area)
-	inputWidth := cb.Rect.W - 3 // Reserve 3 chars for button " ▼ "
+	inputWidth := cb.inputWidth()
 	if !cb.Transparent {
 		p.FillDynamic(core.Rect{X: cb.Rect.X, Y: cb.Rect.Y, W: inputWidth, H: 1}, ' ', baseDS)
 		// Fill button area without underline
-		p.FillDynamic(core.Rect{X: cb.Rect.X + inputWidth, Y: cb.Rect.Y, W: 3, H: 1}, ' ', btnDS)
+		p.FillDynamic(core.Rect{X: cb.Rect.X + inputWidth, Y: cb.Rect.Y, W: cb.Rect.W - inputWidth, H: 1}, ' ', btnDS)
 	}
 
 	x := cb.Rect.X
@@ -320,10 +414,17 @@ func (cb *ComboBox) Draw(p *core.Painter) {
 		}
 	}
 
+	// Draw clear button
+	if cb.showsClearButton() {
+		clearDS := color.DynamicStyle{FG: color.Solid(dimFg), BG: color.Solid(bg)}
+		p.SetDynamicCell(x+inputWidth, y, '✕', clearDS)
+		p.SetDynamicCell(x+inputWidth+1, y, ' ', clearDS)
+	}
+
 	// Draw dropdown button
 	btnX := cb.Rect.X + cb.Rect.W - 3
 	btnChar := '▼'
-	if cb.expanded {
+	if cb.expanded != cb.dropsUp() {
 		btnChar = '▲'
 	}
 	p.SetDynamicCell(btnX, y, ' ', btnDS)
@@ -495,6 +596,12 @@ func (cb *ComboBox) HandleKey(ev *tcell.EventKey) bool {
 		return false
 
 	case tcell.KeyLeft:
+		if cb.expanded && cb.list.Columns > 1 {
+			if cb.list.HandleKey(ev) {
+				cb.invalidate()
+			}
+			return true
+		}
 		if cb.Editable && cb.cursorPos > 0 {
 			cb.cursorPos--
 			cb.invalidate()
@@ -503,6 +610,12 @@ func (cb *ComboBox) HandleKey(ev *tcell.EventKey) bool {
 		return false
 
 	case tcell.KeyRight:
+		if cb.expanded && cb.list.Columns > 1 {
+			if cb.list.HandleKey(ev) {
+				cb.invalidate()
+			}
+			return true
+		}
 		if cb.Editable {
 			autocomplete := cb.autocompleteMatch()
 			maxPos := len(cb.Text)
@@ -538,6 +651,19 @@ func (cb *ComboBox) HandleKey(ev *tcell.EventKey) bool {
 			cb.invalidate()
 			return true
 		}
+		// Without text editing, Del clears the value. Editable combos only
+		// delete characters, so Del at the end of the text is harmless.
+		if cb.Clearable && !cb.Editable && !cb.expanded && cb.Text != "" {
+			cb.Clear()
+			return true
+		}
+		return false
+
+	case tcell.KeyCtrlU:
+		if cb.Clearable && cb.Text != "" {
+			cb.Clear()
+			return true
+		}
 		return false
 
 	case tcell.KeyRune:
@@ -641,6 +767,12 @@ func (cb *ComboBox) HandleMouse(ev *tcell.EventMouse) bool {
 	// Click on main area
 	if inMainRect {
 		btnX := cb.Rect.X + cb.Rect.W - 3
+		if cb.showsClearButton() && x >= cb.Rect.X+cb.inputWidth() && x < btnX {
+			// Click on clear button
+			cb.expanded = false
+			cb.Clear()
+			return true
+		}
 		if x >= btnX {
 			// Click on button - toggle dropdown
 			if !cb.expanded {
@@ -732,11 +864,11 @@ func (cb *ComboBox) invalidate() {
 		r := cb.Rect
 		if cb.expanded {
 			dr := cb.dropdownRect()
-			// Dropdown is shifted 1 char left and 1 char wider
-			r.X = dr.X - 1
-			r.W = dr.W + 1
-			// Main (1) + top border (1) + content (dr.H) + bottom border (1)
-			r.H = 1 + 1 + dr.H + 1
+			// Dropdown is shifted 1 char left and 1 char wider, and spans
+			// top border (1) + content (dr.H) + bottom border (1)
+			top := min(cb.Rect.Y, dr.Y)
+			bottom := max(cb.Rect.Y+cb.Rect.H, dr.Y+dr.H+2)
+			r = core.Rect{X: dr.X - 1, Y: top, W: dr.W + 1, H: bottom - top}
 		}
 		cb.inv(r)
 	}
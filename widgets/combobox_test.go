@@ -107,3 +107,122 @@ func TestComboBox_EscapeClosesDropdown(t *testing.T) {
 		t.Error("ComboBox should not be expanded after Escape")
 	}
 }
+
+func TestComboBox_DropdownFlipsAboveNearBottom(t *testing.T) {
+	items := []string{"Apple", "Banana", "Cherry", "Date"}
+	cb := widgets.NewComboBox(items, false)
+	cb.SetPosition(2, 20)
+	cb.Resize(20, 1)
+	cb.SetSurfaceSize(40, 22)
+	cb.Focus()
+
+	cb.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if !cb.IsModal() {
+		t.Fatal("ComboBox should be expanded after Enter")
+	}
+
+	// 4 items + 2 border rows don't fit below row 20; the dropdown should
+	// occupy rows 14..19 above the field instead.
+	if cb.HitTest(2, 21) {
+		t.Error("dropdown should not extend below the field near the surface bottom")
+	}
+	if !cb.HitTest(2, 14) || !cb.HitTest(2, 19) {
+		t.Error("dropdown should be placed directly above the field")
+	}
+}
+
+func TestComboBox_DropdownShrinksWhenNoRoomAbove(t *testing.T) {
+	items := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	cb := widgets.NewComboBox(items, false)
+	cb.SetPosition(2, 1)
+	cb.Resize(20, 1)
+	cb.SetSurfaceSize(40, 8)
+	cb.Focus()
+
+	cb.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+
+	// Below the field: rows 2..7; borders take 2 rows, leaving 4 for items.
+	if !cb.HitTest(2, 7) {
+		t.Error("dropdown should extend to the last surface row")
+	}
+	if cb.HitTest(2, 8) {
+		t.Error("dropdown should be shrunk to fit the surface")
+	}
+}
+
+func TestComboBox_ClearFiresCallbacks(t *testing.T) {
+	cb := widgets.NewComboBox([]string{"Apple", "Banana"}, false)
+	cb.Clearable = true
+	cb.SetValue("Banana")
+	cb.Focus()
+
+	var cleared bool
+	var changed []string
+	cb.OnClear = func() { cleared = true }
+	cb.OnChange = func(s string) { changed = append(changed, s) }
+
+	if !cb.HandleKey(tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModNone)) {
+		t.Fatal("Ctrl+U should be consumed by a clearable combo with a value")
+	}
+	if cb.Value() != "" {
+		t.Errorf("expected empty value after clear, got %q", cb.Value())
+	}
+	if !cleared {
+		t.Error("expected OnClear to fire")
+	}
+	if len(changed) != 1 || changed[0] != "" {
+		t.Errorf("expected a single OnChange(\"\"), got %v", changed)
+	}
+
+	// Nothing to clear: key passes through
+	if cb.HandleKey(tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModNone)) {
+		t.Error("Ctrl+U should not be consumed when the value is already empty")
+	}
+}
+
+func TestComboBox_DeleteClearsAndClearButton(t *testing.T) {
+	cb := widgets.NewComboBox([]string{"Apple", "Banana"}, true)
+	cb.Clearable = true
+	cb.SetPosition(0, 0)
+	cb.Resize(20, 1)
+	cb.SetValue("Apple")
+	cb.Focus()
+
+	// Cursor sits at the end of the text: an editable combo keeps its value
+	if cb.HandleKey(tcell.NewEventKey(tcell.KeyDelete, 0, tcell.ModNone)) {
+		t.Error("Del at the end of an editable combo should not be consumed")
+	}
+	if cb.Value() != "Apple" {
+		t.Errorf("expected Del at end of text to keep the value, got %q", cb.Value())
+	}
+
+	// A non-editable combo has no text to edit, so Del clears it
+	fixed := widgets.NewComboBox([]string{"Apple", "Banana"}, false)
+	fixed.Clearable = true
+	fixed.SetValue("Banana")
+	fixed.HandleKey(tcell.NewEventKey(tcell.KeyDelete, 0, tcell.ModNone))
+	if fixed.Value() != "" {
+		t.Errorf("expected Del to clear a non-editable combo, got %q", fixed.Value())
+	}
+
+	// Clear button sits just left of the dropdown button: "...✕  ▼ "
+	cb.SetValue("Banana")
+	cb.HandleMouse(tcell.NewEventMouse(15, 0, tcell.Button1, tcell.ModNone))
+	if cb.Value() != "" {
+		t.Errorf("expected click on ✕ to clear, got %q", cb.Value())
+	}
+	if cb.IsModal() {
+		t.Error("clicking ✕ should not open the dropdown")
+	}
+}
+
+func TestComboBox_NotClearableIgnoresCtrlU(t *testing.T) {
+	cb := widgets.NewComboBox([]string{"Apple"}, false)
+	cb.SetValue("Apple")
+	if cb.HandleKey(tcell.NewEventKey(tcell.KeyCtrlU, 0, tcell.ModNone)) {
+		t.Error("Ctrl+U should not be consumed when Clearable is false")
+	}
+	if cb.Value() != "Apple" {
+		t.Errorf("value should be unchanged, got %q", cb.Value())
+	}
+}
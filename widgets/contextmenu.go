@@ -0,0 +1,339 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/contextmenu.go
+// Summary: Popup context menu with keyboard and mouse selection.
+
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+)
+
+// MenuItem is one entry of a ContextMenu.
+type MenuItem struct {
+	Label    string
+	Action   func()
+	Disabled bool       // Shown muted and skipped by selection
+	Items    []MenuItem // Submenu, opened instead of running Action
+}
+
+// ContextMenu is a bordered popup list of actions, opened at a screen
+// position (typically from an OnContextMenu hook). While open it is modal:
+// it receives all keys, and a click outside closes it.
+//
+// The owning container draws it, routes input to it and reports it from
+// VisitChildren while it is open. Items with a submenu open it to their
+// right, with Enter, Space, Right or a click; Left or Esc closes it again.
+type ContextMenu struct {
+	core.BaseWidget
+	Items []MenuItem
+
+	// OnClose is called whenever the menu closes, before a chosen item's
+	// Action runs.
+	OnClose func()
+
+	open      bool
+	selected  int
+	mouseDown bool
+	surfaceW  int // Surface size reported by the UIManager (0 = unknown)
+	surfaceH  int
+	inv       func(core.Rect)
+	sub       *ContextMenu // Submenu, shown while sub.open
+	parent    *ContextMenu // Menu this is a submenu of
+}
+
+// NewContextMenu creates a closed context menu.
+func NewContextMenu() *ContextMenu {
+	return &ContextMenu{selected: -1}
+}
+
+// Show opens the menu with items at screen position x, y. The menu is
+// placed with core.PlacePopup: it flips above y when there is more room
+// there and is moved left as needed to stay on the surface.
+func (m *ContextMenu) Show(items []MenuItem, x, y int) {
+	m.Items = items
+	w := 0
+	for _, it := range items {
+		n := len([]rune(it.Label))
+		if len(it.Items) > 0 {
+			n += 2 // Space and submenu arrow
+		}
+		if n > w {
+			w = n
+		}
+	}
+	w += 4 // Borders plus one column of padding each side
+	h := len(items) + 2
+
+	r := core.PlacePopup(core.Rect{X: x, Y: y}, w, h,
+		core.Rect{W: m.surfaceW, H: m.surfaceH})
+	m.SetPosition(r.X, r.Y)
+	m.Resize(r.W, r.H)
+
+	m.selected = m.step(-1, 1)
+	m.mouseDown = false
+	m.open = true
+	m.SetFocusable(true)
+	m.Focus()
+	m.invalidate()
+}
+
+// Close hides the menu without running an action.
+func (m *ContextMenu) Close() {
+	if !m.open {
+		return
+	}
+	if m.subOpen() {
+		m.sub.Close()
+	}
+	m.invalidate()
+	m.open = false
+	m.SetFocusable(false)
+	m.BaseWidget.Blur()
+	if m.OnClose != nil {
+		m.OnClose()
+	}
+}
+
+// IsOpen reports whether the menu is showing.
+func (m *ContextMenu) IsOpen() bool {
+	return m.open
+}
+
+// HitTest reports whether x, y is on the menu or its open submenu.
+func (m *ContextMenu) HitTest(x, y int) bool {
+	return m.Rect.Contains(x, y) || m.subOpen() && m.sub.HitTest(x, y)
+}
+
+// IsModal implements core.Modal.
+func (m *ContextMenu) IsModal() bool {
+	return m.open
+}
+
+// DismissModal implements core.Modal; a click outside closes the menu.
+func (m *ContextMenu) DismissModal() {
+	m.Close()
+}
+
+// Blur closes the menu when it loses focus.
+func (m *ContextMenu) Blur() {
+	m.Close()
+	m.BaseWidget.Blur()
+}
+
+// SetSurfaceSize implements core.SurfaceAware.
+func (m *ContextMenu) SetSurfaceSize(w, h int) {
+	m.surfaceW, m.surfaceH = w, h
+}
+
+// SetInvalidator implements core.InvalidationAware.
+func (m *ContextMenu) SetInvalidator(fn func(core.Rect)) {
+	m.inv = fn
+}
+
+// ZIndex keeps the open menu above its siblings.
+func (m *ContextMenu) ZIndex() int {
+	if m.open {
+		return 100
+	}
+	return 0
+}
+
+// Draw renders the border and items.
+func (m *ContextMenu) Draw(p *core.Painter) {
+	if !m.open {
+		return
+	}
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	muted := tm.GetSemanticColor("text.muted")
+	borderFg := tm.GetSemanticColor("border.default")
+	borderDS := color.DynamicStyle{FG: color.Solid(borderFg), BG: color.Solid(bg)}
+
+	r := m.Rect
+	for x := r.X; x < r.X+r.W; x++ {
+		p.SetDynamicCell(x, r.Y, '─', borderDS)
+		p.SetDynamicCell(x, r.Y+r.H-1, '─', borderDS)
+	}
+	for y := r.Y + 1; y < r.Y+r.H-1; y++ {
+		p.SetDynamicCell(r.X, y, '│', borderDS)
+		p.SetDynamicCell(r.X+r.W-1, y, '│', borderDS)
+	}
+	p.SetDynamicCell(r.X, r.Y, '╭', borderDS)
+	p.SetDynamicCell(r.X+r.W-1, r.Y, '╮', borderDS)
+	p.SetDynamicCell(r.X, r.Y+r.H-1, '╰', borderDS)
+	p.SetDynamicCell(r.X+r.W-1, r.Y+r.H-1, '╯', borderDS)
+
+	for i, it := range m.Items {
+		ds := color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)}
+		if it.Disabled {
+			ds.FG = color.Solid(muted)
+		} else if i == m.selected {
+			ds.Attrs |= tcell.AttrReverse
+		}
+		row := core.Rect{X: r.X + 1, Y: r.Y + 1 + i, W: r.W - 2, H: 1}
+		p.FillDynamic(row, ' ', ds)
+		p.DrawDynamicText(row.X+1, row.Y, it.Label, ds)
+		if len(it.Items) > 0 {
+			p.SetDynamicCell(row.X+row.W-2, row.Y, '▸', ds)
+		}
+	}
+	if m.subOpen() {
+		m.sub.Draw(p)
+	}
+}
+
+// HandleKey moves the selection with Up/Down/Home/End, runs the selected
+// item with Enter/Space and closes with Esc. Other keys are swallowed
+// while the menu is open.
+func (m *ContextMenu) HandleKey(ev *tcell.EventKey) bool {
+	if !m.open {
+		return false
+	}
+	if m.subOpen() {
+		if ev.Key() == tcell.KeyLeft || ev.Key() == tcell.KeyEscape {
+			m.sub.Close()
+			return true
+		}
+		return m.sub.HandleKey(ev)
+	}
+	switch ev.Key() {
+	case tcell.KeyUp, tcell.KeyBacktab:
+		m.selectIdx(m.step(m.selected, -1))
+	case tcell.KeyDown, tcell.KeyTab:
+		m.selectIdx(m.step(m.selected, 1))
+	case tcell.KeyHome:
+		m.selectIdx(m.step(-1, 1))
+	case tcell.KeyEnd:
+		m.selectIdx(m.step(len(m.Items), -1))
+	case tcell.KeyEnter:
+		m.activate(m.selected)
+	case tcell.KeyRight:
+		if m.hasSubmenu(m.selected) {
+			m.openSub(m.selected)
+		}
+	case tcell.KeyEscape:
+		m.Close()
+	case tcell.KeyRune:
+		if ev.Rune() == ' ' {
+			m.activate(m.selected)
+		}
+	}
+	return true
+}
+
+// HandleMouse highlights the item under the pointer and runs it on click.
+// A press outside the menu closes it and is not consumed.
+func (m *ContextMenu) HandleMouse(ev *tcell.EventMouse) bool {
+	if !m.open {
+		return false
+	}
+	x, y := ev.Position()
+	held := ev.Buttons()&tcell.Button1 != 0
+	pressed := held && !m.mouseDown
+	m.mouseDown = held
+
+	if m.subOpen() && m.sub.HitTest(x, y) {
+		return m.sub.HandleMouse(ev)
+	}
+	if !m.Rect.Contains(x, y) {
+		if pressed || ev.Buttons()&tcell.Button2 != 0 {
+			m.Close()
+		}
+		return false
+	}
+
+	idx := y - m.Rect.Y - 1
+	if idx < 0 || idx >= len(m.Items) || x == m.Rect.X || x == m.Rect.X+m.Rect.W-1 {
+		return true
+	}
+	if !m.Items[idx].Disabled {
+		m.selectIdx(idx)
+		if pressed {
+			m.activate(idx)
+		}
+	}
+	return true
+}
+
+// GetKeyHints implements core.KeyHintsProvider.
+func (m *ContextMenu) GetKeyHints() []core.KeyHint {
+	return []core.KeyHint{
+		{Key: "↑↓", Label: "Select"},
+		{Key: "Enter", Label: "Run"},
+		{Key: "Esc", Label: "Close"},
+	}
+}
+
+// activate opens the submenu of item idx, or closes the menu and any it
+// is a submenu of and runs the item, if it is enabled.
+func (m *ContextMenu) activate(idx int) {
+	if idx < 0 || idx >= len(m.Items) || m.Items[idx].Disabled {
+		return
+	}
+	if m.hasSubmenu(idx) {
+		m.openSub(idx)
+		return
+	}
+	action := m.Items[idx].Action
+	root := m
+	for root.parent != nil {
+		root = root.parent
+	}
+	root.Close()
+	if action != nil {
+		action()
+	}
+}
+
+// openSub shows the submenu of item idx next to it.
+func (m *ContextMenu) openSub(idx int) {
+	if m.sub == nil {
+		m.sub = NewContextMenu()
+		m.sub.parent = m
+	}
+	m.sub.surfaceW, m.sub.surfaceH = m.surfaceW, m.surfaceH
+	m.sub.inv = m.inv
+	m.selectIdx(idx)
+	m.sub.Show(m.Items[idx].Items, m.Rect.X+m.Rect.W, m.Rect.Y+idx)
+}
+
+// hasSubmenu reports whether item idx opens a submenu.
+func (m *ContextMenu) hasSubmenu(idx int) bool {
+	return idx >= 0 && idx < len(m.Items) && len(m.Items[idx].Items) > 0
+}
+
+// subOpen reports whether a submenu is showing.
+func (m *ContextMenu) subOpen() bool {
+	return m.sub != nil && m.sub.open
+}
+
+// selectIdx highlights item idx; -1 leaves the selection unchanged.
+func (m *ContextMenu) selectIdx(idx int) {
+	if idx >= 0 && idx != m.selected {
+		m.selected = idx
+		m.invalidate()
+	}
+}
+
+// step returns the next enabled item from idx in direction dir, or -1.
+func (m *ContextMenu) step(idx, dir int) int {
+	for i := idx + dir; i >= 0 && i < len(m.Items); i += dir {
+		if !m.Items[i].Disabled {
+			return i
+		}
+	}
+	return -1
+}
+
+// invalidate marks the menu area as needing redraw.
+func (m *ContextMenu) invalidate() {
+	if m.inv != nil {
+		m.inv(m.Rect)
+	}
+}
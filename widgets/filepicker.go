@@ -0,0 +1,416 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/filepicker.go
+// Summary: Path field opening a file browser to pick a file or directory.
+
+package widgets
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/primitives"
+	"github.com/framegrace/texelui/theme"
+)
+
+// File browser size, including its border.
+const (
+	filePickerMinW = 40
+	filePickerH    = 14
+)
+
+// fileEntry is the Value of a browser list item.
+type fileEntry struct {
+	path string
+	dir  bool
+	here bool // The directory being browsed, offered when DirsOnly
+}
+
+// FilePicker is a path field that opens a file browser. Collapsed it shows
+// the chosen path; Space or a click opens the browser, an overlay listing
+// the browsed directory: its parent, subdirectories, then the files
+// matching Pattern. Enter or a double-click opens a directory or picks a
+// file, and Backspace or Left goes up.
+//
+// With DirsOnly, files are hidden and the first entry picks the browsed
+// directory itself.
+type FilePicker struct {
+	core.BaseWidget
+	Dir         string // Directory the browser starts in; "" is the working directory
+	Pattern     string // filepath.Match pattern files must match; "" matches all
+	DirsOnly    bool
+	ShowHidden  bool   // List entries starting with a dot
+	Placeholder string // Shown while no path is chosen
+
+	// OnPick is called with the path chosen in the browser.
+	OnPick func(path string)
+
+	path     string
+	expanded bool
+	cwd      string // Directory being browsed
+	err      error  // Why cwd could not be read
+	list     *primitives.ScrollableList
+	popup    core.Rect
+	surfaceW int
+	surfaceH int
+	inv      func(core.Rect)
+}
+
+// NewFilePicker creates a file picker browsing dir, 20 columns wide.
+func NewFilePicker(dir string) *FilePicker {
+	fp := &FilePicker{Dir: dir}
+	fp.SetPosition(0, 0)
+	fp.Resize(20, 1)
+	fp.SetFocusable(true)
+	fp.applyTheme()
+
+	fp.list = primitives.NewScrollableList(0, 0, filePickerMinW-2, filePickerH-2)
+	fp.list.OnActivate = func(idx int) {
+		if idx >= 0 && idx < len(fp.list.Items) {
+			fp.activate(fp.list.Items[idx].Value.(fileEntry))
+		}
+	}
+	return fp
+}
+
+// applyTheme resolves the focused style from the current theme.
+func (fp *FilePicker) applyTheme() {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	fp.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
+}
+
+// OnThemeChanged implements core.ThemeAware, restyling the browser list
+// as well since it is not one of the visited children.
+func (fp *FilePicker) OnThemeChanged() {
+	fp.applyTheme()
+	core.NotifyThemeChanged(fp.list)
+}
+
+// Path returns the chosen path, "" if none.
+func (fp *FilePicker) Path() string {
+	return fp.path
+}
+
+// SetPath sets the chosen path without calling OnPick.
+func (fp *FilePicker) SetPath(path string) {
+	fp.path = path
+	fp.invalidate()
+}
+
+// Expand opens the browser, in the directory of the chosen path if there
+// is one.
+func (fp *FilePicker) Expand() {
+	if fp.expanded {
+		return
+	}
+	dir := fp.Dir
+	if fp.path != "" {
+		dir = filepath.Dir(fp.path)
+		if info, err := os.Stat(fp.path); err == nil && info.IsDir() {
+			dir = fp.path
+		}
+	}
+	if dir == "" {
+		dir = "."
+	}
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	fp.expanded = true
+	fp.browse(dir)
+	fp.place()
+	fp.invalidate()
+}
+
+// Collapse closes the browser without picking.
+func (fp *FilePicker) Collapse() {
+	if fp.expanded {
+		fp.invalidate() // The browser's extent
+		fp.expanded = false
+		fp.invalidate()
+	}
+}
+
+// IsExpanded reports whether the browser is open.
+func (fp *FilePicker) IsExpanded() bool {
+	return fp.expanded
+}
+
+// IsModal implements core.Modal: the open browser takes all input.
+func (fp *FilePicker) IsModal() bool {
+	return fp.expanded
+}
+
+// DismissModal implements core.Modal; a click outside closes the browser.
+func (fp *FilePicker) DismissModal() {
+	fp.Collapse()
+}
+
+// Blur closes the browser.
+func (fp *FilePicker) Blur() {
+	fp.Collapse()
+	fp.BaseWidget.Blur()
+}
+
+// browse lists dir in the browser.
+func (fp *FilePicker) browse(dir string) {
+	fp.cwd = dir
+	entries, err := os.ReadDir(dir)
+	fp.err = err
+
+	var items []primitives.ListItem
+	if fp.DirsOnly {
+		items = append(items, primitives.ListItem{Text: "./ (choose this directory)", Value: fileEntry{path: dir, dir: true, here: true}})
+	}
+	if parent := filepath.Dir(dir); parent != dir {
+		items = append(items, primitives.ListItem{Text: "../", Value: fileEntry{path: parent, dir: true}})
+	}
+	var dirs, files []primitives.ListItem
+	for _, e := range entries {
+		name := e.Name()
+		if !fp.ShowHidden && strings.HasPrefix(name, ".") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		isDir := e.IsDir()
+		if e.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(path); err == nil {
+				isDir = info.IsDir()
+			}
+		}
+		switch {
+		case isDir:
+			dirs = append(dirs, primitives.ListItem{Text: name + "/", Value: fileEntry{path: path, dir: true}})
+		case fp.DirsOnly:
+		case fp.matches(name):
+			files = append(files, primitives.ListItem{Text: name, Value: fileEntry{path: path}})
+		}
+	}
+	byName := func(items []primitives.ListItem) {
+		sort.SliceStable(items, func(i, j int) bool {
+			return strings.ToLower(items[i].Text) < strings.ToLower(items[j].Text)
+		})
+	}
+	byName(dirs)
+	byName(files)
+	items = append(append(items, dirs...), files...)
+	fp.list.SetItems(items)
+	fp.list.SetSelected(0)
+	fp.invalidate()
+}
+
+// matches reports whether a file name matches Pattern.
+func (fp *FilePicker) matches(name string) bool {
+	if fp.Pattern == "" {
+		return true
+	}
+	ok, err := filepath.Match(fp.Pattern, name)
+	return err == nil && ok
+}
+
+// activate opens a directory entry, or picks a file (or, with DirsOnly,
+// the browsed directory).
+func (fp *FilePicker) activate(e fileEntry) {
+	if e.dir && !e.here {
+		fp.browse(e.path)
+		return
+	}
+	fp.path = e.path
+	fp.Collapse()
+	if fp.OnPick != nil {
+		fp.OnPick(e.path)
+	}
+}
+
+// SetSurfaceSize implements core.SurfaceAware so the browser flips above
+// the field or shifts to stay on the surface.
+func (fp *FilePicker) SetSurfaceSize(w, h int) {
+	fp.surfaceW, fp.surfaceH = w, h
+	fp.place()
+}
+
+// SetPosition moves the field and the open browser with it.
+func (fp *FilePicker) SetPosition(x, y int) {
+	fp.BaseWidget.SetPosition(x, y)
+	fp.place()
+}
+
+// Resize resizes the field; the browser is at least as wide.
+func (fp *FilePicker) Resize(w, h int) {
+	fp.BaseWidget.Resize(w, h)
+	fp.place()
+}
+
+// place attaches the browser to the field with core.PlacePopup.
+func (fp *FilePicker) place() {
+	fp.popup = core.PlacePopup(fp.Rect, max(fp.Rect.W, filePickerMinW), filePickerH,
+		core.Rect{W: fp.surfaceW, H: fp.surfaceH})
+	if fp.list != nil {
+		fp.list.SetPosition(fp.popup.X+1, fp.popup.Y+1)
+		fp.list.Resize(max(fp.popup.W-2, 1), max(fp.popup.H-2, 1))
+	}
+}
+
+// OverlayRect implements core.Overlay: the open browser.
+func (fp *FilePicker) OverlayRect() (core.Rect, bool) {
+	return fp.popup, fp.expanded
+}
+
+// HitTest covers the field and, when open, the browser.
+func (fp *FilePicker) HitTest(x, y int) bool {
+	return fp.BaseWidget.HitTest(x, y) || fp.expanded && fp.popup.Contains(x, y)
+}
+
+// ZIndex keeps the open browser above the field's siblings.
+func (fp *FilePicker) ZIndex() int {
+	if fp.expanded {
+		return 100
+	}
+	return 0
+}
+
+// Draw renders the field: the chosen path, its start cut off when it does
+// not fit. The browser is drawn by the UIManager's overlay pass.
+func (fp *FilePicker) Draw(p *core.Painter) {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	style := fp.EffectiveStyle(tcell.StyleDefault.Foreground(fg).Background(bg))
+	p.Fill(fp.Rect, ' ', style)
+
+	text := fp.path
+	if text == "" {
+		text = fp.Placeholder
+		style = style.Foreground(tm.GetSemanticColor("text.muted"))
+	}
+	// The last column shows the browse marker
+	avail := fp.Rect.W - 2
+	if runes := []rune(text); len(runes) > avail && avail > 1 {
+		text = "…" + string(runes[len(runes)-avail+1:])
+	}
+	p.DrawText(fp.Rect.X, fp.Rect.Y, text, style)
+	p.SetCell(fp.Rect.X+fp.Rect.W-1, fp.Rect.Y, '…', style.Bold(true))
+}
+
+// DrawOverlay implements core.Overlay: the bordered browser with the
+// browsed directory in its top border.
+func (fp *FilePicker) DrawOverlay(p *core.Painter) {
+	if !fp.expanded {
+		return
+	}
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	borderDS := color.DynamicStyle{FG: color.Solid(tm.GetSemanticColor("border.focus")), BG: color.Solid(bg)}
+
+	r := fp.popup
+	for x := r.X; x < r.X+r.W; x++ {
+		p.SetDynamicCell(x, r.Y, '─', borderDS)
+		p.SetDynamicCell(x, r.Y+r.H-1, '─', borderDS)
+	}
+	for y := r.Y + 1; y < r.Y+r.H-1; y++ {
+		p.SetDynamicCell(r.X, y, '│', borderDS)
+		p.SetDynamicCell(r.X+r.W-1, y, '│', borderDS)
+	}
+	p.SetDynamicCell(r.X, r.Y, '╭', borderDS)
+	p.SetDynamicCell(r.X+r.W-1, r.Y, '╮', borderDS)
+	p.SetDynamicCell(r.X, r.Y+r.H-1, '╰', borderDS)
+	p.SetDynamicCell(r.X+r.W-1, r.Y+r.H-1, '╯', borderDS)
+
+	title := " " + fp.cwd + " "
+	if runes := []rune(title); len(runes) > r.W-4 && r.W > 6 {
+		title = " …" + string(runes[len(runes)-(r.W-6):])
+	}
+	p.DrawDynamicText(r.X+2, r.Y, title, borderDS)
+
+	inner := core.Rect{X: r.X + 1, Y: r.Y + 1, W: r.W - 2, H: r.H - 2}
+	if fp.err != nil {
+		style := tcell.StyleDefault.Foreground(tm.GetSemanticColor("action.danger")).Background(bg)
+		p.Fill(inner, ' ', tcell.StyleDefault.Foreground(fg).Background(bg))
+		p.DrawText(inner.X+1, inner.Y, fp.err.Error(), style)
+		return
+	}
+	fp.list.Draw(p)
+}
+
+// HandleKey opens the browser with Space and, while it is open, navigates
+// it: Esc closes it, Backspace or Left goes to the parent directory and
+// other keys go to the list.
+func (fp *FilePicker) HandleKey(ev *tcell.EventKey) bool {
+	if !fp.expanded {
+		if ev.Key() == tcell.KeyRune && ev.Rune() == ' ' {
+			fp.Expand()
+			return true
+		}
+		return false
+	}
+	switch ev.Key() {
+	case tcell.KeyEscape:
+		fp.Collapse()
+	case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyLeft:
+		fp.browse(filepath.Dir(fp.cwd))
+	default:
+		fp.list.HandleKey(ev)
+	}
+	return true
+}
+
+// HandleMouse opens the browser on a click on the field, closes it on
+// another, and passes events over the browser to its list.
+func (fp *FilePicker) HandleMouse(ev *tcell.EventMouse) bool {
+	x, y := ev.Position()
+	if !fp.HitTest(x, y) {
+		return false
+	}
+	if fp.BaseWidget.HitTest(x, y) {
+		if ev.Buttons() == tcell.Button1 {
+			if fp.expanded {
+				fp.Collapse()
+			} else {
+				fp.Expand()
+			}
+		}
+		return true
+	}
+	if fp.list.HitTest(x, y) {
+		fp.list.HandleMouse(ev)
+	}
+	return true
+}
+
+// GetKeyHints implements core.KeyHintsProvider.
+func (fp *FilePicker) GetKeyHints() []core.KeyHint {
+	if !fp.expanded {
+		return []core.KeyHint{{Key: "Space", Label: "Browse"}}
+	}
+	return []core.KeyHint{
+		{Key: "Enter", Label: "Open"},
+		{Key: "⌫", Label: "Up"},
+		{Key: "Esc", Label: "Close"},
+	}
+}
+
+// SetInvalidator implements core.InvalidationAware.
+func (fp *FilePicker) SetInvalidator(fn func(core.Rect)) {
+	fp.inv = fn
+	fp.list.SetInvalidator(fn)
+}
+
+// invalidate marks the field and the open browser as needing redraw.
+func (fp *FilePicker) invalidate() {
+	if fp.inv == nil {
+		return
+	}
+	fp.inv(fp.Rect)
+	if fp.expanded {
+		fp.inv(fp.popup)
+	}
+}
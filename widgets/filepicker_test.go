@@ -0,0 +1,118 @@
+package widgets
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func newFileTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, dir := range []string{"src", "docs", ".git"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"main.go", "README.md", "src/util.go", "src/notes.txt"} {
+		if err := os.WriteFile(filepath.Join(root, file), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func listTexts(fp *FilePicker) []string {
+	var texts []string
+	for _, it := range fp.list.Items {
+		texts = append(texts, it.Text)
+	}
+	return texts
+}
+
+func TestFilePicker_BrowseAndPick(t *testing.T) {
+	root := newFileTree(t)
+	fp := NewFilePicker(root)
+	fp.Pattern = "*.go"
+	var picked string
+	fp.OnPick = func(path string) { picked = path }
+	key := func(k tcell.Key) { fp.HandleKey(tcell.NewEventKey(k, 0, tcell.ModNone)) }
+
+	fp.HandleKey(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone))
+	if !fp.IsExpanded() || !fp.IsModal() {
+		t.Fatal("Space should open the browser")
+	}
+	want := []string{"../", "docs/", "src/", "main.go"}
+	if got := listTexts(fp); len(got) != len(want) || got[1] != want[1] || got[3] != want[3] {
+		t.Fatalf("expected %v (dirs first, hidden and unmatched files left out), got %v", want, got)
+	}
+
+	key(tcell.KeyDown)
+	key(tcell.KeyDown) // src/
+	key(tcell.KeyEnter)
+	if fp.cwd != filepath.Join(root, "src") || !fp.IsExpanded() {
+		t.Fatalf("Enter on src/ should browse it, browsing %s", fp.cwd)
+	}
+	key(tcell.KeyDown) // util.go
+	key(tcell.KeyEnter)
+	if want := filepath.Join(root, "src", "util.go"); picked != want || fp.Path() != want || fp.IsExpanded() {
+		t.Fatalf("Enter on util.go should pick it and close, picked %q", picked)
+	}
+
+	// Reopening starts in the chosen file's directory; Backspace goes up
+	fp.Expand()
+	if fp.cwd != filepath.Join(root, "src") {
+		t.Errorf("browser should reopen in src, got %s", fp.cwd)
+	}
+	key(tcell.KeyBackspace2)
+	if fp.cwd != root {
+		t.Errorf("Backspace should go up to %s, got %s", root, fp.cwd)
+	}
+	key(tcell.KeyEscape)
+	if fp.IsExpanded() || fp.Path() != filepath.Join(root, "src", "util.go") {
+		t.Error("Esc should close the browser and keep the path")
+	}
+}
+
+func TestFilePicker_DirsOnly(t *testing.T) {
+	root := newFileTree(t)
+	fp := NewFilePicker(root)
+	fp.DirsOnly = true
+	fp.ShowHidden = true
+	fp.Expand()
+	got := listTexts(fp)
+	if len(got) != 5 || got[2] != ".git/" {
+		t.Fatalf("expected the current directory, parent and three directories, got %v", got)
+	}
+	fp.list.OnActivate(4) // src/
+	fp.list.OnActivate(0) // Choose src
+	if fp.Path() != filepath.Join(root, "src") || fp.IsExpanded() {
+		t.Errorf("choosing the browsed directory should pick it, got %q", fp.Path())
+	}
+}
+
+func TestFilePicker_Mouse(t *testing.T) {
+	fp := NewFilePicker(newFileTree(t))
+	fp.Resize(30, 1)
+	fp.SetSurfaceSize(80, 24)
+	click := func(x, y int) {
+		fp.HandleMouse(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+		fp.HandleMouse(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+	}
+	click(2, 0)
+	if !fp.IsExpanded() {
+		t.Fatal("clicking the field should open the browser")
+	}
+	if r, open := fp.OverlayRect(); !open || r.Y != 1 || r.W != filePickerMinW {
+		t.Errorf("browser should open below the field, %d wide, got %+v", filePickerMinW, r)
+	}
+	if !fp.HitTest(5, 5) {
+		t.Error("the open browser should hit-test as part of the picker")
+	}
+	click(2, 0)
+	if fp.IsExpanded() {
+		t.Error("clicking the field again should close the browser")
+	}
+}
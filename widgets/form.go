@@ -7,7 +7,9 @@
 package widgets
 
 import (
+	"errors"
 	"sort"
+	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/color"
@@ -17,18 +19,52 @@ import (
 
 // FormRow represents a single row in a Form.
 type FormRow struct {
+	ID        string      // Optional key for Values/SetValues
 	Label     *Label      // Optional label (nil for full-width fields)
 	Field     core.Widget // The input field widget
 	Height    int         // Row height (default 1)
 	FullWidth bool        // If true, field spans full width (no label column)
+
+	// Validator, if set, checks the field's current value. A non-nil error
+	// is shown inline under the field after Validate runs.
+	Validator func() error
+
+	// Required marks the label with an asterisk and makes Validate fail
+	// while the field is empty.
+	Required bool
+
+	// Help is a hint shown dimmed under the field, wrapped to its width,
+	// or in the StatusBar while the field is focused (see HelpInStatusBar).
+	Help string
+
+	// Hidden rows take no space, can't be focused and skip validation.
+	Hidden bool
+
+	// VisibleIf, if set, is re-evaluated after input and decides whether
+	// the row is shown (e.g. show B only while checkbox A is checked).
+	VisibleIf func() bool
+
+	group *FormGroup // Set on a group's header and add-button rows
 }
 
+// errRequired is the inline error shown for empty required fields.
+var errRequired = errors.New("required")
+
 // FormConfig holds configuration for a Form widget.
 type FormConfig struct {
 	PaddingX   int // Horizontal padding (default 2)
 	PaddingY   int // Vertical padding (default 1)
 	LabelWidth int // Width of label column (default 22)
 	RowSpacing int // Vertical spacing between rows (default 0)
+
+	// TwoColumnMinWidth is the form width at or above which label/field
+	// pairs are laid out in two columns. 0 keeps a single column.
+	TwoColumnMinWidth int
+	ColumnGap         int // Space between the two columns (default 4)
+
+	// HelpInStatusBar shows row help in the StatusBar while the field is
+	// focused instead of under the field.
+	HelpInStatusBar bool
 }
 
 // DefaultFormConfig returns the default form configuration.
@@ -38,6 +74,7 @@ func DefaultFormConfig() FormConfig {
 		PaddingY:   1,
 		LabelWidth: 22,
 		RowSpacing: 0,
+		ColumnGap:  4,
 	}
 }
 
@@ -50,6 +87,13 @@ type Form struct {
 	Config FormConfig
 
 	rows           []FormRow
+	groups         []*FormGroup
+	initial        map[core.Widget]any // Field values when added or last marked clean
+	errs           map[int]error       // Validation errors by row index
+	slots          []formSlot          // Row areas from the last layout
+	helpLines      map[int][]string    // Wrapped inline help by row index, from the last layout
+	contentH       int                 // Total height from the last layout
+	submit         *Button             // Disabled until all required fields are filled
 	inv            func(core.Rect)
 	lastFocusedIdx int // Index of last focused field for focus restoration
 }
@@ -63,31 +107,34 @@ func NewForm() *Form {
 
 // NewFormWithConfig creates a new form with custom configuration.
 func NewFormWithConfig(config FormConfig) *Form {
-	tm := theme.Get()
-	bg := tm.GetSemanticColor("bg.surface")
-	fg := tm.GetSemanticColor("text.primary")
-
 	f := &Form{
-		Style: color.DynamicStyle{
-			FG: color.Solid(fg),
-			BG: color.Solid(bg),
-		},
 		Config:         config,
 		lastFocusedIdx: -1,
 	}
+	f.SetThemeStyle(&f.Style, themeSurfaceStyle())
 	f.SetPosition(0, 0)
 	f.Resize(1, 1)
 	f.SetFocusable(true)
 	return f
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (f *Form) OnThemeChanged() { f.SetThemeStyle(&f.Style, themeSurfaceStyle()) }
+
 // AddRow adds a row to the form.
 func (f *Form) AddRow(row FormRow) {
 	if row.Height <= 0 {
 		row.Height = 1
 	}
 	f.rows = append(f.rows, row)
+	core.InvalidateCascade()
+	f.snapshot(row.Field)
+	f.applyHelp(row)
+	if row.VisibleIf != nil {
+		f.rows[len(f.rows)-1].Hidden = !row.VisibleIf()
+	}
 	f.layout()
+	f.updateSubmit()
 }
 
 // AddField adds a labeled field to the form (convenience method).
@@ -99,6 +146,16 @@ func (f *Form) AddField(label string, field core.Widget) {
 	})
 }
 
+// AddRequiredField adds a labeled field that must be filled in.
+func (f *Form) AddRequiredField(label string, field core.Widget) {
+	f.AddRow(FormRow{
+		Label:    NewLabel(label),
+		Field:    field,
+		Height:   1,
+		Required: true,
+	})
+}
+
 // AddFullWidthField adds a field that spans the full width (no label).
 func (f *Form) AddFullWidthField(field core.Widget, height int) {
 	if height <= 0 {
@@ -119,10 +176,427 @@ func (f *Form) AddSpacer(height int) {
 	f.AddRow(FormRow{Height: height})
 }
 
+// insertRows inserts rows before index at, shifting errors of later rows.
+func (f *Form) insertRows(at int, rows []FormRow) {
+	for i := range rows {
+		if rows[i].Height <= 0 {
+			rows[i].Height = 1
+		}
+		f.snapshot(rows[i].Field)
+		f.applyHelp(rows[i])
+		if rows[i].VisibleIf != nil {
+			rows[i].Hidden = !rows[i].VisibleIf()
+		}
+		if f.inv == nil {
+			continue
+		}
+		if rows[i].Label != nil {
+			rows[i].Label.SetInvalidator(f.inv)
+		}
+		if ia, ok := rows[i].Field.(core.InvalidationAware); ok {
+			ia.SetInvalidator(f.inv)
+		}
+	}
+	f.rows = append(f.rows[:at], append(append([]FormRow(nil), rows...), f.rows[at:]...)...)
+	core.InvalidateCascade()
+	if len(f.errs) > 0 {
+		errs := make(map[int]error, len(f.errs))
+		for i, err := range f.errs {
+			if i >= at {
+				i += len(rows)
+			}
+			errs[i] = err
+		}
+		f.errs = errs
+	}
+	f.syncFocusIndex()
+	f.layout()
+	f.updateSubmit()
+	f.invalidate()
+}
+
+// removeRows deletes n rows starting at index at. If the focused field
+// was removed, focus moves to the next remaining field.
+func (f *Form) removeRows(at, n int) {
+	lostFocus := false
+	for _, row := range f.rows[at : at+n] {
+		delete(f.initial, row.Field)
+		if row.Field != nil && core.IsDescendantFocused(row.Field) {
+			row.Field.Blur()
+			lostFocus = true
+		}
+	}
+	f.rows = append(f.rows[:at], f.rows[at+n:]...)
+	if len(f.errs) > 0 {
+		errs := make(map[int]error, len(f.errs))
+		for i, err := range f.errs {
+			if i >= at+n {
+				errs[i-n] = err
+			} else if i < at {
+				errs[i] = err
+			}
+		}
+		f.errs = errs
+	}
+	if lostFocus {
+		f.focusNear(at)
+	}
+	f.syncFocusIndex()
+	f.layout()
+	f.updateSubmit()
+	f.invalidate()
+}
+
+// focusNear focuses the first focusable field at or after row at, falling
+// back to the last one before it.
+func (f *Form) focusNear(at int) {
+	for i := at; i < len(f.rows); i++ {
+		if w := f.rows[i].Field; rowFocusable(f.rows[i]) {
+			w.Focus()
+			return
+		}
+	}
+	for i := at - 1; i >= 0; i-- {
+		if w := f.rows[i].Field; rowFocusable(f.rows[i]) {
+			w.Focus()
+			return
+		}
+	}
+}
+
+// syncFocusIndex points lastFocusedIdx at the focused field after rows
+// have been inserted or removed.
+func (f *Form) syncFocusIndex() {
+	for i, w := range f.getFocusableFields() {
+		if core.IsDescendantFocused(w) {
+			f.lastFocusedIdx = i
+			return
+		}
+	}
+	if f.lastFocusedIdx >= len(f.getFocusableFields()) {
+		f.lastFocusedIdx = -1
+	}
+}
+
 // ClearRows removes all rows from the form.
 func (f *Form) ClearRows() {
 	f.rows = nil
+	f.groups = nil
+	f.initial = nil
+	f.errs = nil
 	f.lastFocusedIdx = -1
+	f.layout()
+}
+
+// SetValidator registers a validator for the row containing field.
+func (f *Form) SetValidator(field core.Widget, fn func() error) {
+	if i := f.rowIndexOf(field); i >= 0 {
+		f.rows[i].Validator = fn
+	}
+}
+
+// Validate runs every row validator and shows the resulting errors inline.
+// Returns true if all rows are valid.
+func (f *Form) Validate() bool {
+	errs := make(map[int]error)
+	for i := range f.rows {
+		if err := f.checkRow(i); err != nil {
+			errs[i] = err
+		}
+	}
+	f.setErrors(errs)
+	return len(errs) == 0
+}
+
+// checkRow returns the validation error for row i: empty required fields
+// fail first, then the row's Validator runs.
+func (f *Form) checkRow(i int) error {
+	row := f.rows[i]
+	if row.Hidden {
+		return nil
+	}
+	if row.Required && row.Field != nil && fieldIsEmpty(row.Field) {
+		return errRequired
+	}
+	if row.Validator != nil {
+		return row.Validator()
+	}
+	return nil
+}
+
+// SetRequired marks the row containing field as required or optional.
+func (f *Form) SetRequired(field core.Widget, required bool) {
+	if i := f.rowIndexOf(field); i >= 0 {
+		f.rows[i].Required = required
+		f.updateSubmit()
+		f.invalidate()
+	}
+}
+
+// RequiredFilled reports whether every required field has a value.
+func (f *Form) RequiredFilled() bool {
+	for _, row := range f.rows {
+		if row.Required && !row.Hidden && row.Field != nil && fieldIsEmpty(row.Field) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSubmitButton designates a button that stays disabled until all
+// required fields are filled. Pass nil to release it.
+func (f *Form) SetSubmitButton(btn *Button) {
+	f.submit = btn
+	f.updateSubmit()
+}
+
+// Refresh re-checks the form after field values were changed from code:
+// fields showing an error are revalidated, VisibleIf rules re-applied and
+// the submit button enabled or disabled to match the required fields.
+// Key and mouse input through the form does this automatically.
+func (f *Form) Refresh() {
+	for i := range f.rows {
+		if f.errs[i] != nil {
+			f.validateRow(i)
+		}
+	}
+	f.updateSubmit()
+	f.applyRules()
+}
+
+// fieldChanged re-checks the form after input was routed to field.
+func (f *Form) fieldChanged(field core.Widget) {
+	// Re-check a field with a shown error so it clears once fixed
+	if row := f.rowIndexOf(field); row >= 0 && f.errs[row] != nil {
+		f.validateRow(row)
+	}
+	f.updateSubmit()
+	f.applyRules()
+}
+
+// updateSubmit syncs the submit button's disabled state.
+func (f *Form) updateSubmit() {
+	if f.submit != nil {
+		f.submit.SetDisabled(!f.RequiredFilled())
+	}
+}
+
+// fieldIsEmpty reports whether a field widget holds no value.
+// Unknown widget types are treated as filled.
+func fieldIsEmpty(w core.Widget) bool {
+	switch fw := w.(type) {
+	case *Input:
+		return fw.Text == ""
+	case *TextArea:
+		return fw.Text() == ""
+	case *ComboBox:
+		return fw.Value() == ""
+	case *Checkbox:
+		return !fw.Checked
+	case interface{ Value() string }:
+		return fw.Value() == ""
+	}
+	return false
+}
+
+// ValidateField runs the validator for the row containing field only.
+// Returns true if the field is valid (or has no validator).
+func (f *Form) ValidateField(field core.Widget) bool {
+	i := f.rowIndexOf(field)
+	if i < 0 {
+		return true
+	}
+	return f.validateRow(i)
+}
+
+// validateRow re-runs one row's validator, updating its inline error.
+func (f *Form) validateRow(i int) bool {
+	err := f.checkRow(i)
+	errs := make(map[int]error, len(f.errs)+1)
+	for k, v := range f.errs {
+		errs[k] = v
+	}
+	if err != nil {
+		errs[i] = err
+	} else {
+		delete(errs, i)
+	}
+	f.setErrors(errs)
+	return err == nil
+}
+
+// setErrors replaces the inline errors and relays out the rows, since
+// error lines take vertical space.
+func (f *Form) setErrors(errs map[int]error) {
+	f.errs = errs
+	f.layout()
+	f.invalidate()
+}
+
+// FieldError returns the current validation error for field, if any.
+func (f *Form) FieldError(field core.Widget) error {
+	if i := f.rowIndexOf(field); i >= 0 {
+		return f.errs[i]
+	}
+	return nil
+}
+
+// IsValid reports whether the last validation left no errors.
+func (f *Form) IsValid() bool {
+	return len(f.errs) == 0
+}
+
+// ClearErrors removes all inline validation errors.
+func (f *Form) ClearErrors() {
+	if len(f.errs) > 0 {
+		f.setErrors(nil)
+	}
+}
+
+// Submit validates the form and calls fn only if every field is valid.
+// Otherwise focus moves to the first invalid field and false is returned.
+func (f *Form) Submit(fn func()) bool {
+	if !f.Validate() {
+		f.focusFirstError()
+		return false
+	}
+	if fn != nil {
+		fn()
+	}
+	return true
+}
+
+// focusFirstError moves focus to the first row with a validation error.
+func (f *Form) focusFirstError() {
+	for i, row := range f.rows {
+		if f.errs[i] == nil || !rowFocusable(row) {
+			continue
+		}
+		for _, w := range f.getFocusableFields() {
+			if w != row.Field {
+				w.Blur()
+			}
+		}
+		row.Field.Focus()
+		for idx, w := range f.getFocusableFields() {
+			if w == row.Field {
+				f.lastFocusedIdx = idx
+			}
+		}
+		f.invalidate()
+		return
+	}
+}
+
+// SetRowVisible shows or hides row idx. A hidden focused field passes
+// focus to the next visible one. Rows with a VisibleIf rule follow the
+// rule again after the next input.
+func (f *Form) SetRowVisible(idx int, visible bool) {
+	if idx < 0 || idx >= len(f.rows) || f.rows[idx].Hidden == !visible {
+		return
+	}
+	f.setHidden(idx, !visible)
+	f.layout()
+	f.updateSubmit()
+	f.invalidate()
+}
+
+// RowVisible reports whether row idx is shown.
+func (f *Form) RowVisible(idx int) bool {
+	return idx >= 0 && idx < len(f.rows) && !f.rows[idx].Hidden
+}
+
+// ShowWhen sets a visibility rule on the row containing field and
+// applies it immediately.
+func (f *Form) ShowWhen(field core.Widget, cond func() bool) {
+	if i := f.rowIndexOf(field); i >= 0 {
+		f.rows[i].VisibleIf = cond
+		f.applyRules()
+	}
+}
+
+// applyRules re-evaluates every VisibleIf rule and relays out if any row
+// changed visibility.
+func (f *Form) applyRules() {
+	changed := false
+	for i, row := range f.rows {
+		if row.VisibleIf == nil {
+			continue
+		}
+		if hidden := !row.VisibleIf(); hidden != row.Hidden {
+			f.setHidden(i, hidden)
+			changed = true
+		}
+	}
+	if changed {
+		f.layout()
+		f.updateSubmit()
+		f.invalidate()
+	}
+}
+
+// setHidden updates a row's visibility, clearing its error and moving
+// focus away from it when hidden.
+func (f *Form) setHidden(i int, hidden bool) {
+	f.rows[i].Hidden = hidden
+	if !hidden {
+		core.InvalidateCascade() // Shown rows take on the form's colors
+		return
+	}
+	if f.errs[i] != nil {
+		delete(f.errs, i)
+	}
+	if w := f.rows[i].Field; w != nil && core.IsDescendantFocused(w) {
+		w.Blur()
+		f.focusNear(i + 1)
+		f.syncFocusIndex()
+	}
+}
+
+// rowFocusable reports whether row holds a visible, focusable field.
+func rowFocusable(row FormRow) bool {
+	return !row.Hidden && row.Field != nil && row.Field.Focusable()
+}
+
+// rowIndexOf returns the index of the row containing field, or -1.
+func (f *Form) rowIndexOf(field core.Widget) int {
+	for i, row := range f.rows {
+		if row.Field == field {
+			return i
+		}
+	}
+	return -1
+}
+
+// rowHeight returns the vertical space of row i, including its error
+// line and inline help.
+func (f *Form) rowHeight(i int) int {
+	h := f.rows[i].Height
+	if f.errs[i] != nil {
+		h++
+	}
+	return h + len(f.helpLines[i])
+}
+
+// SetHelp sets the help text of the row containing field.
+func (f *Form) SetHelp(field core.Widget, help string) {
+	if i := f.rowIndexOf(field); i >= 0 {
+		f.rows[i].Help = help
+		f.applyHelp(f.rows[i])
+		f.layout()
+		f.invalidate()
+	}
+}
+
+// applyHelp hands a row's help to its field so the StatusBar can show it
+// while the field is focused.
+func (f *Form) applyHelp(row FormRow) {
+	if !f.Config.HelpInStatusBar || row.Help == "" {
+		return
+	}
+	if hs, ok := row.Field.(interface{ SetHelpText(string) }); ok {
+		hs.SetHelpText(row.Help)
+	}
 }
 
 // SetInvalidator implements core.InvalidationAware.
@@ -142,7 +616,7 @@ func (f *Form) SetInvalidator(fn func(core.Rect)) {
 
 // Draw renders the form with all rows.
 func (f *Form) Draw(painter *core.Painter) {
-	ds := f.Style
+	ds := f.EffectiveDynamic(f.Style)
 	if f.IsFocused() {
 		ds.Attrs |= tcell.AttrBold
 	}
@@ -164,6 +638,7 @@ func (f *Form) Draw(painter *core.Painter) {
 		widget core.Widget
 		z      int
 		order  int
+		muted  bool // Label or field of a disabled field
 	}
 	items := make([]drawItem, 0, len(f.rows)*2)
 
@@ -171,6 +646,9 @@ func (f *Form) Draw(painter *core.Painter) {
 	focusedIdx := f.getFocusedFieldIndex()
 
 	for i, row := range f.rows {
+		if row.Hidden {
+			continue
+		}
 		if row.Label != nil {
 			// Highlight label if its associated field is focused
 			if focusedIdx == i {
@@ -182,6 +660,7 @@ func (f *Form) Draw(painter *core.Painter) {
 				widget: row.Label,
 				z:      widgetZIndex(row.Label),
 				order:  i * 2,
+				muted:  row.Field != nil && core.IsDisabled(row.Field),
 			})
 		}
 		if row.Field != nil {
@@ -189,6 +668,7 @@ func (f *Form) Draw(painter *core.Painter) {
 				widget: row.Field,
 				z:      widgetZIndex(row.Field),
 				order:  i*2 + 1,
+				muted:  core.IsDisabled(row.Field),
 			})
 		}
 	}
@@ -203,7 +683,132 @@ func (f *Form) Draw(painter *core.Painter) {
 
 	for _, item := range items {
 		item.widget.Draw(painter)
+		if item.muted {
+			muteWidget(painter, item.widget)
+		}
+	}
+
+	f.drawRequiredMarks(painter)
+	f.drawErrors(painter)
+	f.drawHelp(painter)
+}
+
+// drawRequiredMarks renders an asterisk after the label of required rows,
+// followed by a dot if the field has been edited.
+func (f *Form) drawRequiredMarks(painter *core.Painter) {
+	tm := theme.Get()
+	bg := f.surfaceBG()
+	reqStyle := tcell.StyleDefault.Foreground(tm.GetSemanticColor("action.danger")).Background(bg)
+	dirtyStyle := tcell.StyleDefault.Foreground(tm.GetSemanticColor("action.warning")).Background(bg)
+	for _, row := range f.rows {
+		if row.Label == nil || row.Hidden {
+			continue
+		}
+		x, y := row.Label.Position()
+		w, _ := row.Label.Size()
+		if w <= 0 {
+			continue
+		}
+		pos := len([]rune(row.Label.Text)) + 1
+		if row.Required {
+			painter.SetCell(x+min(pos, w-1), y, '*', reqStyle)
+			pos++
+		}
+		if row.Field != nil && f.FieldDirty(row.Field) {
+			painter.SetCell(x+min(pos, w-1), y, '•', dirtyStyle)
+		}
+	}
+}
+
+// drawErrors renders validation errors on the line below each invalid field.
+func (f *Form) drawErrors(painter *core.Painter) {
+	if len(f.errs) == 0 {
+		return
+	}
+	tm := theme.Get()
+	style := tcell.StyleDefault.
+		Foreground(tm.GetSemanticColor("action.danger")).
+		Background(f.surfaceBG())
+	for i, row := range f.rows {
+		err := f.errs[i]
+		if err == nil || row.Field == nil {
+			continue
+		}
+		x, y := row.Field.Position()
+		w, h := row.Field.Size()
+		if w <= 0 {
+			continue
+		}
+		text := []rune("✗ " + err.Error())
+		if len(text) > w {
+			text = text[:w]
+		}
+		painter.DrawText(x, y+h, string(text), style)
+	}
+}
+
+// drawHelp renders inline help dimmed under each field and its error line.
+func (f *Form) drawHelp(painter *core.Painter) {
+	if len(f.helpLines) == 0 {
+		return
+	}
+	tm := theme.Get()
+	style := tcell.StyleDefault.
+		Foreground(tm.GetSemanticColor("text.muted")).
+		Background(f.surfaceBG())
+	for i, lines := range f.helpLines {
+		row := f.rows[i]
+		x, y := row.Field.Position()
+		y += row.Height
+		if f.errs[i] != nil {
+			y++
+		}
+		for j, line := range lines {
+			painter.DrawText(x, y+j, line, style)
+		}
+	}
+}
+
+// surfaceBG returns the background the form is drawn on: the cascaded one
+// if a container sets it, else the theme surface.
+func (f *Form) surfaceBG() tcell.Color {
+	if _, bg, _ := f.CascadedStyle().Decompose(); bg != tcell.ColorDefault {
+		return bg
+	}
+	return theme.Get().GetSemanticColor("bg.surface")
+}
+
+// wrapText word-wraps text to lines of at most w runes, breaking words
+// that don't fit on a line of their own.
+func wrapText(text string, w int) []string {
+	if w < 1 {
+		w = 1
+	}
+	var lines []string
+	var line []rune
+	for _, word := range strings.Fields(text) {
+		rw := []rune(word)
+		if len(line) > 0 && len(line)+1+len(rw) > w {
+			lines = append(lines, string(line))
+			line = line[:0]
+		}
+		for len(rw) > w {
+			if len(line) > 0 {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			lines = append(lines, string(rw[:w]))
+			rw = rw[w:]
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, rw...)
+	}
+	if len(line) > 0 {
+		lines = append(lines, string(line))
 	}
+	return lines
 }
 
 // syncLabelFocus updates the label's visual style based on field focus.
@@ -237,7 +842,29 @@ func (f *Form) SetPosition(x, y int) {
 	f.layout()
 }
 
-// layout positions all rows within the form.
+// formSlot is the area a row occupies, relative to the form origin.
+type formSlot struct {
+	x, y, w, h int
+}
+
+// columnCount returns the number of label/field columns for the current width.
+func (f *Form) columnCount() int {
+	if f.Config.TwoColumnMinWidth > 0 && f.Rect.W >= f.Config.TwoColumnMinWidth {
+		return 2
+	}
+	return 1
+}
+
+// pairable reports whether row i can share a line with another row in
+// two-column mode. Full-width rows and spacers span both columns.
+func (f *Form) pairable(i int) bool {
+	row := f.rows[i]
+	return row.Label != nil && row.Field != nil && !row.FullWidth && row.group == nil
+}
+
+// layout positions all rows within the form. In two-column mode,
+// consecutive label/field rows are paired side by side; full-width rows
+// and spacers take a line of their own. Hidden rows are skipped.
 func (f *Form) layout() {
 	x := f.Rect.X + f.Config.PaddingX
 	y := f.Rect.Y + f.Config.PaddingY
@@ -246,47 +873,115 @@ func (f *Form) layout() {
 		maxW = 1
 	}
 
-	for _, row := range f.rows {
-		if row.Label != nil {
-			row.Label.SetPosition(x, y)
-			labelW := f.Config.LabelWidth
-			if labelW > maxW {
-				labelW = maxW
-			}
-			row.Label.Resize(labelW, 1)
+	f.slots = make([]formSlot, len(f.rows))
+	twoCol := f.columnCount() == 2
+	gap := f.Config.ColumnGap
+	if gap <= 0 {
+		gap = 4
+	}
+	colW := (maxW - gap) / 2
+	split := f.Config.PaddingX + colW + gap/2 // Hit-test boundary between columns
+
+	f.helpLines = nil
+	for i := 0; i < len(f.rows); i++ {
+		if f.rows[i].Hidden {
+			continue // Leaves a zero slot, so it is never hit
+		}
+		if !twoCol || !f.pairable(i) {
+			f.wrapHelp(i, maxW, f.Config.LabelWidth)
+			lineH := f.rowHeight(i)
+			f.placeRow(i, x, y, maxW, f.Config.LabelWidth)
+			f.slots[i] = formSlot{x: 0, y: y - f.Rect.Y, w: f.Rect.W, h: lineH + f.Config.RowSpacing}
+			y += lineH + f.Config.RowSpacing
+			continue
 		}
 
-		if row.Field != nil {
-			// Check if field is expanded (e.g., ColorPicker)
-			isExpanded := false
-			if exp, ok := row.Field.(core.Expandable); ok && exp.IsExpanded() {
-				isExpanded = true
+		labelW := f.Config.LabelWidth
+		if labelW > colW/2 {
+			labelW = colW / 2
+		}
+		f.wrapHelp(i, colW, labelW)
+		lineH := f.rowHeight(i)
+		f.placeRow(i, x, y, colW, labelW)
+		left := i
+		next := i + 1
+		for next < len(f.rows) && f.rows[next].Hidden {
+			next++
+		}
+		if next < len(f.rows) && f.pairable(next) {
+			i = next
+			f.wrapHelp(i, colW, labelW)
+			f.placeRow(i, x+colW+gap, y, colW, labelW)
+			if h := f.rowHeight(i); h > lineH {
+				lineH = h
 			}
+			f.slots[i] = formSlot{x: split, y: y - f.Rect.Y, w: f.Rect.W - split, h: lineH + f.Config.RowSpacing}
+		}
+		f.slots[left] = formSlot{x: 0, y: y - f.Rect.Y, w: split, h: lineH + f.Config.RowSpacing}
+		y += lineH + f.Config.RowSpacing
+	}
+	f.contentH = y - f.Rect.Y + f.Config.PaddingY
+}
 
-			if row.FullWidth || row.Label == nil {
-				row.Field.SetPosition(x, y)
-				if !isExpanded {
-					row.Field.Resize(maxW, row.Height)
-				}
-			} else {
-				fieldX := x + f.Config.LabelWidth + 2
-				fieldW := f.Rect.X + f.Rect.W - fieldX - f.Config.PaddingX
-				if fieldW < 1 {
-					fieldW = 1
-				}
-				row.Field.SetPosition(fieldX, y)
-				if !isExpanded {
-					row.Field.Resize(fieldW, row.Height)
-				}
-			}
+// wrapHelp wraps row i's inline help to the width its field gets in a
+// column w cells wide.
+func (f *Form) wrapHelp(i, w, labelW int) {
+	row := f.rows[i]
+	if row.Help == "" || row.Field == nil || f.Config.HelpInStatusBar {
+		return
+	}
+	_, fieldW := fieldSpan(row, w, labelW)
+	if f.helpLines == nil {
+		f.helpLines = make(map[int][]string)
+	}
+	f.helpLines[i] = wrapText(row.Help, fieldW)
+}
+
+// fieldSpan returns the offset and width of a row's field within a column
+// w cells wide, with labelW cells reserved for the label.
+func fieldSpan(row FormRow, w, labelW int) (dx, fieldW int) {
+	if row.FullWidth || row.Label == nil {
+		return 0, w
+	}
+	dx = labelW + 2
+	fieldW = w - dx
+	if fieldW < 1 {
+		fieldW = 1
+	}
+	return dx, fieldW
+}
+
+// placeRow positions row i's label and field within a column starting at x
+// that is w cells wide, with labelW cells reserved for the label.
+func (f *Form) placeRow(i, x, y, w, labelW int) {
+	row := f.rows[i]
+	if row.Label != nil {
+		row.Label.SetPosition(x, y)
+		lw := labelW
+		if lw > w {
+			lw = w
 		}
-		y += row.Height + f.Config.RowSpacing
+		row.Label.Resize(lw, 1)
+	}
+
+	if row.Field == nil {
+		return
+	}
+	dx, fieldW := fieldSpan(row, w, labelW)
+	row.Field.SetPosition(x+dx, y)
+	// Group controls keep their natural button size. Popups (ColorPicker)
+	// open as overlays and keep their row size too.
+	if row.group == nil {
+		row.Field.Resize(fieldW, row.Height)
 	}
 }
 
 // VisitChildren implements core.ChildContainer.
 func (f *Form) VisitChildren(fn func(core.Widget)) {
 	for _, row := range f.rows {
+		if row.Hidden {
+			continue
+		}
 		if row.Label != nil {
 			fn(row.Label)
 		}
@@ -307,6 +1002,9 @@ func (f *Form) WidgetAt(x, y int) core.Widget {
 	bestOrder := -1
 
 	for i, row := range f.rows {
+		if row.Hidden {
+			continue
+		}
 		if row.Label != nil && row.Label.HitTest(x, y) {
 			order := i * 2
 			z := widgetZIndex(row.Label)
@@ -333,7 +1031,7 @@ func (f *Form) WidgetAt(x, y int) core.Widget {
 func (f *Form) getFocusableFields() []core.Widget {
 	var result []core.Widget
 	for _, row := range f.rows {
-		if row.Field != nil && row.Field.Focusable() {
+		if rowFocusable(row) {
 			result = append(result, row.Field)
 		}
 	}
@@ -454,7 +1152,7 @@ func (f *Form) CycleFocus(forward bool) bool {
 // HandleKey routes key events to the focused field.
 func (f *Form) HandleKey(ev *tcell.EventKey) bool {
 	fields := f.getFocusableFields()
-	for i, w := range fields {
+	for _, w := range fields {
 		// Check both direct focus and descendant focus (e.g., TextArea inside Border)
 		isFocused := false
 		if fs, ok := w.(core.FocusState); ok && fs.IsFocused() {
@@ -476,7 +1174,9 @@ func (f *Form) HandleKey(ev *tcell.EventKey) bool {
 			}
 			// Route other keys to focused field
 			if w.HandleKey(ev) {
-				f.lastFocusedIdx = i
+				// Handling may have added or removed rows (FormGroup)
+				f.syncFocusIndex()
+				f.fieldChanged(w)
 				return true
 			}
 			return false
@@ -487,7 +1187,7 @@ func (f *Form) HandleKey(ev *tcell.EventKey) bool {
 
 // HandleMouse routes mouse events to fields, handling click-to-focus.
 func (f *Form) HandleMouse(ev *tcell.EventMouse) bool {
-	_, y := ev.Position()
+	x, y := ev.Position()
 	buttons := ev.Buttons()
 	isPress := buttons&tcell.Button1 != 0
 	isWheel := buttons&(tcell.WheelUp|tcell.WheelDown|tcell.WheelLeft|tcell.WheelRight) != 0
@@ -508,7 +1208,7 @@ func (f *Form) HandleMouse(ev *tcell.EventMouse) bool {
 	// Build field list with row indices for position matching
 	fieldIdx := 0
 	for rowIdx, row := range f.rows {
-		if row.Field != nil && row.Field.Focusable() {
+		if rowFocusable(row) {
 			sortedFields = append(sortedFields, fieldInfo{
 				field: row.Field,
 				z:     widgetZIndex(row.Field),
@@ -542,61 +1242,57 @@ func (f *Form) HandleMouse(ev *tcell.EventMouse) bool {
 		return false
 	}
 
-	// For click events, find field by checking which row contains the Y coordinate
-	// Use relative Y position since absolute positions may be offset by scroll
-	relY := y - f.Rect.Y - f.Config.PaddingY
-	if relY < 0 {
-		return false
-	}
-
-	// Find which row the click is in (iterate in row order, not z-order)
-	rowY := 0
+	// For click events, find the row whose slot contains the point.
+	// Use relative positions since absolute positions may be offset by scroll
+	relX, relY := x-f.Rect.X, y-f.Rect.Y
 	for rowIdx, row := range f.rows {
-		rowEnd := rowY + row.Height + f.Config.RowSpacing
-		if relY >= rowY && relY < rowEnd {
-			// Click is in this row - find the corresponding field
-			if row.Field != nil && row.Field.Focusable() {
-				// Find field index for lastFocusedIdx
-				fieldIdx := 0
-				for i := 0; i < rowIdx; i++ {
-					if f.rows[i].Field != nil && f.rows[i].Field.Focusable() {
-						fieldIdx++
-					}
+		if rowIdx >= len(f.slots) {
+			break
+		}
+		s := f.slots[rowIdx]
+		if relY < s.y || relY >= s.y+s.h || relX < s.x || relX >= s.x+s.w {
+			continue
+		}
+		// Click is in this row - find the corresponding field
+		if rowFocusable(row) {
+			// Find field index for lastFocusedIdx
+			fieldIdx := 0
+			for i := 0; i < rowIdx; i++ {
+				if rowFocusable(f.rows[i]) {
+					fieldIdx++
 				}
+			}
 
-				if isPress {
-					// Blur currently focused field
-					for _, w := range fields {
-						if fs, ok := w.(core.FocusState); ok && fs.IsFocused() && w != row.Field {
-							w.Blur()
-						}
+			if isPress {
+				// Blur currently focused field
+				for _, w := range fields {
+					if fs, ok := w.(core.FocusState); ok && fs.IsFocused() && w != row.Field {
+						w.Blur()
 					}
-					row.Field.Focus()
-					f.lastFocusedIdx = fieldIdx
-					f.invalidate()
-				}
-				if ma, ok := row.Field.(core.MouseAware); ok {
-					return ma.HandleMouse(ev)
 				}
-				return true
+				row.Field.Focus()
+				f.lastFocusedIdx = fieldIdx
+				f.invalidate()
 			}
-			// Row has no focusable field (spacer?), but we're in it
-			return false
+			if ma, ok := row.Field.(core.MouseAware); ok {
+				handled := ma.HandleMouse(ev)
+				// A click may toggle a checkbox or pick a combo option
+				f.fieldChanged(row.Field)
+				return handled
+			}
+			return true
 		}
-		rowY = rowEnd
+		// Row has no focusable field (spacer?), but we're in it
+		return false
 	}
 
 	return false
 }
 
 // ContentHeight returns the total height needed to display all rows.
+// In two-column mode paired rows share a line.
 func (f *Form) ContentHeight() int {
-	height := f.Config.PaddingY
-	for _, row := range f.rows {
-		height += row.Height + f.Config.RowSpacing
-	}
-	height += f.Config.PaddingY
-	return height
+	return f.contentH
 }
 
 // invalidate marks the form as needing redraw.
@@ -606,6 +1302,14 @@ func (f *Form) invalidate() {
 	}
 }
 
+// muteWidget recolors the text w drew with the muted theme color, the way
+// containers show disabled widgets.
+func muteWidget(p *core.Painter, w core.Widget) {
+	x, y := w.Position()
+	width, height := w.Size()
+	p.SetForeground(core.Rect{X: x, Y: y, W: width, H: height}, theme.Get().GetSemanticColor("text.muted"))
+}
+
 // widgetZIndex returns the z-index of a widget.
 func widgetZIndex(w core.Widget) int {
 	if zi, ok := w.(core.ZIndexer); ok {
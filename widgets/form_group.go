@@ -0,0 +1,177 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/form_group.go
+// Summary: Repeatable row groups for Form with add/remove controls.
+
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/framegrace/texelui/core"
+)
+
+// FormGroup is a repeatable set of rows in a Form, such as
+// "add another address". Each instance gets a numbered header with a
+// remove (-) button; a trailing add (+) button appends a new instance
+// built from the group's template.
+type FormGroup struct {
+	Title string
+	ID    string // Optional key for the group's entry in Form.Values
+
+	// OnChange is called after an instance is added or removed.
+	OnChange func(count int)
+
+	form     *Form
+	template func() []FormRow
+	add      *Button
+	items    []*formGroupItem
+	min, max int // Instance limits; max 0 means unlimited
+}
+
+// formGroupItem is one instance of a group: its header row plus the
+// rows produced by the template.
+type formGroupItem struct {
+	header *Label
+	remove *Button
+	rows   []FormRow
+}
+
+// AddGroup appends a repeatable row group to the form. template is called
+// for every new instance and must return fresh widgets each time. The
+// group starts empty; use Add or SetLimits to create instances.
+func (f *Form) AddGroup(title string, template func() []FormRow) *FormGroup {
+	g := &FormGroup{
+		Title:    title,
+		form:     f,
+		template: template,
+	}
+	g.add = NewButton("+ " + title)
+	g.add.OnClick = func() { g.Add() }
+	f.groups = append(f.groups, g)
+	f.AddRow(FormRow{Field: g.add, Height: 1, FullWidth: true, group: g})
+	return g
+}
+
+// SetLimits sets the minimum and maximum number of instances (max 0 means
+// unlimited) and adds instances until the minimum is reached.
+func (g *FormGroup) SetLimits(min, max int) {
+	g.min, g.max = min, max
+	for len(g.items) < g.min && g.canAdd() {
+		g.Add()
+	}
+	g.syncControls()
+}
+
+// Len returns the number of instances.
+func (g *FormGroup) Len() int {
+	return len(g.items)
+}
+
+// Rows returns the template rows of instance i, or nil if out of range.
+func (g *FormGroup) Rows(i int) []FormRow {
+	if i < 0 || i >= len(g.items) {
+		return nil
+	}
+	return g.items[i].rows
+}
+
+// IndexOf returns the instance containing field, or -1. Indices follow
+// the current order, so they shift down when an earlier instance is removed.
+func (g *FormGroup) IndexOf(field core.Widget) int {
+	for i, item := range g.items {
+		for _, row := range item.rows {
+			if row.Field == field {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Add appends a new instance built from the template. Returns false if
+// the group is at its maximum or no longer part of the form.
+func (g *FormGroup) Add() bool {
+	if !g.canAdd() {
+		return false
+	}
+	at := g.form.rowIndexOf(g.add)
+	if at < 0 {
+		return false
+	}
+
+	item := &formGroupItem{
+		header: NewLabel(""),
+		remove: NewButton("-"),
+		rows:   g.template(),
+	}
+	item.remove.OnClick = func() { g.removeItem(item) }
+
+	rows := make([]FormRow, 0, len(item.rows)+1)
+	rows = append(rows, FormRow{Label: item.header, Field: item.remove, Height: 1, group: g})
+	rows = append(rows, item.rows...)
+	g.form.insertRows(at, rows)
+
+	g.items = append(g.items, item)
+	if core.IsDescendantFocused(g.add) {
+		// Move straight into the new instance
+		g.add.Blur()
+		g.form.focusNear(at + 1)
+		g.form.syncFocusIndex()
+	}
+	g.syncControls()
+	if g.OnChange != nil {
+		g.OnChange(len(g.items))
+	}
+	return true
+}
+
+// Remove deletes instance i. Returns false if i is out of range or the
+// group is at its minimum.
+func (g *FormGroup) Remove(i int) bool {
+	if i < 0 || i >= len(g.items) {
+		return false
+	}
+	return g.removeItem(g.items[i])
+}
+
+// removeItem deletes an instance's rows from the form and renumbers the rest.
+func (g *FormGroup) removeItem(item *formGroupItem) bool {
+	if len(g.items) <= g.min {
+		return false
+	}
+	at := g.form.rowIndexOf(item.remove)
+	if at < 0 {
+		return false
+	}
+	g.form.removeRows(at, len(item.rows)+1)
+
+	for i, it := range g.items {
+		if it == item {
+			g.items = append(g.items[:i], g.items[i+1:]...)
+			break
+		}
+	}
+	g.syncControls()
+	if g.OnChange != nil {
+		g.OnChange(len(g.items))
+	}
+	return true
+}
+
+// canAdd reports whether another instance fits within the maximum.
+func (g *FormGroup) canAdd() bool {
+	return g.max <= 0 || len(g.items) < g.max
+}
+
+// syncControls renumbers the headers and enables the +/- buttons
+// according to the limits.
+func (g *FormGroup) syncControls() {
+	for i, item := range g.items {
+		item.header.Text = fmt.Sprintf("%s %d", g.Title, i+1)
+		item.remove.SetDisabled(len(g.items) <= g.min)
+	}
+	g.add.SetDisabled(!g.canAdd())
+	g.form.invalidate()
+}
@@ -0,0 +1,494 @@
+package widgets
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+	"github.com/gdamore/tcell/v2"
+)
+
+func newTestFormBuffer(w, h int) [][]core.Cell {
+	buf := make([][]core.Cell, h)
+	for i := range buf {
+		buf[i] = make([]core.Cell, w)
+	}
+	return buf
+}
+
+func rowText(buf [][]core.Cell, y int) string {
+	var sb strings.Builder
+	for _, c := range buf[y] {
+		if c.Ch == 0 {
+			sb.WriteRune(' ')
+		} else {
+			sb.WriteRune(c.Ch)
+		}
+	}
+	return sb.String()
+}
+
+func TestForm_ValidateShowsInlineErrors(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 6})
+	f.SetPosition(0, 0)
+	f.Resize(30, 6)
+
+	name := NewInput()
+	age := NewInput()
+	f.AddField("Name", name)
+	f.AddField("Age", age)
+	f.SetValidator(name, func() error {
+		if name.Text == "" {
+			return errors.New("required")
+		}
+		return nil
+	})
+
+	if f.Validate() {
+		t.Fatal("expected validation to fail with empty name")
+	}
+	if f.FieldError(name) == nil || f.FieldError(age) != nil {
+		t.Fatalf("unexpected errors: name=%v age=%v", f.FieldError(name), f.FieldError(age))
+	}
+
+	// The error line pushes the next row down
+	if _, y := age.Position(); y != 2 {
+		t.Errorf("expected Age row at y=2 below the error line, got %d", y)
+	}
+
+	buf := newTestFormBuffer(30, 6)
+	f.Draw(core.NewPainter(buf, core.Rect{W: 30, H: 6}))
+	if !strings.Contains(rowText(buf, 1), "✗ required") {
+		t.Errorf("expected inline error under Name, got %q", rowText(buf, 1))
+	}
+
+	// Typing into the invalid field re-validates and clears the error
+	name.Focus()
+	f.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone))
+	if f.FieldError(name) != nil || !f.IsValid() {
+		t.Errorf("error should clear once the field is fixed, got %v", f.FieldError(name))
+	}
+	if _, y := age.Position(); y != 1 {
+		t.Errorf("expected Age row back at y=1, got %d", y)
+	}
+}
+
+func TestForm_SubmitBlocksUntilValid(t *testing.T) {
+	f := NewForm()
+	a := NewInput()
+	b := NewInput()
+	f.AddField("A", a)
+	f.AddField("B", b)
+	f.SetValidator(b, func() error {
+		if b.Text == "" {
+			return errors.New("required")
+		}
+		return nil
+	})
+	a.Focus()
+
+	submitted := false
+	if f.Submit(func() { submitted = true }) || submitted {
+		t.Fatal("Submit should not proceed while invalid")
+	}
+	if !b.IsFocused() || a.IsFocused() {
+		t.Error("Submit should focus the first invalid field")
+	}
+
+	b.Text = "ok"
+	if !f.Submit(func() { submitted = true }) || !submitted {
+		t.Error("Submit should call fn once the form is valid")
+	}
+}
+
+func TestForm_RequiredFieldsGateSubmitButton(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 8})
+	f.SetPosition(0, 0)
+	f.Resize(30, 4)
+
+	name := NewInput()
+	note := NewInput()
+	f.AddRequiredField("Name", name)
+	f.AddField("Note", note)
+
+	submit := NewButton("OK")
+	clicked := false
+	submit.OnClick = func() { clicked = true }
+	f.SetSubmitButton(submit)
+
+	if !submit.Disabled || submit.Focusable() {
+		t.Fatal("submit should start disabled while a required field is empty")
+	}
+	submit.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if clicked {
+		t.Error("disabled button should not activate")
+	}
+
+	buf := newTestFormBuffer(30, 4)
+	f.Draw(core.NewPainter(buf, core.Rect{W: 30, H: 4}))
+	if buf[0][5].Ch != '*' {
+		t.Errorf("expected asterisk after required label, got %q", rowText(buf, 0))
+	}
+
+	if f.Validate() || f.FieldError(name) == nil {
+		t.Error("Validate should fail for an empty required field")
+	}
+
+	name.Focus()
+	f.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'a', tcell.ModNone))
+	if submit.Disabled {
+		t.Error("submit should enable once required fields are filled")
+	}
+	if !f.Validate() {
+		t.Error("Validate should pass once required fields are filled")
+	}
+}
+
+func TestForm_MouseAndRefreshGateSubmitButton(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 8})
+	f.SetPosition(0, 0)
+	f.Resize(30, 4)
+
+	terms := NewCheckbox("Accept")
+	name := NewInput()
+	f.AddRequiredField("Terms", terms)
+	f.AddRequiredField("Name", name)
+	name.Text = "Ada"
+
+	submit := NewButton("OK")
+	f.SetSubmitButton(submit)
+	if f.Validate() || f.FieldError(terms) == nil {
+		t.Fatal("Validate should fail while the checkbox is unticked")
+	}
+
+	// Clicking the checkbox re-gates submit and clears its shown error
+	tx, ty := terms.Position()
+	f.HandleMouse(tcell.NewEventMouse(tx, ty, tcell.Button1, tcell.ModNone))
+	if !terms.Checked || submit.Disabled {
+		t.Fatal("submit should enable once the checkbox is ticked by mouse")
+	}
+	if f.FieldError(terms) != nil {
+		t.Error("ticking the checkbox should clear its error")
+	}
+
+	// Changes made from code need an explicit Refresh
+	name.Text = ""
+	f.Validate()
+	name.Text = "Grace"
+	f.Refresh()
+	if submit.Disabled || f.FieldError(name) != nil {
+		t.Error("Refresh should revalidate shown errors and re-gate submit")
+	}
+	name.Text = ""
+	f.Refresh()
+	if !submit.Disabled {
+		t.Error("Refresh should disable submit once a required field is emptied")
+	}
+}
+
+func TestForm_TwoColumnLayoutFollowsWidth(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 6, ColumnGap: 4, TwoColumnMinWidth: 40})
+	f.SetPosition(0, 0)
+	f.Resize(44, 6)
+
+	a := NewInput()
+	b := NewInput()
+	notes := NewInput()
+	f.AddField("A", a)
+	f.AddField("B", b)
+	f.AddFullWidthField(notes, 1)
+
+	// Wide: A and B share a line, the full-width row sits below
+	ax, ay := a.Position()
+	bx, by := b.Position()
+	if ay != 0 || by != 0 || bx <= ax {
+		t.Fatalf("expected A and B side by side, got A=(%d,%d) B=(%d,%d)", ax, ay, bx, by)
+	}
+	if _, y := notes.Position(); y != 1 {
+		t.Errorf("expected full-width row on its own line at y=1, got %d", y)
+	}
+	if h := f.ContentHeight(); h != 2 {
+		t.Errorf("expected content height 2, got %d", h)
+	}
+
+	// Clicking the right column focuses B
+	f.HandleMouse(tcell.NewEventMouse(bx+1, 0, tcell.Button1, tcell.ModNone))
+	if !b.IsFocused() || a.IsFocused() {
+		t.Error("click in right column should focus B")
+	}
+
+	// Narrow: back to a single column
+	f.Resize(30, 6)
+	if _, y := b.Position(); y != 1 {
+		t.Errorf("expected B on its own line at y=1 when narrow, got %d", y)
+	}
+	if h := f.ContentHeight(); h != 3 {
+		t.Errorf("expected content height 3, got %d", h)
+	}
+}
+
+func TestForm_GroupAddRemoveRenumbers(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 8})
+	f.SetPosition(0, 0)
+	f.Resize(30, 10)
+
+	name := NewInput()
+	f.AddField("Name", name)
+	var streets []*Input
+	g := f.AddGroup("Address", func() []FormRow {
+		street := NewInput()
+		streets = append(streets, street)
+		return []FormRow{{Label: NewLabel("Street"), Field: street}}
+	})
+	g.SetLimits(1, 3)
+
+	if g.Len() != 1 {
+		t.Fatalf("expected min instance to be created, got %d", g.Len())
+	}
+	if g.items[0].remove.Focusable() {
+		t.Error("remove should be disabled at the minimum")
+	}
+
+	// Activating + from the keyboard inserts before it and focuses the new row
+	g.add.Focus()
+	f.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if g.Len() != 2 || !streets[1].IsFocused() {
+		t.Fatalf("expected a focused second instance, len=%d", g.Len())
+	}
+	if _, y := g.add.Position(); y != 5 {
+		t.Errorf("expected add button pushed to y=5, got %d", y)
+	}
+
+	// Errors stay attached to their rows across removal
+	f.SetRequired(streets[1], true)
+	f.Validate()
+	if !g.Remove(0) {
+		t.Fatal("Remove should succeed above the minimum")
+	}
+	if g.items[0].header.Text != "Address 1" || g.IndexOf(streets[1]) != 0 {
+		t.Errorf("expected remaining instance renumbered to 1, got %q", g.items[0].header.Text)
+	}
+	if f.FieldError(streets[1]) == nil || f.FieldError(name) != nil {
+		t.Error("errors should move with their rows")
+	}
+	if g.Remove(0) {
+		t.Error("Remove should refuse to go below the minimum")
+	}
+}
+
+func TestForm_ValuesJSONRoundTrip(t *testing.T) {
+	build := func() (*Form, *FormGroup) {
+		f := NewForm()
+		f.AddRow(FormRow{ID: "name", Label: NewLabel("Name"), Field: NewInput()})
+		f.AddRow(FormRow{ID: "subscribe", Label: NewLabel("News"), Field: NewCheckbox("Subscribe")})
+		f.AddField("Untracked", NewInput())
+		g := f.AddGroup("Phone", func() []FormRow {
+			return []FormRow{{ID: "number", Label: NewLabel("Number"), Field: NewInput()}}
+		})
+		g.ID = "phones"
+		return f, g
+	}
+
+	src, _ := build()
+	if err := src.SetValues(map[string]any{
+		"name":      "Ada",
+		"subscribe": true,
+		"phones":    []map[string]any{{"number": "123"}, {"number": "456"}},
+	}); err != nil {
+		t.Fatalf("SetValues: %v", err)
+	}
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	dst, g := build()
+	if err := json.Unmarshal(data, dst); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if g.Len() != 2 {
+		t.Fatalf("expected group resized to 2 instances, got %d", g.Len())
+	}
+	vals := dst.Values()
+	if vals["name"] != "Ada" || vals["subscribe"] != true {
+		t.Errorf("unexpected values: %v", vals)
+	}
+	phones := vals["phones"].([]map[string]any)
+	if phones[1]["number"] != "456" {
+		t.Errorf("unexpected group values: %v", phones)
+	}
+	if _, ok := vals["number"]; ok {
+		t.Error("group rows should not appear at the top level")
+	}
+
+	if err := dst.SetValues(map[string]any{"subscribe": "yes"}); err == nil {
+		t.Error("expected an error for a non-bool checkbox value")
+	}
+}
+
+func TestForm_DirtyTrackingAndReset(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 8})
+	f.SetPosition(0, 0)
+	f.Resize(30, 3)
+
+	name := NewInput()
+	name.Text = "Ada"
+	news := NewCheckbox("News")
+	f.AddField("Name", name)
+	f.AddField("Opt", news)
+
+	if f.IsDirty() {
+		t.Fatal("new form should be clean")
+	}
+
+	name.Focus()
+	f.HandleKey(tcell.NewEventKey(tcell.KeyRune, '!', tcell.ModNone))
+	if !f.IsDirty() || !f.FieldDirty(name) || f.FieldDirty(news) {
+		t.Fatal("editing Name should mark only that field dirty")
+	}
+
+	buf := newTestFormBuffer(30, 3)
+	f.Draw(core.NewPainter(buf, core.Rect{W: 30, H: 3}))
+	if buf[0][5].Ch != '•' {
+		t.Errorf("expected dirty marker after label, got %q", rowText(buf, 0))
+	}
+
+	f.Reset()
+	if name.Text != "Ada" || f.IsDirty() {
+		t.Errorf("Reset should restore the initial value, got %q", name.Text)
+	}
+
+	news.Checked = true
+	f.MarkClean()
+	if f.IsDirty() {
+		t.Error("MarkClean should accept current values as initial")
+	}
+}
+
+func TestForm_HelpWrapsUnderField(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 6})
+	f.SetPosition(0, 0)
+	f.Resize(20, 6)
+
+	pw := NewInput()
+	next := NewInput()
+	f.AddRow(FormRow{Label: NewLabel("Pass"), Field: pw, Help: "at least eight characters"})
+	f.AddField("Next", next)
+
+	// Field is 12 wide, so the help wraps onto three lines
+	if _, y := next.Position(); y != 4 {
+		t.Fatalf("expected help lines to push Next to y=4, got %d", y)
+	}
+	buf := newTestFormBuffer(20, 6)
+	f.Draw(core.NewPainter(buf, core.Rect{W: 20, H: 6}))
+	if got := strings.TrimSpace(rowText(buf, 1)); got != "at least" {
+		t.Errorf("unexpected first help line %q", got)
+	}
+	if got := strings.TrimSpace(rowText(buf, 3)); got != "characters" {
+		t.Errorf("unexpected last help line %q", got)
+	}
+}
+
+func TestForm_HelpInStatusBar(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 6, HelpInStatusBar: true})
+	name := NewInput()
+	f.AddRow(FormRow{Label: NewLabel("Name"), Field: name, Help: "Your full name"})
+	if f.ContentHeight() != 1 {
+		t.Errorf("status bar help should not take form space, got height %d", f.ContentHeight())
+	}
+
+	sb := NewStatusBar()
+	sb.SetPosition(0, 0)
+	sb.Resize(80, 2)
+	f.Focus()
+	sb.OnFocusChanged(f)
+
+	buf := newTestFormBuffer(80, 2)
+	sb.Draw(core.NewPainter(buf, core.Rect{W: 80, H: 2}))
+	if !strings.Contains(rowText(buf, 1), "Your full name") {
+		t.Errorf("expected focused field help in status bar, got %q", rowText(buf, 1))
+	}
+}
+
+func TestForm_VisibilityRules(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 8})
+	f.SetPosition(0, 0)
+	f.Resize(30, 6)
+
+	ship := NewCheckbox("Ship elsewhere")
+	addr := NewInput()
+	note := NewInput()
+	f.AddField("Ship", ship)
+	f.AddRequiredField("Address", addr)
+	f.AddField("Note", note)
+	f.ShowWhen(addr, func() bool { return ship.Checked })
+
+	if f.RowVisible(1) || f.ContentHeight() != 2 {
+		t.Fatalf("address should start hidden, height=%d", f.ContentHeight())
+	}
+	if _, y := note.Position(); y != 1 {
+		t.Errorf("expected Note to move up to y=1, got %d", y)
+	}
+	if !f.Validate() {
+		t.Error("hidden required fields should not fail validation")
+	}
+
+	// Toggling the checkbox from the keyboard reveals the row
+	ship.Focus()
+	f.HandleKey(tcell.NewEventKey(tcell.KeyRune, ' ', tcell.ModNone))
+	if !f.RowVisible(1) || f.ContentHeight() != 3 {
+		t.Fatalf("address should be shown once checked, height=%d", f.ContentHeight())
+	}
+	f.CycleFocus(true)
+	if !addr.IsFocused() {
+		t.Error("visible address should be reachable by Tab")
+	}
+
+	// Hiding the focused row moves focus on
+	f.SetRowVisible(1, false)
+	if addr.IsFocused() || !note.IsFocused() {
+		t.Error("hiding the focused row should move focus to the next field")
+	}
+}
+
+func TestForm_DisabledFieldsSkipFocusAndDrawMuted(t *testing.T) {
+	f := NewFormWithConfig(FormConfig{LabelWidth: 6})
+	f.SetPosition(0, 0)
+	f.Resize(30, 3)
+	name := NewInput()
+	age := NewInput()
+	f.AddField("Name", name)
+	f.AddField("Age", age)
+
+	name.SetDisabled(true)
+	if name.Focusable() {
+		t.Fatal("a disabled input should not be focusable")
+	}
+	f.Focus()
+	if name.IsFocused() || !age.IsFocused() {
+		t.Fatal("focus should skip the disabled field")
+	}
+	f.HandleMouse(tcell.NewEventMouse(10, 0, tcell.Button1, tcell.ModNone))
+	if name.IsFocused() {
+		t.Error("clicking a disabled field should not focus it")
+	}
+
+	buf := newTestFormBuffer(30, 3)
+	f.Draw(core.NewPainter(buf, core.Rect{W: 30, H: 3}))
+	muted := theme.Get().GetSemanticColor("text.muted")
+	if fg, _, _ := buf[0][0].Style.Decompose(); fg != muted {
+		t.Error("the label of a disabled field should be muted")
+	}
+	if fg, _, _ := buf[1][0].Style.Decompose(); fg == muted {
+		t.Error("the label of an enabled field should not be muted")
+	}
+
+	name.SetDisabled(false)
+	if !name.Focusable() {
+		t.Error("enabling should restore focusability")
+	}
+}
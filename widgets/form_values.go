@@ -0,0 +1,285 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/form_values.go
+// Summary: Form field values by row ID, JSON support and dirty tracking.
+
+package widgets
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/framegrace/texelui/core"
+)
+
+// Values returns the current value of every row that has an ID, keyed by
+// that ID. Text fields yield strings and checkboxes/toggles yield bools.
+// Groups with an ID yield a []map[string]any with one entry per instance.
+func (f *Form) Values() map[string]any {
+	vals := rowValues(f.ownRows())
+	for _, g := range f.groups {
+		if g.ID == "" {
+			continue
+		}
+		items := make([]map[string]any, len(g.items))
+		for i, item := range g.items {
+			items[i] = rowValues(item.rows)
+		}
+		vals[g.ID] = items
+	}
+	return vals
+}
+
+// SetValues fills fields from vals, keyed by row ID. IDs missing from vals
+// are left untouched. Group entries resize the group to the number of
+// instances given (within its limits) before filling them. The first value
+// that doesn't fit its field is reported; the remaining values are still set.
+func (f *Form) SetValues(vals map[string]any) error {
+	err := setRowValues(f.ownRows(), vals)
+	for _, g := range f.groups {
+		v, ok := vals[g.ID]
+		if g.ID == "" || !ok {
+			continue
+		}
+		if gerr := g.setValues(v); err == nil {
+			err = gerr
+		}
+	}
+	f.applyRules()
+	f.updateSubmit()
+	f.invalidate()
+	return err
+}
+
+// MarshalJSON implements json.Marshaler using Values.
+func (f *Form) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler using SetValues.
+func (f *Form) UnmarshalJSON(data []byte) error {
+	var vals map[string]any
+	if err := json.Unmarshal(data, &vals); err != nil {
+		return err
+	}
+	return f.SetValues(vals)
+}
+
+// IsDirty reports whether any field differs from its initial value.
+func (f *Form) IsDirty() bool {
+	for _, row := range f.rows {
+		if row.Field != nil && f.FieldDirty(row.Field) {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldDirty reports whether field differs from its initial value.
+func (f *Form) FieldDirty(field core.Widget) bool {
+	init, ok := f.initial[field]
+	if !ok {
+		return false
+	}
+	v, _ := fieldValue(field)
+	return v != init
+}
+
+// MarkClean records the current values as the initial ones, e.g. after
+// prefilling with SetValues or saving.
+func (f *Form) MarkClean() {
+	f.initial = nil
+	for _, row := range f.rows {
+		f.snapshot(row.Field)
+	}
+	f.invalidate()
+}
+
+// Reset restores every field to its initial value and clears validation
+// errors. Group instances added or removed since are left as they are.
+func (f *Form) Reset() {
+	for _, row := range f.rows {
+		if init, ok := f.initial[row.Field]; ok {
+			setFieldValue(row.Field, init)
+		}
+	}
+	f.errs = nil
+	f.applyRules()
+	f.layout()
+	f.updateSubmit()
+	f.invalidate()
+}
+
+// snapshot records field's current value as its initial value.
+func (f *Form) snapshot(field core.Widget) {
+	if field == nil {
+		return
+	}
+	if v, ok := fieldValue(field); ok {
+		if f.initial == nil {
+			f.initial = make(map[core.Widget]any)
+		}
+		f.initial[field] = v
+	}
+}
+
+// ownRows returns the rows that don't belong to a group instance.
+func (f *Form) ownRows() []FormRow {
+	inGroup := make(map[core.Widget]bool)
+	for _, g := range f.groups {
+		for _, item := range g.items {
+			for _, row := range item.rows {
+				if row.Field != nil {
+					inGroup[row.Field] = true
+				}
+			}
+		}
+	}
+	rows := make([]FormRow, 0, len(f.rows))
+	for _, row := range f.rows {
+		if row.Field == nil || !inGroup[row.Field] {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// setValues matches the group's instances to a list of value maps.
+func (g *FormGroup) setValues(v any) error {
+	var items []map[string]any
+	switch list := v.(type) {
+	case []map[string]any:
+		items = list
+	case []any: // As decoded from JSON
+		for _, e := range list {
+			m, ok := e.(map[string]any)
+			if !ok {
+				return fmt.Errorf("form: group %q: expected object, got %T", g.ID, e)
+			}
+			items = append(items, m)
+		}
+	default:
+		return fmt.Errorf("form: group %q: expected list, got %T", g.ID, v)
+	}
+
+	for len(g.items) < len(items) {
+		if !g.Add() {
+			break
+		}
+	}
+	for len(g.items) > len(items) {
+		if !g.Remove(len(g.items) - 1) {
+			break
+		}
+	}
+	var err error
+	for i, item := range g.items {
+		if i >= len(items) {
+			break
+		}
+		if ierr := setRowValues(item.rows, items[i]); err == nil {
+			err = ierr
+		}
+	}
+	return err
+}
+
+// rowValues collects the values of rows that have an ID.
+func rowValues(rows []FormRow) map[string]any {
+	vals := make(map[string]any)
+	for _, row := range rows {
+		if row.ID == "" || row.Field == nil {
+			continue
+		}
+		if v, ok := fieldValue(row.Field); ok {
+			vals[row.ID] = v
+		}
+	}
+	return vals
+}
+
+// setRowValues sets the fields of rows whose ID appears in vals.
+func setRowValues(rows []FormRow, vals map[string]any) error {
+	var err error
+	for _, row := range rows {
+		if row.ID == "" || row.Field == nil {
+			continue
+		}
+		v, ok := vals[row.ID]
+		if !ok {
+			continue
+		}
+		if ferr := setFieldValue(row.Field, v); ferr != nil && err == nil {
+			err = fmt.Errorf("form: field %q: %w", row.ID, ferr)
+		}
+	}
+	return err
+}
+
+// fieldValue returns the value held by a field widget. Unknown widget
+// types report false.
+func fieldValue(w core.Widget) (any, bool) {
+	switch fw := w.(type) {
+	case *Input:
+		return fw.Text, true
+	case *TextArea:
+		return fw.Text(), true
+	case *ComboBox:
+		return fw.Value(), true
+	case *Checkbox:
+		return fw.Checked, true
+	case *ToggleButton:
+		return fw.Active, true
+	case *ColorPicker:
+		return fw.GetResult().Source, true
+	case interface{ Value() string }:
+		return fw.Value(), true
+	}
+	return nil, false
+}
+
+// setFieldValue stores v in a field widget. Text fields accept any value
+// and format it; boolean fields require a bool.
+func setFieldValue(w core.Widget, v any) error {
+	switch fw := w.(type) {
+	case *Input:
+		fw.Text = valueString(v)
+		fw.CaretPos = len([]rune(fw.Text))
+		fw.OffX = 0
+	case *TextArea:
+		fw.SetText(valueString(v))
+	case *ComboBox:
+		fw.SetValue(valueString(v))
+	case *ColorPicker:
+		fw.SetValue(valueString(v))
+	case *Checkbox:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", v)
+		}
+		fw.Checked = b
+	case *ToggleButton:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", v)
+		}
+		fw.Active = b
+	case interface{ SetValue(string) }:
+		fw.SetValue(valueString(v))
+	default:
+		return fmt.Errorf("unsupported field type %T", w)
+	}
+	return nil
+}
+
+// valueString formats a value for a text field; nil becomes "".
+func valueString(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -1,6 +1,8 @@
 package widgets
 
 import (
+	"strings"
+
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/core"
@@ -28,6 +30,13 @@ type Input struct {
 
 	// Mouse state
 	mouseDown bool
+	// Selection: the runes between the anchor and the caret, made by
+	// dragging with the mouse
+	anchor    int
+	hasAnchor bool
+
+	// Clipboard for Ctrl+C/Ctrl+V, set by the UIManager
+	clipboard core.ClipboardService
 
 	// Insert vs replace mode: false=insert (default), true=replace (overwrite)
 	replaceMode bool
@@ -40,25 +49,11 @@ type Input struct {
 // Position defaults to 0,0 and width to 20.
 // Use SetPosition and Resize to adjust after adding to a layout.
 func NewInput() *Input {
-	tm := theme.Get()
-	bg := tm.GetSemanticColor("bg.surface")
-	fg := tm.GetSemanticColor("text.primary")
-	caret := tm.GetSemanticColor("caret")
-
 	i := &Input{
 		Text:     "",
 		CaretPos: 0,
-		Style: color.DynamicStyle{
-			FG: color.Solid(fg),
-			BG: color.Solid(bg),
-		},
-		CaretStyle: color.DynamicStyle{
-			FG: color.Solid(caret),
-		},
 	}
-
-	// Configure focused style
-	i.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
+	i.applyTheme()
 
 	i.Resize(20, 1) // Default width, always single-line
 	i.SetFocusable(true)
@@ -66,6 +61,28 @@ func NewInput() *Input {
 	return i
 }
 
+// applyTheme resolves the text, caret and focused styles from the current theme.
+func (i *Input) applyTheme() {
+	tm := theme.Get()
+	bg := tm.GetSemanticColor("bg.surface")
+	fg := tm.GetSemanticColor("text.primary")
+	caret := tm.GetSemanticColor("caret")
+
+	i.SetThemeStyle(&i.Style, color.DynamicStyle{
+		FG: color.Solid(fg),
+		BG: color.Solid(bg),
+	})
+	i.SetThemeStyle(&i.CaretStyle, color.DynamicStyle{
+		FG: color.Solid(caret),
+	})
+
+	// Configure focused style
+	i.SetFocusedStyle(tcell.StyleDefault.Foreground(fg).Background(bg), true)
+}
+
+// OnThemeChanged implements core.ThemeAware.
+func (i *Input) OnThemeChanged() { i.applyTheme() }
+
 // SetInvalidator allows the UI manager to inject a dirty-region invalidator.
 func (i *Input) SetInvalidator(fn func(core.Rect)) { i.inv = fn }
 
@@ -125,14 +142,21 @@ func (i *Input) Draw(painter *core.Painter) {
 	// Convert to runes for proper unicode handling
 	runes := []rune(displayText)
 
-	// Render visible portion of text
+	// Render visible portion of text, the selection highlighted
 	x := i.Rect.X
 	drawText := painter.DrawDynamicText
 	if i.Transparent {
 		drawText = painter.DrawDynamicTextKeepBG
 	}
+	selStart, selEnd := i.Selection()
+	selDS := ds
+	selDS.BG = color.Solid(theme.Get().GetSemanticColor("selection"))
 	for idx := i.OffX; idx < len(runes) && x < i.Rect.X+i.Rect.W; idx++ {
-		drawText(x, i.Rect.Y, string(runes[idx]), ds)
+		if idx >= selStart && idx < selEnd {
+			painter.DrawDynamicText(x, i.Rect.Y, string(runes[idx]), selDS)
+		} else {
+			drawText(x, i.Rect.Y, string(runes[idx]), ds)
+		}
 		x++
 	}
 
@@ -187,8 +211,32 @@ func (i *Input) ensureCaretVisible() {
 
 // HandleKey processes keyboard input for text editing.
 func (i *Input) HandleKey(ev *tcell.EventKey) bool {
+	// Alt+character is left to shortcuts such as Alt+digit tab switching
+	if ev.Key() == tcell.KeyRune && ev.Modifiers()&tcell.ModAlt != 0 {
+		return false
+	}
+	// Clipboard keys act on the selection before any key clears it
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		return i.copySelection()
+	case tcell.KeyCtrlV:
+		return i.paste()
+	}
+	// Editing keys replace the selection
+	replaced := false
+	switch ev.Key() {
+	case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyDelete:
+		if i.deleteSelection() {
+			i.onChange()
+			i.invalidate()
+			return true
+		}
+	case tcell.KeyRune:
+		replaced = i.deleteSelection()
+	}
 	runes := []rune(i.Text)
 	textLen := len(runes)
+	i.ClearSelection()
 
 	switch ev.Key() {
 	case tcell.KeyLeft:
@@ -251,7 +299,7 @@ func (i *Input) HandleKey(ev *tcell.EventKey) bool {
 	case tcell.KeyRune:
 		// Insert or replace character at caret position
 		r := ev.Rune()
-		if i.replaceMode && i.CaretPos < textLen {
+		if i.replaceMode && !replaced && i.CaretPos < textLen {
 			// Overwrite current character
 			runes[i.CaretPos] = r
 			i.CaretPos++
@@ -280,27 +328,159 @@ func (i *Input) HandleMouse(ev *tcell.EventMouse) bool {
 
 	switch ev.Buttons() {
 	case tcell.Button1: // Left mouse button
-		i.mouseDown = true
-		// Position caret at click location
-		clickX := x - i.Rect.X + i.OffX
-		runes := []rune(i.Text)
-		if clickX < 0 {
-			i.CaretPos = 0
-		} else if clickX >= len(runes) {
-			i.CaretPos = len(runes)
-		} else {
-			i.CaretPos = clickX
+		// Position caret at click location and start a selection there;
+		// while held, extend it
+		i.caretToColumn(x - i.Rect.X + i.OffX)
+		if !i.mouseDown {
+			i.anchor, i.hasAnchor = i.CaretPos, true
 		}
+		i.mouseDown = true
 		i.invalidate()
 		return true
 
 	case tcell.ButtonNone: // Mouse release
-		i.mouseDown = false
+		i.endDrag()
 	}
 
 	return false
 }
 
+// GrabsPointer implements core.PointerGrabber: the mouse is held while
+// dragging a selection, so the drag keeps going outside the field.
+func (i *Input) GrabsPointer() bool { return i.mouseDown }
+
+// HandleGrabbedMouse implements core.PointerGrabber. It extends the
+// selection to the pointer; past either edge it scrolls a rune per event.
+func (i *Input) HandleGrabbedMouse(ev *tcell.EventMouse, _ core.Cell) {
+	if ev.Buttons()&tcell.Button1 == 0 {
+		i.endDrag()
+		return
+	}
+	x, _ := ev.Position()
+	switch {
+	case x < i.Rect.X:
+		i.caretToColumn(i.CaretPos - 1)
+	case x >= i.Rect.X+i.Rect.W:
+		i.caretToColumn(i.CaretPos + 1)
+	default:
+		i.caretToColumn(x - i.Rect.X + i.OffX)
+	}
+	i.ensureCaretVisible()
+	i.invalidate()
+}
+
+// Selection returns the selected rune range [start, end); start == end
+// when nothing is selected.
+func (i *Input) Selection() (start, end int) {
+	n := len([]rune(i.Text))
+	if !i.hasAnchor || i.anchor > n || i.CaretPos > n {
+		return 0, 0
+	}
+	if i.anchor < i.CaretPos {
+		return i.anchor, i.CaretPos
+	}
+	return i.CaretPos, i.anchor
+}
+
+// SelectedText returns the selected text, or "" if none.
+func (i *Input) SelectedText() string {
+	start, end := i.Selection()
+	return string([]rune(i.Text)[start:end])
+}
+
+// ClearSelection drops the selection, keeping the caret.
+func (i *Input) ClearSelection() {
+	if i.hasAnchor {
+		i.hasAnchor = false
+		i.invalidate()
+	}
+}
+
+// deleteSelection removes the selected text, leaving the caret where it
+// began, and drops the selection. Returns false if nothing was selected.
+// The caller reports the change.
+func (i *Input) deleteSelection() bool {
+	start, end := i.Selection()
+	i.ClearSelection()
+	if start == end {
+		return false
+	}
+	runes := []rune(i.Text)
+	i.Text = string(append(runes[:start], runes[end:]...))
+	i.CaretPos = start
+	i.ensureCaretVisible()
+	return true
+}
+
+// endDrag finishes a mouse drag; a click without movement selects nothing.
+func (i *Input) endDrag() {
+	i.mouseDown = false
+	if i.hasAnchor && i.anchor == i.CaretPos {
+		i.hasAnchor = false
+	}
+	i.invalidate()
+}
+
+// SetClipboardService implements core.ClipboardAware.
+func (i *Input) SetClipboardService(cs core.ClipboardService) { i.clipboard = cs }
+
+// copySelection puts the selected text on the clipboard. Returns false
+// when there is nothing to copy, leaving Ctrl+C to the app.
+func (i *Input) copySelection() bool {
+	text := i.SelectedText()
+	if text == "" || i.clipboard == nil {
+		return false
+	}
+	i.clipboard.SetClipboard("text/plain", []byte(text))
+	return true
+}
+
+// paste inserts the clipboard text at the caret.
+func (i *Input) paste() bool {
+	if i.clipboard == nil {
+		return false
+	}
+	if _, data, ok := i.clipboard.GetClipboard(); ok {
+		return i.HandlePaste(string(data))
+	}
+	return true
+}
+
+// HandlePaste implements core.PasteAware: the text is inserted at the
+// caret in one piece, newlines turned into spaces, so a multi-line paste
+// neither submits nor moves focus.
+func (i *Input) HandlePaste(text string) bool {
+	i.deleteSelection()
+	i.insertText(strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(text))
+	return true
+}
+
+// insertText inserts s at the caret.
+func (i *Input) insertText(s string) {
+	runes := []rune(i.Text)
+	pos := i.CaretPos
+	if pos > len(runes) {
+		pos = len(runes)
+	}
+	ins := []rune(s)
+	runes = append(runes[:pos], append(ins, runes[pos:]...)...)
+	i.Text = string(runes)
+	i.CaretPos = pos + len(ins)
+	i.onChange()
+	i.invalidate()
+}
+
+// caretToColumn moves the caret to rune col, clamped to the text.
+func (i *Input) caretToColumn(col int) {
+	n := len([]rune(i.Text))
+	if col < 0 {
+		col = 0
+	} else if col > n {
+		col = n
+	}
+	i.CaretPos = col
+}
+
 // onChange triggers the OnChange callback if set.
 func (i *Input) onChange() {
 	if i.OnChange != nil {
@@ -38,13 +38,7 @@ func NewLabel(text string) *Label {
 	}
 
 	// Get default style from theme
-	tm := theme.Get()
-	fg := tm.GetSemanticColor("text.primary")
-	bg := tm.GetSemanticColor("bg.surface")
-	l.Style = color.DynamicStyle{
-		FG: color.Solid(fg),
-		BG: color.Solid(bg),
-	}
+	l.applyTheme()
 
 	// Auto-size to fit text
 	l.Resize(len(text), 1)
@@ -55,9 +49,23 @@ func NewLabel(text string) *Label {
 	return l
 }
 
+// applyTheme resolves the default style from the current theme.
+func (l *Label) applyTheme() {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	l.SetThemeStyle(&l.Style, color.DynamicStyle{
+		FG: color.Solid(fg),
+		BG: color.Solid(bg),
+	})
+}
+
+// OnThemeChanged implements core.ThemeAware.
+func (l *Label) OnThemeChanged() { l.applyTheme() }
+
 // Draw renders the label text with the configured alignment.
 func (l *Label) Draw(painter *core.Painter) {
-	ds := l.Style
+	ds := l.EffectiveDynamic(l.Style)
 	if l.IsFocused() {
 		ds.Attrs |= tcell.AttrBold
 	}
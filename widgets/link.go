@@ -28,30 +28,39 @@ func NewLink(text string) *Link {
 		Text: text,
 	}
 
-	// Get default style from theme — use accent color with underline
+	// Get default and focused styles from theme
+	l.applyTheme()
+
+	// Auto-size to fit text
+	l.Resize(len(text), 1)
+
+	// Links are focusable by default
+	l.SetFocusable(true)
+
+	return l
+}
+
+// applyTheme resolves the default and focused styles from the current theme.
+func (l *Link) applyTheme() {
+	// Use accent color with underline
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("accent.primary")
 	bg := tm.GetSemanticColor("bg.surface")
-	l.Style = color.DynamicStyle{
+	l.SetThemeStyle(&l.Style, color.DynamicStyle{
 		FG:    color.Solid(fg),
 		BG:    color.Solid(bg),
 		Attrs: tcell.AttrUnderline,
-	}
+	})
 
 	// Configure focused style — reverse colors but keep underline
 	focusFg := tm.GetSemanticColor("text.inverse")
 	focusBg := tm.GetSemanticColor("border.focus")
 	l.SetFocusedStyle(tcell.StyleDefault.Foreground(focusFg).Background(focusBg).Underline(true), true)
-
-	// Auto-size to fit text
-	l.Resize(len(text), 1)
-
-	// Links are focusable by default
-	l.SetFocusable(true)
-
-	return l
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (l *Link) OnThemeChanged() { l.applyTheme() }
+
 // Draw renders the link text with underline style.
 func (l *Link) Draw(painter *core.Painter) {
 	ds := l.Style
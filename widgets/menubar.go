@@ -0,0 +1,245 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/menubar.go
+// Summary: One-row menu bar opening drop-down menus.
+
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+)
+
+// Menu is a drop-down menu of a MenuBar.
+type Menu struct {
+	Label string
+	Items []MenuItem
+}
+
+// MenuBar is a row of menu titles, each opening a drop-down ContextMenu
+// below it. On the focused bar, Left/Right select a title and Enter, Space
+// or Down open its menu; while a menu is open, Left/Right move to the
+// neighbouring menus. Clicking a title opens or closes its menu.
+//
+// Like an expanded ComboBox, the bar is modal while a menu is open and
+// hit-tests over the menu, which it draws and routes input to itself.
+type MenuBar struct {
+	core.BaseWidget
+	Menus []Menu
+
+	selected int
+	menu     *ContextMenu
+	inv      func(core.Rect)
+}
+
+// NewMenuBar creates a menu bar with the given menus.
+func NewMenuBar(menus []Menu) *MenuBar {
+	mb := &MenuBar{Menus: menus, menu: NewContextMenu()}
+	mb.menu.OnClose = mb.invalidate
+	mb.Resize(mb.titlesWidth(), 1)
+	mb.SetFocusable(true)
+	return mb
+}
+
+// SetMenus replaces the menus, closing an open one.
+func (mb *MenuBar) SetMenus(menus []Menu) {
+	mb.menu.Close()
+	mb.Menus = menus
+	mb.selected = min(mb.selected, max(len(menus)-1, 0))
+	mb.invalidate()
+}
+
+// Open opens menu idx, selecting its title.
+func (mb *MenuBar) Open(idx int) {
+	if idx < 0 || idx >= len(mb.Menus) {
+		return
+	}
+	mb.selected = idx
+	mb.menu.Show(mb.Menus[idx].Items, mb.titleX(idx), mb.Rect.Y+1)
+	mb.invalidate()
+}
+
+// IsOpen reports whether a menu is showing.
+func (mb *MenuBar) IsOpen() bool {
+	return mb.menu.IsOpen()
+}
+
+// titleX returns the screen column where title idx starts.
+func (mb *MenuBar) titleX(idx int) int {
+	x := mb.Rect.X
+	for i := 0; i < idx; i++ {
+		x += len([]rune(mb.Menus[i].Label)) + 2
+	}
+	return x
+}
+
+// titlesWidth returns the width of all titles, each padded by one column
+// on either side.
+func (mb *MenuBar) titlesWidth() int {
+	return mb.titleX(len(mb.Menus)) - mb.Rect.X
+}
+
+// titleAt returns the index of the title at column x, or -1.
+func (mb *MenuBar) titleAt(x int) int {
+	for i := range mb.Menus {
+		if x >= mb.titleX(i) && x < mb.titleX(i+1) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Draw renders the titles, highlighting the selected one while the bar is
+// focused, and the open menu.
+func (mb *MenuBar) Draw(p *core.Painter) {
+	tm := theme.Get()
+	ds := color.DynamicStyle{
+		FG: color.Solid(tm.GetSemanticColor("text.primary")),
+		BG: color.Solid(tm.GetSemanticColor("bg.surface")),
+	}
+	p.FillDynamic(core.Rect{X: mb.Rect.X, Y: mb.Rect.Y, W: mb.Rect.W, H: 1}, ' ', ds)
+	for i, m := range mb.Menus {
+		title := ds
+		if i == mb.selected && (mb.IsFocused() || mb.menu.IsOpen()) {
+			title.Attrs |= tcell.AttrReverse
+		}
+		x := mb.titleX(i)
+		if x >= mb.Rect.X+mb.Rect.W {
+			break
+		}
+		p.DrawDynamicText(x, mb.Rect.Y, " "+m.Label+" ", title)
+	}
+	mb.menu.Draw(p)
+}
+
+// HandleKey selects and opens menus, and passes keys to the open menu.
+func (mb *MenuBar) HandleKey(ev *tcell.EventKey) bool {
+	if len(mb.Menus) == 0 {
+		return false
+	}
+	if mb.menu.IsOpen() {
+		// Left and Right move between menus unless a submenu takes them
+		switch ev.Key() {
+		case tcell.KeyLeft:
+			if !mb.menu.subOpen() {
+				mb.Open((mb.selected + len(mb.Menus) - 1) % len(mb.Menus))
+				return true
+			}
+		case tcell.KeyRight:
+			if !mb.menu.subOpen() && !mb.menu.hasSubmenu(mb.menu.selected) {
+				mb.Open((mb.selected + 1) % len(mb.Menus))
+				return true
+			}
+		}
+		return mb.menu.HandleKey(ev)
+	}
+	switch ev.Key() {
+	case tcell.KeyLeft:
+		mb.selected = (mb.selected + len(mb.Menus) - 1) % len(mb.Menus)
+	case tcell.KeyRight:
+		mb.selected = (mb.selected + 1) % len(mb.Menus)
+	case tcell.KeyHome:
+		mb.selected = 0
+	case tcell.KeyEnd:
+		mb.selected = len(mb.Menus) - 1
+	case tcell.KeyEnter, tcell.KeyDown:
+		mb.Open(mb.selected)
+	case tcell.KeyRune:
+		if ev.Rune() != ' ' {
+			return false
+		}
+		mb.Open(mb.selected)
+	default:
+		return false
+	}
+	mb.invalidate()
+	return true
+}
+
+// HandleMouse opens the menu of a clicked title, or closes it when it is
+// already open, and passes events over the open menu to it.
+func (mb *MenuBar) HandleMouse(ev *tcell.EventMouse) bool {
+	x, y := ev.Position()
+	if mb.menu.IsOpen() && mb.menu.HitTest(x, y) {
+		return mb.menu.HandleMouse(ev)
+	}
+	if ev.Buttons()&tcell.Button1 == 0 {
+		return mb.HitTest(x, y)
+	}
+	idx := -1
+	if mb.Rect.Contains(x, y) {
+		idx = mb.titleAt(x)
+	}
+	switch {
+	case idx < 0:
+		mb.menu.Close()
+		return mb.Rect.Contains(x, y)
+	case mb.menu.IsOpen() && idx == mb.selected:
+		mb.menu.Close()
+	default:
+		mb.Open(idx)
+	}
+	return true
+}
+
+// HitTest reports whether x, y is on the bar or its open menu.
+func (mb *MenuBar) HitTest(x, y int) bool {
+	return mb.Rect.Contains(x, y) || mb.menu.IsOpen() && mb.menu.HitTest(x, y)
+}
+
+// IsModal implements core.Modal.
+func (mb *MenuBar) IsModal() bool {
+	return mb.menu.IsOpen()
+}
+
+// DismissModal implements core.Modal; a click outside closes the menu.
+func (mb *MenuBar) DismissModal() {
+	mb.menu.Close()
+}
+
+// ZIndex keeps the open menu above the bar's siblings.
+func (mb *MenuBar) ZIndex() int {
+	if mb.menu.IsOpen() {
+		return 100
+	}
+	return 0
+}
+
+// Blur closes the open menu.
+func (mb *MenuBar) Blur() {
+	mb.menu.Close()
+	mb.BaseWidget.Blur()
+	mb.invalidate()
+}
+
+// SetSurfaceSize implements core.SurfaceAware so menus stay on screen.
+func (mb *MenuBar) SetSurfaceSize(w, h int) {
+	mb.menu.SetSurfaceSize(w, h)
+}
+
+// SetInvalidator implements core.InvalidationAware.
+func (mb *MenuBar) SetInvalidator(fn func(core.Rect)) {
+	mb.inv = fn
+	mb.menu.SetInvalidator(fn)
+}
+
+// GetKeyHints implements core.KeyHintsProvider.
+func (mb *MenuBar) GetKeyHints() []core.KeyHint {
+	if mb.menu.IsOpen() {
+		return mb.menu.GetKeyHints()
+	}
+	return []core.KeyHint{
+		{Key: "←→", Label: "Select"},
+		{Key: "Enter", Label: "Open"},
+	}
+}
+
+// invalidate marks the bar as needing redraw.
+func (mb *MenuBar) invalidate() {
+	if mb.inv != nil {
+		mb.inv(mb.Rect)
+	}
+}
@@ -0,0 +1,92 @@
+package widgets
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestMenuBar_KeyboardAndSubmenus(t *testing.T) {
+	var ran string
+	run := func(name string) func() { return func() { ran = name } }
+	mb := NewMenuBar([]Menu{
+		{Label: "File", Items: []MenuItem{
+			{Label: "Open", Action: run("open")},
+			{Label: "Recent", Items: []MenuItem{
+				{Label: "a.txt", Action: run("a")},
+				{Label: "b.txt", Action: run("b")},
+			}},
+		}},
+		{Label: "Help", Items: []MenuItem{{Label: "About", Action: run("about")}}},
+	})
+	mb.Resize(40, 1)
+	mb.Focus()
+	key := func(k tcell.Key) { mb.HandleKey(tcell.NewEventKey(k, 0, tcell.ModNone)) }
+
+	if w, _ := NewMenuBar(mb.Menus).Size(); w != 12 {
+		t.Errorf("new bar should fit its titles, got width %d", w)
+	}
+
+	key(tcell.KeyEnter)
+	if !mb.IsOpen() || !mb.IsModal() || mb.menu.Rect.Y != 1 {
+		t.Fatal("Enter should open the File menu below the bar")
+	}
+	key(tcell.KeyRight) // Open is selected: moves to Help
+	if mb.selected != 1 || mb.menu.Rect.X != 6 {
+		t.Fatalf("Right should open Help at column 6, got menu %d at %d", mb.selected, mb.menu.Rect.X)
+	}
+	key(tcell.KeyLeft)
+	key(tcell.KeyDown) // Recent
+	key(tcell.KeyRight)
+	if !mb.menu.subOpen() {
+		t.Fatal("Right on Recent should open its submenu")
+	}
+	key(tcell.KeyLeft)
+	if mb.menu.subOpen() || !mb.IsOpen() || mb.selected != 0 {
+		t.Fatal("Left should close the submenu only")
+	}
+	key(tcell.KeyEnter)
+	key(tcell.KeyDown)
+	key(tcell.KeyEnter)
+	if ran != "b" || mb.IsOpen() {
+		t.Errorf("Enter in the submenu should run b.txt and close the menus, ran %q", ran)
+	}
+	if !mb.IsFocused() {
+		t.Error("the bar should keep focus after running an item")
+	}
+}
+
+func TestMenuBar_Mouse(t *testing.T) {
+	ran := ""
+	mb := NewMenuBar([]Menu{
+		{Label: "File", Items: []MenuItem{{Label: "Quit", Action: func() { ran = "quit" }}}},
+		{Label: "Help", Items: []MenuItem{{Label: "About", Action: func() { ran = "about" }}}},
+	})
+	mb.Resize(40, 1)
+	click := func(x, y int) {
+		mb.HandleMouse(tcell.NewEventMouse(x, y, tcell.Button1, tcell.ModNone))
+		mb.HandleMouse(tcell.NewEventMouse(x, y, tcell.ButtonNone, tcell.ModNone))
+	}
+
+	click(7, 0) // Help
+	if !mb.IsOpen() || mb.selected != 1 {
+		t.Fatal("clicking Help should open it")
+	}
+	if !mb.HitTest(8, 2) {
+		t.Error("the open menu should hit-test as part of the bar")
+	}
+	click(7, 0)
+	if mb.IsOpen() {
+		t.Fatal("clicking the open title should close its menu")
+	}
+	click(1, 0)
+	click(3, 2)
+	if ran != "quit" || mb.IsOpen() {
+		t.Errorf("clicking Quit should run it and close, ran %q", ran)
+	}
+	click(1, 0)
+	mb.DismissModal()
+	if mb.IsOpen() {
+		t.Error("DismissModal should close the menu")
+	}
+}
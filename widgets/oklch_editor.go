@@ -8,6 +8,9 @@ package widgets
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"unicode"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/framegrace/texelui/theme"
@@ -33,8 +36,16 @@ type OKLCHEditor struct {
 	hcPlane  *primitives.HCPlane
 	lSlider  *primitives.LightnessSlider
 
+	// Steps for the +/- keys
+	LStep float64 // Lightness step (default 0.01)
+	CStep float64 // Chroma step (default 0.01)
+	HStep float64 // Hue step in degrees (default 1)
+
 	// State
-	focus OKLCHEditorFocus
+	focus     OKLCHEditorFocus
+	channel   rune   // Channel adjusted by +/- ('l', 'c' or 'h'); 0 follows focus
+	entering  bool   // Typing a value for channel
+	entryText string // Value typed so far
 
 	// Callbacks
 	OnChange func(tcell.Color) // Called when the color changes
@@ -48,6 +59,9 @@ type OKLCHEditor struct {
 func NewOKLCHEditor() *OKLCHEditor {
 	oe := &OKLCHEditor{
 		focus: OKLCHFocusPlane,
+		LStep: 0.01,
+		CStep: 0.01,
+		HStep: 1,
 	}
 	oe.SetPosition(0, 0)
 	oe.Resize(25, 10) // Minimum usable size
@@ -164,6 +178,7 @@ func (oe *OKLCHEditor) Blur() {
 
 // updateChildFocus updates which child has focus.
 func (oe *OKLCHEditor) updateChildFocus() {
+	oe.channel = 0 // +/- follow the focused child again
 	oe.hcPlane.Blur()
 	oe.lSlider.Blur()
 
@@ -228,9 +243,31 @@ func (oe *OKLCHEditor) drawPreview(p *core.Painter, x, y int, baseStyle tcell.St
 	p.SetCell(x, y, ']', baseStyle)
 	x += 2
 
-	// Draw OKLCH values
-	preview := fmt.Sprintf("L:%.2f C:%.2f H:%.0f°", l, c, h)
-	p.DrawText(x, y, preview, baseStyle)
+	// Draw OKLCH values, the adjusted channel highlighted
+	accent := baseStyle.Foreground(theme.Get().GetSemanticColor("accent"))
+	active := oe.activeChannel()
+	for _, f := range []struct {
+		ch    rune
+		label string
+		value string
+	}{
+		{'l', "L:", fmt.Sprintf("%.2f", l)},
+		{'c', "C:", fmt.Sprintf("%.2f", c)},
+		{'h', "H:", fmt.Sprintf("%.0f°", h)},
+	} {
+		style := baseStyle
+		value := f.value
+		if f.ch == active {
+			style = accent
+			if oe.entering {
+				value = oe.entryText + "_"
+				style = style.Underline(true)
+			}
+		}
+		text := f.label + value
+		p.DrawText(x, y, text, style)
+		x += len([]rune(text)) + 1
+	}
 
 	// Second line: RGB values
 	y++
@@ -248,6 +285,32 @@ func (oe *OKLCHEditor) HandleKey(ev *tcell.EventKey) bool {
 		return false
 	}
 
+	if oe.entering {
+		if oe.handleEntryKey(ev) {
+			oe.invalidate()
+			return true
+		}
+		// Any other key cancels the entry and is handled normally
+		oe.cancelEntry()
+	}
+
+	if ev.Key() == tcell.KeyRune {
+		switch r := ev.Rune(); r {
+		case 'l', 'L', 'c', 'C', 'h', 'H':
+			oe.channel = unicode.ToLower(r)
+			oe.entering = true
+			oe.entryText = ""
+			oe.invalidate()
+			return true
+		case '+', '=':
+			oe.step(1)
+			return true
+		case '-', '_':
+			oe.step(-1)
+			return true
+		}
+	}
+
 	// Route to focused child
 	var handled bool
 	switch oe.focus {
@@ -263,6 +326,96 @@ func (oe *OKLCHEditor) HandleKey(ev *tcell.EventKey) bool {
 	return handled
 }
 
+// IsEntering reports whether a value is being typed for a channel.
+// Esc then cancels the entry rather than closing an enclosing picker.
+func (oe *OKLCHEditor) IsEntering() bool {
+	return oe.entering
+}
+
+// activeChannel returns the channel adjusted by +/-: the one last picked
+// with l/c/h, or else lightness on the slider and hue on the plane.
+func (oe *OKLCHEditor) activeChannel() rune {
+	if oe.channel != 0 {
+		return oe.channel
+	}
+	if oe.focus == OKLCHFocusSlider {
+		return 'l'
+	}
+	return 'h'
+}
+
+// handleEntryKey edits the typed value: digits and '.' append, Backspace
+// deletes, Enter applies and Esc cancels. It returns false for other keys.
+func (oe *OKLCHEditor) handleEntryKey(ev *tcell.EventKey) bool {
+	switch ev.Key() {
+	case tcell.KeyRune:
+		if r := ev.Rune(); r >= '0' && r <= '9' || r == '.' {
+			oe.entryText += string(r)
+			return true
+		}
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if n := len(oe.entryText); n > 0 {
+			oe.entryText = oe.entryText[:n-1]
+		}
+		return true
+	case tcell.KeyEnter:
+		// Consume Enter even for an empty or invalid entry, so it never
+		// commits an enclosing picker
+		if v, err := strconv.ParseFloat(oe.entryText, 64); err == nil {
+			oe.setChannel(oe.channel, v)
+		}
+		oe.cancelEntry()
+		return true
+	case tcell.KeyEsc:
+		oe.cancelEntry()
+		return true
+	}
+	return false
+}
+
+// cancelEntry leaves numeric entry mode.
+func (oe *OKLCHEditor) cancelEntry() {
+	oe.entering = false
+	oe.entryText = ""
+	oe.invalidate()
+}
+
+// step moves the active channel by dir times its step.
+func (oe *OKLCHEditor) step(dir float64) {
+	switch ch := oe.activeChannel(); ch {
+	case 'l':
+		oe.setChannel(ch, oe.lSlider.L+dir*oe.LStep)
+	case 'c':
+		oe.setChannel(ch, oe.hcPlane.C+dir*oe.CStep)
+	case 'h':
+		oe.setChannel(ch, oe.hcPlane.H+dir*oe.HStep)
+	}
+}
+
+// setChannel sets one OKLCH channel, clamping L to 0-1 and C to 0-0.4 and
+// wrapping H into 0-360, and notifies OnChange.
+func (oe *OKLCHEditor) setChannel(ch rune, v float64) {
+	h, c := oe.hcPlane.H, oe.hcPlane.C
+	switch ch {
+	case 'l':
+		// The slider's OnChange syncs the plane and notifies
+		oe.lSlider.SetLightness(v)
+		oe.invalidate()
+		return
+	case 'c':
+		c = math.Max(0, math.Min(0.4, v))
+	case 'h':
+		h = math.Mod(v, 360)
+		if h < 0 {
+			h += 360
+		}
+	}
+	oe.hcPlane.SetHC(h, c)
+	oe.lSlider.SetHC(h, c)
+	oe.notifyChange()
+	oe.invalidate()
+}
+
 // HandleMouse processes mouse input.
 func (oe *OKLCHEditor) HandleMouse(ev *tcell.EventMouse) bool {
 	x, y := ev.Position()
@@ -366,6 +519,8 @@ func (oe *OKLCHEditor) notifyChange() {
 
 // ResetFocus resets focus to the first element (HCPlane).
 func (oe *OKLCHEditor) ResetFocus() {
+	oe.entering = false
+	oe.entryText = ""
 	oe.focus = OKLCHFocusPlane
 	oe.updateChildFocus()
 }
@@ -4,6 +4,7 @@
 package widgets
 
 import (
+	"math"
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
@@ -204,3 +205,68 @@ func TestOKLCHEditor_VisitChildren(t *testing.T) {
 		t.Errorf("VisitChildren found %d children, want 2", count)
 	}
 }
+
+func TestOKLCHEditor_NumericEntryAndSteps(t *testing.T) {
+	oe := NewOKLCHEditor()
+	oe.Resize(30, 14)
+	changes := 0
+	oe.OnChange = func(tcell.Color) { changes++ }
+
+	typeKeys := func(s string) {
+		for _, r := range s {
+			oe.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		}
+	}
+	enter := tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone)
+
+	// l, then a value and Enter sets the lightness exactly
+	typeKeys("l0.42")
+	if !oe.IsEntering() {
+		t.Fatal("expected numeric entry after 'l'")
+	}
+	if !oe.HandleKey(enter) {
+		t.Fatal("Enter should be consumed by the entry")
+	}
+	if oe.IsEntering() || oe.lSlider.L != 0.42 || oe.hcPlane.L != 0.42 {
+		t.Errorf("after entry: entering=%v L=%v plane L=%v, want 0.42", oe.IsEntering(), oe.lSlider.L, oe.hcPlane.L)
+	}
+
+	// Values are clamped (chroma) and wrapped (hue)
+	typeKeys("c0.9")
+	oe.HandleKey(enter)
+	typeKeys("h365")
+	oe.HandleKey(enter)
+	if oe.hcPlane.C != 0.4 || oe.hcPlane.H != 5 || oe.lSlider.C != 0.4 || oe.lSlider.H != 5 {
+		t.Errorf("C=%v H=%v (slider %v, %v), want 0.4 and 5", oe.hcPlane.C, oe.hcPlane.H, oe.lSlider.C, oe.lSlider.H)
+	}
+
+	// +/- step the channel picked last
+	typeKeys("--")
+	if oe.hcPlane.H != 3 {
+		t.Errorf("H after two '-' = %v, want 3", oe.hcPlane.H)
+	}
+	oe.HStep = 10
+	typeKeys("h")
+	oe.HandleKey(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+	typeKeys("-")
+	if oe.hcPlane.H != 353 {
+		t.Errorf("H after '-' with step 10 = %v, want 353", oe.hcPlane.H)
+	}
+
+	// Esc cancels an entry without changing the value
+	typeKeys("c0.1")
+	oe.HandleKey(tcell.NewEventKey(tcell.KeyEsc, 0, tcell.ModNone))
+	if oe.IsEntering() || oe.hcPlane.C != 0.4 {
+		t.Errorf("after Esc: entering=%v C=%v, want false and 0.4", oe.IsEntering(), oe.hcPlane.C)
+	}
+
+	// Without a picked channel, +/- follow the focused child
+	oe.CycleFocus(true)
+	typeKeys("+")
+	if math.Abs(oe.lSlider.L-0.43) > 1e-9 {
+		t.Errorf("L after '+' on the slider = %v, want 0.43", oe.lSlider.L)
+	}
+	if changes == 0 {
+		t.Error("OnChange was not called")
+	}
+}
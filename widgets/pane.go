@@ -32,19 +32,27 @@ func NewPane() *Pane {
 	p.Resize(1, 1)
 
 	// Get default colors from theme
+	p.applyTheme()
+	return p
+}
+
+// applyTheme resolves the default and focused styles from the current theme.
+func (p *Pane) applyTheme() {
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("text.primary")
 	bg := tm.GetSemanticColor("bg.surface")
-	p.Style = color.DynamicStyle{
+	p.SetThemeStyle(&p.Style, color.DynamicStyle{
 		FG: color.Solid(fg),
 		BG: color.Solid(bg),
-	}
+	})
 
 	// Configure focus style
 	p.SetFocusedStyle(tcell.StyleDefault.Background(bg).Foreground(fg), true)
-	return p
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (p *Pane) OnThemeChanged() { p.applyTheme() }
+
 // SetTrapsFocus sets whether this pane wraps focus at boundaries.
 // Set to true for root containers that should cycle focus internally.
 func (p *Pane) SetTrapsFocus(trap bool) {
@@ -59,6 +67,7 @@ func (p *Pane) TrapsFocus() bool {
 // AddChild adds a child widget to this pane.
 func (p *Pane) AddChild(w core.Widget) {
 	p.children = append(p.children, w)
+	core.InvalidateCascade()
 	// Propagate invalidator if set
 	if p.inv != nil {
 		if ia, ok := w.(core.InvalidationAware); ok {
@@ -89,7 +98,7 @@ func (p *Pane) SetInvalidator(fn func(core.Rect)) {
 
 // Draw fills the pane background and draws all children sorted by z-index.
 func (p *Pane) Draw(painter *core.Painter) {
-	ds := p.Style
+	ds := p.EffectiveDynamic(p.Style)
 	if p.IsFocused() {
 		ds.Attrs |= tcell.AttrBold
 	}
@@ -114,6 +123,9 @@ func (p *Pane) Draw(painter *core.Painter) {
 	// Draw children
 	for _, child := range sorted {
 		child.Draw(painter)
+		if core.IsDisabled(child) {
+			muteWidget(painter, child)
+		}
 	}
 }
 
@@ -134,6 +146,9 @@ func (p *Pane) DrawChildren(painter *core.Painter) {
 	})
 	for _, child := range sorted {
 		child.Draw(painter)
+		if core.IsDisabled(child) {
+			muteWidget(painter, child)
+		}
 	}
 }
 
@@ -365,6 +380,10 @@ func (p *Pane) HandleMouse(ev *tcell.EventMouse) bool {
 	// Check children in Z-order (highest first)
 	for _, child := range sorted {
 		if child.HitTest(x, y) {
+			// Disabled children take no clicks, but still cover what's below
+			if core.IsDisabled(child) {
+				return !isWheel
+			}
 			// Focus the clicked widget on button press
 			if isPress && child.Focusable() {
 				// Blur currently focused widget
@@ -0,0 +1,114 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/progressbar.go
+// Summary: Determinate progress bar with optional label and percentage.
+
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+)
+
+// minProgressBarW is the narrowest bar drawn next to a label.
+const minProgressBarW = 4
+
+// ProgressBar shows determinate progress as "label ████▌     42%", the bar
+// filling the width left by the label and the percentage. It is not
+// focusable.
+type ProgressBar struct {
+	core.BaseWidget
+	Label    string
+	Fraction float64 // Progress in [0, 1]
+
+	inv func(core.Rect)
+}
+
+// NewProgressBar creates a progress bar with the given label at 0%.
+// Position defaults to 0,0 and size to 20x1.
+// Use SetPosition and Resize to adjust after adding to a layout.
+func NewProgressBar(label string) *ProgressBar {
+	pb := &ProgressBar{Label: label}
+	pb.Resize(20, 1)
+	pb.SetFocusable(false)
+	return pb
+}
+
+// SetFraction sets the progress, clamped to [0, 1].
+func (pb *ProgressBar) SetFraction(fraction float64) {
+	fraction = min(max(fraction, 0), 1)
+	if pb.Fraction == fraction {
+		return
+	}
+	pb.Fraction = fraction
+	pb.invalidate()
+}
+
+// SetLabel sets the text shown before the bar.
+func (pb *ProgressBar) SetLabel(label string) {
+	if pb.Label == label {
+		return
+	}
+	pb.Label = label
+	pb.invalidate()
+}
+
+// Draw renders the label, the bar and the percentage on the middle row.
+func (pb *ProgressBar) Draw(p *core.Painter) {
+	tm := theme.Get()
+	textDS := color.DynamicStyle{
+		FG: color.Solid(tm.GetSemanticColor("text.primary")),
+		BG: color.Solid(tm.GetSemanticColor("bg.surface")),
+	}
+	p.FillDynamic(pb.Rect, ' ', textDS)
+
+	x, y := pb.Rect.X, pb.Rect.Y+pb.Rect.H/2
+	barW := pb.Rect.W - 5 // Leave room for " 100%"
+	// The label goes first when space is short
+	if labelW := len([]rune(pb.Label)) + 1; pb.Label != "" && barW-labelW >= minProgressBarW {
+		p.DrawDynamicText(x, y, pb.Label, textDS)
+		x += labelW
+		barW -= labelW
+	}
+	if barW <= 0 {
+		return
+	}
+	drawProgressCells(p, x, y, barW, pb.Fraction)
+	p.DrawDynamicText(x+barW, y, fmt.Sprintf(" %3d%%", int(pb.Fraction*100+0.5)), textDS)
+}
+
+// drawProgressCells draws a w-cell bar filled to fraction in eighth-cell
+// steps.
+func drawProgressCells(p *core.Painter, x, y, w int, fraction float64) {
+	tm := theme.Get()
+	barDS := color.DynamicStyle{
+		FG: color.Solid(tm.GetSemanticColor("accent")),
+		BG: color.Solid(tm.GetSemanticColor("bg.mantle")),
+	}
+	eighths := int(fraction*float64(w)*8 + 0.5)
+	partial := []rune(" ▏▎▍▌▋▊▉")
+	for i := 0; i < w; i++ {
+		ch := ' '
+		switch n := eighths - i*8; {
+		case n >= 8:
+			ch = '█'
+		case n > 0:
+			ch = partial[n]
+		}
+		p.SetDynamicCell(x+i, y, ch, barDS)
+	}
+}
+
+// SetInvalidator allows the UI manager to inject a dirty-region invalidator.
+func (pb *ProgressBar) SetInvalidator(fn func(core.Rect)) { pb.inv = fn }
+
+// invalidate marks the widget as needing redraw.
+func (pb *ProgressBar) invalidate() {
+	if pb.inv != nil {
+		pb.inv(pb.Rect)
+	}
+}
@@ -0,0 +1,62 @@
+package widgets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/framegrace/texelui/core"
+)
+
+func TestProgressBar_Render(t *testing.T) {
+	pb := NewProgressBar("Copy")
+	pb.Resize(20, 1)
+	pb.SetFraction(0.5)
+
+	buf := createTestBuffer(20, 1)
+	pb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 20, H: 1}))
+
+	var row strings.Builder
+	for _, cell := range buf[0] {
+		row.WriteRune(cell.Ch)
+	}
+	// "Copy " + 10-cell bar + "  50%"
+	if got, want := row.String(), "Copy █████       50%"; got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+}
+
+func TestProgressBar_SetFractionClamps(t *testing.T) {
+	pb := NewProgressBar("")
+	invalidated := 0
+	pb.SetInvalidator(func(core.Rect) { invalidated++ })
+
+	pb.SetFraction(1.5)
+	if pb.Fraction != 1 {
+		t.Errorf("Fraction = %v, want 1", pb.Fraction)
+	}
+	pb.SetFraction(-1)
+	if pb.Fraction != 0 {
+		t.Errorf("Fraction = %v, want 0", pb.Fraction)
+	}
+	pb.SetFraction(0)
+	if invalidated != 2 {
+		t.Errorf("invalidated %d times, want 2 (no redraw without a change)", invalidated)
+	}
+}
+
+func TestProgressBar_DropsLabelWhenNarrow(t *testing.T) {
+	pb := NewProgressBar("Copying files")
+	pb.Resize(12, 1)
+	pb.SetFraction(1)
+
+	buf := createTestBuffer(12, 1)
+	pb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 12, H: 1}))
+
+	var row strings.Builder
+	for _, cell := range buf[0] {
+		row.WriteRune(cell.Ch)
+	}
+	if got, want := row.String(), "███████ 100%"; got != want {
+		t.Errorf("row = %q, want %q", got, want)
+	}
+}
@@ -7,6 +7,7 @@
 package widgets
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -31,21 +32,45 @@ type TimedMessage struct {
 	Text      string
 	Level     MessageLevel
 	ExpiresAt time.Time
+	Action    *MessageAction // Optional action offered with the message
+	Source    string         // Optional subsystem tag (see ShowFrom)
 }
 
-// StatusBar displays key hints (left) and timed messages (right).
+// StatusBar displays key hints (left) and timed messages (right). When given
+// more than one content row (see UIManager.SetStatusBarHeight), messages are
+// word-wrapped on the rows below the hints instead.
 // It implements FocusObserver to automatically update key hints when focus changes.
 // IMPORTANT: Call Stop() before discarding a StatusBar to prevent goroutine leaks.
 type StatusBar struct {
 	core.BaseWidget
 
-	mu            sync.Mutex
-	leftText      string         // Current key hints (formatted)
-	leftWidgets   []core.Widget  // Child widgets for left side (overrides leftText)
-	messages      []TimedMessage // Message queue, highest priority shown
-	focusedWidget core.Widget    // Currently focused widget for hint extraction
-	hoverHelp     string         // Currently displayed hover help text (empty = none)
-	hintText      string         // Persistent hint text (shown on right when no hover help or message)
+	mu             sync.Mutex
+	leftText       string                  // Current key hints (formatted)
+	hints          []core.KeyHint          // Current key hints, before fitting to the width
+	leftWidgets    []core.Widget           // Child widgets for left side (overrides leftText)
+	messages       []TimedMessage          // Message queue, highest priority shown
+	focusedWidget  core.Widget             // Currently focused widget for hint extraction
+	hoverHelp      string                  // Currently displayed hover help text (empty = none)
+	hintText       string                  // Persistent hint text (shown on right when no hover help or message)
+	focusHelp      string                  // Help text of the focused widget (shown before hintText)
+	segments       []StatusSegment         // App-supplied segments (see AddSegment)
+	segWidgets     []core.Widget           // Widgets of the segments placed by the last draw
+	progress       *statusProgress         // Progress bar segment (nil = hidden)
+	infos          []*statusInfo           // Periodically refreshed info providers
+	clockFormat    string                  // strftime-style clock format (empty = no clock)
+	clockShown     string                  // Clock text drawn last, to detect changes
+	tasks          []string                // Running background tasks (see BeginTask)
+	pendingKeys    string                  // Unfinished key sequence (see SetPendingKeys)
+	pendingUntil   time.Time               // When pendingKeys times out
+	msgRect        core.Rect               // Where the right-hand text was drawn last
+	actionRect     core.Rect               // Where the message action was drawn last
+	msgHovered     bool                    // Mouse is over the message (pauses expiry)
+	mouseDown      bool                    // Button 1 is held (press edges only count as clicks)
+	prompt         *statusPrompt           // Open prompt replacing hints and messages (nil = none)
+	commandPrompt  *PromptOptions          // ":" prompt opened by unhandled keys (nil = disabled)
+	history        map[string][]string     // Prompt history by prefix
+	sourceMinLevel map[string]MessageLevel // Lowest level shown per message source
+	sourcePriority map[string]int          // Tie-break priority per message source
 
 	inv      func(core.Rect)
 	ticker   *time.Ticker
@@ -56,6 +81,12 @@ type StatusBar struct {
 	// DefaultMessageDuration is the default duration for messages
 	DefaultMessageDuration time.Duration
 
+	// OnMoreHints, if set, is offered when key hints had to be dropped for
+	// lack of space: an "F1:More…" hint is shown and F1 (when no widget
+	// handles it) calls OnMoreHints with the full list, e.g. to open a
+	// help overlay.
+	OnMoreHints func(hints []core.KeyHint)
+
 	// ShowSeparator controls whether the top separator line is drawn.
 	// When false, the status bar is 1 row (content only).
 	ShowSeparator bool
@@ -78,7 +109,8 @@ func NewStatusBar() *StatusBar {
 	return sb
 }
 
-// Start begins the background ticker for message expiration.
+// Start begins the background ticker for message expiration, the clock
+// and info provider refreshes.
 // Should be called after the status bar is added to UIManager.
 func (s *StatusBar) Start() {
 	s.mu.Lock()
@@ -96,14 +128,25 @@ func (s *StatusBar) Start() {
 			case <-s.stopCh:
 				return
 			case <-ticker.C:
-				if s.expireMessages() {
-					s.invalidate()
-				}
+				s.Tick()
 			}
 		}
 	}()
 }
 
+// Tick does the work of one ticker step now: it expires messages and a
+// timed-out key sequence indicator, refreshes the clock and info providers
+// that are due, and redraws if anything changed or a task spinner is
+// running. Tests driving core's clock call it instead of Start.
+func (s *StatusBar) Tick() {
+	now := core.Now()
+	expired := s.expireMessages()
+	expired = s.expirePendingKeys(now) || expired
+	if s.refreshInfo(now) || expired || (s.busy() && !core.ReduceMotion()) {
+		s.invalidate()
+	}
+}
+
 // Stop stops the background ticker.
 // Must be called before discarding a StatusBar to prevent goroutine leaks.
 // Safe to call multiple times.
@@ -146,6 +189,7 @@ func (s *StatusBar) OnFocusChanged(focused core.Widget) {
 func (s *StatusBar) updateKeyHintsLocked() {
 	if s.focusedWidget == nil {
 		s.leftText = ""
+		s.focusHelp = ""
 		return
 	}
 
@@ -155,26 +199,59 @@ func (s *StatusBar) updateKeyHintsLocked() {
 		deepWidget = s.focusedWidget
 	}
 
-	// Get widget's own key hints
-	var hints []core.KeyHint
-	if khp, ok := deepWidget.(core.KeyHintsProvider); ok {
-		hints = khp.GetKeyHints()
+	// Show the focused widget's help text (e.g., Form row help)
+	s.focusHelp = ""
+	if hp, ok := deepWidget.(core.HelpTextProvider); ok {
+		s.focusHelp = hp.HelpText()
+	}
+
+	// Merge the hints of the focused widget and its focused ancestors,
+	// innermost first so nested containers don't repeat keys
+	var lists [][]core.KeyHint
+	for w := s.focusedWidget; w != nil; w = focusedChild(w) {
+		if khp, ok := w.(core.KeyHintsProvider); ok {
+			lists = append([][]core.KeyHint{khp.GetKeyHints()}, lists...)
+		}
+		if w == deepWidget {
+			break
+		}
 	}
+	hints := core.MergeKeyHints(lists...)
 
 	// Add focus navigation hints if inside a focus cycler
 	// Skip hints for keys the widget already defines (avoid duplicates like Tab:Content + Tab:Next)
 	if s.hasFocusCycling() {
 		if !hasKeyHint(hints, "Tab") {
-			hints = append(hints, core.KeyHint{Key: "Tab", Label: "Next"})
+			hints = append(hints, core.KeyHint{Key: "Tab", Label: "Next", Priority: -1})
 		}
 		if !hasKeyHint(hints, "S-Tab") {
-			hints = append(hints, core.KeyHint{Key: "S-Tab", Label: "Prev"})
+			hints = append(hints, core.KeyHint{Key: "S-Tab", Label: "Prev", Priority: -2})
 		}
 	}
 
+	s.hints = hints
 	s.leftText = core.FormatKeyHints(hints)
 }
 
+// focusedChild returns the child of w that is or contains the focused
+// widget, or nil if there is none.
+func focusedChild(w core.Widget) core.Widget {
+	cc, ok := w.(core.ChildContainer)
+	if !ok {
+		return nil
+	}
+	var found core.Widget
+	cc.VisitChildren(func(child core.Widget) {
+		if found != nil || child == w {
+			return
+		}
+		if fs, ok := child.(core.FocusState); ok && fs.IsFocused() || core.IsDescendantFocused(child) {
+			found = child
+		}
+	})
+	return found
+}
+
 // hasKeyHint checks if hints already contains a hint for the given key.
 func hasKeyHint(hints []core.KeyHint, key string) bool {
 	for _, h := range hints {
@@ -247,18 +324,29 @@ func (s *StatusBar) ShowErrorWithDuration(text string, duration time.Duration) {
 
 // addMessage adds a message to the queue.
 func (s *StatusBar) addMessage(text string, level MessageLevel, duration time.Duration) {
-	s.mu.Lock()
-
-	msg := TimedMessage{
+	s.pushMessage(TimedMessage{
 		Text:      text,
 		Level:     level,
-		ExpiresAt: time.Now().Add(duration),
+		ExpiresAt: core.Now().Add(duration),
+	})
+}
+
+// pushMessage adds a prepared message to the queue.
+func (s *StatusBar) pushMessage(msg TimedMessage) {
+	s.mu.Lock()
+
+	if msg.Level < s.sourceMinLevel[msg.Source] {
+		// Filtered or muted source
+		s.mu.Unlock()
+		return
 	}
 
 	// Limit queue size to prevent memory growth
 	if len(s.messages) >= 10 {
-		// Remove oldest message
-		s.messages = s.messages[1:]
+		// Remove the oldest of the lowest-ranked messages, so chatty
+		// sources can't push out more important ones
+		i := s.lowestRankedLocked()
+		s.messages = append(s.messages[:i], s.messages[i+1:]...)
 	}
 
 	s.messages = append(s.messages, msg)
@@ -273,9 +361,11 @@ func (s *StatusBar) expireMessages() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	now := time.Now()
+	now := core.Now()
 	originalLen := len(s.messages)
 
+	s.extendHoveredLocked(now)
+
 	// Filter out expired messages
 	filtered := s.messages[:0]
 	for _, msg := range s.messages {
@@ -288,16 +378,17 @@ func (s *StatusBar) expireMessages() bool {
 	return len(s.messages) != originalLen
 }
 
-// getActiveMessage returns the highest priority non-expired message.
+// getActiveMessage returns the highest priority non-expired message: the
+// highest level, then the highest source priority, then the oldest.
 // Returns nil if no messages are active.
 func (s *StatusBar) getActiveMessage() *TimedMessage {
-	now := time.Now()
+	now := core.Now()
 	var best *TimedMessage
 
 	for i := range s.messages {
 		msg := &s.messages[i]
 		if msg.ExpiresAt.After(now) {
-			if best == nil || msg.Level > best.Level {
+			if best == nil || s.outranksLocked(msg, best) {
 				best = msg
 			}
 		}
@@ -324,11 +415,52 @@ func (s *StatusBar) Draw(p *core.Painter) {
 		}
 		contentY++
 	}
-	for x := 0; x < s.Rect.W; x++ {
-		p.SetDynamicCell(s.Rect.X+x, contentY, ' ', bgDS)
+	// With more than one content row, messages are stacked below the hints
+	rows := max(s.Rect.Y+s.Rect.H-contentY, 1)
+	stacked := rows > 1
+	p.FillDynamic(core.Rect{X: s.Rect.X, Y: contentY, W: s.Rect.W, H: rows}, ' ', bgDS)
+
+	// An open prompt takes over the content row
+	s.mu.Lock()
+	pr := s.prompt
+	if pr != nil {
+		s.msgRect, s.actionRect = core.Rect{}, core.Rect{}
+		s.segWidgets = s.segWidgets[:0]
+	}
+	s.mu.Unlock()
+	if pr != nil {
+		s.drawPrompt(p, pr, contentY)
+		return
 	}
 
+	// Segments claim their space first; hints and messages share the rest.
+	// The task spinner and progress bar lead the right-hand group; info
+	// providers and the clock end it. A pending key sequence leads the left.
+	now := core.Now()
 	s.mu.Lock()
+	segs := make([]StatusSegment, 0, len(s.segments)+len(s.infos)+4)
+	if s.pendingKeys != "" && now.Before(s.pendingUntil) {
+		segs = append(segs, s.pendingKeysSegmentLocked())
+	}
+	if len(s.tasks) > 0 {
+		segs = append(segs, s.taskSegmentLocked(now))
+	}
+	if s.progress != nil {
+		segs = append(segs, StatusSegment{Align: AlignRight, Widget: s.progress})
+	}
+	segs = append(segs, s.segments...)
+	segs = append(segs, s.infoSegmentsLocked(now)...)
+	s.mu.Unlock()
+	placed, hintSpan, msgSpan := s.layoutSegments(segs, contentY)
+	split := hintSpan != msgSpan
+
+	s.mu.Lock()
+	s.segWidgets = s.segWidgets[:0]
+	for _, ps := range placed {
+		if ps.seg.Widget != nil {
+			s.segWidgets = append(s.segWidgets, ps.seg.Widget)
+		}
+	}
 	hasLeftWidgets := len(s.leftWidgets) > 0
 	hoverHelp := s.hoverHelp
 	hintText := s.hintText
@@ -337,9 +469,12 @@ func (s *StatusBar) Draw(p *core.Painter) {
 	var leftUsedWidth int
 	if hasLeftWidgets {
 		// Layout and copy child widgets under the lock
-		leftUsedWidth = s.layoutLeftWidgets()
+		leftUsedWidth = s.layoutLeftWidgets(hintSpan.x0)
 		widgets := make([]core.Widget, len(s.leftWidgets))
 		copy(widgets, s.leftWidgets)
+		if s.focusHelp != "" {
+			hintText = s.focusHelp
+		}
 		s.mu.Unlock()
 
 		// Draw each widget outside the lock
@@ -351,48 +486,44 @@ func (s *StatusBar) Draw(p *core.Painter) {
 		// (e.g., TabLayout switching between tab bar and content)
 		s.updateKeyHintsLocked()
 		leftText := s.leftText
+		hints := s.hints
+		if s.focusHelp != "" {
+			hintText = s.focusHelp
+		}
 		s.mu.Unlock()
 
 		// Get rune slices for proper UTF-8 handling
 		leftRunes := []rune(leftText)
-		var rightText string
 		var rightRunes []rune
-		if activeMsg != nil {
-			rightText = activeMsg.Text
-			rightRunes = []rune(rightText)
+		if activeMsg != nil && !split && !stacked {
+			rightRunes = []rune(activeMsg.Text + activeMsg.actionSuffix())
 		}
 
 		// Calculate available space
-		availableWidth := s.Rect.W - 2 // 1 char padding on each side
+		availableWidth := hintSpan.x1 - hintSpan.x0
 
 		// Only truncate key hints if there's a message that needs space
+		maxLeft := availableWidth
 		if len(rightRunes) > 0 {
 			// Reserve space for message + gap (3 chars gap between hints and message)
-			msgSpace := len(rightRunes) + 3
-			maxLeft := availableWidth - msgSpace
-			if maxLeft < 1 {
-				maxLeft = 1
-			}
-			if len(leftRunes) > maxLeft {
-				if maxLeft > 1 {
-					leftText = string(leftRunes[:maxLeft-1]) + "…"
-					leftRunes = []rune(leftText)
-				} else {
-					leftText = "…"
-					leftRunes = []rune(leftText)
-				}
+			maxLeft = max(availableWidth-len(rightRunes)-3, 1)
+		}
+		if len(leftRunes) > maxLeft && len(hints) > 0 {
+			// Drop low-priority hints before truncating
+			var more *core.KeyHint
+			if s.OnMoreHints != nil {
+				more = &core.KeyHint{Key: "F1", Label: "More…"}
 			}
-		} else {
-			// No message - only truncate if hints exceed available width
-			if len(leftRunes) > availableWidth {
-				if availableWidth > 1 {
-					leftText = string(leftRunes[:availableWidth-1]) + "…"
-					leftRunes = []rune(leftText)
-				} else {
-					leftText = "…"
-					leftRunes = []rune(leftText)
-				}
+			leftText = core.FormatKeyHints(core.FitKeyHints(hints, maxLeft, more))
+			leftRunes = []rune(leftText)
+		}
+		if len(leftRunes) > maxLeft {
+			if maxLeft > 1 {
+				leftText = string(leftRunes[:maxLeft-1]) + "…"
+			} else {
+				leftText = "…"
 			}
+			leftRunes = []rune(leftText)
 		}
 
 		leftUsedWidth = len(leftRunes)
@@ -404,12 +535,14 @@ func (s *StatusBar) Draw(p *core.Painter) {
 				hintFg = tcell.ColorGray
 			}
 			hintDS := color.DynamicStyle{FG: color.Solid(hintFg), BG: color.Solid(bg)}
-			p.DrawDynamicText(s.Rect.X+1, contentY, leftText, hintDS)
+			p.DrawDynamicText(hintSpan.x0, contentY, leftText, hintDS)
 		}
 	}
 
-	// Draw right text - priority: hover help > timed messages > persistent hints
-	var rightText string
+	s.drawSegments(p, placed, contentY)
+
+	// Draw right text - priority: hover help > timed messages > focus help > persistent hints
+	var rightText, suffix string
 	var rightLevel MessageLevel
 	if hoverHelp != "" {
 		rightText = hoverHelp
@@ -417,25 +550,34 @@ func (s *StatusBar) Draw(p *core.Painter) {
 	} else if activeMsg != nil {
 		rightText = activeMsg.Text
 		rightLevel = activeMsg.Level
+		suffix = activeMsg.actionSuffix()
 	} else if hintText != "" {
 		rightText = hintText
 		rightLevel = MessageInfo
 	}
 
-	if rightRunes := []rune(rightText); len(rightRunes) > 0 {
+	var msgRect, actionRect core.Rect
+	if stacked {
+		msgRect, actionRect = s.drawStackedText(p, rightText, suffix, rightLevel, contentY+1, rows-1)
+	} else if rightRunes := []rune(rightText); len(rightRunes) > 0 {
 		msgDS := s.getMessageDynamicStyle(rightLevel, bg)
+		suffixW := len([]rune(suffix))
 
-		// Calculate right-aligned position
-		rightX := s.Rect.X + s.Rect.W - len(rightRunes) - 1
+		// Calculate right-aligned position; the action suffix is never truncated
+		x1 := msgSpan.x1 - suffixW
+		rightX := x1 - len(rightRunes)
 
 		// Check if message needs truncation
-		minX := s.Rect.X + leftUsedWidth + 3
+		minX := msgSpan.x0
+		if !split {
+			minX += leftUsedWidth + 2
+		}
 		if rightX < minX {
-			maxLen := s.Rect.W - leftUsedWidth - 4
+			maxLen := x1 - minX
 			if maxLen > 3 && maxLen-1 < len(rightRunes) {
 				rightText = string(rightRunes[:maxLen-1]) + "…"
 				rightRunes = []rune(rightText)
-				rightX = s.Rect.X + s.Rect.W - len(rightRunes) - 1
+				rightX = x1 - len(rightRunes)
 			} else if maxLen <= 3 {
 				rightText = "" // Not enough space
 			}
@@ -443,12 +585,80 @@ func (s *StatusBar) Draw(p *core.Painter) {
 
 		if rightText != "" {
 			p.DrawDynamicText(rightX, contentY, rightText, msgDS)
+			msgRect = core.Rect{X: rightX, Y: contentY, W: msgSpan.x1 - rightX, H: 1}
+			if suffix != "" {
+				actionDS := color.DynamicStyle{FG: color.Solid(tm.GetSemanticColor("accent")), BG: color.Solid(bg), Attrs: tcell.AttrBold}
+				p.DrawDynamicText(x1, contentY, suffix, actionDS)
+				actionRect = core.Rect{X: x1 + 1, Y: contentY, W: suffixW - 1, H: 1}
+			}
+		}
+	}
+
+	// Remember where the message and its action went for mouse handling
+	s.mu.Lock()
+	s.msgRect, s.actionRect = msgRect, actionRect
+	s.mu.Unlock()
+}
+
+// drawStackedText draws the right-hand text word-wrapped on the n rows
+// starting at y, with the action suffix kept whole after the last line.
+// Returns where the text and the action were drawn.
+func (s *StatusBar) drawStackedText(p *core.Painter, text, suffix string, level MessageLevel, y, n int) (core.Rect, core.Rect) {
+	if text == "" || n < 1 {
+		return core.Rect{}, core.Rect{}
+	}
+	tm := theme.Get()
+	bg := tm.GetSemanticColor("bg.surface")
+	x := s.Rect.X + 1
+	w := max(s.Rect.W-2, 1)
+	suffixW := len([]rune(suffix))
+
+	lines := wrapText(text, w)
+	if len(lines) == 0 {
+		return core.Rect{}, core.Rect{}
+	}
+	// The suffix goes after the last line, or on a line of its own
+	if suffix != "" && len([]rune(lines[len(lines)-1]))+suffixW > w {
+		lines = append(lines, "")
+	}
+	if len(lines) > n {
+		lines = lines[:n]
+		room := w - 1
+		if suffix != "" {
+			room -= suffixW
+		}
+		last := []rune(lines[n-1])
+		lines[n-1] = string(last[:max(min(len(last), room), 0)]) + "…"
+	}
+
+	msgDS := s.getMessageDynamicStyle(level, bg)
+	for i, line := range lines {
+		p.DrawDynamicText(x, y+i, line, msgDS)
+	}
+	msgRect := core.Rect{X: x, Y: y, W: w, H: len(lines)}
+
+	var actionRect core.Rect
+	if suffix != "" {
+		last := lines[len(lines)-1]
+		ax, ay := x+len([]rune(last)), y+len(lines)-1
+		if last == "" {
+			suffix = strings.TrimPrefix(suffix, " ") // On a line of its own
 		}
+		actionDS := color.DynamicStyle{FG: color.Solid(tm.GetSemanticColor("accent")), BG: color.Solid(bg), Attrs: tcell.AttrBold}
+		p.DrawDynamicText(ax, ay, suffix, actionDS)
+		actionRect = core.Rect{X: ax + len([]rune(suffix)) - (suffixW - 1), Y: ay, W: suffixW - 1, H: 1}
 	}
+	return msgRect, actionRect
 }
 
 // getMessageDynamicStyle returns the DynamicStyle for a message based on its level.
 func (s *StatusBar) getMessageDynamicStyle(level MessageLevel, bg tcell.Color) color.DynamicStyle {
+	return color.DynamicStyle{FG: color.Solid(MessageColor(level)), BG: color.Solid(bg)}
+}
+
+// MessageColor returns the theme's text color for messages of level, so
+// segments can match the messages.
+func MessageColor(level MessageLevel) tcell.Color {
 	tm := theme.Get()
 
 	var fg tcell.Color
@@ -471,8 +681,7 @@ func (s *StatusBar) getMessageDynamicStyle(level MessageLevel, bg tcell.Color) c
 	default: // MessageInfo
 		fg = tm.GetSemanticColor("text.primary")
 	}
-
-	return color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)}
+	return fg
 }
 
 // SetInvalidator implements core.InvalidationAware.
@@ -514,10 +723,27 @@ func (s *StatusBar) ClearMessages() {
 func (s *StatusBar) ClearKeyHints() {
 	s.mu.Lock()
 	s.leftText = ""
+	s.hints = nil
 	s.mu.Unlock()
 	s.invalidate()
 }
 
+// OnThemeChanged implements core.ThemeAware, restyling the left widgets
+// and segment widgets. The bar itself resolves its colors when drawn.
+func (s *StatusBar) OnThemeChanged() {
+	s.mu.Lock()
+	widgets := append([]core.Widget(nil), s.leftWidgets...)
+	for _, seg := range s.segments {
+		if seg.Widget != nil {
+			widgets = append(widgets, seg.Widget)
+		}
+	}
+	s.mu.Unlock()
+	for _, w := range widgets {
+		core.NotifyThemeChanged(w)
+	}
+}
+
 // SetLeftWidgets sets widgets to display on the left side of the status bar.
 // Widgets are positioned left-to-right with 1-char gaps during Draw.
 // Takes priority over KeyHintsProvider hints when set.
@@ -529,15 +755,16 @@ func (s *StatusBar) SetLeftWidgets(widgets []core.Widget) {
 	s.invalidate()
 }
 
-// layoutLeftWidgets positions left-side widgets sequentially on the content row.
-// Returns the total width consumed (for spacing right-side messages).
+// layoutLeftWidgets positions left-side widgets sequentially on the content
+// row, starting at x0. Returns the total width consumed (for spacing
+// right-side messages).
 // Must be called with s.mu held.
-func (s *StatusBar) layoutLeftWidgets() int {
+func (s *StatusBar) layoutLeftWidgets(x0 int) int {
 	contentY := s.Rect.Y
 	if s.ShowSeparator {
 		contentY++
 	}
-	xx := s.Rect.X + 1 // 1-char left padding
+	xx := x0
 	for i, w := range s.leftWidgets {
 		w.SetPosition(xx, contentY)
 		ww, _ := w.Size()
@@ -546,24 +773,34 @@ func (s *StatusBar) layoutLeftWidgets() int {
 			xx++ // 1-char gap between widgets
 		}
 	}
-	return xx - (s.Rect.X + 1) // total width consumed
+	return xx - x0 // total width consumed
 }
 
-// HandleMouse forwards mouse events to left-side widgets and shows
+// HandleMouse forwards mouse events to left-side and segment widgets and shows
 // hover help text from any widget implementing core.HelpTextProvider.
 // Returns true if a widget handled the event.
 func (s *StatusBar) HandleMouse(ev *tcell.EventMouse) bool {
 	x, y := ev.Position()
 	if !s.Rect.Contains(x, y) {
+		s.mu.Lock()
+		s.msgHovered = false
+		s.mouseDown = false
+		s.mu.Unlock()
 		s.clearHoverHelp()
 		return false
 	}
 
 	s.mu.Lock()
-	widgets := make([]core.Widget, len(s.leftWidgets))
-	copy(widgets, s.leftWidgets)
+	widgets := make([]core.Widget, 0, len(s.leftWidgets)+len(s.segWidgets))
+	widgets = append(widgets, s.leftWidgets...)
+	widgets = append(widgets, s.segWidgets...)
 	s.mu.Unlock()
 
+	// Message actions run on click; hovering the message pauses its expiry
+	if s.handleMessageMouse(ev) {
+		return true
+	}
+
 	// Check hover help for all widgets (works on motion and click events)
 	helpFound := false
 	for _, w := range widgets {
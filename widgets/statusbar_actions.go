@@ -0,0 +1,206 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_actions.go
+// Summary: Actionable status messages (e.g. "Undo") for StatusBar.
+
+package widgets
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/core"
+)
+
+// messageHoverGrace is the minimum time left on a message while it is
+// hovered, so it lingers briefly after the mouse leaves.
+const messageHoverGrace = 2 * time.Second
+
+// MessageAction is an optional action offered with a status message, such
+// as "Undo". It is shown after the message text and runs when clicked or
+// when its hotkey is pressed while the message is visible; either way the
+// message is dismissed. A hotkey with Ctrl or Alt, or a special key such
+// as F5, takes precedence over the focused widget; a plain character only
+// works when the focused widget doesn't take it, so it can still be typed.
+type MessageAction struct {
+	Label string
+	Run   func()
+
+	// Hotkey. Key 0 (tcell.KeyNUL) means no hotkey; tcell.KeyRune matches Rune.
+	Key  tcell.Key
+	Rune rune
+	Mod  tcell.ModMask
+}
+
+// ShowWithAction displays a message with an action for the default
+// duration. The message's remaining time is extended while hovered.
+func (s *StatusBar) ShowWithAction(text string, level MessageLevel, action MessageAction) {
+	s.ShowWithActionDuration(text, level, action, s.DefaultMessageDuration)
+}
+
+// ShowWithActionDuration displays a message with an action for a custom
+// duration.
+func (s *StatusBar) ShowWithActionDuration(text string, level MessageLevel, action MessageAction, duration time.Duration) {
+	s.pushMessage(TimedMessage{
+		Text:      text,
+		Level:     level,
+		ExpiresAt: core.Now().Add(duration),
+		Action:    &action,
+	})
+}
+
+// HandleKey feeds keys to an open prompt, or runs the action of the
+// visible message when its hotkey, other than a plain character, is
+// pressed. The UIManager offers keys here before the focused widget.
+func (s *StatusBar) HandleKey(ev *tcell.EventKey) bool {
+	if s.handlePromptKey(ev) {
+		return true
+	}
+	return !isPlainRune(ev) && s.handleActionKey(ev)
+}
+
+// handleActionKey runs the action of the visible message if ev is its
+// hotkey. Returns true if it ran.
+func (s *StatusBar) handleActionKey(ev *tcell.EventKey) bool {
+	s.mu.Lock()
+	var action *MessageAction
+	if msg := s.getActiveMessage(); msg != nil && s.hoverHelp == "" && msg.Action != nil && msg.Action.matches(ev) {
+		action = s.takeActionLocked()
+	}
+	s.mu.Unlock()
+	return s.runAction(action)
+}
+
+// isPlainRune reports whether ev types a character, without Ctrl or Alt.
+func isPlainRune(ev *tcell.EventKey) bool {
+	return ev.Key() == tcell.KeyRune && ev.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) == 0
+}
+
+// handleMessageMouse tracks hovering over the message and runs its action
+// when clicked. Returns true if an action ran.
+func (s *StatusBar) handleMessageMouse(ev *tcell.EventMouse) bool {
+	x, y := ev.Position()
+	held := ev.Buttons()&tcell.Button1 != 0
+
+	s.mu.Lock()
+	s.msgHovered = s.msgRect.Contains(x, y)
+	s.extendHoveredLocked(core.Now())
+	pressed := held && !s.mouseDown
+	s.mouseDown = held
+	var action *MessageAction
+	if pressed && s.actionRect.Contains(x, y) {
+		action = s.takeActionLocked()
+	}
+	s.mu.Unlock()
+	return s.runAction(action)
+}
+
+// extendHoveredLocked keeps some time left on a hovered message so its
+// action can still be reached. Must be called with s.mu held.
+func (s *StatusBar) extendHoveredLocked(now time.Time) {
+	if !s.msgHovered {
+		return
+	}
+	if msg := s.getActiveMessage(); msg != nil && msg.ExpiresAt.Before(now.Add(messageHoverGrace)) {
+		msg.ExpiresAt = now.Add(messageHoverGrace)
+	}
+}
+
+// takeActionLocked dismisses the active message and returns its action.
+// Must be called with s.mu held.
+func (s *StatusBar) takeActionLocked() *MessageAction {
+	msg := s.getActiveMessage()
+	if msg == nil || msg.Action == nil {
+		return nil
+	}
+	action := msg.Action
+	for i := range s.messages {
+		if &s.messages[i] == msg {
+			s.messages = append(s.messages[:i], s.messages[i+1:]...)
+			break
+		}
+	}
+	s.actionRect = core.Rect{}
+	s.msgHovered = false
+	return action
+}
+
+// runAction runs action outside the lock. Returns false if action is nil.
+func (s *StatusBar) runAction(action *MessageAction) bool {
+	if action == nil {
+		return false
+	}
+	s.invalidate()
+	if action.Run != nil {
+		action.Run()
+	}
+	return true
+}
+
+// actionSuffix returns the text drawn after the message for its action,
+// e.g. " [Undo C-z]", or "" if it has none.
+func (m *TimedMessage) actionSuffix() string {
+	if m.Action == nil {
+		return ""
+	}
+	label := m.Action.Label
+	if k := m.Action.keyLabel(); k != "" {
+		label += " " + k
+	}
+	return " [" + label + "]"
+}
+
+// isCtrlLetter reports whether k is Ctrl+A..Ctrl+Z, other than the keys
+// that share those codes (Tab, Enter, Backspace).
+func isCtrlLetter(k tcell.Key) bool {
+	return k >= tcell.KeyCtrlA && k <= tcell.KeyCtrlZ &&
+		k != tcell.KeyTab && k != tcell.KeyEnter && k != tcell.KeyBackspace
+}
+
+// matches reports whether ev is the action's hotkey. Control keys match
+// with or without the Ctrl modifier reported.
+func (a *MessageAction) matches(ev *tcell.EventKey) bool {
+	if a.Key == tcell.KeyNUL || ev.Key() != a.Key {
+		return false
+	}
+	want, got := a.Mod, ev.Modifiers()
+	if isCtrlLetter(a.Key) {
+		want &^= tcell.ModCtrl
+		got &^= tcell.ModCtrl
+	}
+	if want != got {
+		return false
+	}
+	return a.Key != tcell.KeyRune || ev.Rune() == a.Rune
+}
+
+// keyLabel formats the hotkey in key hint style ("C-z", "A-u", "F5").
+func (a *MessageAction) keyLabel() string {
+	var b strings.Builder
+	mod := a.Mod
+	if isCtrlLetter(a.Key) {
+		mod |= tcell.ModCtrl
+	}
+	if mod&tcell.ModCtrl != 0 {
+		b.WriteString("C-")
+	}
+	if mod&tcell.ModAlt != 0 {
+		b.WriteString("A-")
+	}
+	if mod&tcell.ModShift != 0 {
+		b.WriteString("S-")
+	}
+	switch {
+	case a.Key == tcell.KeyNUL:
+		return ""
+	case a.Key == tcell.KeyRune:
+		b.WriteRune(a.Rune)
+	case isCtrlLetter(a.Key):
+		b.WriteRune(rune('a' + a.Key - tcell.KeyCtrlA))
+	default:
+		b.WriteString(tcell.KeyNames[a.Key])
+	}
+	return b.String()
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_clock.go
+// Summary: Clock and periodically refreshed info segments for StatusBar.
+
+package widgets
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/framegrace/texelui/core"
+)
+
+// statusInfo is a text provider refreshed by the status bar's ticker.
+type statusInfo struct {
+	id       string
+	interval time.Duration
+	fn       func() string
+	text     string    // Last value returned by fn
+	next     time.Time // When fn is due again
+}
+
+// ShowClock shows a clock at the right edge of the status bar, formatted
+// with strftime-style verbs (see the list below). It is kept current by
+// the ticker started with Start.
+//
+// Supported verbs: %H %I %M %S %p (time), %a %A %b %B %d %e %m %y %Y
+// (date), %Z (zone) and %% (a literal %). Other verbs are kept as is.
+func (s *StatusBar) ShowClock(format string) {
+	s.mu.Lock()
+	s.clockFormat = format
+	s.mu.Unlock()
+	s.invalidate()
+}
+
+// HideClock removes the clock.
+func (s *StatusBar) HideClock() {
+	s.ShowClock("")
+}
+
+// AddInfoProvider shows the text returned by fn as a right-side segment,
+// such as the system load or battery level. fn is called right away and
+// then every interval (at least the ticker's 100ms) from the status bar's
+// ticker goroutine, so apps don't need their own. A provider with the same
+// id is replaced.
+func (s *StatusBar) AddInfoProvider(id string, interval time.Duration, fn func() string) {
+	info := &statusInfo{id: id, interval: interval, fn: fn, text: fn(), next: core.Now().Add(interval)}
+	s.mu.Lock()
+	replaced := false
+	for i, old := range s.infos {
+		if old.id == id {
+			s.infos[i] = info
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		s.infos = append(s.infos, info)
+	}
+	s.mu.Unlock()
+	s.invalidate()
+}
+
+// RemoveInfoProvider removes the provider with the given id. Returns false
+// if there is none.
+func (s *StatusBar) RemoveInfoProvider(id string) bool {
+	s.mu.Lock()
+	found := false
+	for i, info := range s.infos {
+		if info.id == id {
+			s.infos = append(s.infos[:i], s.infos[i+1:]...)
+			found = true
+			break
+		}
+	}
+	s.mu.Unlock()
+	if found {
+		s.invalidate()
+	}
+	return found
+}
+
+// refreshInfo calls the providers that are due and reports whether the
+// clock or any provider text changed since the last draw.
+func (s *StatusBar) refreshInfo(now time.Time) bool {
+	s.mu.Lock()
+	var due []*statusInfo
+	for _, info := range s.infos {
+		if !now.Before(info.next) {
+			due = append(due, info)
+		}
+	}
+	changed := s.clockFormat != "" && strftime(now, s.clockFormat) != s.clockShown
+	s.mu.Unlock()
+
+	// Providers run without the lock so they may use the status bar
+	texts := make([]string, len(due))
+	for i, info := range due {
+		texts[i] = info.fn()
+	}
+
+	s.mu.Lock()
+	for i, info := range due {
+		info.next = now.Add(info.interval)
+		if info.text != texts[i] {
+			info.text = texts[i]
+			changed = true
+		}
+	}
+	s.mu.Unlock()
+	return changed
+}
+
+// infoSegmentsLocked returns the info providers and the clock as right-side
+// segments, clock last. Must be called with s.mu held.
+func (s *StatusBar) infoSegmentsLocked(now time.Time) []StatusSegment {
+	var segs []StatusSegment
+	for _, info := range s.infos {
+		text := info.text
+		segs = append(segs, StatusSegment{Align: AlignRight, Text: func() string { return text }})
+	}
+	s.clockShown = ""
+	if s.clockFormat != "" {
+		clock := strftime(now, s.clockFormat)
+		s.clockShown = clock
+		segs = append(segs, StatusSegment{Align: AlignRight, Text: func() string { return clock }})
+	}
+	return segs
+}
+
+// strftime formats t using the strftime-style verbs listed on ShowClock.
+func strftime(t time.Time, format string) string {
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		c := format[i]
+		if c != '%' || i == len(format)-1 {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'I':
+			fmt.Fprintf(&b, "%02d", (t.Hour()+11)%12+1)
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case 'S':
+			fmt.Fprintf(&b, "%02d", t.Second())
+		case 'p':
+			b.WriteString(t.Format("PM"))
+		case 'a':
+			b.WriteString(t.Format("Mon"))
+		case 'A':
+			b.WriteString(t.Weekday().String())
+		case 'b':
+			b.WriteString(t.Format("Jan"))
+		case 'B':
+			b.WriteString(t.Month().String())
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'e':
+			fmt.Fprintf(&b, "%2d", t.Day())
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'y':
+			fmt.Fprintf(&b, "%02d", t.Year()%100)
+		case 'Y':
+			fmt.Fprintf(&b, "%d", t.Year())
+		case 'Z':
+			b.WriteString(t.Format("MST"))
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte('%')
+			b.WriteByte(format[i])
+		}
+	}
+	return b.String()
+}
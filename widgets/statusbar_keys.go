@@ -0,0 +1,61 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_keys.go
+// Summary: Pending key sequence indicator for StatusBar.
+
+package widgets
+
+import (
+	"time"
+
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/gdamore/tcell/v2"
+)
+
+// SetPendingKeys implements core.PendingKeysIndicator. While a shortcut
+// key sequence is unfinished, its keys are shown on the left as
+// "Ctrl+X -", until the sequence completes or until passes. Empty keys
+// hide the indicator.
+func (s *StatusBar) SetPendingKeys(keys string, until time.Time) {
+	s.mu.Lock()
+	s.pendingKeys = keys
+	s.pendingUntil = until
+	s.mu.Unlock()
+	s.invalidate()
+}
+
+// PendingKeys returns the keys of the unfinished key sequence shown, or ""
+// if none is.
+func (s *StatusBar) PendingKeys() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pendingKeys == "" || !core.Now().Before(s.pendingUntil) {
+		return ""
+	}
+	return s.pendingKeys
+}
+
+// expirePendingKeys hides the indicator once its sequence has timed out.
+// Returns true if it did.
+func (s *StatusBar) expirePendingKeys(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pendingKeys == "" || now.Before(s.pendingUntil) {
+		return false
+	}
+	s.pendingKeys = ""
+	return true
+}
+
+// pendingKeysSegmentLocked returns the indicator segment. Must be called
+// with s.mu held and keys pending.
+func (s *StatusBar) pendingKeysSegmentLocked() StatusSegment {
+	text := s.pendingKeys + " -"
+	return StatusSegment{
+		Align: AlignLeft,
+		Text:  func() string { return text },
+		Style: color.DynamicStyle{Attrs: tcell.AttrBold},
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_progress.go
+// Summary: Persistent progress bar segment for StatusBar.
+
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+)
+
+// progressBarWidth is the number of cells used by the bar itself.
+const progressBarWidth = 10
+
+// SetProgress shows a compact progress bar with label on the right side of
+// the status bar, for long-running background jobs. fraction is clamped to
+// [0, 1]. The bar stays until ClearProgress is called and is independent of
+// the timed messages. Safe to call from any goroutine.
+func (s *StatusBar) SetProgress(label string, fraction float64) {
+	fraction = min(max(fraction, 0), 1)
+	s.mu.Lock()
+	old := s.progress
+	changed := old == nil || old.label != label || old.fraction != fraction
+	if changed {
+		// Draw uses the segment outside the lock, so replace rather than mutate
+		s.progress = newStatusProgress(label, fraction)
+	}
+	s.mu.Unlock()
+	if changed {
+		s.invalidate()
+	}
+}
+
+// ClearProgress removes the progress bar.
+func (s *StatusBar) ClearProgress() {
+	s.mu.Lock()
+	had := s.progress != nil
+	s.progress = nil
+	s.mu.Unlock()
+	if had {
+		s.invalidate()
+	}
+}
+
+// statusProgress draws "label ████▌     42%" as a status bar segment.
+type statusProgress struct {
+	core.BaseWidget
+	label    string
+	fraction float64
+}
+
+// newStatusProgress creates a progress segment sized to fit its content.
+func newStatusProgress(label string, fraction float64) *statusProgress {
+	sp := &statusProgress{label: label, fraction: fraction}
+	w := progressBarWidth + 5 // Bar plus " 100%"
+	if label != "" {
+		w += len([]rune(label)) + 1
+	}
+	sp.Resize(w, 1)
+	return sp
+}
+
+// Draw renders the label, the bar in eighth-cell steps and the percentage.
+func (sp *statusProgress) Draw(p *core.Painter) {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	textDS := color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)}
+
+	x, y := sp.Rect.X, sp.Rect.Y
+	if sp.label != "" {
+		p.DrawDynamicText(x, y, sp.label, textDS)
+		x += len([]rune(sp.label)) + 1
+	}
+
+	drawProgressCells(p, x, y, progressBarWidth, sp.fraction)
+	p.DrawDynamicText(x+progressBarWidth, y, fmt.Sprintf(" %3d%%", int(sp.fraction*100+0.5)), textDS)
+}
@@ -0,0 +1,246 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_prompt.go
+// Summary: Vim-style command-line prompt mode for StatusBar.
+
+package widgets
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+)
+
+// PromptOptions configures a status bar prompt (see OpenPrompt).
+type PromptOptions struct {
+	Prefix  string // Shown before the input, e.g. ":"
+	Initial string // Initial input text
+
+	// Complete returns the completions for the current input. Tab cycles
+	// forward through them and Shift+Tab backward. Optional.
+	Complete func(input string) []string
+
+	// OnSubmit receives the entered text when Enter is pressed.
+	OnSubmit func(input string)
+	// OnCancel is called when the prompt is dismissed with Esc. Optional.
+	OnCancel func()
+}
+
+// statusPrompt is the state of an open prompt. It is only touched from the
+// UI goroutine (HandleKey and Draw); the pointer itself is guarded by s.mu.
+type statusPrompt struct {
+	opts  PromptOptions
+	input *Input
+
+	history []string // Entries for this prefix, oldest first
+	histIdx int      // Position in history; len(history) is the draft
+	draft   string   // Text typed before browsing history
+
+	matches  []string // Completions being cycled (nil = not cycling)
+	matchIdx int
+}
+
+// OpenPrompt turns the content row of the status bar into an input line.
+// While open, the prompt captures all keys: Enter submits, Esc cancels,
+// Up/Down browse the history of prompts with the same prefix and Tab
+// completes. The hints and messages are restored when it closes.
+func (s *StatusBar) OpenPrompt(opts PromptOptions) {
+	in := NewInput()
+	in.Text = opts.Initial
+	in.CaretPos = len([]rune(opts.Initial))
+	in.Focus()
+
+	s.mu.Lock()
+	history := s.history[opts.Prefix]
+	s.prompt = &statusPrompt{opts: opts, input: in, history: history, histIdx: len(history)}
+	s.mu.Unlock()
+	s.invalidate()
+}
+
+// ClosePrompt closes the prompt without calling any callback.
+func (s *StatusBar) ClosePrompt() {
+	s.mu.Lock()
+	had := s.prompt != nil
+	s.prompt = nil
+	s.mu.Unlock()
+	if had {
+		s.invalidate()
+	}
+}
+
+// PromptActive reports whether a prompt is open.
+func (s *StatusBar) PromptActive() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prompt != nil
+}
+
+// EnableCommandPrompt opens a ":" prompt when ':' is typed and no widget
+// handles it, passing the entered command to onCommand. complete may be
+// nil. Pass a nil onCommand to disable.
+func (s *StatusBar) EnableCommandPrompt(onCommand func(cmd string), complete func(input string) []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if onCommand == nil {
+		s.commandPrompt = nil
+		return
+	}
+	s.commandPrompt = &PromptOptions{Prefix: ":", Complete: complete, OnSubmit: onCommand}
+}
+
+// HandleUnhandledKey implements core.FallbackKeyHandler, running the
+// visible message's action for its plain character hotkey, and opening the
+// command prompt on ':' and the full key hint list on F1.
+func (s *StatusBar) HandleUnhandledKey(ev *tcell.EventKey) bool {
+	if isPlainRune(ev) && s.handleActionKey(ev) {
+		return true
+	}
+	s.mu.Lock()
+	cmd := s.commandPrompt
+	active := s.prompt != nil
+	hints := append([]core.KeyHint(nil), s.hints...)
+	s.mu.Unlock()
+	if ev.Key() == tcell.KeyF1 && s.OnMoreHints != nil && len(hints) > 0 {
+		s.OnMoreHints(hints)
+		return true
+	}
+	if cmd == nil || active || ev.Key() != tcell.KeyRune || ev.Rune() != ':' ||
+		ev.Modifiers()&(tcell.ModCtrl|tcell.ModAlt) != 0 {
+		return false
+	}
+	s.OpenPrompt(*cmd)
+	return true
+}
+
+// handlePromptKey edits the open prompt. Returns false if no prompt is
+// open; otherwise every key is consumed.
+func (s *StatusBar) handlePromptKey(ev *tcell.EventKey) bool {
+	s.mu.Lock()
+	pr := s.prompt
+	s.mu.Unlock()
+	if pr == nil {
+		return false
+	}
+
+	switch ev.Key() {
+	case tcell.KeyEnter:
+		text := pr.input.Text
+		s.mu.Lock()
+		if s.prompt == pr {
+			s.prompt = nil
+		}
+		if h := s.history[pr.opts.Prefix]; text != "" && (len(h) == 0 || h[len(h)-1] != text) {
+			if s.history == nil {
+				s.history = make(map[string][]string)
+			}
+			s.history[pr.opts.Prefix] = append(h, text)
+		}
+		s.mu.Unlock()
+		s.invalidate()
+		if pr.opts.OnSubmit != nil {
+			pr.opts.OnSubmit(text)
+		}
+		return true
+	case tcell.KeyEscape:
+		s.ClosePrompt()
+		if pr.opts.OnCancel != nil {
+			pr.opts.OnCancel()
+		}
+		return true
+	case tcell.KeyTab, tcell.KeyBacktab:
+		pr.complete(ev.Key() == tcell.KeyTab)
+	case tcell.KeyUp:
+		pr.browse(-1)
+	case tcell.KeyDown:
+		pr.browse(1)
+	default:
+		pr.matches = nil
+		pr.input.HandleKey(ev)
+		pr.histIdx = len(pr.history)
+	}
+	s.invalidate()
+	return true
+}
+
+// complete fills in the next (or previous) completion of the input.
+func (pr *statusPrompt) complete(forward bool) {
+	if pr.matches == nil {
+		if pr.opts.Complete == nil {
+			return
+		}
+		pr.matches = pr.opts.Complete(pr.input.Text)
+		if len(pr.matches) == 0 {
+			pr.matches = nil
+			return
+		}
+		pr.matchIdx = 0
+		if !forward {
+			pr.matchIdx = len(pr.matches) - 1
+		}
+	} else if forward {
+		pr.matchIdx = (pr.matchIdx + 1) % len(pr.matches)
+	} else {
+		pr.matchIdx = (pr.matchIdx + len(pr.matches) - 1) % len(pr.matches)
+	}
+	pr.setText(pr.matches[pr.matchIdx])
+	if len(pr.matches) == 1 {
+		pr.matches = nil
+	}
+}
+
+// browse moves through the history by delta, keeping the draft at the end.
+func (pr *statusPrompt) browse(delta int) {
+	idx := pr.histIdx + delta
+	if idx < 0 || idx > len(pr.history) {
+		return
+	}
+	if pr.histIdx == len(pr.history) {
+		pr.draft = pr.input.Text
+	}
+	pr.histIdx = idx
+	pr.matches = nil
+	if idx == len(pr.history) {
+		pr.setText(pr.draft)
+	} else {
+		pr.setText(pr.history[idx])
+	}
+}
+
+// setText replaces the input text and moves the caret to its end.
+func (pr *statusPrompt) setText(text string) {
+	pr.input.Text = text
+	pr.input.CaretPos = len([]rune(text))
+}
+
+// drawPrompt renders the prompt on row y, with the completion position
+// ("2/5") at the right while cycling.
+func (s *StatusBar) drawPrompt(p *core.Painter, pr *statusPrompt, y int) {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	muted := tm.GetSemanticColor("text.muted")
+	ds := color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)}
+
+	x := s.Rect.X + 1
+	end := s.Rect.X + s.Rect.W - 1
+	p.DrawDynamicText(x, y, pr.opts.Prefix, ds)
+	x += len([]rune(pr.opts.Prefix))
+
+	if pr.matches != nil {
+		info := fmt.Sprintf("%d/%d", pr.matchIdx+1, len(pr.matches))
+		if end-len(info)-1 > x {
+			end -= len(info)
+			p.DrawDynamicText(end, y, info, color.DynamicStyle{FG: color.Solid(muted), BG: color.Solid(bg)})
+			end--
+		}
+	}
+
+	pr.input.Style = ds
+	pr.input.SetPosition(x, y)
+	pr.input.Resize(max(end-x, 1), 1)
+	pr.input.Draw(p)
+}
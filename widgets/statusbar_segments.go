@@ -0,0 +1,189 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_segments.go
+// Summary: Pluggable left/center/right segments for StatusBar.
+
+package widgets
+
+import (
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+)
+
+// segmentSep separates segments from each other and from the hints and
+// message area.
+const segmentSep = " │ "
+
+// StatusSegment is an app-supplied piece of the status bar, such as a mode
+// indicator or the current git branch. It shows either a widget, laid out
+// at its own width on the content row, or the text returned by Text, which
+// is re-evaluated on every draw. Segments with no width (an empty text) are
+// skipped.
+type StatusSegment struct {
+	ID     string    // Identifies the segment for replacement and removal
+	Align  Alignment // AlignLeft, AlignCenter or AlignRight
+	Widget core.Widget
+	Text   func() string
+
+	// Style for text segments. Zero colors fall back to the status bar's.
+	Style color.DynamicStyle
+}
+
+// AddSegment adds seg to the status bar. A segment with the same non-empty
+// ID is replaced in place. Left and right segments keep the order they were
+// added in; the key hints and messages use the space between them.
+func (s *StatusBar) AddSegment(seg StatusSegment) {
+	s.mu.Lock()
+	replaced := false
+	if seg.ID != "" {
+		for i := range s.segments {
+			if s.segments[i].ID == seg.ID {
+				s.segments[i] = seg
+				replaced = true
+				break
+			}
+		}
+	}
+	if !replaced {
+		s.segments = append(s.segments, seg)
+	}
+	s.mu.Unlock()
+	s.invalidate()
+}
+
+// RemoveSegment removes the segment with the given ID. Returns false if
+// there is none.
+func (s *StatusBar) RemoveSegment(id string) bool {
+	s.mu.Lock()
+	found := false
+	for i := range s.segments {
+		if s.segments[i].ID == id {
+			s.segments = append(s.segments[:i], s.segments[i+1:]...)
+			found = true
+			break
+		}
+	}
+	s.mu.Unlock()
+	if found {
+		s.invalidate()
+	}
+	return found
+}
+
+// placedSegment is a segment measured and positioned for one draw.
+type placedSegment struct {
+	seg  StatusSegment
+	text string
+	x, w int
+}
+
+// span is a horizontal range [x0, x1) of the content row.
+type span struct{ x0, x1 int }
+
+// layoutSegments measures segs and positions them on row y. It returns the
+// placed segments and the spans left for key hints and for the right-hand
+// text. Without center segments both spans are the same range.
+// Text providers are called, so s.mu must not be held.
+func (s *StatusBar) layoutSegments(segs []StatusSegment, y int) ([]placedSegment, span, span) {
+	sepW := len([]rune(segmentSep))
+	var groups [3][]placedSegment
+	var widths [3]int
+	for _, seg := range segs {
+		ps := placedSegment{seg: seg}
+		if seg.Widget != nil {
+			ps.w, _ = seg.Widget.Size()
+		} else if seg.Text != nil {
+			ps.text = seg.Text()
+			ps.w = len([]rune(ps.text))
+		}
+		if ps.w <= 0 {
+			continue
+		}
+		a := seg.Align
+		if a < AlignLeft || a > AlignRight {
+			a = AlignLeft
+		}
+		ps.seg.Align = a
+		if len(groups[a]) > 0 {
+			widths[a] += sepW
+		}
+		groups[a] = append(groups[a], ps)
+		widths[a] += ps.w
+	}
+
+	start, end := s.Rect.X+1, s.Rect.X+s.Rect.W-1 // 1-char padding each side
+	if len(groups[AlignLeft]) > 0 {
+		start += widths[AlignLeft] + sepW
+	}
+	if len(groups[AlignRight]) > 0 {
+		end -= widths[AlignRight] + sepW
+	}
+	hints, msg := span{start, end}, span{start, end}
+
+	// The center group is dropped when it would overlap the side groups
+	cw := widths[AlignCenter]
+	cx := s.Rect.X + (s.Rect.W-cw)/2
+	if cw > 0 && cx-sepW >= start && cx+cw+sepW <= end {
+		hints.x1 = cx - sepW
+		msg.x0 = cx + cw + sepW
+	} else {
+		groups[AlignCenter] = nil
+	}
+
+	var placed []placedSegment
+	place := func(group []placedSegment, x int) {
+		for _, ps := range group {
+			ps.x = x
+			if ps.seg.Widget != nil {
+				ps.seg.Widget.SetPosition(x, y)
+			}
+			placed = append(placed, ps)
+			x += ps.w + sepW
+		}
+	}
+	place(groups[AlignLeft], s.Rect.X+1)
+	place(groups[AlignCenter], cx)
+	place(groups[AlignRight], end+sepW)
+	return placed, hints, msg
+}
+
+// drawSegments draws placed segments and the separators around them.
+func (s *StatusBar) drawSegments(p *core.Painter, placed []placedSegment, y int) {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	sepFg := tm.GetSemanticColor("border.default")
+	sepDS := color.DynamicStyle{FG: color.Solid(sepFg), BG: color.Solid(bg)}
+	sepW := len([]rune(segmentSep))
+
+	for i, ps := range placed {
+		// Left segments are followed by a separator, right ones preceded
+		// by one, and the center group is enclosed
+		switch ps.seg.Align {
+		case AlignLeft:
+			p.DrawDynamicText(ps.x+ps.w, y, segmentSep, sepDS)
+		case AlignRight:
+			p.DrawDynamicText(ps.x-sepW, y, segmentSep, sepDS)
+		case AlignCenter:
+			p.DrawDynamicText(ps.x-sepW, y, segmentSep, sepDS)
+			if i == len(placed)-1 || placed[i+1].seg.Align != AlignCenter {
+				p.DrawDynamicText(ps.x+ps.w, y, segmentSep, sepDS)
+			}
+		}
+
+		if ps.seg.Widget != nil {
+			ps.seg.Widget.Draw(p)
+			continue
+		}
+		ds := ps.seg.Style
+		if ds.FG.IsZero() {
+			ds.FG = color.Solid(fg)
+		}
+		if ds.BG.IsZero() {
+			ds.BG = color.Solid(bg)
+		}
+		p.DrawDynamicText(ps.x, y, ps.text, ds)
+	}
+}
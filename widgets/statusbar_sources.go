@@ -0,0 +1,118 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_sources.go
+// Summary: Per-source filtering and prioritization of StatusBar messages.
+
+package widgets
+
+import (
+	"time"
+
+	"github.com/framegrace/texelui/core"
+)
+
+// sourceMuted is a minimum level above every MessageLevel, hiding all
+// messages from a source.
+const sourceMuted = MessageError + 1
+
+// ShowFrom displays a message tagged with the subsystem that produced it
+// (e.g. "sync", "lsp"), for the default duration. Tagged messages can be
+// filtered with MuteSource and SetSourceMinLevel and ranked with
+// SetSourcePriority. Untagged messages use the source "".
+func (s *StatusBar) ShowFrom(source, text string, level MessageLevel) {
+	s.ShowFromWithDuration(source, text, level, s.DefaultMessageDuration)
+}
+
+// ShowFromWithDuration displays a tagged message for a custom duration.
+func (s *StatusBar) ShowFromWithDuration(source, text string, level MessageLevel, duration time.Duration) {
+	s.pushMessage(TimedMessage{
+		Text:      text,
+		Level:     level,
+		ExpiresAt: core.Now().Add(duration),
+		Source:    source,
+	})
+}
+
+// MuteSource hides all messages from source, including queued ones.
+func (s *StatusBar) MuteSource(source string) {
+	s.SetSourceMinLevel(source, sourceMuted)
+}
+
+// UnmuteSource shows all messages from source again.
+func (s *StatusBar) UnmuteSource(source string) {
+	s.SetSourceMinLevel(source, MessageInfo)
+}
+
+// IsSourceMuted reports whether source was muted with MuteSource.
+func (s *StatusBar) IsSourceMuted(source string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sourceMinLevel[source] >= sourceMuted
+}
+
+// SetSourceMinLevel drops messages from source below level, e.g. keeping
+// only warnings and errors of a chatty background component. Queued
+// messages below the new level are removed.
+func (s *StatusBar) SetSourceMinLevel(source string, level MessageLevel) {
+	s.mu.Lock()
+	if level <= MessageInfo {
+		delete(s.sourceMinLevel, source)
+	} else {
+		if s.sourceMinLevel == nil {
+			s.sourceMinLevel = make(map[string]MessageLevel)
+		}
+		s.sourceMinLevel[source] = level
+	}
+	kept := s.messages[:0]
+	for _, msg := range s.messages {
+		if msg.Source != source || msg.Level >= level {
+			kept = append(kept, msg)
+		}
+	}
+	removed := len(kept) != len(s.messages)
+	s.messages = kept
+	s.mu.Unlock()
+	if removed {
+		s.invalidate()
+	}
+}
+
+// SetSourcePriority ranks messages from source against other messages of
+// the same level: the highest priority is shown first and evicted last
+// when the queue is full. The default priority is 0.
+func (s *StatusBar) SetSourcePriority(source string, priority int) {
+	s.mu.Lock()
+	if priority == 0 {
+		delete(s.sourcePriority, source)
+	} else {
+		if s.sourcePriority == nil {
+			s.sourcePriority = make(map[string]int)
+		}
+		s.sourcePriority[source] = priority
+	}
+	s.mu.Unlock()
+	s.invalidate()
+}
+
+// outranksLocked reports whether a is more important than b: a higher
+// level, or the same level from a higher priority source. Must be called
+// with s.mu held.
+func (s *StatusBar) outranksLocked(a, b *TimedMessage) bool {
+	if a.Level != b.Level {
+		return a.Level > b.Level
+	}
+	return s.sourcePriority[a.Source] > s.sourcePriority[b.Source]
+}
+
+// lowestRankedLocked returns the index of the oldest of the least important
+// queued messages. Must be called with s.mu held and messages queued.
+func (s *StatusBar) lowestRankedLocked() int {
+	low := 0
+	for i := 1; i < len(s.messages); i++ {
+		if s.outranksLocked(&s.messages[low], &s.messages[i]) {
+			low = i
+		}
+	}
+	return low
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/statusbar_tasks.go
+// Summary: Background task registry with an activity spinner for StatusBar.
+
+package widgets
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/framegrace/texelui/core"
+)
+
+// spinnerFrames are the animation frames of the activity spinner.
+var spinnerFrames = []rune("⠋⠙⠹⠸⠼⠴⠦⠧⠇⠏")
+
+// spinnerStill replaces the spinner when reduced motion is enabled.
+const spinnerStill = '⠿'
+
+// spinnerInterval is how long each spinner frame is shown.
+const spinnerInterval = 100 * time.Millisecond
+
+// BeginTask registers a running background task. While any task is active
+// the status bar shows an animated spinner with the task's name, or the
+// number of tasks when several are running. Every BeginTask must be paired
+// with an EndTask for the same name. Safe to call from any goroutine.
+func (s *StatusBar) BeginTask(name string) {
+	s.mu.Lock()
+	s.tasks = append(s.tasks, name)
+	s.mu.Unlock()
+	s.invalidate()
+}
+
+// EndTask unregisters one running task with the given name. Unknown names
+// are ignored.
+func (s *StatusBar) EndTask(name string) {
+	s.mu.Lock()
+	found := false
+	for i, t := range s.tasks {
+		if t == name {
+			s.tasks = append(s.tasks[:i], s.tasks[i+1:]...)
+			found = true
+			break
+		}
+	}
+	s.mu.Unlock()
+	if found {
+		s.invalidate()
+	}
+}
+
+// ActiveTasks returns the names of the running tasks, oldest first.
+func (s *StatusBar) ActiveTasks() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.tasks...)
+}
+
+// busy reports whether any task is running, i.e. the spinner animates
+// (unless motion is reduced).
+func (s *StatusBar) busy() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.tasks) > 0
+}
+
+// taskSegmentLocked returns the spinner segment for the running tasks, with
+// the frame chosen from now. Must be called with s.mu held and tasks active.
+func (s *StatusBar) taskSegmentLocked(now time.Time) StatusSegment {
+	frame := spinnerFrames[int(now.UnixMilli()/spinnerInterval.Milliseconds())%len(spinnerFrames)]
+	if core.ReduceMotion() {
+		frame = spinnerStill
+	}
+	text := string(frame)
+	switch n := len(s.tasks); {
+	case n == 1 && s.tasks[0] != "":
+		text += " " + s.tasks[0]
+	case n > 1:
+		text += fmt.Sprintf(" %d tasks", n)
+	}
+	return StatusSegment{Align: AlignRight, Text: func() string { return text }}
+}
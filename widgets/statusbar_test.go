@@ -1,10 +1,14 @@
 package widgets
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/primitives"
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -344,3 +348,497 @@ func TestStatusBarLeftWidgetsOverridesHints(t *testing.T) {
 		}
 	}
 }
+
+// TestStatusBarSegments verifies that left, center and right segments are
+// laid out around the key hints and message, and can be replaced or removed.
+func TestStatusBarSegments(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetPosition(0, 0)
+	sb.Resize(40, 2)
+	sb.SetHintText("hint")
+
+	branch := "main"
+	sb.AddSegment(StatusSegment{ID: "mode", Text: func() string { return "NOR" }})
+	sb.AddSegment(StatusSegment{ID: "git", Align: AlignRight, Text: func() string { return branch }})
+	sb.AddSegment(StatusSegment{ID: "pos", Align: AlignCenter, Widget: NewLabel("1:1")})
+
+	row := func() string {
+		buf := createTestBuffer(40, 2)
+		sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 2}))
+		var r []rune
+		for _, c := range buf[1] {
+			r = append(r, c.Ch)
+		}
+		return string(r)
+	}
+
+	// 40 cols: [pad]NOR │ ...[center 1:1 at 18] ...hint │ main[pad]
+	want := " NOR │          │ 1:1 │     hint │ main "
+	if got := row(); got != want {
+		t.Errorf("segment layout:\n got %q\nwant %q", got, want)
+	}
+
+	// Providers are re-evaluated; empty segments are skipped
+	branch = ""
+	sb.RemoveSegment("pos")
+	sb.AddSegment(StatusSegment{ID: "mode", Text: func() string { return "INS" }})
+	want = " INS │                             hint "
+	if got := row(); got != want {
+		t.Errorf("after update:\n got %q\nwant %q", got, want)
+	}
+	if sb.RemoveSegment("missing") {
+		t.Error("RemoveSegment should report unknown IDs")
+	}
+}
+
+// TestStatusBarProgress verifies the progress segment renders next to the
+// right edge, survives message expiry and disappears when cleared.
+func TestStatusBarProgress(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetPosition(0, 0)
+	sb.Resize(40, 2)
+
+	row := func() string {
+		buf := createTestBuffer(40, 2)
+		sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 2}))
+		var r []rune
+		for _, c := range buf[1] {
+			r = append(r, c.Ch)
+		}
+		return string(r)
+	}
+
+	sb.SetProgress("Sync", 0.45)
+	want := "                 │ Sync ████▌       45% "
+	if got := row(); got != want {
+		t.Errorf("progress:\n got %q\nwant %q", got, want)
+	}
+
+	sb.ShowMessageWithDuration("done", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	sb.expireMessages()
+	if got := row(); got != want {
+		t.Errorf("progress should outlive messages:\n got %q", got)
+	}
+
+	sb.SetProgress("Sync", 2)
+	if got := row(); !strings.HasSuffix(got, "██████████ 100% ") {
+		t.Errorf("fraction should clamp to 100%%, got %q", got)
+	}
+
+	sb.ClearProgress()
+	if got := row(); strings.TrimSpace(got) != "" {
+		t.Errorf("expected an empty row after ClearProgress, got %q", got)
+	}
+}
+
+// TestStatusBarClockAndInfo verifies strftime-style clock formatting and
+// that info providers are refreshed on their interval by the ticker.
+func TestStatusBarClockAndInfo(t *testing.T) {
+	ts := time.Date(2025, time.March, 7, 14, 5, 9, 0, time.UTC)
+	if got := strftime(ts, "%a %d %b %Y %H:%M:%S %I%p %% %q"); got != "Fri 07 Mar 2025 14:05:09 02PM % %q" {
+		t.Errorf("strftime = %q", got)
+	}
+
+	sb := NewStatusBar()
+	sb.SetPosition(0, 0)
+	sb.Resize(40, 2)
+
+	calls := 0
+	sb.AddInfoProvider("load", time.Second, func() string {
+		calls++
+		return fmt.Sprintf("load %d", calls)
+	})
+	sb.ShowClock("%Y")
+
+	row := func() string {
+		buf := createTestBuffer(40, 2)
+		sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 2}))
+		var r []rune
+		for _, c := range buf[1] {
+			r = append(r, c.Ch)
+		}
+		return string(r)
+	}
+	year := strconv.Itoa(time.Now().Year())
+	if got := row(); !strings.HasSuffix(got, "│ load 1 │ "+year+" ") {
+		t.Errorf("expected info then clock at the right edge, got %q", got)
+	}
+
+	now := time.Now()
+	if sb.refreshInfo(now) {
+		t.Error("nothing should change before the provider is due")
+	}
+	if !sb.refreshInfo(now.Add(2*time.Second)) || calls != 2 {
+		t.Errorf("provider should refresh once due, calls=%d", calls)
+	}
+	if got := row(); !strings.Contains(got, "load 2") {
+		t.Errorf("expected refreshed provider text, got %q", got)
+	}
+
+	sb.HideClock()
+	sb.RemoveInfoProvider("load")
+	if got := row(); strings.TrimSpace(got) != "" {
+		t.Errorf("expected an empty row, got %q", got)
+	}
+}
+
+// TestStatusBarTasks verifies the spinner shows the task name or count
+// while tasks run and disappears once they end.
+func TestStatusBarTasks(t *testing.T) {
+	sb := NewStatusBar()
+	sb.SetPosition(0, 0)
+	sb.Resize(40, 2)
+
+	row := func() string {
+		buf := createTestBuffer(40, 2)
+		sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 2}))
+		var r []rune
+		for _, c := range buf[1] {
+			r = append(r, c.Ch)
+		}
+		return string(r)
+	}
+
+	sb.BeginTask("Indexing")
+	if got := row(); !strings.HasSuffix(got, " Indexing ") || !strings.ContainsAny(got, string(spinnerFrames)) {
+		t.Errorf("expected spinner with task name, got %q", got)
+	}
+	sb.BeginTask("Fetching")
+	if got := row(); !strings.HasSuffix(got, " 2 tasks ") {
+		t.Errorf("expected task count, got %q", got)
+	}
+	if !sb.busy() {
+		t.Error("status bar should be busy while tasks run")
+	}
+
+	sb.EndTask("Indexing")
+	sb.EndTask("Unknown")
+	if got := sb.ActiveTasks(); len(got) != 1 || got[0] != "Fetching" {
+		t.Errorf("ActiveTasks = %v, want [Fetching]", got)
+	}
+	sb.EndTask("Fetching")
+	if got := row(); strings.TrimSpace(got) != "" || sb.busy() {
+		t.Errorf("spinner should disappear after the last task, got %q", got)
+	}
+}
+
+// TestStatusBarMessageAction verifies that a message action is drawn as a
+// suffix, runs on click or hotkey, and that hovering keeps it alive.
+func TestStatusBarMessageAction(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 12)
+	sb := NewStatusBar()
+	ui.SetStatusBar(sb)
+
+	row := func() string {
+		buf := createTestBuffer(40, 12)
+		sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 12}))
+		var r []rune
+		for _, c := range buf[11] {
+			r = append(r, c.Ch)
+		}
+		return string(r)
+	}
+
+	undos := 0
+	undo := MessageAction{Label: "Undo", Key: tcell.KeyCtrlZ, Run: func() { undos++ }}
+	sb.ShowWithAction("Deleted 3 rows", MessageInfo, undo)
+	if got := row(); !strings.HasSuffix(got, "Deleted 3 rows [Undo C-z] ") {
+		t.Fatalf("expected action suffix, got %q", got)
+	}
+
+	// Click on the action (columns 30-37 of the content row)
+	ui.HandleMouse(tcell.NewEventMouse(32, 11, tcell.Button1, tcell.ModNone))
+	ui.HandleMouse(tcell.NewEventMouse(32, 11, tcell.ButtonNone, tcell.ModNone))
+	if undos != 1 || len(sb.messages) != 0 {
+		t.Fatalf("click should run and dismiss the action, runs=%d msgs=%d", undos, len(sb.messages))
+	}
+
+	// Hotkey while shown
+	sb.ShowWithAction("Deleted 3 rows", MessageInfo, undo)
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModCtrl))
+	if undos != 2 {
+		t.Errorf("Ctrl+Z should run the action, runs=%d", undos)
+	}
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyCtrlZ, 0, tcell.ModCtrl))
+	if undos != 2 {
+		t.Error("hotkey should do nothing once the message is gone")
+	}
+
+	// Hovering keeps a nearly expired message alive
+	sb.ShowWithActionDuration("Deleted 3 rows", MessageInfo, undo, 10*time.Millisecond)
+	row()
+	ui.HandleMouse(tcell.NewEventMouse(20, 11, tcell.ButtonNone, tcell.ModNone))
+	time.Sleep(20 * time.Millisecond)
+	sb.expireMessages()
+	if len(sb.messages) != 1 {
+		t.Error("hovered message should not expire")
+	}
+	ui.HandleMouse(tcell.NewEventMouse(20, 2, tcell.ButtonNone, tcell.ModNone))
+	if sb.msgHovered {
+		t.Error("moving away should end the hover")
+	}
+}
+
+// TestStatusBarPlainHotkeyYieldsToFocus verifies that a plain character
+// hotkey is typed into a focused input rather than running the action.
+func TestStatusBarPlainHotkeyYieldsToFocus(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 12)
+	sb := NewStatusBar()
+	ui.SetStatusBar(sb)
+	input := NewInput()
+	ui.SetRootWidget(input)
+	ui.Focus(input)
+
+	undos := 0
+	undo := MessageAction{Label: "Undo", Key: tcell.KeyRune, Rune: 'u', Run: func() { undos++ }}
+	sb.ShowWithAction("Deleted 3 rows", MessageInfo, undo)
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone))
+	if undos != 0 || input.Text != "u" {
+		t.Fatalf("expected u typed into the input, runs=%d text=%q", undos, input.Text)
+	}
+
+	// Without a widget taking it, the hotkey runs the action
+	button := NewButton("OK")
+	ui.SetRootWidget(button)
+	ui.Focus(button)
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'u', tcell.ModNone))
+	if undos != 1 {
+		t.Errorf("expected the unhandled u to run the action, runs=%d", undos)
+	}
+}
+
+// TestStatusBarStackedRows verifies that a taller status bar shrinks the
+// content area and wraps messages on the rows below the key hints.
+func TestStatusBarStackedRows(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(30, 12)
+	root := NewPane()
+	ui.SetRootWidget(root)
+	sb := NewStatusBar()
+	ui.SetStatusBar(sb)
+
+	ui.SetStatusBarHeight(4)
+	if ui.ContentHeight() != 8 || ui.StatusBarHeight() != 4 {
+		t.Fatalf("content height = %d, want 8", ui.ContentHeight())
+	}
+	if _, h := root.Size(); h != 8 {
+		t.Errorf("root widget should relayout to height 8, got %d", h)
+	}
+	if x, y := sb.Position(); x != 0 || y != 8 {
+		t.Errorf("status bar at %d,%d, want 0,8", x, y)
+	}
+
+	sb.ShowWithAction("Saved the file and reformatted imports", MessageInfo,
+		MessageAction{Label: "Undo", Run: func() {}})
+	buf := createTestBuffer(30, 12)
+	sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 30, H: 12}))
+	line := func(y int) string {
+		var r []rune
+		for _, c := range buf[y] {
+			r = append(r, c.Ch)
+		}
+		return strings.TrimRight(string(r), " ")
+	}
+	if got := line(10); got != " Saved the file and" {
+		t.Errorf("row 10 = %q", got)
+	}
+	if got := line(11); got != " reformatted imports [Undo]" {
+		t.Errorf("row 11 = %q", got)
+	}
+	if sb.actionRect != (core.Rect{X: 21, Y: 11, W: 6, H: 1}) {
+		t.Errorf("action rect = %+v", sb.actionRect)
+	}
+
+	ui.SetStatusBarHeight(2)
+	if ui.ContentHeight() != 10 {
+		t.Errorf("content height = %d after shrinking the status bar, want 10", ui.ContentHeight())
+	}
+}
+
+// TestStatusBarCommandPrompt verifies the ':' prompt: it opens on unhandled
+// ':' only, captures keys, completes, keeps history and restores the bar.
+func TestStatusBarCommandPrompt(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(40, 12)
+	sb := NewStatusBar()
+	ui.SetStatusBar(sb)
+	in := NewInput()
+	btn := NewButton("OK")
+	ui.AddWidget(in)
+	ui.AddWidget(btn)
+	ui.Focus(in)
+
+	row := func() string {
+		buf := createTestBuffer(40, 12)
+		sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 12}))
+		var r []rune
+		for _, c := range buf[11] {
+			r = append(r, c.Ch)
+		}
+		return string(r)
+	}
+	typeText := func(s string) {
+		for _, r := range s {
+			ui.HandleKey(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+		}
+	}
+	press := func(k tcell.Key) { ui.HandleKey(tcell.NewEventKey(k, 0, tcell.ModNone)) }
+
+	var cmds []string
+	sb.EnableCommandPrompt(func(cmd string) { cmds = append(cmds, cmd) }, func(input string) []string {
+		var out []string
+		for _, c := range []string{"quit", "qall", "write"} {
+			if strings.HasPrefix(c, input) {
+				out = append(out, c)
+			}
+		}
+		return out
+	})
+
+	// The focused input takes ':' itself
+	typeText(":")
+	if sb.PromptActive() || in.Text != ":" {
+		t.Fatalf("focused input should get ':', prompt=%v text=%q", sb.PromptActive(), in.Text)
+	}
+
+	// When the focused widget ignores it, ':' opens the prompt
+	ui.Focus(btn)
+	before := row()
+	typeText(":wq")
+	if !sb.PromptActive() {
+		t.Fatal("':' should open the prompt")
+	}
+	if got := row(); !strings.HasPrefix(got, " :wq") {
+		t.Errorf("prompt row = %q", got)
+	}
+	press(tcell.KeyEnter)
+	if sb.PromptActive() || len(cmds) != 1 || cmds[0] != "wq" {
+		t.Fatalf("Enter should submit, active=%v cmds=%v", sb.PromptActive(), cmds)
+	}
+	if got := row(); got != before {
+		t.Errorf("hints not restored: %q, want %q", got, before)
+	}
+
+	// Tab cycles completions, Shift+Tab goes back
+	typeText(":q")
+	press(tcell.KeyTab)
+	if got := row(); !strings.HasPrefix(got, " :quit") || !strings.HasSuffix(got, "1/2 ") {
+		t.Errorf("first completion row = %q", got)
+	}
+	press(tcell.KeyTab)
+	if sb.prompt.input.Text != "qall" {
+		t.Errorf("second completion = %q", sb.prompt.input.Text)
+	}
+	press(tcell.KeyBacktab)
+	if sb.prompt.input.Text != "quit" {
+		t.Errorf("Shift+Tab completion = %q", sb.prompt.input.Text)
+	}
+
+	// History browsing keeps the draft; Esc cancels without submitting
+	press(tcell.KeyUp)
+	if sb.prompt.input.Text != "wq" {
+		t.Errorf("Up should recall history, got %q", sb.prompt.input.Text)
+	}
+	press(tcell.KeyDown)
+	if sb.prompt.input.Text != "quit" {
+		t.Errorf("Down should restore the draft, got %q", sb.prompt.input.Text)
+	}
+	press(tcell.KeyEscape)
+	if sb.PromptActive() || len(cmds) != 1 {
+		t.Errorf("Esc should cancel, active=%v cmds=%v", sb.PromptActive(), cmds)
+	}
+}
+
+// TestStatusBarMessageSources verifies muting, level filtering and
+// prioritization of tagged messages.
+func TestStatusBarMessageSources(t *testing.T) {
+	sb := NewStatusBar()
+	active := func() string {
+		sb.mu.Lock()
+		defer sb.mu.Unlock()
+		if msg := sb.getActiveMessage(); msg != nil {
+			return msg.Text
+		}
+		return ""
+	}
+
+	// Muting drops new and queued messages from the source
+	sb.ShowFrom("sync", "syncing", MessageInfo)
+	sb.MuteSource("sync")
+	sb.ShowFrom("sync", "sync failed", MessageError)
+	if got := active(); got != "" || !sb.IsSourceMuted("sync") {
+		t.Errorf("muted source shown: %q", got)
+	}
+	sb.UnmuteSource("sync")
+
+	// A minimum level keeps a chatty source's errors only
+	sb.SetSourceMinLevel("lsp", MessageError)
+	sb.ShowFrom("lsp", "indexing", MessageWarning)
+	if got := active(); got != "" {
+		t.Errorf("filtered message shown: %q", got)
+	}
+
+	// Priority breaks ties between levels, never overrides them
+	sb.SetSourcePriority("build", 5)
+	sb.ShowFrom("sync", "synced", MessageInfo)
+	sb.ShowFrom("build", "built", MessageInfo)
+	if got := active(); got != "built" {
+		t.Errorf("higher priority source should win, got %q", got)
+	}
+	sb.ShowFrom("sync", "conflict", MessageWarning)
+	if got := active(); got != "conflict" {
+		t.Errorf("higher level should win, got %q", got)
+	}
+
+	// A flood of chatty messages doesn't evict an error
+	sb.ClearMessages()
+	sb.ShowError("disk full")
+	for i := 0; i < 20; i++ {
+		sb.ShowFrom("sync", "tick "+strconv.Itoa(i), MessageInfo)
+	}
+	if got := active(); got != "disk full" || len(sb.messages) != 10 {
+		t.Errorf("error evicted by chatty source: active=%q queued=%d", got, len(sb.messages))
+	}
+}
+
+// TestStatusBarHintPriority verifies that low-priority hints are dropped
+// first on a narrow bar, with an F1 hint listing all of them.
+func TestStatusBarHintPriority(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(44, 10)
+	sb := NewStatusBar()
+	ui.SetStatusBar(sb)
+	tl := NewTabLayout([]primitives.TabItem{{Label: "One"}, {Label: "Two"}})
+	ui.AddWidget(tl)
+	ui.Focus(tl)
+
+	row := func() string {
+		buf := createTestBuffer(44, 10)
+		sb.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 44, H: 10}))
+		var r []rune
+		for _, c := range buf[9] {
+			r = append(r, c.Ch)
+		}
+		return strings.TrimSpace(string(r))
+	}
+
+	if got := row(); strings.Contains(got, "1-9:Jump") || !strings.Contains(got, "←→:Switch") || !strings.Contains(got, "↓:Content") {
+		t.Errorf("low-priority hints should be dropped first, got %q", got)
+	}
+	if strings.Contains(row(), "F1") {
+		t.Error("F1 hint shown without OnMoreHints")
+	}
+
+	var all []core.KeyHint
+	sb.OnMoreHints = func(hints []core.KeyHint) { all = hints }
+	if got := row(); !strings.HasSuffix(got, "F1:More…") {
+		t.Errorf("expected More hint, got %q", got)
+	}
+	ui.HandleKey(tcell.NewEventKey(tcell.KeyF1, 0, tcell.ModNone))
+	if !hasKeyHint(all, "1-9") || !hasKeyHint(all, "←→") {
+		t.Errorf("F1 should pass the full hint list, got %v", all)
+	}
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/framegrace/texelui/color"
 	"github.com/framegrace/texelui/core"
 	"github.com/framegrace/texelui/primitives"
-	"github.com/framegrace/texelui/theme"
 )
 
 // TabLayout is a container that combines a TabBar with switchable content panels.
@@ -27,29 +26,64 @@ type TabLayout struct {
 	focusArea int
 	// trapsFocus: if true, wraps focus at boundaries instead of returning false
 	trapsFocus bool
+
+	// OnReorder is called after a tab is moved (drag, Alt+Left/Right or MoveTab).
+	// Content widgets have already been moved along with their tabs.
+	OnReorder func(from, to int)
+
+	// OnCloseTab is called before CloseTab removes a tab (including from the
+	// tab context menu). Returning false keeps the tab open, e.g. to confirm
+	// discarding unsaved changes first.
+	OnCloseTab func(idx int) bool
+
+	// ContextActions are app-supplied entries appended to the tab context
+	// menu after Close, Close Others and Close to the Right.
+	ContextActions []TabAction
+
+	menu *ContextMenu // Tab context menu
+}
+
+// TabAction is a custom entry in the tab context menu. Run receives the
+// index of the tab the menu was opened on.
+type TabAction struct {
+	Label string
+	Run   func(idx int)
 }
 
 // NewTabLayout creates a new tab layout with the specified tabs.
 // Position defaults to 0,0 and size to 1x1.
 // Use SetPosition and Resize to adjust after adding to a layout.
 func NewTabLayout(tabs []primitives.TabItem) *TabLayout {
-	tm := theme.Get()
-	bg := tm.GetSemanticColor("bg.surface")
-	fg := tm.GetSemanticColor("text.primary")
-
 	tl := &TabLayout{
-		Style:    color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)},
 		tabBar:   primitives.NewTabBar(0, 0, 1, tabs),
 		children: make([]core.Widget, len(tabs)),
 	}
+	tl.SetThemeStyle(&tl.Style, themeSurfaceStyle())
 
 	tl.SetPosition(0, 0)
 	tl.Resize(1, 1)
 	tl.SetFocusable(true)
 
-	// Wire up tab change to trigger redraw
+	// Wire up tab change to trigger redraw; the cascade only reaches the
+	// active tab's content
 	tl.tabBar.OnChange = func(idx int) {
+		core.InvalidateCascade()
+		tl.invalidate()
+	}
+
+	// Keep content in step with reordered tabs
+	tl.tabBar.OnReorder = func(from, to int) {
+		child := tl.children[from]
+		if from < to {
+			copy(tl.children[from:to], tl.children[from+1:to+1])
+		} else {
+			copy(tl.children[to+1:from+1], tl.children[to:from])
+		}
+		tl.children[to] = child
 		tl.invalidate()
+		if tl.OnReorder != nil {
+			tl.OnReorder(from, to)
+		}
 	}
 
 	// Wire up Up/Down focus cycling from tab bar
@@ -57,9 +91,31 @@ func NewTabLayout(tabs []primitives.TabItem) *TabLayout {
 		tl.CycleFocus(forward)
 	}
 
+	// Right-click (or the Menu key) on a tab opens its context menu
+	tl.menu = NewContextMenu()
+	tl.menu.OnClose = func() {
+		if tl.IsFocused() && tl.focusArea == 0 {
+			tl.tabBar.Focus()
+		}
+		tl.invalidate()
+	}
+	tl.tabBar.OnContextMenu = tl.openTabMenu
+
 	return tl
 }
 
+// OnThemeChanged implements core.ThemeAware. The inactive tabs, which
+// VisitChildren skips, are restyled here too.
+func (tl *TabLayout) OnThemeChanged() {
+	tl.SetThemeStyle(&tl.Style, themeSurfaceStyle())
+	active := tl.activeChild()
+	for _, child := range tl.children {
+		if child != nil && child != active {
+			core.NotifyThemeChanged(child)
+		}
+	}
+}
+
 // SetTabContent sets the content widget for a specific tab index.
 func (tl *TabLayout) SetTabContent(idx int, w core.Widget) {
 	if idx < 0 || idx >= len(tl.children) {
@@ -67,6 +123,7 @@ func (tl *TabLayout) SetTabContent(idx int, w core.Widget) {
 	}
 	tl.children[idx] = w
 	if w != nil {
+		core.InvalidateCascade()
 		// Position in content area
 		cr := tl.contentRect()
 		w.SetPosition(cr.X, cr.Y)
@@ -78,6 +135,156 @@ func (tl *TabLayout) SetTabContent(idx int, w core.Widget) {
 	}
 }
 
+// AddTab appends a tab with its content widget and returns its index.
+func (tl *TabLayout) AddTab(item primitives.TabItem, content core.Widget) int {
+	tl.tabBar.Tabs = append(tl.tabBar.Tabs, item)
+	tl.children = append(tl.children, nil)
+	idx := len(tl.children) - 1
+	tl.SetTabContent(idx, content)
+	tl.invalidate()
+	return idx
+}
+
+// RemoveTab removes the tab at idx together with its content.
+// Removing the active tab activates its right neighbor (or the new last
+// tab); if the content had focus, focus moves to the tab bar.
+func (tl *TabLayout) RemoveTab(idx int) {
+	if idx < 0 || idx >= len(tl.children) {
+		return
+	}
+	tb := tl.tabBar
+	tb.CancelEdit()
+	tb.ClearHover()
+
+	wasActive := idx == tb.ActiveIdx
+	if wasActive && tl.focusArea == 1 {
+		tl.blurContentFocus()
+		tl.focusArea = 0
+		if tl.IsFocused() {
+			tb.Focus()
+		}
+	}
+
+	tb.Tabs = append(tb.Tabs[:idx], tb.Tabs[idx+1:]...)
+	tl.children = append(tl.children[:idx], tl.children[idx+1:]...)
+
+	switch {
+	case idx < tb.ActiveIdx:
+		tb.ActiveIdx--
+	case wasActive:
+		if tb.ActiveIdx >= len(tb.Tabs) {
+			tb.ActiveIdx = len(tb.Tabs) - 1
+		}
+		if tb.ActiveIdx >= 0 && tb.OnChange != nil {
+			tb.OnChange(tb.ActiveIdx)
+		}
+	}
+	if len(tb.Tabs) == 0 {
+		tb.ActiveIdx = 0
+		tl.focusArea = 0
+	}
+	tl.invalidate()
+}
+
+// CloseTab removes the tab at idx unless OnCloseTab vetoes it.
+// Returns true if the tab was removed.
+func (tl *TabLayout) CloseTab(idx int) bool {
+	if idx < 0 || idx >= len(tl.children) {
+		return false
+	}
+	if tl.OnCloseTab != nil && !tl.OnCloseTab(idx) {
+		return false
+	}
+	tl.RemoveTab(idx)
+	return true
+}
+
+// CloseOtherTabs closes every tab except idx, which becomes active.
+// Tabs whose close is vetoed stay open.
+func (tl *TabLayout) CloseOtherTabs(idx int) {
+	if idx < 0 || idx >= len(tl.children) {
+		return
+	}
+	tl.CloseTabsToRight(idx)
+	for i := idx - 1; i >= 0; i-- {
+		if tl.CloseTab(i) {
+			idx--
+		}
+	}
+	tl.switchTab(idx)
+}
+
+// CloseTabsToRight closes every tab after idx. Tabs whose close is
+// vetoed stay open.
+func (tl *TabLayout) CloseTabsToRight(idx int) {
+	for i := len(tl.children) - 1; i > idx && i >= 0; i-- {
+		tl.CloseTab(i)
+	}
+}
+
+// openTabMenu shows the context menu for tab idx at screen position x, y.
+func (tl *TabLayout) openTabMenu(idx, x, y int) {
+	last := len(tl.children) - 1
+	items := []MenuItem{
+		{Label: "Close", Action: func() { tl.CloseTab(idx) }},
+		{Label: "Close Others", Action: func() { tl.CloseOtherTabs(idx) }, Disabled: last == 0},
+		{Label: "Close to the Right", Action: func() { tl.CloseTabsToRight(idx) }, Disabled: idx == last},
+	}
+	for _, a := range tl.ContextActions {
+		run := a.Run
+		items = append(items, MenuItem{Label: a.Label, Action: func() {
+			if run != nil {
+				run(idx)
+			}
+		}})
+	}
+	tl.tabBar.Blur()
+	tl.menu.Show(items, x, y+1)
+	tl.invalidate()
+}
+
+// MoveTab moves the tab at from, with its content, to index to.
+func (tl *TabLayout) MoveTab(from, to int) {
+	tl.tabBar.MoveTab(from, to)
+}
+
+// SetTabTitle changes the label of the tab at idx.
+func (tl *TabLayout) SetTabTitle(idx int, title string) {
+	if idx < 0 || idx >= len(tl.tabBar.Tabs) {
+		return
+	}
+	tl.tabBar.Tabs[idx].Label = title
+	tl.invalidate()
+}
+
+// SetTabBadge sets a small badge on the tab at idx, such as an unread
+// count or primitives.BadgeDot. Pass "" to remove it.
+func (tl *TabLayout) SetTabBadge(idx int, badge string) {
+	tl.tabBar.SetBadge(idx, badge)
+}
+
+// SetTabModified marks the tab at idx as having unsaved changes, shown as
+// a "•" before its title.
+func (tl *TabLayout) SetTabModified(idx int, modified bool) {
+	tl.tabBar.SetModified(idx, modified)
+}
+
+// HasDirtyTabs reports whether any tab is marked modified, e.g. to warn
+// before closing.
+func (tl *TabLayout) HasDirtyTabs() bool {
+	for _, tab := range tl.tabBar.Tabs {
+		if tab.Modified {
+			return true
+		}
+	}
+	return false
+}
+
+// TabCount returns the number of tabs.
+func (tl *TabLayout) TabCount() int {
+	return len(tl.children)
+}
+
 // ActiveIndex returns the currently active tab index.
 func (tl *TabLayout) ActiveIndex() int {
 	return tl.tabBar.ActiveIdx
@@ -88,6 +295,56 @@ func (tl *TabLayout) SetActive(idx int) {
 	tl.tabBar.SetActive(idx)
 }
 
+// HandleNavigation implements core.NavigationTarget. It handles the tab
+// switching actions (Ctrl+Tab, Ctrl+Shift+Tab, Alt+1..9), which the
+// UIManager offers even while focus is inside the content.
+func (tl *TabLayout) HandleNavigation(action core.NavAction) bool {
+	n := len(tl.children)
+	if n == 0 {
+		return false
+	}
+	idx := tl.tabBar.ActiveIdx
+	switch {
+	case action == core.NavNextTab:
+		idx = (idx + 1) % n
+	case action == core.NavPrevTab:
+		idx = (idx - 1 + n) % n
+	case action.IsTabAction():
+		idx = int(action - core.NavTab1)
+		if idx >= n {
+			return false
+		}
+	default:
+		return false
+	}
+	tl.switchTab(idx)
+	return true
+}
+
+// switchTab activates tab idx. If the content had focus, focus moves to
+// the new tab's first focusable widget (or the tab bar if it has none).
+func (tl *TabLayout) switchTab(idx int) {
+	if idx == tl.tabBar.ActiveIdx {
+		return
+	}
+	if tl.focusArea != 1 {
+		tl.tabBar.SetActive(idx)
+		return
+	}
+	tl.blurContentFocus()
+	tl.tabBar.SetActive(idx)
+	if child := tl.activeChild(); child != nil {
+		if first := tl.findFirstFocusable(child); first != nil {
+			first.Focus()
+			tl.invalidate()
+			return
+		}
+	}
+	tl.focusArea = 0
+	tl.tabBar.Focus()
+	tl.invalidate()
+}
+
 // SetTrapsFocus sets whether this TabLayout wraps focus at boundaries.
 // Set to true for root containers that should cycle focus internally.
 func (tl *TabLayout) SetTrapsFocus(trap bool) {
@@ -261,11 +518,21 @@ func (tl *TabLayout) Draw(p *core.Painter) {
 	activeIdx := tl.tabBar.ActiveIdx
 	if activeIdx >= 0 && activeIdx < len(tl.children) && tl.children[activeIdx] != nil {
 		tl.children[activeIdx].Draw(p)
+		if core.IsDisabled(tl.children[activeIdx]) {
+			muteWidget(p, tl.children[activeIdx])
+		}
 	}
+
+	// Context menu over everything
+	tl.menu.Draw(p)
 }
 
 // HandleKey processes keyboard input.
 func (tl *TabLayout) HandleKey(ev *tcell.EventKey) bool {
+	if tl.menu.IsOpen() {
+		return tl.menu.HandleKey(ev)
+	}
+
 	// Handle Tab/Shift-Tab for focus cycling
 	if ev.Key() == tcell.KeyTab || ev.Key() == tcell.KeyBacktab {
 		forward := ev.Key() == tcell.KeyTab && ev.Modifiers()&tcell.ModShift == 0
@@ -299,6 +566,14 @@ func (tl *TabLayout) HandleKey(ev *tcell.EventKey) bool {
 // HandleMouse processes mouse input.
 func (tl *TabLayout) HandleMouse(ev *tcell.EventMouse) bool {
 	x, y := ev.Position()
+	if tl.menu.IsOpen() {
+		if tl.menu.HandleMouse(ev) {
+			return true
+		}
+		if tl.menu.IsOpen() {
+			return false
+		}
+	}
 	if !tl.HitTest(x, y) {
 		return false
 	}
@@ -320,9 +595,9 @@ func (tl *TabLayout) HandleMouse(ev *tcell.EventMouse) bool {
 	// Mouse not on tab bar - clear hover
 	tl.tabBar.ClearHover()
 
-	// Click is on content area
+	// Click is on content area; disabled content takes no clicks
 	activeChild := tl.activeChild()
-	if activeChild != nil {
+	if activeChild != nil && !core.IsDisabled(activeChild) {
 		// Focus content area
 		if tl.focusArea != 1 {
 			tl.tabBar.Blur()
@@ -451,6 +726,7 @@ func (tl *TabLayout) blurContentFocus() {
 // Preserves focusArea for restoration when Focus() is called again.
 func (tl *TabLayout) Blur() {
 	tl.BaseWidget.Blur()
+	tl.menu.Close()
 	tl.tabBar.Blur()
 	tl.blurContentFocus()
 	// Keep focusArea as-is for restoration on next Focus()
@@ -460,6 +736,7 @@ func (tl *TabLayout) Blur() {
 func (tl *TabLayout) SetInvalidator(fn func(core.Rect)) {
 	tl.inv = fn
 	tl.tabBar.SetInvalidator(fn)
+	tl.menu.SetInvalidator(fn)
 	// Propagate to all children
 	for _, child := range tl.children {
 		if child != nil {
@@ -478,10 +755,23 @@ func (tl *TabLayout) VisitChildren(f func(core.Widget)) {
 	if activeChild := tl.activeChild(); activeChild != nil {
 		f(activeChild)
 	}
+	// Visit the context menu while it is open
+	if tl.menu.IsOpen() {
+		f(tl.menu)
+	}
+}
+
+// SetSurfaceSize implements core.SurfaceAware so the tab context menu
+// stays on screen.
+func (tl *TabLayout) SetSurfaceSize(w, h int) {
+	tl.menu.SetSurfaceSize(w, h)
 }
 
 // WidgetAt implements core.HitTester for deep focus traversal.
 func (tl *TabLayout) WidgetAt(x, y int) core.Widget {
+	if tl.menu.IsOpen() && tl.menu.HitTest(x, y) {
+		return tl.menu
+	}
 	if !tl.HitTest(x, y) {
 		return nil
 	}
@@ -524,12 +814,16 @@ func (tl *TabLayout) invalidate() {
 // GetKeyHints implements core.KeyHintsProvider.
 // Returns hints based on whether tab bar or content has focus.
 func (tl *TabLayout) GetKeyHints() []core.KeyHint {
+	if tl.menu.IsOpen() {
+		return tl.menu.GetKeyHints()
+	}
 	if tl.focusArea == 0 {
 		// Tab bar focused - includes Tab/S-Tab to suppress focus cycler hints
 		// (TabLayout handles these internally for tab bar <-> content navigation)
 		return []core.KeyHint{
 			{Key: "←→", Label: "Switch"},
-			{Key: "1-9", Label: "Jump"},
+			{Key: "A-←→", Label: "Move", Priority: -1},
+			{Key: "1-9", Label: "Jump", Priority: -1},
 			{Key: "↓", Label: "Content"},
 		}
 	}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/core"
 	"github.com/framegrace/texelui/primitives"
 )
 
@@ -285,3 +286,147 @@ func TestTabLayout_TabBarAlwaysAccessible(t *testing.T) {
 	tl.HandleKey(ev)
 	// No crash = success
 }
+
+func TestTabLayout_AddRemoveTabs(t *testing.T) {
+	tl := NewTabLayout([]primitives.TabItem{{Label: "One"}})
+	tl.Resize(40, 10)
+	first := NewInput()
+	tl.SetTabContent(0, first)
+
+	second := NewInput()
+	third := NewInput()
+	if idx := tl.AddTab(primitives.TabItem{Label: "Two"}, second); idx != 1 {
+		t.Fatalf("expected AddTab to return 1, got %d", idx)
+	}
+	tl.AddTab(primitives.TabItem{Label: "Three"}, third)
+	if tl.TabCount() != 3 {
+		t.Fatalf("expected 3 tabs, got %d", tl.TabCount())
+	}
+	if _, y := third.Position(); y != tl.contentRect().Y {
+		t.Errorf("added content should be placed in the content area, got y=%d", y)
+	}
+
+	tl.SetTabTitle(1, "Deux")
+	if tl.tabBar.Tabs[1].Label != "Deux" {
+		t.Errorf("expected renamed tab, got %q", tl.tabBar.Tabs[1].Label)
+	}
+
+	// Removing a tab before the active one keeps the same content active
+	tl.SetActive(2)
+	tl.RemoveTab(0)
+	if tl.ActiveIndex() != 1 || tl.activeChild() != third {
+		t.Errorf("expected Three to stay active at 1, got %d", tl.ActiveIndex())
+	}
+
+	// Removing the focused active tab moves focus to the tab bar
+	tl.Focus()
+	tl.CycleFocus(true)
+	if !third.IsFocused() {
+		t.Fatal("expected content focus before removal")
+	}
+	tl.RemoveTab(1)
+	if third.IsFocused() || !tl.tabBar.IsFocused() || tl.activeChild() != second {
+		t.Error("removing the active tab should focus the tab bar and activate its neighbor")
+	}
+}
+
+func TestTabLayout_ReorderMovesContent(t *testing.T) {
+	tl := NewTabLayout([]primitives.TabItem{{Label: "One"}, {Label: "Two"}})
+	tl.Resize(40, 10)
+	one, two := NewInput(), NewInput()
+	tl.SetTabContent(0, one)
+	tl.SetTabContent(1, two)
+
+	reordered := false
+	tl.OnReorder = func(from, to int) { reordered = from == 0 && to == 1 }
+	tl.Focus()
+	tl.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModAlt))
+
+	if !reordered || tl.ActiveIndex() != 1 || tl.activeChild() != one || tl.children[0] != two {
+		t.Errorf("content should follow the moved tab, active=%d", tl.ActiveIndex())
+	}
+}
+
+func TestTabLayout_DirtyTabs(t *testing.T) {
+	tl := NewTabLayout([]primitives.TabItem{{Label: "a"}, {Label: "b"}})
+	if tl.HasDirtyTabs() {
+		t.Fatal("new tabs should be clean")
+	}
+	tl.SetTabModified(1, true)
+	if !tl.HasDirtyTabs() {
+		t.Error("expected a dirty tab after SetTabModified")
+	}
+	tl.SetTabModified(1, false)
+	if tl.HasDirtyTabs() {
+		t.Error("clearing the mark should leave no dirty tabs")
+	}
+}
+
+func TestTabLayout_TabContextMenu(t *testing.T) {
+	labels := func(tl *TabLayout) string {
+		s := ""
+		for _, tab := range tl.tabBar.Tabs {
+			s += tab.Label
+		}
+		return s
+	}
+	newLayout := func() *TabLayout {
+		tl := NewTabLayout([]primitives.TabItem{{Label: "A"}, {Label: "B"}, {Label: "C"}, {Label: "D"}})
+		tl.Resize(40, 10)
+		tl.Focus()
+		return tl
+	}
+	// Layout: [tri][ A ][sep][ B ][sep][ C ][sep][ D ] -> B at 5-7
+	rightClickB := func(tl *TabLayout) {
+		tl.tabBar.HandleAuxClick(core.AuxClick{Button: tcell.Button2, X: 6, Y: 0})
+	}
+	key := func(tl *TabLayout, k tcell.Key) {
+		tl.HandleKey(tcell.NewEventKey(k, 0, tcell.ModNone))
+	}
+
+	tl := newLayout()
+	rightClickB(tl)
+	if !tl.menu.IsOpen() || !tl.menu.IsModal() {
+		t.Fatal("right-click on a tab should open the context menu")
+	}
+	key(tl, tcell.KeyDown)
+	key(tl, tcell.KeyDown) // Close to the Right
+	key(tl, tcell.KeyEnter)
+	if got := labels(tl); got != "AB" || tl.menu.IsOpen() {
+		t.Fatalf("Close to the Right on B should leave AB, got %s", got)
+	}
+	if !tl.tabBar.IsFocused() {
+		t.Error("focus should return to the tab bar after the menu closes")
+	}
+
+	// Close Others honors OnCloseTab vetoes and activates the kept tab
+	tl = newLayout()
+	tl.OnCloseTab = func(idx int) bool { return tl.tabBar.Tabs[idx].Label != "D" }
+	tl.CloseOtherTabs(1)
+	if got := labels(tl); got != "BD" || tl.ActiveIndex() != 0 {
+		t.Errorf("expected BD with B active, got %s active=%d", got, tl.ActiveIndex())
+	}
+
+	// Custom actions follow the built-in entries and receive the tab index
+	tl = newLayout()
+	ran := -1
+	tl.ContextActions = []TabAction{{Label: "Duplicate", Run: func(idx int) { ran = idx }}}
+	rightClickB(tl)
+	key(tl, tcell.KeyEnd)
+	key(tl, tcell.KeyEnter)
+	if ran != 1 {
+		t.Errorf("custom action should run for tab 1, got %d", ran)
+	}
+
+	// Esc closes without acting; Close removes the clicked tab
+	rightClickB(tl)
+	key(tl, tcell.KeyEscape)
+	if tl.menu.IsOpen() || tl.TabCount() != 4 {
+		t.Error("Esc should close the menu without closing tabs")
+	}
+	rightClickB(tl)
+	key(tl, tcell.KeyEnter)
+	if got := labels(tl); got != "ACD" {
+		t.Errorf("Close on B should leave ACD, got %s", got)
+	}
+}
@@ -23,6 +23,9 @@ type TextArea struct {
 	// Optional blur callback
 	OnBlur func(text string)
 
+	// Clipboard for copy and paste, set by the UIManager
+	clipboard core.ClipboardService
+
 	// Internal components
 	scrollPane *scroll.ScrollPane
 	content    *textAreaContent
@@ -41,8 +44,12 @@ type textAreaContent struct {
 	CaretX int
 	CaretY int
 
-	// local clipboard (paste only for now)
+	// local clipboard, used when no clipboard service is set
 	clip string
+	// Selection between the anchor and the caret, made by dragging
+	anchorX, anchorY int
+	hasAnchor        bool
+	dragging         bool
 	// insert vs replace mode: false=insert (default), true=replace (overwrite)
 	replaceMode bool
 	// editing: when false, Up/Down pass through for focus cycling.
@@ -55,20 +62,7 @@ type textAreaContent struct {
 // NewTextArea creates a multi-line text input widget.
 // Position defaults to 0,0 and size defaults to 20x4. Use SetPosition() and Resize() to configure.
 func NewTextArea() *TextArea {
-	tm := theme.Get()
-	bg := tm.GetSemanticColor("bg.surface")
-	fg := tm.GetSemanticColor("text.primary")
-	caret := tm.GetSemanticColor("caret")
-
-	ta := &TextArea{
-		Style: color.DynamicStyle{
-			FG: color.Solid(fg),
-			BG: color.Solid(bg),
-		},
-		CaretStyle: color.DynamicStyle{
-			FG: color.Solid(caret),
-		},
-	}
+	ta := &TextArea{}
 
 	// Create internal content
 	// Note: content is NOT focusable - TextArea is the focusable widget,
@@ -78,20 +72,41 @@ func NewTextArea() *TextArea {
 		Lines:     []string{""},
 		wrapWidth: 20,
 	}
-	ta.content.SetFocusedStyle(tcell.StyleDefault.Background(bg).Foreground(fg), true)
 
 	// Create internal ScrollPane
 	ta.scrollPane = scroll.NewScrollPane()
 	ta.scrollPane.SetChild(ta.content)
 
 	// Enable focused styling
-	ta.SetFocusedStyle(tcell.StyleDefault.Background(bg).Foreground(fg), true)
+	ta.applyTheme()
 	ta.Resize(20, 4) // Default size
 	ta.SetFocusable(true)
 
 	return ta
 }
 
+// applyTheme resolves the text, caret and focused styles from the current
+// theme. The internal ScrollPane is restyled by the UIManager tree walk.
+func (t *TextArea) applyTheme() {
+	tm := theme.Get()
+	bg := tm.GetSemanticColor("bg.surface")
+	fg := tm.GetSemanticColor("text.primary")
+	caret := tm.GetSemanticColor("caret")
+
+	t.SetThemeStyle(&t.Style, color.DynamicStyle{
+		FG: color.Solid(fg),
+		BG: color.Solid(bg),
+	})
+	t.SetThemeStyle(&t.CaretStyle, color.DynamicStyle{
+		FG: color.Solid(caret),
+	})
+	t.content.SetFocusedStyle(tcell.StyleDefault.Background(bg).Foreground(fg), true)
+	t.SetFocusedStyle(tcell.StyleDefault.Background(bg).Foreground(fg), true)
+}
+
+// OnThemeChanged implements core.ThemeAware.
+func (t *TextArea) OnThemeChanged() { t.applyTheme() }
+
 // SetInvalidator allows the UI manager to inject a dirty-region invalidator.
 func (t *TextArea) SetInvalidator(fn func(core.Rect)) {
 	t.inv = fn
@@ -143,6 +158,50 @@ func (t *TextArea) GetKeyHints() []core.KeyHint {
 	}
 }
 
+// HandleNavigation implements core.NavigationTarget for keymap profiles.
+// Navigation bindings only apply outside edit mode, so typed text is never
+// swallowed.
+func (t *TextArea) HandleNavigation(action core.NavAction) bool {
+	c := t.content
+	if c.editing {
+		return false
+	}
+	moveLines := func(n int) bool {
+		c.CaretY += n
+		c.clampCaret()
+		c.ensureCaretVisible()
+		t.invalidate()
+		return true
+	}
+	page := t.Rect.H
+	if page < 1 {
+		page = 1
+	}
+	switch action {
+	case core.NavUp:
+		return moveLines(-1)
+	case core.NavDown:
+		return moveLines(1)
+	case core.NavLeft:
+		return c.HandleKey(tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone))
+	case core.NavRight:
+		return c.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+	case core.NavTop:
+		return c.HandleKey(tcell.NewEventKey(tcell.KeyHome, 0, tcell.ModCtrl))
+	case core.NavBottom:
+		return c.HandleKey(tcell.NewEventKey(tcell.KeyEnd, 0, tcell.ModCtrl))
+	case core.NavPageUp:
+		return moveLines(-page)
+	case core.NavPageDown:
+		return moveLines(page)
+	case core.NavHalfPageUp:
+		return moveLines(-page / 2)
+	case core.NavHalfPageDown:
+		return moveLines(page / 2)
+	}
+	return false
+}
+
 // IsMultiline implements core.MultilineWidget.
 func (t *TextArea) IsMultiline() bool {
 	return true
@@ -188,6 +247,7 @@ func (t *TextArea) SetText(text string) {
 	}
 	t.content.CaretX = 0
 	t.content.CaretY = 0
+	t.content.hasAnchor = false
 	// Update content height
 	contentH := t.content.totalVisualRows()
 	t.content.Resize(t.content.wrapWidth, contentH)
@@ -198,6 +258,29 @@ func (t *TextArea) SetText(text string) {
 	t.invalidate()
 }
 
+// AppendText adds text at the end of the content without moving the caret.
+// Newlines in text start new lines.
+func (t *TextArea) AppendText(text string) {
+	if t.content == nil || text == "" {
+		return
+	}
+	parts := strings.Split(text, "\n")
+	last := len(t.content.Lines) - 1
+	t.content.Lines[last] += parts[0]
+	t.content.Lines = append(t.content.Lines, parts[1:]...)
+	t.updateContentSize()
+	t.onChange()
+	t.invalidate()
+}
+
+// SetFollowTail keeps the view pinned to the last line as text is appended,
+// until the user scrolls up. See scroll.ScrollPane.SetFollowTail.
+func (t *TextArea) SetFollowTail(enabled bool) {
+	if t.scrollPane != nil {
+		t.scrollPane.SetFollowTail(enabled)
+	}
+}
+
 // onChange triggers the OnChange callback if set.
 func (t *TextArea) onChange() {
 	if t.OnChange != nil {
@@ -229,6 +312,114 @@ func (t *TextArea) HandleMouse(ev *tcell.EventMouse) bool {
 	return false
 }
 
+// HandleAuxClick implements core.AuxClickAware: a middle click moves the
+// caret to the pointer and pastes the clipboard there, or the text last
+// kept locally when no clipboard service is set.
+func (t *TextArea) HandleAuxClick(click core.AuxClick) bool {
+	if click.Button != tcell.Button3 || !t.content.HitTest(click.X, click.Y) {
+		return false
+	}
+	t.content.moveCaretTo(click.X, click.Y)
+	t.paste()
+	return true
+}
+
+// copySelection puts the selected text on the clipboard. Returns false
+// when nothing is selected, leaving Ctrl+C to the app.
+func (t *TextArea) copySelection() bool {
+	text := t.SelectedText()
+	if text == "" {
+		return false
+	}
+	t.content.clip = text
+	if t.clipboard != nil {
+		t.clipboard.SetClipboard("text/plain", []byte(text))
+	}
+	return true
+}
+
+// paste inserts the clipboard text at the caret, or the text last copied
+// locally when no clipboard service is set.
+func (t *TextArea) paste() bool {
+	text := t.content.clip
+	if t.clipboard != nil {
+		if _, data, ok := t.clipboard.GetClipboard(); ok {
+			text = string(data)
+		}
+	}
+	return t.HandlePaste(text)
+}
+
+// HandlePaste implements core.PasteAware: the text is inserted at the
+// caret in one piece.
+func (t *TextArea) HandlePaste(text string) bool {
+	if t.content.deleteSelection() && text == "" {
+		t.updateContentSize()
+		t.onChange()
+		t.invalidate()
+	}
+	if text != "" {
+		t.content.insertText(text)
+		t.content.ensureCaretVisible()
+	}
+	return true
+}
+
+// GrabsPointer implements core.PointerGrabber: the mouse is held while
+// dragging a selection, so the drag keeps going outside the text area.
+func (t *TextArea) GrabsPointer() bool { return t.content.dragging }
+
+// HandleGrabbedMouse implements core.PointerGrabber. It extends the
+// selection to the pointer; above or below the viewport it scrolls a row
+// per event.
+func (t *TextArea) HandleGrabbedMouse(ev *tcell.EventMouse, _ core.Cell) {
+	c := t.content
+	if ev.Buttons()&tcell.Button1 == 0 {
+		c.endDrag()
+		return
+	}
+	x, y := ev.Position()
+	row := y - t.Rect.Y
+	if row < 0 {
+		t.scrollPane.ScrollBy(-1)
+		row = 0
+	} else if row >= t.Rect.H {
+		t.scrollPane.ScrollBy(1)
+		row = t.Rect.H - 1
+	}
+	c.placeCaret(x-t.Rect.X, row+t.scrollPane.ScrollOffset())
+}
+
+// SelectedText returns the selected text, lines joined with newlines, or
+// "" if none.
+func (t *TextArea) SelectedText() string {
+	c := t.content
+	y0, x0, y1, x1, ok := c.selection()
+	if !ok {
+		return ""
+	}
+	if y0 == y1 {
+		return string([]rune(c.Lines[y0])[x0:x1])
+	}
+	parts := []string{string([]rune(c.Lines[y0])[x0:])}
+	parts = append(parts, c.Lines[y0+1:y1]...)
+	parts = append(parts, string([]rune(c.Lines[y1])[:x1]))
+	return strings.Join(parts, "\n")
+}
+
+// ClearSelection drops the selection, keeping the caret.
+func (t *TextArea) ClearSelection() {
+	if t.content.hasAnchor {
+		t.content.hasAnchor = false
+		t.invalidate()
+	}
+}
+
+// SetClipboardService implements core.ClipboardAware.
+func (t *TextArea) SetClipboardService(cs core.ClipboardService) {
+	t.clipboard = cs
+}
+
 // VisitChildren implements core.ChildContainer for recursive operations.
 func (t *TextArea) VisitChildren(f func(core.Widget)) {
 	if t.scrollPane != nil {
@@ -284,7 +475,9 @@ func (c *textAreaContent) Draw(p *core.Painter) {
 		return
 	}
 
-	// Draw all lines
+	// Draw all lines, the selection highlighted
+	selDS := ds
+	selDS.BG = color.Solid(theme.Get().GetSemanticColor("selection"))
 	globalRow := 0
 	for li := 0; li < len(c.Lines); li++ {
 		r := []rune(c.Lines[li])
@@ -300,7 +493,11 @@ func (c *textAreaContent) Draw(p *core.Painter) {
 			row := globalRow
 			col := 0
 			for i := start; i < end && col < textWidth; i++ {
-				p.SetDynamicCell(c.Rect.X+col, c.Rect.Y+row, r[i], ds)
+				cellDS := ds
+				if c.isSelected(li, i) {
+					cellDS = selDS
+				}
+				p.SetDynamicCell(c.Rect.X+col, c.Rect.Y+row, r[i], cellDS)
 				col++
 			}
 			globalRow++
@@ -345,6 +542,36 @@ func (c *textAreaContent) HandleKey(ev *tcell.EventKey) bool {
 	if !c.parent.IsFocused() {
 		return false
 	}
+	// Alt+character is left to shortcuts such as Alt+digit tab switching
+	if ev.Key() == tcell.KeyRune && ev.Modifiers()&tcell.ModAlt != 0 {
+		return false
+	}
+	// Clipboard keys act on the selection before any key clears it
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		return c.parent.copySelection()
+	case tcell.KeyCtrlV:
+		return c.parent.paste()
+	}
+	// Editing keys replace the selection
+	replaced := false
+	switch ev.Key() {
+	case tcell.KeyBackspace, tcell.KeyBackspace2, tcell.KeyDelete:
+		if c.deleteSelection() {
+			c.parent.updateContentSize()
+			c.ensureCaretVisible()
+			c.parent.onChange()
+			c.parent.invalidate()
+			return true
+		}
+	case tcell.KeyRune:
+		replaced = c.deleteSelection()
+	case tcell.KeyEnter:
+		if c.editing {
+			c.deleteSelection()
+		}
+	}
+	c.parent.ClearSelection()
 
 	// Escape exits edit mode
 	if ev.Key() == tcell.KeyEsc {
@@ -395,9 +622,8 @@ func (c *textAreaContent) HandleKey(ev *tcell.EventKey) bool {
 			c.parent.invalidate()
 			return true
 		}
-		if ev.Rune() == 'v' && c.clip != "" {
-			c.insertText(c.clip)
-			return true
+		if ev.Rune() == 'v' {
+			return c.parent.paste()
 		}
 	}
 
@@ -481,7 +707,7 @@ func (c *textAreaContent) HandleKey(ev *tcell.EventKey) bool {
 		if c.CaretX > len(line) {
 			c.CaretX = len(line)
 		}
-		if c.replaceMode && c.CaretX < len(line) {
+		if c.replaceMode && !replaced && c.CaretX < len(line) {
 			line[c.CaretX] = r
 			c.Lines[c.CaretY] = string(line)
 			c.CaretX++
@@ -513,28 +739,97 @@ func (c *textAreaContent) HandleMouse(ev *tcell.EventMouse) bool {
 		return false
 	}
 
-	// For clicks, use relative position
-	lx := x - c.Rect.X
-	ly := y - c.Rect.Y
-
 	if btn&tcell.Button1 != 0 {
-		// Click to position caret
-		li, start := c.visualRowToLogical(ly)
-		c.CaretY = li
-		segLen := c.segmentLen(li, start)
-		dx := lx
-		if dx > segLen {
-			dx = segLen
+		// Click to position caret and start a selection there; while
+		// held, extend it
+		c.moveCaretTo(x, y)
+		if !c.dragging {
+			c.anchorX, c.anchorY, c.hasAnchor = c.CaretX, c.CaretY, true
 		}
-		c.CaretX = start + dx
-		c.clampCaret()
-		c.parent.invalidate()
+		c.dragging = true
+		return true
+	}
+	if btn == tcell.ButtonNone && c.dragging {
+		c.endDrag()
 		return true
 	}
 
 	return false
 }
 
+// endDrag finishes a mouse drag; a click without movement selects nothing.
+func (c *textAreaContent) endDrag() {
+	c.dragging = false
+	if c.hasAnchor && c.anchorX == c.CaretX && c.anchorY == c.CaretY {
+		c.hasAnchor = false
+	}
+	c.parent.invalidate()
+}
+
+// selection returns the selected range from line y0, rune x0 up to line
+// y1, rune x1, in text order. ok is false when nothing is selected.
+func (c *textAreaContent) selection() (y0, x0, y1, x1 int, ok bool) {
+	if !c.hasAnchor || c.anchorY >= len(c.Lines) || c.anchorX > len([]rune(c.Lines[c.anchorY])) {
+		return 0, 0, 0, 0, false
+	}
+	y0, x0, y1, x1 = c.anchorY, c.anchorX, c.CaretY, c.CaretX
+	if y1 < y0 || y1 == y0 && x1 < x0 {
+		y0, x0, y1, x1 = y1, x1, y0, x0
+	}
+	return y0, x0, y1, x1, y0 != y1 || x0 != x1
+}
+
+// deleteSelection removes the selected text, leaving the caret where it
+// began, and drops the selection. Returns false if nothing was selected.
+// The caller reports the change.
+func (c *textAreaContent) deleteSelection() bool {
+	y0, x0, y1, x1, ok := c.selection()
+	c.parent.ClearSelection()
+	if !ok {
+		return false
+	}
+	head := []rune(c.Lines[y0])[:x0]
+	tail := []rune(c.Lines[y1])[x1:]
+	c.Lines[y0] = string(head) + string(tail)
+	c.Lines = append(c.Lines[:y0+1], c.Lines[y1+1:]...)
+	c.CaretY, c.CaretX = y0, x0
+	return true
+}
+
+// isSelected reports whether rune x of line y is selected.
+func (c *textAreaContent) isSelected(y, x int) bool {
+	y0, x0, y1, x1, ok := c.selection()
+	if !ok || y < y0 || y > y1 {
+		return false
+	}
+	return (y > y0 || x >= x0) && (y < y1 || x < x1)
+}
+
+// moveCaretTo puts the caret at the text under screen position x, y.
+func (c *textAreaContent) moveCaretTo(x, y int) {
+	c.placeCaret(x-c.Rect.X, y-c.Rect.Y)
+}
+
+// placeCaret puts the caret at column col of visual row vrow.
+func (c *textAreaContent) placeCaret(col, vrow int) {
+	if vrow < 0 {
+		vrow = 0
+	}
+	li, start := c.visualRowToLogical(vrow)
+	c.CaretY = li
+	segLen := c.segmentLen(li, start)
+	dx := col
+	if dx > segLen {
+		dx = segLen
+	}
+	if dx < 0 {
+		dx = 0
+	}
+	c.CaretX = start + dx
+	c.clampCaret()
+	c.parent.invalidate()
+}
+
 func (c *textAreaContent) clampCaret() {
 	if c.CaretY < 0 {
 		c.CaretY = 0
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gdamore/tcell/v2"
 
+	"github.com/framegrace/texelui/core"
 	"github.com/framegrace/texelui/widgets"
 )
 
@@ -117,3 +118,84 @@ func TestTextArea_EscapeWhenNotEditing(t *testing.T) {
 		t.Error("Escape should NOT be consumed when not in edit mode")
 	}
 }
+
+func TestTextArea_AppendText(t *testing.T) {
+	ta := widgets.NewTextArea()
+	ta.SetText("one")
+	ta.AppendText(" more\ntwo\n")
+	ta.AppendText("three")
+	if got, want := ta.Text(), "one more\ntwo\nthree"; got != want {
+		t.Errorf("Text() = %q, want %q", got, want)
+	}
+}
+
+func TestTextArea_DragSelectsAndAutoScrolls(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(30, 8)
+	border := widgets.NewBorder()
+	border.SetPosition(0, 0)
+	border.Resize(22, 5)
+	ta := widgets.NewTextArea()
+	ta.Resize(20, 3)
+	ta.SetText("l0\nl1\nl2\nl3\nl4\nl5")
+	border.SetChild(ta)
+	ui.AddWidget(border)
+	ui.Render()
+
+	ui.HandleMouse(tcell.NewEventMouse(1, 1, tcell.Button1, 0))
+	// Dragging below the viewport scrolls a row and keeps selecting
+	ui.HandleMouse(tcell.NewEventMouse(2, 6, tcell.Button1, 0))
+	ui.HandleMouse(tcell.NewEventMouse(2, 6, tcell.ButtonNone, 0))
+	if got := ta.SelectedText(); got != "l0\nl1\nl2\nl" {
+		t.Errorf("selection = %q, want %q", got, "l0\nl1\nl2\nl")
+	}
+
+	// A plain click selects nothing
+	ui.HandleMouse(tcell.NewEventMouse(1, 1, tcell.Button1, 0))
+	ui.HandleMouse(tcell.NewEventMouse(1, 1, tcell.ButtonNone, 0))
+	if got := ta.SelectedText(); got != "" {
+		t.Errorf("click left selection %q", got)
+	}
+}
+
+func TestTextArea_EditReplacesSelection(t *testing.T) {
+	ui := core.NewUIManager()
+	ui.Resize(30, 8)
+	border := widgets.NewBorder()
+	border.SetPosition(0, 0)
+	border.Resize(22, 6)
+	ta := widgets.NewTextArea()
+	ta.Resize(20, 4)
+	ta.SetText("one\ntwo\nthree")
+	border.SetChild(ta)
+	ui.AddWidget(border)
+	ui.Render()
+	// Coordinates are inside the border
+	drag := func(x0, y0, x1, y1 int) {
+		x0, y0, x1, y1 = x0+1, y0+1, x1+1, y1+1
+		ui.HandleMouse(tcell.NewEventMouse(x0, y0, tcell.Button1, 0))
+		ui.HandleMouse(tcell.NewEventMouse(x1, y1, tcell.Button1, 0))
+		ui.HandleMouse(tcell.NewEventMouse(x1, y1, tcell.ButtonNone, 0))
+	}
+
+	// Typing over a selection spanning lines replaces it
+	drag(1, 0, 2, 2)
+	ta.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'X', 0))
+	if got, want := ta.Text(), "oXree"; got != want {
+		t.Errorf("typing: Text() = %q, want %q", got, want)
+	}
+
+	// Backspace deletes just the selection
+	drag(0, 0, 2, 0)
+	ta.HandleKey(tcell.NewEventKey(tcell.KeyBackspace2, 0, 0))
+	if got, want := ta.Text(), "ree"; got != want {
+		t.Errorf("backspace: Text() = %q, want %q", got, want)
+	}
+
+	// Paste replaces the selection too
+	drag(0, 0, 1, 0)
+	ta.HandlePaste("a\nb")
+	if got, want := ta.Text(), "a\nbee"; got != want {
+		t.Errorf("paste: Text() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,21 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/theme.go
+// Summary: Shared theme style helpers for widgets.
+
+package widgets
+
+import (
+	"github.com/framegrace/texelui/color"
+	"github.com/framegrace/texelui/theme"
+)
+
+// themeSurfaceStyle returns primary text on the surface background, the
+// default style of containers.
+func themeSurfaceStyle() color.DynamicStyle {
+	tm := theme.Get()
+	fg := tm.GetSemanticColor("text.primary")
+	bg := tm.GetSemanticColor("bg.surface")
+	return color.DynamicStyle{FG: color.Solid(fg), BG: color.Solid(bg)}
+}
@@ -0,0 +1,199 @@
+// Copyright 2025 Texelation contributors
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// File: texelui/widgets/themeswitcher.go
+// Summary: Theme list with palette swatch previews that switches themes live.
+
+package widgets
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/primitives"
+	"github.com/framegrace/texelui/theme"
+)
+
+// themeSwatchColors are the palette colors shown in a theme's preview strip:
+// background, surface and text, then the accents.
+var themeSwatchColors = []string{
+	"base", "surface0", "text", "mauve", "blue", "green", "yellow", "red",
+}
+
+// themeSwatchWidth is the width of one color in the preview strip.
+const themeSwatchWidth = 2
+
+// ThemeSwitcher lists the available themes (palettes) with a strip of their
+// key colors, and switches to the chosen one with theme.Set. Enter or a
+// double-click applies the highlighted theme; the current one is marked.
+// Drop it into a settings screen as is.
+type ThemeSwitcher struct {
+	core.BaseWidget
+
+	// OnChange is called after a theme was applied, with its name.
+	OnChange func(name string)
+	// OnError is called if the theme could not be applied.
+	OnError func(err error)
+
+	list     *primitives.ScrollableList
+	palettes map[string]theme.Palette
+	inv      func(core.Rect)
+}
+
+// NewThemeSwitcher creates a switcher listing theme.Palettes(), with the
+// current theme highlighted.
+func NewThemeSwitcher() *ThemeSwitcher {
+	ts := &ThemeSwitcher{
+		list:     primitives.NewScrollableList(0, 0, 30, 6),
+		palettes: make(map[string]theme.Palette),
+	}
+	ts.list.RenderItem = ts.renderItem
+	ts.list.OnActivate = func(int) { ts.apply() }
+	ts.Reload()
+	ts.SetFocusable(true)
+	ts.Resize(30, 6)
+	return ts
+}
+
+// Reload re-reads the available themes, e.g. after palette files were added.
+func (ts *ThemeSwitcher) Reload() {
+	names := theme.Palettes()
+	items := make([]primitives.ListItem, 0, len(names))
+	current := ts.Current()
+	selected := 0
+	for _, name := range names {
+		p, err := theme.PaletteColors(name)
+		if err != nil {
+			continue // Broken palette file: don't offer it
+		}
+		ts.palettes[name] = p
+		if name == current {
+			selected = len(items)
+		}
+		items = append(items, primitives.ListItem{Text: name, Value: name})
+	}
+	ts.list.SetItems(items)
+	ts.list.SetSelected(selected)
+	ts.invalidate()
+}
+
+// Current returns the name of the theme in use.
+func (ts *ThemeSwitcher) Current() string {
+	return theme.Get().GetString("meta", "palette", "mocha")
+}
+
+// Selected returns the name of the highlighted theme, or "" if there is none.
+func (ts *ThemeSwitcher) Selected() string {
+	if item := ts.list.SelectedItem(); item != nil {
+		return item.Text
+	}
+	return ""
+}
+
+// apply switches to the highlighted theme.
+func (ts *ThemeSwitcher) apply() {
+	name := ts.Selected()
+	if name == "" {
+		return
+	}
+	if err := theme.Set(name); err != nil {
+		if ts.OnError != nil {
+			ts.OnError(err)
+		}
+		return
+	}
+	ts.invalidate()
+	if ts.OnChange != nil {
+		ts.OnChange(name)
+	}
+}
+
+// renderItem draws "● name" followed by the palette's swatch strip.
+func (ts *ThemeSwitcher) renderItem(p *core.Painter, rect core.Rect, item primitives.ListItem, selected bool) {
+	tm := theme.Get()
+	style := tcell.StyleDefault.Foreground(tm.GetSemanticColor("text.primary")).
+		Background(tm.GetSemanticColor("bg.surface"))
+	if selected {
+		style = style.Reverse(true)
+	}
+	p.Fill(rect, ' ', style)
+
+	mark := "  "
+	if item.Text == ts.Current() {
+		mark = "● "
+	}
+	stripW := len(themeSwatchColors) * themeSwatchWidth
+	nameW := rect.W - stripW - 1
+	if nameW < 4 {
+		// Too narrow for the strip: names only
+		nameW, stripW = rect.W, 0
+	}
+	label := []rune(mark + item.Text)
+	if len(label) > nameW {
+		label = label[:nameW]
+	}
+	p.DrawText(rect.X, rect.Y, string(label), style)
+
+	if stripW == 0 {
+		return
+	}
+	palette := ts.palettes[item.Text]
+	x := rect.X + rect.W - stripW
+	for _, name := range themeSwatchColors {
+		c, ok := palette[name]
+		if !ok {
+			c = tcell.ColorDefault
+		}
+		p.Fill(core.Rect{X: x, Y: rect.Y, W: themeSwatchWidth, H: 1}, ' ', tcell.StyleDefault.Background(c))
+		x += themeSwatchWidth
+	}
+}
+
+// OnThemeChanged implements core.ThemeAware.
+func (ts *ThemeSwitcher) OnThemeChanged() { core.NotifyThemeChanged(ts.list) }
+
+// Draw renders the theme list.
+func (ts *ThemeSwitcher) Draw(painter *core.Painter) {
+	ts.list.Draw(painter)
+}
+
+// SetPosition moves the switcher and its list.
+func (ts *ThemeSwitcher) SetPosition(x, y int) {
+	ts.BaseWidget.SetPosition(x, y)
+	ts.list.SetPosition(x, y)
+}
+
+// Resize resizes the switcher and its list.
+func (ts *ThemeSwitcher) Resize(w, h int) {
+	ts.BaseWidget.Resize(w, h)
+	ts.list.Resize(w, h)
+}
+
+// HandleKey navigates the list; Enter applies the highlighted theme.
+func (ts *ThemeSwitcher) HandleKey(ev *tcell.EventKey) bool {
+	return ts.list.HandleKey(ev)
+}
+
+// HandleMouse selects with a click and applies with a double-click.
+func (ts *ThemeSwitcher) HandleMouse(ev *tcell.EventMouse) bool {
+	return ts.list.HandleMouse(ev)
+}
+
+// SetInvalidator implements core.InvalidationAware.
+func (ts *ThemeSwitcher) SetInvalidator(fn func(core.Rect)) {
+	ts.inv = fn
+	ts.list.SetInvalidator(fn)
+}
+
+// GetKeyHints implements core.KeyHintsProvider.
+func (ts *ThemeSwitcher) GetKeyHints() []core.KeyHint {
+	return []core.KeyHint{
+		{Key: "↑↓", Label: "Theme"},
+		{Key: "Enter", Label: "Apply"},
+	}
+}
+
+func (ts *ThemeSwitcher) invalidate() {
+	if ts.inv != nil {
+		ts.inv(ts.Rect)
+	}
+}
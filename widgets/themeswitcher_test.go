@@ -0,0 +1,56 @@
+package widgets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/framegrace/texelui/core"
+	"github.com/framegrace/texelui/theme"
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestThemeSwitcherAppliesSelection(t *testing.T) {
+	defer theme.Apply(theme.Config{})
+	if err := theme.Apply(theme.Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := NewThemeSwitcher()
+	ts.SetPosition(0, 0)
+	ts.Resize(40, 6)
+	if ts.Selected() != "mocha" {
+		t.Fatalf("current theme should be highlighted, got %q", ts.Selected())
+	}
+
+	latte := -1
+	for i, item := range ts.list.Items {
+		if item.Text == "latte" {
+			latte = i
+		}
+	}
+	if latte < 0 {
+		t.Fatal("latte not listed")
+	}
+
+	buf := createTestBuffer(40, 6)
+	ts.Draw(core.NewPainter(buf, core.Rect{X: 0, Y: 0, W: 40, H: 6}))
+	row := ""
+	for x := 0; x < 20; x++ {
+		row += string(buf[latte][x].Ch)
+	}
+	if !strings.HasPrefix(row, "  latte") {
+		t.Errorf("row %d = %q, want the unmarked latte entry", latte, row)
+	}
+	base, _ := theme.PaletteColors("latte")
+	if _, bg, _ := buf[latte][40-len(themeSwatchColors)*themeSwatchWidth].Style.Decompose(); bg != base["base"] {
+		t.Errorf("swatch strip should start with the palette base color, got %v", bg)
+	}
+
+	var applied string
+	ts.OnChange = func(name string) { applied = name }
+	ts.list.SetSelected(latte)
+	ts.HandleKey(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if applied != "latte" || ts.Current() != "latte" {
+		t.Errorf("Enter should apply latte: applied=%q current=%q", applied, ts.Current())
+	}
+}
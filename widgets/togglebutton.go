@@ -33,6 +33,17 @@ func NewToggleButton(label string) *ToggleButton {
 	}
 
 	// Get default style from theme
+	tb.applyTheme()
+
+	tb.SetPosition(0, 0)
+	tb.Resize(utf8.RuneCountInString(label), 1)
+	tb.SetFocusable(false)
+
+	return tb
+}
+
+// applyTheme resolves the default style from the current theme.
+func (tb *ToggleButton) applyTheme() {
 	tm := theme.Get()
 	fg := tm.GetSemanticColor("text.primary")
 	bg := tm.GetSemanticColor("bg.surface")
@@ -45,18 +56,15 @@ func NewToggleButton(label string) *ToggleButton {
 		bg = tcell.ColorBlack
 	}
 
-	tb.Style = color.DynamicStyle{
+	tb.SetThemeStyle(&tb.Style, color.DynamicStyle{
 		FG: color.Solid(fg),
 		BG: color.Solid(bg),
-	}
-
-	tb.SetPosition(0, 0)
-	tb.Resize(utf8.RuneCountInString(label), 1)
-	tb.SetFocusable(false)
-
-	return tb
+	})
 }
 
+// OnThemeChanged implements core.ThemeAware.
+func (tb *ToggleButton) OnThemeChanged() { tb.applyTheme() }
+
 // Draw renders the toggle button label with normal, reversed, or faded style.
 // Disabled+Active shows a faded reversed style (visible but non-interactive).
 func (tb *ToggleButton) Draw(painter *core.Painter) {
@@ -98,6 +106,15 @@ func fadeColor(fg, bg tcell.Color, ratio float64) tcell.Color {
 	return tcell.NewRGBColor(mix(fr, br, ratio), mix(ffg, bbg, ratio), mix(fb, bb, ratio))
 }
 
+// SetDisabled implements core.Disableable.
+func (tb *ToggleButton) SetDisabled(disabled bool) {
+	tb.Disabled = disabled
+	tb.invalidate()
+}
+
+// IsDisabled implements core.Disableable.
+func (tb *ToggleButton) IsDisabled() bool { return tb.Disabled }
+
 // HandleMouse processes mouse input. Left click toggles the active state.
 // Disabled buttons ignore all mouse input.
 func (tb *ToggleButton) HandleMouse(ev *tcell.EventMouse) bool {
@@ -170,6 +170,85 @@ func TestInputNavigation(t *testing.T) {
 	}
 }
 
+func TestInputDragSelection(t *testing.T) {
+	input := newTestInput(20)
+	input.Text = "hello world"
+
+	input.HandleMouse(tcell.NewEventMouse(0, 0, tcell.Button1, tcell.ModNone))
+	input.HandleMouse(tcell.NewEventMouse(5, 0, tcell.Button1, tcell.ModNone))
+	input.HandleMouse(tcell.NewEventMouse(5, 0, tcell.ButtonNone, tcell.ModNone))
+	if got := input.SelectedText(); got != "hello" {
+		t.Errorf("expected selection 'hello', got '%s'", got)
+	}
+
+	// Dragging past the right edge scrolls a rune at a time
+	input.Resize(4, 1)
+	input.HandleGrabbedMouse(tcell.NewEventMouse(9, 0, tcell.Button1, tcell.ModNone), core.Cell{})
+	if input.CaretPos != 6 || input.OffX != 3 {
+		t.Errorf("expected caret 6 scrolled to 3, got %d, %d", input.CaretPos, input.OffX)
+	}
+
+	input.HandleKey(tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone))
+	if got := input.SelectedText(); got != "" {
+		t.Errorf("keys should clear the selection, got '%s'", got)
+	}
+}
+
+func TestInputCopyPaste(t *testing.T) {
+	input := newTestInput(20)
+	clip := &testClipboard{}
+	input.SetClipboardService(clip)
+	input.Text = "hello world"
+
+	input.HandleMouse(tcell.NewEventMouse(6, 0, tcell.Button1, tcell.ModNone))
+	input.HandleMouse(tcell.NewEventMouse(11, 0, tcell.Button1, tcell.ModNone))
+	input.HandleMouse(tcell.NewEventMouse(11, 0, tcell.ButtonNone, tcell.ModNone))
+	if !input.HandleKey(tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModCtrl)) || clip.data != "world" {
+		t.Fatalf("expected Ctrl+C to copy 'world', got '%s'", clip.data)
+	}
+
+	clip.data = "a\nb"
+	input.HandleKey(tcell.NewEventKey(tcell.KeyHome, 0, tcell.ModNone))
+	input.HandleKey(tcell.NewEventKey(tcell.KeyCtrlV, 0, tcell.ModCtrl))
+	if input.Text != "a bhello world" || input.CaretPos != 3 {
+		t.Errorf("expected 'a bhello world' caret 3, got '%s' caret %d", input.Text, input.CaretPos)
+	}
+	if input.HandleKey(tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModCtrl)) {
+		t.Error("Ctrl+C without a selection should pass through")
+	}
+}
+
+func TestInputEditReplacesSelection(t *testing.T) {
+	input := newTestInput(20)
+	clip := &testClipboard{}
+	input.SetClipboardService(clip)
+	selectRange := func(from, to int) {
+		input.HandleMouse(tcell.NewEventMouse(from, 0, tcell.Button1, tcell.ModNone))
+		input.HandleMouse(tcell.NewEventMouse(to, 0, tcell.Button1, tcell.ModNone))
+		input.HandleMouse(tcell.NewEventMouse(to, 0, tcell.ButtonNone, tcell.ModNone))
+	}
+
+	input.Text = "hello world"
+	selectRange(6, 11)
+	input.HandleKey(tcell.NewEventKey(tcell.KeyRune, 'X', tcell.ModNone))
+	if input.Text != "hello X" || input.CaretPos != 7 {
+		t.Errorf("typing: expected 'hello X' caret 7, got '%s' caret %d", input.Text, input.CaretPos)
+	}
+
+	selectRange(0, 5)
+	input.HandleKey(tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone))
+	if input.Text != " X" || input.CaretPos != 0 {
+		t.Errorf("backspace: expected ' X' caret 0, got '%s' caret %d", input.Text, input.CaretPos)
+	}
+
+	clip.data = "bye"
+	selectRange(1, 2)
+	input.HandleKey(tcell.NewEventKey(tcell.KeyCtrlV, 0, tcell.ModCtrl))
+	if input.Text != " bye" || input.CaretPos != 4 {
+		t.Errorf("paste: expected ' bye' caret 4, got '%s' caret %d", input.Text, input.CaretPos)
+	}
+}
+
 func TestCheckboxCreation(t *testing.T) {
 	checkbox := NewCheckbox("Enable feature")
 	if checkbox.Label != "Enable feature" {